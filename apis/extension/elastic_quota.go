@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apiserver/pkg/quota/v1"
 	"sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
 )
@@ -34,12 +35,78 @@ const (
 	LabelQuotaIsParent     = QuotaKoordinatorPrefix + "/is-parent"
 	LabelQuotaParent       = QuotaKoordinatorPrefix + "/parent"
 	LabelAllowLentResource = QuotaKoordinatorPrefix + "/allow-lent-resource"
+	LabelNonPreemptible    = QuotaKoordinatorPrefix + "/non-preemptible"
 	LabelQuotaName         = QuotaKoordinatorPrefix + "/name"
 	AnnotationSharedWeight = QuotaKoordinatorPrefix + "/shared-weight"
 	AnnotationRuntime      = QuotaKoordinatorPrefix + "/runtime"
 	AnnotationRequest      = QuotaKoordinatorPrefix + "/request"
+	AnnotationBorrowLimit  = QuotaKoordinatorPrefix + "/borrow-limit"
+	AnnotationLendLimit    = QuotaKoordinatorPrefix + "/lend-limit"
+	AnnotationQuotaStatus  = QuotaKoordinatorPrefix + "/status"
+	// AnnotationTreeNodeSelector makes a quota whose parent is root the root of an independent quota tree,
+	// which only shares the resources of the nodes selected by the node selector.
+	AnnotationTreeNodeSelector = QuotaKoordinatorPrefix + "/tree-node-selector"
 )
 
+// ElasticQuotaStatus is the observed status of the quota written by the scheduler into AnnotationQuotaStatus,
+// because the status of ElasticQuota only has the used. The annotation is neither validated by the CRD schema nor
+// protected by the status subresource, see the multi-hierarchy elastic quota proposal for the limitations.
+type ElasticQuotaStatus struct {
+	Used    corev1.ResourceList `json:"used,omitempty"`
+	Request corev1.ResourceList `json:"request,omitempty"`
+	Runtime corev1.ResourceList `json:"runtime,omitempty"`
+	// Min is the min after scaled if the sum of min is larger than the total resource.
+	Min      corev1.ResourceList `json:"min,omitempty"`
+	Max      corev1.ResourceList `json:"max,omitempty"`
+	Children []string            `json:"children,omitempty"`
+	// OverUsedSince is the time since when the used exceeds the runtime continuously.
+	OverUsedSince *metav1.Time            `json:"overUsedSince,omitempty"`
+	Conditions    []ElasticQuotaCondition `json:"conditions,omitempty"`
+}
+
+type ElasticQuotaConditionType string
+
+const (
+	// ElasticQuotaOverUsed means the used of the quota exceeds its runtime.
+	ElasticQuotaOverUsed ElasticQuotaConditionType = "OverUsed"
+	// ElasticQuotaStarved means the runtime of the quota is less than its request within its min.
+	ElasticQuotaStarved ElasticQuotaConditionType = "Starved"
+)
+
+type ElasticQuotaCondition struct {
+	Type               ElasticQuotaConditionType `json:"type"`
+	Status             corev1.ConditionStatus    `json:"status"`
+	LastTransitionTime metav1.Time               `json:"lastTransitionTime,omitempty"`
+	Reason             string                    `json:"reason,omitempty"`
+	Message            string                    `json:"message,omitempty"`
+}
+
+// GetQuotaStatus returns the status of the quota in AnnotationQuotaStatus, or nil if it is not set.
+func GetQuotaStatus(quota *v1alpha1.ElasticQuota) (*ElasticQuotaStatus, error) {
+	value, exist := quota.Annotations[AnnotationQuotaStatus]
+	if !exist || value == "" {
+		return nil, nil
+	}
+	status := &ElasticQuotaStatus{}
+	if err := json.Unmarshal([]byte(value), status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// GetQuotaCondition returns the condition of the type in the status, or nil if it does not exist.
+func (s *ElasticQuotaStatus) GetQuotaCondition(conditionType ElasticQuotaConditionType) *ElasticQuotaCondition {
+	if s == nil {
+		return nil
+	}
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == conditionType {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
 func GetParentQuotaName(quota *v1alpha1.ElasticQuota) string {
 	parentName := quota.Labels[LabelQuotaParent]
 	if parentName == "" {
@@ -56,6 +123,12 @@ func IsAllowLentResource(quota *v1alpha1.ElasticQuota) bool {
 	return quota.Labels[LabelAllowLentResource] != "false"
 }
 
+// IsNonPreemptible returns whether the pods of the quota can not be preempted by other quotas
+// which reclaim their lent resources.
+func IsNonPreemptible(quota *v1alpha1.ElasticQuota) bool {
+	return quota.Labels[LabelNonPreemptible] == "true"
+}
+
 func GetSharedWeight(quota *v1alpha1.ElasticQuota) corev1.ResourceList {
 	value, exist := quota.Annotations[AnnotationSharedWeight]
 	if exist {
@@ -68,6 +141,60 @@ func GetSharedWeight(quota *v1alpha1.ElasticQuota) corev1.ResourceList {
 	return quota.Spec.Max.DeepCopy() //default equals to max
 }
 
+// GetBorrowLimit returns how much resource the quota can borrow above its min,
+// the resource dimensions not specified are unlimited.
+func GetBorrowLimit(quota *v1alpha1.ElasticQuota) corev1.ResourceList {
+	return getResourceListFromAnnotation(quota, AnnotationBorrowLimit)
+}
+
+// GetLendLimit returns how much of its min the quota can lend to others,
+// the resource dimensions not specified are unlimited.
+func GetLendLimit(quota *v1alpha1.ElasticQuota) corev1.ResourceList {
+	return getResourceListFromAnnotation(quota, AnnotationLendLimit)
+}
+
+// GetTreeNodeSelector returns the node selector of the quota tree rooted on the quota, or nil if it is not set or invalid.
+func GetTreeNodeSelector(quota *v1alpha1.ElasticQuota) map[string]string {
+	nodeSelector, err := ParseTreeNodeSelector(quota)
+	if err != nil {
+		return nil
+	}
+	return nodeSelector
+}
+
+// ParseTreeNodeSelector parses the node selector of the quota tree rooted on the quota, and returns error if the node
+// selector is invalid or empty, or the parent of the quota is not root.
+func ParseTreeNodeSelector(quota *v1alpha1.ElasticQuota) (map[string]string, error) {
+	value, exist := quota.Annotations[AnnotationTreeNodeSelector]
+	if !exist {
+		return nil, nil
+	}
+	nodeSelector := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &nodeSelector); err != nil {
+		return nil, fmt.Errorf("invalid %v %v, err: %v", AnnotationTreeNodeSelector, value, err)
+	}
+	if len(nodeSelector) == 0 {
+		return nil, fmt.Errorf("empty %v", AnnotationTreeNodeSelector)
+	}
+	if parentName := GetParentQuotaName(quota); parentName != RootQuotaName {
+		return nil, fmt.Errorf("%v only takes effect on the quota whose parent is %v, parent: %v",
+			AnnotationTreeNodeSelector, RootQuotaName, parentName)
+	}
+	return nodeSelector, nil
+}
+
+func getResourceListFromAnnotation(quota *v1alpha1.ElasticQuota, key string) corev1.ResourceList {
+	value, exist := quota.Annotations[key]
+	if !exist {
+		return nil
+	}
+	resList := corev1.ResourceList{}
+	if err := json.Unmarshal([]byte(value), &resList); err != nil {
+		return nil
+	}
+	return resList
+}
+
 func IsForbiddenModify(quota *v1alpha1.ElasticQuota) (bool, error) {
 	if quota.Name == SystemQuotaName || quota.Name == RootQuotaName {
 		// can't modify SystemQuotaGroup
@@ -18,6 +18,9 @@ package extension
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -35,6 +38,9 @@ const (
 	LabelNodeCPUBindPolicy = NodeDomainPrefix + "/cpu-bind-policy"
 	// LabelNodeNUMAAllocateStrategy indicates how to choose satisfied NUMA Nodes when scheduling.
 	LabelNodeNUMAAllocateStrategy = NodeDomainPrefix + "/numa-allocate-strategy"
+	// LabelNodeNUMATopologyPolicy indicates how to align the resources on NUMA Nodes when scheduling.
+	// It takes precedence over the topology manager policy of kubelet reported in NodeResourceTopology.
+	LabelNodeNUMATopologyPolicy = NodeDomainPrefix + "/numa-topology-policy"
 )
 
 const (
@@ -47,6 +53,16 @@ const (
 	NodeCPUBindPolicySpreadByPCPUs = "SpreadByPCPUs"
 )
 
+type NUMATopologyPolicy string
+
+const (
+	// NUMATopologyPolicyNone does not require the resources aligned on NUMA Nodes.
+	NUMATopologyPolicyNone NUMATopologyPolicy = "None"
+	// NUMATopologyPolicySingleNUMANode requires that the CPUs, memory and devices of the Pod
+	// must be allocated on the same NUMA Node. Equivalent to kubelet topology manager policy single-numa-node.
+	NUMATopologyPolicySingleNUMANode NUMATopologyPolicy = "SingleNUMANode"
+)
+
 const (
 	NodeNUMAAllocateStrategyLeastAllocated = string(NUMALeastAllocated)
 	NodeNUMAAllocateStrategyMostAllocated  = string(NUMAMostAllocated)
@@ -62,6 +78,30 @@ const (
 	KubeletCPUManagerPolicyDistributeCPUsAcrossNUMAOption = "distribute-cpus-across-numa"
 )
 
+const (
+	// NUMANodeZoneType is the type of the NodeResourceTopology zones that describe the resources of NUMA Nodes.
+	NUMANodeZoneType = "Node"
+	// numaNodeZoneNamePrefix is the prefix of the NUMA Node zone names, e.g. node-0, node-1.
+	numaNodeZoneNamePrefix = "node-"
+)
+
+// GenNUMANodeZoneName returns the name of NodeResourceTopology zone of the NUMA Node.
+func GenNUMANodeZoneName(numaNodeID int) string {
+	return numaNodeZoneNamePrefix + strconv.Itoa(numaNodeID)
+}
+
+// ParseNUMANodeZoneName parses the NUMA Node ID from the name of NodeResourceTopology zone.
+func ParseNUMANodeZoneName(zoneName string) (int, error) {
+	if !strings.HasPrefix(zoneName, numaNodeZoneNamePrefix) {
+		return -1, fmt.Errorf("invalid NUMA Node zone name %q", zoneName)
+	}
+	numaNodeID, err := strconv.Atoi(strings.TrimPrefix(zoneName, numaNodeZoneNamePrefix))
+	if err != nil || numaNodeID < 0 {
+		return -1, fmt.Errorf("invalid NUMA Node zone name %q", zoneName)
+	}
+	return numaNodeID, nil
+}
+
 type CPUTopology struct {
 	Detail []CPUInfo `json:"detail,omitempty"`
 }
@@ -0,0 +1,36 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNUMANodeZoneName(t *testing.T) {
+	for _, numaNodeID := range []int{0, 1, 16} {
+		numaNodeIDGot, err := ParseNUMANodeZoneName(GenNUMANodeZoneName(numaNodeID))
+		assert.NoError(t, err)
+		assert.Equal(t, numaNodeID, numaNodeIDGot)
+	}
+
+	for _, zoneName := range []string{"", "fake-name", "node-", "node-a", "node--1", "socket-0"} {
+		_, err := ParseNUMANodeZoneName(zoneName)
+		assert.Error(t, err, zoneName)
+	}
+}
@@ -83,6 +83,15 @@ type ResourceStatus struct {
 	CPUSet string `json:"cpuset,omitempty"`
 	// CPUSharedPools represents the desired CPU Shared Pools used by LS Pods.
 	CPUSharedPools []CPUSharedPool `json:"cpuSharedPools,omitempty"`
+	// NUMANodeResources represents the resources allocated on each NUMA Node.
+	// When LSE/LSR Pod requested, koord-scheduler will update the field with the NUMA Nodes of the allocated CPUs,
+	// and koordlet binds the memory of the Pod to these NUMA Nodes.
+	NUMANodeResources []NUMANodeResource `json:"numaNodeResources,omitempty"`
+}
+
+type NUMANodeResource struct {
+	Node      int32               `json:"node"`
+	Resources corev1.ResourceList `json:"resources,omitempty"`
 }
 
 // CPUBindPolicy defines the CPU binding policy
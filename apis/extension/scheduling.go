@@ -193,6 +193,23 @@ type DeviceAllocation struct {
 	Extension json.RawMessage     `json:"extension,omitempty"`
 }
 
+// DeviceAllocationExtension is the extension of DeviceAllocation, e.g. the SR-IOV virtual functions allocated from the device.
+type DeviceAllocationExtension struct {
+	VirtualFunctions []schedulingv1alpha1.VirtualFunction `json:"vfs,omitempty"`
+}
+
+// GetDeviceAllocationExtension parses the extension of the DeviceAllocation, it returns nil if the extension is empty.
+func GetDeviceAllocationExtension(allocation *DeviceAllocation) (*DeviceAllocationExtension, error) {
+	if allocation == nil || len(allocation.Extension) == 0 {
+		return nil, nil
+	}
+	extension := &DeviceAllocationExtension{}
+	if err := json.Unmarshal(allocation.Extension, extension); err != nil {
+		return nil, err
+	}
+	return extension, nil
+}
+
 var GetDeviceAllocations = func(podAnnotations map[string]string) (DeviceAllocations, error) {
 	deviceAllocations := DeviceAllocations{}
 	data, ok := podAnnotations[AnnotationDeviceAllocated]
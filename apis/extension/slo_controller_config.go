@@ -143,6 +143,8 @@ type ColocationStrategy struct {
 	MetricAggregateDurationSeconds *int64                       `json:"metricAggregateDurationSeconds,omitempty"`
 	MetricReportIntervalSeconds    *int64                       `json:"metricReportIntervalSeconds,omitempty"`
 	MetricAggregatePolicy          *slov1alpha1.AggregatePolicy `json:"metricAggregatePolicy,omitempty"`
+	MetricPodAggregatePolicy       *slov1alpha1.AggregatePolicy `json:"metricPodAggregatePolicy,omitempty"`
+	PodUsageAggregationType        *slov1alpha1.AggregationType `json:"podUsageAggregationType,omitempty"`
 	CPUReclaimThresholdPercent     *int64                       `json:"cpuReclaimThresholdPercent,omitempty"`
 	MemoryReclaimThresholdPercent  *int64                       `json:"memoryReclaimThresholdPercent,omitempty"`
 	MemoryCalculatePolicy          *CalculatePolicy             `json:"memoryCalculatePolicy,omitempty"`
@@ -125,6 +125,16 @@ func (in *ColocationStrategy) DeepCopyInto(out *ColocationStrategy) {
 		*out = new(v1alpha1.AggregatePolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MetricPodAggregatePolicy != nil {
+		in, out := &in.MetricPodAggregatePolicy, &out.MetricPodAggregatePolicy
+		*out = new(v1alpha1.AggregatePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodUsageAggregationType != nil {
+		in, out := &in.PodUsageAggregationType, &out.PodUsageAggregationType
+		*out = new(v1alpha1.AggregationType)
+		**out = **in
+	}
 	if in.CPUReclaimThresholdPercent != nil {
 		in, out := &in.CPUReclaimThresholdPercent, &out.CPUReclaimThresholdPercent
 		*out = new(int64)
@@ -60,6 +60,20 @@ type RuntimeHookServiceClient interface {
 	// PreUpdateContainerResourcesHook calls RuntimeHookServer before container resource update to keep resource policy
 	// consistent
 	PreUpdateContainerResourcesHook(ctx context.Context, in *ContainerResourceHookRequest, opts ...grpc.CallOption) (*ContainerResourceHookResponse, error)
+	// PrePullImageHook calls RuntimeHookServer before image pulling. RuntimeHookServer could enforce image policies,
+	// e.g. reject or redirect the pulling.
+	PrePullImageHook(ctx context.Context, in *ImageHookRequest, opts ...grpc.CallOption) (*ImageHookResponse, error)
+	// PreExecSyncHook calls RuntimeHookServer before the exec command runs in container. RuntimeHookServer could audit
+	// or reject the command.
+	PreExecSyncHook(ctx context.Context, in *ExecSyncHookRequest, opts ...grpc.CallOption) (*ExecSyncHookResponse, error)
+	// PostContainerStatsHook calls RuntimeHookServer after container stats returned. RuntimeHookServer could enrich
+	// the stats of the container.
+	PostContainerStatsHook(ctx context.Context, in *ContainerStatsHookRequest, opts ...grpc.CallOption) (*ContainerStatsHookResponse, error)
+	// PostListContainerStatsHook calls RuntimeHookServer after stats of containers listed. RuntimeHookServer could
+	// enrich the stats of the containers.
+	PostListContainerStatsHook(ctx context.Context, in *ContainerStatsHookRequest, opts ...grpc.CallOption) (*ContainerStatsHookResponse, error)
+	// PreUpdateRuntimeConfigHook calls RuntimeHookServer before runtime config updating.
+	PreUpdateRuntimeConfigHook(ctx context.Context, in *RuntimeConfigHookRequest, opts ...grpc.CallOption) (*RuntimeConfigHookResponse, error)
 }
 
 type runtimeHookServiceClient struct {
@@ -133,6 +147,51 @@ func (c *runtimeHookServiceClient) PreUpdateContainerResourcesHook(ctx context.C
 	return out, nil
 }
 
+func (c *runtimeHookServiceClient) PrePullImageHook(ctx context.Context, in *ImageHookRequest, opts ...grpc.CallOption) (*ImageHookResponse, error) {
+	out := new(ImageHookResponse)
+	err := c.cc.Invoke(ctx, "/runtime.v1alpha1.RuntimeHookService/PrePullImageHook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeHookServiceClient) PreExecSyncHook(ctx context.Context, in *ExecSyncHookRequest, opts ...grpc.CallOption) (*ExecSyncHookResponse, error) {
+	out := new(ExecSyncHookResponse)
+	err := c.cc.Invoke(ctx, "/runtime.v1alpha1.RuntimeHookService/PreExecSyncHook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeHookServiceClient) PostContainerStatsHook(ctx context.Context, in *ContainerStatsHookRequest, opts ...grpc.CallOption) (*ContainerStatsHookResponse, error) {
+	out := new(ContainerStatsHookResponse)
+	err := c.cc.Invoke(ctx, "/runtime.v1alpha1.RuntimeHookService/PostContainerStatsHook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeHookServiceClient) PostListContainerStatsHook(ctx context.Context, in *ContainerStatsHookRequest, opts ...grpc.CallOption) (*ContainerStatsHookResponse, error) {
+	out := new(ContainerStatsHookResponse)
+	err := c.cc.Invoke(ctx, "/runtime.v1alpha1.RuntimeHookService/PostListContainerStatsHook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeHookServiceClient) PreUpdateRuntimeConfigHook(ctx context.Context, in *RuntimeConfigHookRequest, opts ...grpc.CallOption) (*RuntimeConfigHookResponse, error) {
+	out := new(RuntimeConfigHookResponse)
+	err := c.cc.Invoke(ctx, "/runtime.v1alpha1.RuntimeHookService/PreUpdateRuntimeConfigHook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RuntimeHookServiceServer is the server API for RuntimeHookService service.
 // All implementations must embed UnimplementedRuntimeHookServiceServer
 // for forward compatibility
@@ -159,6 +218,20 @@ type RuntimeHookServiceServer interface {
 	// PreUpdateContainerResourcesHook calls RuntimeHookServer before container resource update to keep resource policy
 	// consistent
 	PreUpdateContainerResourcesHook(context.Context, *ContainerResourceHookRequest) (*ContainerResourceHookResponse, error)
+	// PrePullImageHook calls RuntimeHookServer before image pulling. RuntimeHookServer could enforce image policies,
+	// e.g. reject or redirect the pulling.
+	PrePullImageHook(context.Context, *ImageHookRequest) (*ImageHookResponse, error)
+	// PreExecSyncHook calls RuntimeHookServer before the exec command runs in container. RuntimeHookServer could audit
+	// or reject the command.
+	PreExecSyncHook(context.Context, *ExecSyncHookRequest) (*ExecSyncHookResponse, error)
+	// PostContainerStatsHook calls RuntimeHookServer after container stats returned. RuntimeHookServer could enrich
+	// the stats of the container.
+	PostContainerStatsHook(context.Context, *ContainerStatsHookRequest) (*ContainerStatsHookResponse, error)
+	// PostListContainerStatsHook calls RuntimeHookServer after stats of containers listed. RuntimeHookServer could
+	// enrich the stats of the containers.
+	PostListContainerStatsHook(context.Context, *ContainerStatsHookRequest) (*ContainerStatsHookResponse, error)
+	// PreUpdateRuntimeConfigHook calls RuntimeHookServer before runtime config updating.
+	PreUpdateRuntimeConfigHook(context.Context, *RuntimeConfigHookRequest) (*RuntimeConfigHookResponse, error)
 	mustEmbedUnimplementedRuntimeHookServiceServer()
 }
 
@@ -187,6 +260,21 @@ func (UnimplementedRuntimeHookServiceServer) PostStopContainerHook(context.Conte
 func (UnimplementedRuntimeHookServiceServer) PreUpdateContainerResourcesHook(context.Context, *ContainerResourceHookRequest) (*ContainerResourceHookResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PreUpdateContainerResourcesHook not implemented")
 }
+func (UnimplementedRuntimeHookServiceServer) PrePullImageHook(context.Context, *ImageHookRequest) (*ImageHookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrePullImageHook not implemented")
+}
+func (UnimplementedRuntimeHookServiceServer) PreExecSyncHook(context.Context, *ExecSyncHookRequest) (*ExecSyncHookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreExecSyncHook not implemented")
+}
+func (UnimplementedRuntimeHookServiceServer) PostContainerStatsHook(context.Context, *ContainerStatsHookRequest) (*ContainerStatsHookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostContainerStatsHook not implemented")
+}
+func (UnimplementedRuntimeHookServiceServer) PostListContainerStatsHook(context.Context, *ContainerStatsHookRequest) (*ContainerStatsHookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostListContainerStatsHook not implemented")
+}
+func (UnimplementedRuntimeHookServiceServer) PreUpdateRuntimeConfigHook(context.Context, *RuntimeConfigHookRequest) (*RuntimeConfigHookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreUpdateRuntimeConfigHook not implemented")
+}
 func (UnimplementedRuntimeHookServiceServer) mustEmbedUnimplementedRuntimeHookServiceServer() {}
 
 // UnsafeRuntimeHookServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -326,6 +414,96 @@ func _RuntimeHookService_PreUpdateContainerResourcesHook_Handler(srv interface{}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeHookService_PrePullImageHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImageHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeHookServiceServer).PrePullImageHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.v1alpha1.RuntimeHookService/PrePullImageHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeHookServiceServer).PrePullImageHook(ctx, req.(*ImageHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeHookService_PreExecSyncHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecSyncHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeHookServiceServer).PreExecSyncHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.v1alpha1.RuntimeHookService/PreExecSyncHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeHookServiceServer).PreExecSyncHook(ctx, req.(*ExecSyncHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeHookService_PostContainerStatsHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainerStatsHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeHookServiceServer).PostContainerStatsHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.v1alpha1.RuntimeHookService/PostContainerStatsHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeHookServiceServer).PostContainerStatsHook(ctx, req.(*ContainerStatsHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeHookService_PostListContainerStatsHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainerStatsHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeHookServiceServer).PostListContainerStatsHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.v1alpha1.RuntimeHookService/PostListContainerStatsHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeHookServiceServer).PostListContainerStatsHook(ctx, req.(*ContainerStatsHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeHookService_PreUpdateRuntimeConfigHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuntimeConfigHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeHookServiceServer).PreUpdateRuntimeConfigHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.v1alpha1.RuntimeHookService/PreUpdateRuntimeConfigHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeHookServiceServer).PreUpdateRuntimeConfigHook(ctx, req.(*RuntimeConfigHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RuntimeHookService_ServiceDesc is the grpc.ServiceDesc for RuntimeHookService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -361,6 +539,26 @@ var RuntimeHookService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PreUpdateContainerResourcesHook",
 			Handler:    _RuntimeHookService_PreUpdateContainerResourcesHook_Handler,
 		},
+		{
+			MethodName: "PrePullImageHook",
+			Handler:    _RuntimeHookService_PrePullImageHook_Handler,
+		},
+		{
+			MethodName: "PreExecSyncHook",
+			Handler:    _RuntimeHookService_PreExecSyncHook_Handler,
+		},
+		{
+			MethodName: "PostContainerStatsHook",
+			Handler:    _RuntimeHookService_PostContainerStatsHook_Handler,
+		},
+		{
+			MethodName: "PostListContainerStatsHook",
+			Handler:    _RuntimeHookService_PostListContainerStatsHook_Handler,
+		},
+		{
+			MethodName: "PreUpdateRuntimeConfigHook",
+			Handler:    _RuntimeHookService_PreUpdateRuntimeConfigHook_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
@@ -44,10 +44,86 @@ type DeviceInfo struct {
 	Health bool `json:"health,omitempty"`
 	// Resources is a set of (resource name, quantity) pairs
 	Resources corev1.ResourceList `json:"resources,omitempty"`
+	// Topology represents the topology information about the device
+	Topology *DeviceTopology `json:"topology,omitempty"`
+	// VFs is the SR-IOV virtual functions of the device, which could be allocated to the pods sharing the device
+	VFs []VirtualFunction `json:"vfs,omitempty"`
+}
+
+type VirtualFunction struct {
+	// Minor represents the Minor number of the virtual function, starting from 0
+	Minor int32 `json:"minor"`
+	// BusID is the PCI bus ID of the virtual function
+	BusID string `json:"busID,omitempty"`
+}
+
+type DeviceTopology struct {
+	// SocketID is the ID of CPU Socket to which the device belongs
+	SocketID int32 `json:"socketID"`
+	// NodeID is the ID of NUMA Node to which the device belongs, it could be -1 if the device has no NUMA affinity
+	NodeID int32 `json:"nodeID"`
+	// PCIEID is the ID of the PCIe root port to which the device is attached,
+	// the devices with the same PCIEID are connected under the same PCIe switch
+	PCIEID string `json:"pcieID,omitempty"`
+	// BusID is the PCI bus ID of the device
+	BusID string `json:"busID,omitempty"`
+	// NVLinks is the minors of the devices directly connected to the device by NVLink
+	NVLinks []int32 `json:"nvlinks,omitempty"`
 }
 
 type DeviceStatus struct {
 	Allocations []DeviceAllocation `json:"allocations,omitempty"`
+	// Conditions represents the health conditions of each device
+	Conditions []DeviceConditions `json:"conditions,omitempty"`
+}
+
+type DeviceConditionType string
+
+const (
+	// DeviceHealthy indicates whether the device is able to run the pods
+	DeviceHealthy DeviceConditionType = "Healthy"
+	// DeviceXIDError indicates whether the GPU reports critical XID errors
+	DeviceXIDError DeviceConditionType = "XIDError"
+	// DeviceECCError indicates whether the GPU reports uncorrectable ECC errors
+	DeviceECCError DeviceConditionType = "ECCError"
+)
+
+// DeviceConditions represents the health conditions of a device
+type DeviceConditions struct {
+	// UUID represents the UUID of device
+	UUID string `json:"id,omitempty"`
+	// Minor represents the Minor number of Device, starting from 0
+	Minor int32 `json:"minor"`
+	// Type represents the type of device
+	Type DeviceType `json:"type,omitempty"`
+	// LastSeenTime is the last time the device was discovered by koordlet
+	LastSeenTime metav1.Time `json:"lastSeenTime,omitempty"`
+	// XIDErrors is the recent critical XID errors reported by the GPU
+	XIDErrors []int64 `json:"xidErrors,omitempty"`
+	// ECCErrors is the volatile ECC error counts of the GPU
+	ECCErrors *DeviceECCErrors `json:"eccErrors,omitempty"`
+	// Conditions is the latest observed conditions of the device
+	Conditions []DeviceCondition `json:"conditions,omitempty"`
+}
+
+type DeviceECCErrors struct {
+	// Corrected is the count of single bit ECC errors
+	Corrected int64 `json:"corrected"`
+	// Uncorrected is the count of double bit ECC errors
+	Uncorrected int64 `json:"uncorrected"`
+}
+
+type DeviceCondition struct {
+	// Type is the type of the condition
+	Type DeviceConditionType `json:"type"`
+	// Status is the status of the condition, one of True, False, Unknown
+	Status metav1.ConditionStatus `json:"status"`
+	// Reason is a brief CamelCase string that describes the reason of the last transition
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about the last transition
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
 type DeviceAllocation struct {
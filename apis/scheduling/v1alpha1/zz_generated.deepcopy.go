@@ -96,6 +96,70 @@ func (in *DeviceAllocationItem) DeepCopy() *DeviceAllocationItem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceCondition) DeepCopyInto(out *DeviceCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceCondition.
+func (in *DeviceCondition) DeepCopy() *DeviceCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceConditions) DeepCopyInto(out *DeviceConditions) {
+	*out = *in
+	in.LastSeenTime.DeepCopyInto(&out.LastSeenTime)
+	if in.XIDErrors != nil {
+		in, out := &in.XIDErrors, &out.XIDErrors
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	if in.ECCErrors != nil {
+		in, out := &in.ECCErrors, &out.ECCErrors
+		*out = new(DeviceECCErrors)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DeviceCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceConditions.
+func (in *DeviceConditions) DeepCopy() *DeviceConditions {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceConditions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceECCErrors) DeepCopyInto(out *DeviceECCErrors) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceECCErrors.
+func (in *DeviceECCErrors) DeepCopy() *DeviceECCErrors {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceECCErrors)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeviceInfo) DeepCopyInto(out *DeviceInfo) {
 	*out = *in
@@ -111,6 +175,16 @@ func (in *DeviceInfo) DeepCopyInto(out *DeviceInfo) {
 			(*out)[key] = val.DeepCopy()
 		}
 	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(DeviceTopology)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VFs != nil {
+		in, out := &in.VFs, &out.VFs
+		*out = make([]VirtualFunction, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceInfo.
@@ -187,6 +261,13 @@ func (in *DeviceStatus) DeepCopyInto(out *DeviceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DeviceConditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceStatus.
@@ -199,6 +280,26 @@ func (in *DeviceStatus) DeepCopy() *DeviceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceTopology) DeepCopyInto(out *DeviceTopology) {
+	*out = *in
+	if in.NVLinks != nil {
+		in, out := &in.NVLinks, &out.NVLinks
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceTopology.
+func (in *DeviceTopology) DeepCopy() *DeviceTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodMigrateReservationOptions) DeepCopyInto(out *PodMigrateReservationOptions) {
 	*out = *in
@@ -634,3 +735,18 @@ func (in *ReservationTemplateSpec) DeepCopy() *ReservationTemplateSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualFunction) DeepCopyInto(out *VirtualFunction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualFunction.
+func (in *VirtualFunction) DeepCopy() *VirtualFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualFunction)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,12 +33,30 @@ const (
 	P95 AggregationType = "p95"
 	P90 AggregationType = "p90"
 	P50 AggregationType = "p50"
+	// EWMA is the exponentially weighted moving average of the samples in the aggregation duration,
+	// the half-life of the weights is a quarter of the time span between the oldest and the latest sample,
+	// so the recent samples dominate even if the samples do not fill the whole duration
+	EWMA AggregationType = "ewma"
 )
 
 type NodeMetricInfo struct {
 	NodeUsage ResourceMap `json:"nodeUsage,omitempty"`
 	// AggregatedNodeUsages will report only if there are enough samples
 	AggregatedNodeUsages []AggregatedUsage `json:"aggregatedNodeUsages,omitempty"`
+	// NodeIOUsage is the average network and disk io throughput of the node
+	NodeIOUsage *IOUsage `json:"nodeIOUsage,omitempty"`
+}
+
+// IOUsage describes the network and disk io throughput, all quantities are in units per second.
+type IOUsage struct {
+	NetworkReceiveBytes    resource.Quantity `json:"networkReceiveBytes,omitempty"`
+	NetworkTransmitBytes   resource.Quantity `json:"networkTransmitBytes,omitempty"`
+	NetworkReceivePackets  resource.Quantity `json:"networkReceivePackets,omitempty"`
+	NetworkTransmitPackets resource.Quantity `json:"networkTransmitPackets,omitempty"`
+	DiskReadBytes          resource.Quantity `json:"diskReadBytes,omitempty"`
+	DiskWriteBytes         resource.Quantity `json:"diskWriteBytes,omitempty"`
+	DiskReadIOPS           resource.Quantity `json:"diskReadIOPS,omitempty"`
+	DiskWriteIOPS          resource.Quantity `json:"diskWriteIOPS,omitempty"`
 }
 
 type AggregatedUsage struct {
@@ -49,6 +68,10 @@ type PodMetricInfo struct {
 	Name      string      `json:"name,omitempty"`
 	Namespace string      `json:"namespace,omitempty"`
 	PodUsage  ResourceMap `json:"podUsage,omitempty"`
+	// AggregatedPodUsages will report only if there are enough samples
+	AggregatedPodUsages []AggregatedUsage `json:"aggregatedPodUsages,omitempty"`
+	// PodIOUsage is the average network and disk io throughput of the pod
+	PodIOUsage *IOUsage `json:"podIOUsage,omitempty"`
 	// Third party extensions for PodMetric
 	Extensions *ExtensionsMap `json:"extensions,omitempty"`
 }
@@ -67,6 +90,8 @@ type NodeMetricCollectPolicy struct {
 	ReportIntervalSeconds *int64 `json:"reportIntervalSeconds,omitempty"`
 	// NodeAggregatePolicy represents the target grain of node aggregated usage
 	NodeAggregatePolicy *AggregatePolicy `json:"nodeAggregatePolicy,omitempty"`
+	// PodAggregatePolicy represents the target grain of pod aggregated usage
+	PodAggregatePolicy *AggregatePolicy `json:"podAggregatePolicy,omitempty"`
 }
 
 type AggregatePolicy struct {
@@ -129,6 +129,7 @@ type ResourceQOS struct {
 	CPUQOS     *CPUQOSCfg     `json:"cpuQOS,omitempty"`
 	MemoryQOS  *MemoryQOSCfg  `json:"memoryQOS,omitempty"`
 	ResctrlQOS *ResctrlQOSCfg `json:"resctrlQOS,omitempty"`
+	IOQOS      *IOQOSCfg      `json:"ioQOS,omitempty"`
 }
 
 type ResourceQOSStrategy struct {
@@ -155,6 +156,23 @@ const (
 	CPUCfsQuotaPolicy CPUSuppressPolicy = "cfsQuota"
 )
 
+type EvictionVictimPolicy string
+
+const (
+	// EvictionVictimPolicyDefault evicts the pods with lower priority and higher resource usage first.
+	EvictionVictimPolicyDefault EvictionVictimPolicy = "Default"
+	// EvictionVictimPolicyEvictionCost evicts the pods with lower eviction cost annotated by
+	// `scheduling.koordinator.sh/eviction-cost` first.
+	EvictionVictimPolicyEvictionCost EvictionVictimPolicy = "EvictionCost"
+	// EvictionVictimPolicyRunningTime evicts the pods started most recently first, which keeps the long-running pods.
+	EvictionVictimPolicyRunningTime EvictionVictimPolicy = "RunningTime"
+	// EvictionVictimPolicyUsageRatio evicts the pods with higher ratio of the resource usage over the request first.
+	EvictionVictimPolicyUsageRatio EvictionVictimPolicy = "UsageRatio"
+	// EvictionVictimPolicyPDBHeadroom evicts the pods whose PodDisruptionBudgets allow more disruptions first.
+	// It requires the koordlet feature gate PDBHeadroomVictimPolicy, otherwise the default policy is used.
+	EvictionVictimPolicyPDBHeadroom EvictionVictimPolicy = "PDBHeadroom"
+)
+
 type ResourceThresholdStrategy struct {
 	// whether the strategy is enabled, default = false
 	Enable *bool `json:"enable,omitempty"`
@@ -174,6 +192,17 @@ type ResourceThresholdStrategy struct {
 	// +kubebuilder:validation:Maximum=100
 	// +kubebuilder:validation:Minimum=0
 	MemoryEvictLowerPercent *int64 `json:"memoryEvictLowerPercent,omitempty"`
+	// memory suppress threshold percentage (0,100), BE pods are throttled by lowering the memory.high of the
+	// best-effort cgroup when the node memory usage exceeds it, which should be less than MemoryEvictThresholdPercent.
+	// Only available on cgroups-v2 or the Anolis OS.
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Minimum=0
+	MemorySuppressThresholdPercent *int64 `json:"memorySuppressThresholdPercent,omitempty"`
+	// the lower bound of the suppressed memory.high for BE pods by the percentage of the node memory capacity,
+	// default = 5
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Minimum=0
+	MemorySuppressMinPercent *int64 `json:"memorySuppressMinPercent,omitempty"`
 
 	// if be CPU RealLimit/allocatedLimit > CPUEvictBESatisfactionUpperPercent/100, then stop evict BE pods
 	CPUEvictBESatisfactionUpperPercent *int64 `json:"cpuEvictBESatisfactionUpperPercent,omitempty"`
@@ -183,6 +212,14 @@ type ResourceThresholdStrategy struct {
 	CPUEvictBEUsageThresholdPercent *int64 `json:"cpuEvictBEUsageThresholdPercent,omitempty"`
 	// cpu evict start after continue avg(cpuusage) > CPUEvictThresholdPercent in seconds
 	CPUEvictTimeWindowSeconds *int64 `json:"cpuEvictTimeWindowSeconds,omitempty"`
+
+	// EvictionVictimPolicy decides the order of the BE pods to evict. The pods with lower priority are always evicted
+	// first, and the policy ranks the pods with the same priority, default = Default
+	// +kubebuilder:validation:Enum=Default;EvictionCost;RunningTime;UsageRatio;PDBHeadroom
+	EvictionVictimPolicy EvictionVictimPolicy `json:"evictionVictimPolicy,omitempty"`
+	// EvictDryRun runs the eviction decisions of the BE pods but only records the pods which would have been evicted
+	// without killing them, which helps to tune the thresholds before enforcing, default = false
+	EvictDryRun *bool `json:"evictDryRun,omitempty"`
 }
 
 // ResctrlQOSCfg stores node-level config of resctrl qos
@@ -207,6 +244,42 @@ type ResctrlQOS struct {
 	MBAPercent *int64 `json:"mbaPercent,omitempty"`
 }
 
+// IOQOSCfg stores node-level config of block io qos
+type IOQOSCfg struct {
+	// Enable indicates whether the io qos is enabled.
+	Enable *bool `json:"enable,omitempty"`
+	IOQOS  `json:",inline"`
+}
+
+type IOQOS struct {
+	// IOWeightPercent is the relative io weight of the pods by percentage, which is mapped to `blkio.weight` (10~1000)
+	// on cgroup v1 and `io.weight` (1~10000) on cgroup v2.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	IOWeightPercent *int64 `json:"ioWeightPercent,omitempty"`
+	// Blocks are the throttling limits of the pods on the block devices.
+	Blocks []BlockIOCfg `json:"blocks,omitempty"`
+}
+
+// BlockIOCfg stores the io throttling limits of a block device, the limit is unlimited when it is zero.
+type BlockIOCfg struct {
+	// Device is the block device, which can be a device name under `/sys/block` like "vda", or
+	// a device number like "253:0".
+	Device string `json:"device"`
+	// ReadBPS is the read bytes per second limit.
+	// +kubebuilder:validation:Minimum=0
+	ReadBPS *int64 `json:"readBPS,omitempty"`
+	// WriteBPS is the write bytes per second limit.
+	// +kubebuilder:validation:Minimum=0
+	WriteBPS *int64 `json:"writeBPS,omitempty"`
+	// ReadIOPS is the read io operations per second limit.
+	// +kubebuilder:validation:Minimum=0
+	ReadIOPS *int64 `json:"readIOPS,omitempty"`
+	// WriteIOPS is the write io operations per second limit.
+	// +kubebuilder:validation:Minimum=0
+	WriteIOPS *int64 `json:"writeIOPS,omitempty"`
+}
+
 type CPUBurstPolicy string
 
 const (
@@ -71,6 +71,41 @@ func (in *AggregatedUsage) DeepCopy() *AggregatedUsage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlockIOCfg) DeepCopyInto(out *BlockIOCfg) {
+	*out = *in
+	if in.ReadBPS != nil {
+		in, out := &in.ReadBPS, &out.ReadBPS
+		*out = new(int64)
+		**out = **in
+	}
+	if in.WriteBPS != nil {
+		in, out := &in.WriteBPS, &out.WriteBPS
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ReadIOPS != nil {
+		in, out := &in.ReadIOPS, &out.ReadIOPS
+		*out = new(int64)
+		**out = **in
+	}
+	if in.WriteIOPS != nil {
+		in, out := &in.WriteIOPS, &out.WriteIOPS
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockIOCfg.
+func (in *BlockIOCfg) DeepCopy() *BlockIOCfg {
+	if in == nil {
+		return nil
+	}
+	out := new(BlockIOCfg)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CPUBurstConfig) DeepCopyInto(out *CPUBurstConfig) {
 	*out = *in
@@ -163,6 +198,77 @@ func (in *CPUQOSCfg) DeepCopy() *CPUQOSCfg {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IOQOS) DeepCopyInto(out *IOQOS) {
+	*out = *in
+	if in.IOWeightPercent != nil {
+		in, out := &in.IOWeightPercent, &out.IOWeightPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Blocks != nil {
+		in, out := &in.Blocks, &out.Blocks
+		*out = make([]BlockIOCfg, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOQOS.
+func (in *IOQOS) DeepCopy() *IOQOS {
+	if in == nil {
+		return nil
+	}
+	out := new(IOQOS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IOQOSCfg) DeepCopyInto(out *IOQOSCfg) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+	in.IOQOS.DeepCopyInto(&out.IOQOS)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOQOSCfg.
+func (in *IOQOSCfg) DeepCopy() *IOQOSCfg {
+	if in == nil {
+		return nil
+	}
+	out := new(IOQOSCfg)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IOUsage) DeepCopyInto(out *IOUsage) {
+	*out = *in
+	out.NetworkReceiveBytes = in.NetworkReceiveBytes.DeepCopy()
+	out.NetworkTransmitBytes = in.NetworkTransmitBytes.DeepCopy()
+	out.NetworkReceivePackets = in.NetworkReceivePackets.DeepCopy()
+	out.NetworkTransmitPackets = in.NetworkTransmitPackets.DeepCopy()
+	out.DiskReadBytes = in.DiskReadBytes.DeepCopy()
+	out.DiskWriteBytes = in.DiskWriteBytes.DeepCopy()
+	out.DiskReadIOPS = in.DiskReadIOPS.DeepCopy()
+	out.DiskWriteIOPS = in.DiskWriteIOPS.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOUsage.
+func (in *IOUsage) DeepCopy() *IOUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(IOUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemoryQOS) DeepCopyInto(out *MemoryQOS) {
 	*out = *in
@@ -289,6 +395,11 @@ func (in *NodeMetricCollectPolicy) DeepCopyInto(out *NodeMetricCollectPolicy) {
 		*out = new(AggregatePolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodAggregatePolicy != nil {
+		in, out := &in.PodAggregatePolicy, &out.PodAggregatePolicy
+		*out = new(AggregatePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMetricCollectPolicy.
@@ -312,6 +423,11 @@ func (in *NodeMetricInfo) DeepCopyInto(out *NodeMetricInfo) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeIOUsage != nil {
+		in, out := &in.NodeIOUsage, &out.NodeIOUsage
+		*out = new(IOUsage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMetricInfo.
@@ -544,6 +660,18 @@ func (in *PodMemoryQOSConfig) DeepCopy() *PodMemoryQOSConfig {
 func (in *PodMetricInfo) DeepCopyInto(out *PodMetricInfo) {
 	*out = *in
 	in.PodUsage.DeepCopyInto(&out.PodUsage)
+	if in.AggregatedPodUsages != nil {
+		in, out := &in.AggregatedPodUsages, &out.AggregatedPodUsages
+		*out = make([]AggregatedUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodIOUsage != nil {
+		in, out := &in.PodIOUsage, &out.PodIOUsage
+		*out = new(IOUsage)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Extensions != nil {
 		in, out := &in.Extensions, &out.Extensions
 		*out = (*in).DeepCopy()
@@ -658,6 +786,11 @@ func (in *ResourceQOS) DeepCopyInto(out *ResourceQOS) {
 		*out = new(ResctrlQOSCfg)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IOQOS != nil {
+		in, out := &in.IOQOS, &out.IOQOS
+		*out = new(IOQOSCfg)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQOS.
@@ -733,6 +866,16 @@ func (in *ResourceThresholdStrategy) DeepCopyInto(out *ResourceThresholdStrategy
 		*out = new(int64)
 		**out = **in
 	}
+	if in.MemorySuppressThresholdPercent != nil {
+		in, out := &in.MemorySuppressThresholdPercent, &out.MemorySuppressThresholdPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MemorySuppressMinPercent != nil {
+		in, out := &in.MemorySuppressMinPercent, &out.MemorySuppressMinPercent
+		*out = new(int64)
+		**out = **in
+	}
 	if in.CPUEvictBESatisfactionUpperPercent != nil {
 		in, out := &in.CPUEvictBESatisfactionUpperPercent, &out.CPUEvictBESatisfactionUpperPercent
 		*out = new(int64)
@@ -753,6 +896,11 @@ func (in *ResourceThresholdStrategy) DeepCopyInto(out *ResourceThresholdStrategy
 		*out = new(int64)
 		**out = **in
 	}
+	if in.EvictDryRun != nil {
+		in, out := &in.EvictDryRun, &out.EvictDryRun
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceThresholdStrategy.
@@ -28,6 +28,7 @@ import (
 	"github.com/koordinator-sh/koordinator/cmd/koord-runtime-proxy/options"
 	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/server/cri"
 	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/server/docker"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/store"
 )
 
 func main() {
@@ -44,6 +45,8 @@ func main() {
 			"skip transferring cri events to hook server")
 	flag.StringVar(&options.RuntimeHookServerVal, "runtime-hook-server-val", options.DefaultHookServerVal,
 		"working combined with runtime-hook-server-key")
+	flag.StringVar(&options.CheckpointDir, "checkpoint-dir", options.DefaultCheckpointDir,
+		"dir to checkpoint pod and container metadata across restarts, empty means keeping them in memory only.")
 
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 	pflag.Parse()
@@ -56,6 +59,10 @@ func main() {
 		klog.Fatalf("failed to mkdir %v: %v", filepath.Dir(options.RuntimeProxyEndpoint), err)
 	}
 
+	if err := store.InitCheckpoint(options.CheckpointDir); err != nil {
+		klog.Fatalf("failed to init checkpoint in %v: %v", options.CheckpointDir, err)
+	}
+
 	switch options.BackendRuntimeMode {
 	case options.BackendRuntimeModeContainerd:
 		server := cri.NewRuntimeManagerCriServer()
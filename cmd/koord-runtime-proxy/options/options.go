@@ -28,6 +28,8 @@ const (
 
 	DefaultHookServerKey = "runtimeproxy.koordinator.sh/skip-hookserver"
 	DefaultHookServerVal = "true"
+
+	DefaultCheckpointDir = "/var/lib/koord-runtimeproxy/checkpoint"
 )
 
 var (
@@ -40,4 +42,7 @@ var (
 
 	RuntimeHookServerKey string
 	RuntimeHookServerVal string
+
+	// CheckpointDir is the dir to checkpoint pod and container infos, empty means no checkpoint
+	CheckpointDir string
 )
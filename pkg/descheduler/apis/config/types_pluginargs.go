@@ -74,6 +74,18 @@ type RemovePodsViolatingNodeAffinityArgs struct {
 	NodeAffinityType []string
 }
 
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsOnUnhealthyDevicesArgs holds arguments used to configure the RemovePodsOnUnhealthyDevices plugin.
+type RemovePodsOnUnhealthyDevicesArgs struct {
+	metav1.TypeMeta
+
+	Namespaces    *Namespaces
+	LabelSelector *metav1.LabelSelector
+	// UnhealthyGracePeriod is the duration that a device keeps unhealthy before the pods using it are evicted.
+	UnhealthyGracePeriod metav1.Duration
+}
+
 // Namespaces carries a list of included/excluded namespaces
 // for which a given strategy is applicable
 type Namespaces struct {
@@ -41,6 +41,8 @@ const (
 	defaultMigrationJobEvictionPolicy = migrationevictor.NativeEvictorName
 	defaultMigrationEvictQPS          = 10
 	defaultMigrationEvictBurst        = 1
+
+	defaultDeviceUnhealthyGracePeriod = 5 * time.Minute
 )
 
 var (
@@ -218,6 +220,12 @@ func SetDefaults_RemovePodsViolatingNodeAffinityArgs(obj *RemovePodsViolatingNod
 	}
 }
 
+func SetDefaults_RemovePodsOnUnhealthyDevicesArgs(obj *RemovePodsOnUnhealthyDevicesArgs) {
+	if obj.UnhealthyGracePeriod == nil {
+		obj.UnhealthyGracePeriod = &metav1.Duration{Duration: defaultDeviceUnhealthyGracePeriod}
+	}
+}
+
 func SetDefaults_MigrationControllerArgs(obj *MigrationControllerArgs) {
 	if obj.MaxConcurrentReconciles == nil {
 		obj.MaxConcurrentReconciles = pointer.Int32(defaultMigrationControllerMaxConcurrentReconciles)
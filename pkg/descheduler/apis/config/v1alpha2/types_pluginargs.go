@@ -76,6 +76,19 @@ type RemovePodsViolatingNodeAffinityArgs struct {
 	NodeAffinityType []string              `json:"nodeAffinityType,omitempty"`
 }
 
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsOnUnhealthyDevicesArgs holds arguments used to configure the RemovePodsOnUnhealthyDevices plugin.
+type RemovePodsOnUnhealthyDevicesArgs struct {
+	metav1.TypeMeta
+
+	Namespaces    *Namespaces           `json:"namespaces,omitempty"`
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// UnhealthyGracePeriod is the duration that a device keeps unhealthy before the pods using it are evicted.
+	// Defaults to 5 minutes.
+	UnhealthyGracePeriod *metav1.Duration `json:"unhealthyGracePeriod,omitempty"`
+}
+
 // Namespaces carries a list of included/excluded namespaces
 // for which a given strategy is applicable
 type Namespaces struct {
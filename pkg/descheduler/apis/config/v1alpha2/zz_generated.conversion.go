@@ -169,6 +169,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RemovePodsOnUnhealthyDevicesArgs)(nil), (*config.RemovePodsOnUnhealthyDevicesArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RemovePodsOnUnhealthyDevicesArgs_To_config_RemovePodsOnUnhealthyDevicesArgs(a.(*RemovePodsOnUnhealthyDevicesArgs), b.(*config.RemovePodsOnUnhealthyDevicesArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RemovePodsOnUnhealthyDevicesArgs)(nil), (*RemovePodsOnUnhealthyDevicesArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RemovePodsOnUnhealthyDevicesArgs_To_v1alpha2_RemovePodsOnUnhealthyDevicesArgs(a.(*config.RemovePodsOnUnhealthyDevicesArgs), b.(*RemovePodsOnUnhealthyDevicesArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RemovePodsViolatingNodeAffinityArgs)(nil), (*config.RemovePodsViolatingNodeAffinityArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_RemovePodsViolatingNodeAffinityArgs_To_config_RemovePodsViolatingNodeAffinityArgs(a.(*RemovePodsViolatingNodeAffinityArgs), b.(*config.RemovePodsViolatingNodeAffinityArgs), scope)
 	}); err != nil {
@@ -706,6 +716,34 @@ func Convert_config_PriorityThreshold_To_v1alpha2_PriorityThreshold(in *config.P
 	return autoConvert_config_PriorityThreshold_To_v1alpha2_PriorityThreshold(in, out, s)
 }
 
+func autoConvert_v1alpha2_RemovePodsOnUnhealthyDevicesArgs_To_config_RemovePodsOnUnhealthyDevicesArgs(in *RemovePodsOnUnhealthyDevicesArgs, out *config.RemovePodsOnUnhealthyDevicesArgs, s conversion.Scope) error {
+	out.Namespaces = (*config.Namespaces)(unsafe.Pointer(in.Namespaces))
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.UnhealthyGracePeriod, &out.UnhealthyGracePeriod, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_RemovePodsOnUnhealthyDevicesArgs_To_config_RemovePodsOnUnhealthyDevicesArgs is an autogenerated conversion function.
+func Convert_v1alpha2_RemovePodsOnUnhealthyDevicesArgs_To_config_RemovePodsOnUnhealthyDevicesArgs(in *RemovePodsOnUnhealthyDevicesArgs, out *config.RemovePodsOnUnhealthyDevicesArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RemovePodsOnUnhealthyDevicesArgs_To_config_RemovePodsOnUnhealthyDevicesArgs(in, out, s)
+}
+
+func autoConvert_config_RemovePodsOnUnhealthyDevicesArgs_To_v1alpha2_RemovePodsOnUnhealthyDevicesArgs(in *config.RemovePodsOnUnhealthyDevicesArgs, out *RemovePodsOnUnhealthyDevicesArgs, s conversion.Scope) error {
+	out.Namespaces = (*Namespaces)(unsafe.Pointer(in.Namespaces))
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.UnhealthyGracePeriod, &out.UnhealthyGracePeriod, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_RemovePodsOnUnhealthyDevicesArgs_To_v1alpha2_RemovePodsOnUnhealthyDevicesArgs is an autogenerated conversion function.
+func Convert_config_RemovePodsOnUnhealthyDevicesArgs_To_v1alpha2_RemovePodsOnUnhealthyDevicesArgs(in *config.RemovePodsOnUnhealthyDevicesArgs, out *RemovePodsOnUnhealthyDevicesArgs, s conversion.Scope) error {
+	return autoConvert_config_RemovePodsOnUnhealthyDevicesArgs_To_v1alpha2_RemovePodsOnUnhealthyDevicesArgs(in, out, s)
+}
+
 func autoConvert_v1alpha2_RemovePodsViolatingNodeAffinityArgs_To_config_RemovePodsViolatingNodeAffinityArgs(in *RemovePodsViolatingNodeAffinityArgs, out *config.RemovePodsViolatingNodeAffinityArgs, s conversion.Scope) error {
 	out.Namespaces = (*config.Namespaces)(unsafe.Pointer(in.Namespaces))
 	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
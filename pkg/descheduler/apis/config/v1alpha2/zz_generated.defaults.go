@@ -33,6 +33,9 @@ func RegisterDefaults(scheme *runtime.Scheme) error {
 	scheme.AddTypeDefaultingFunc(&DeschedulerConfiguration{}, func(obj interface{}) { SetObjectDefaults_DeschedulerConfiguration(obj.(*DeschedulerConfiguration)) })
 	scheme.AddTypeDefaultingFunc(&LowNodeLoadArgs{}, func(obj interface{}) { SetObjectDefaults_LowNodeLoadArgs(obj.(*LowNodeLoadArgs)) })
 	scheme.AddTypeDefaultingFunc(&MigrationControllerArgs{}, func(obj interface{}) { SetObjectDefaults_MigrationControllerArgs(obj.(*MigrationControllerArgs)) })
+	scheme.AddTypeDefaultingFunc(&RemovePodsOnUnhealthyDevicesArgs{}, func(obj interface{}) {
+		SetObjectDefaults_RemovePodsOnUnhealthyDevicesArgs(obj.(*RemovePodsOnUnhealthyDevicesArgs))
+	})
 	scheme.AddTypeDefaultingFunc(&RemovePodsViolatingNodeAffinityArgs{}, func(obj interface{}) {
 		SetObjectDefaults_RemovePodsViolatingNodeAffinityArgs(obj.(*RemovePodsViolatingNodeAffinityArgs))
 	})
@@ -55,6 +58,10 @@ func SetObjectDefaults_MigrationControllerArgs(in *MigrationControllerArgs) {
 	SetDefaults_MigrationControllerArgs(in)
 }
 
+func SetObjectDefaults_RemovePodsOnUnhealthyDevicesArgs(in *RemovePodsOnUnhealthyDevicesArgs) {
+	SetDefaults_RemovePodsOnUnhealthyDevicesArgs(in)
+}
+
 func SetObjectDefaults_RemovePodsViolatingNodeAffinityArgs(in *RemovePodsViolatingNodeAffinityArgs) {
 	SetDefaults_RemovePodsViolatingNodeAffinityArgs(in)
 }
@@ -81,6 +81,7 @@ func validatePluginConfig(path *field.Path, profile *config.DeschedulerProfile)
 		// NOTE: you can add the in-tree plugins configuration validation function
 		names.MigrationController:         ValidateMigrationControllerArgs,
 		"RemovePodsViolatingNodeAffinity": ValidateRemovePodsViolatingNodeAffinityArgs,
+		"RemovePodsOnUnhealthyDevices":    ValidateRemovePodsOnUnhealthyDevicesArgs,
 	}
 
 	seenPluginConfig := make(sets.String)
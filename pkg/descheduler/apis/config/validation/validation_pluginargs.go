@@ -44,6 +44,23 @@ func ValidateRemovePodsViolatingNodeAffinityArgs(path *field.Path, args *desched
 	return allErrs.ToAggregate()
 }
 
+func ValidateRemovePodsOnUnhealthyDevicesArgs(path *field.Path, args *deschedulerconfig.RemovePodsOnUnhealthyDevicesArgs) error {
+	var allErrs field.ErrorList
+
+	if args.UnhealthyGracePeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("unhealthyGracePeriod"), args.UnhealthyGracePeriod, "unhealthyGracePeriod should be greater or equal 0"))
+	}
+	// At most one of include/exclude can be set
+	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("namespaces"), args.Namespaces, "only one of Include/Exclude namespaces can be set"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
+
 func ValidateMigrationControllerArgs(path *field.Path, args *deschedulerconfig.MigrationControllerArgs) error {
 	var allErrs field.ErrorList
 
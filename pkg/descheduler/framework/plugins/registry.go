@@ -19,6 +19,7 @@ package plugins
 import (
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/defaultevictor"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/loadaware"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/removepodsonunhealthydevices"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/removepodsviolatingnodeaffinity"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
 )
@@ -28,5 +29,6 @@ func NewInTreeRegistry() runtime.Registry {
 		removepodsviolatingnodeaffinity.PluginName: removepodsviolatingnodeaffinity.New,
 		defaultevictor.PluginName:                  defaultevictor.New,
 		loadaware.LowLoadUtilizationName:           loadaware.NewLowNodeLoad,
+		removepodsonunhealthydevices.PluginName:    removepodsonunhealthydevices.New,
 	}
 }
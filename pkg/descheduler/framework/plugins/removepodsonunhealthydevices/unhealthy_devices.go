@@ -0,0 +1,200 @@
+/*
+Copyright 2022 The Koordinator Authors.
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsonunhealthydevices
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	schedulinglisters "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+const PluginName = "RemovePodsOnUnhealthyDevices"
+
+// RemovePodsOnUnhealthyDevices evicts pods which are using the unhealthy devices reported in the Device,
+// the evicted pods are migrated by PodMigrationJobs if the MigrationController is the evictor.
+type RemovePodsOnUnhealthyDevices struct {
+	handle       framework.Handle
+	args         *deschedulerconfig.RemovePodsOnUnhealthyDevicesArgs
+	podFilter    podutil.FilterFunc
+	deviceLister schedulinglisters.DeviceLister
+}
+
+var _ framework.Plugin = &RemovePodsOnUnhealthyDevices{}
+var _ framework.DeschedulePlugin = &RemovePodsOnUnhealthyDevices{}
+
+func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	unhealthyDevicesArgs, ok := args.(*deschedulerconfig.RemovePodsOnUnhealthyDevicesArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsOnUnhealthyDevicesArgs, got %T", args)
+	}
+
+	if err := validation.ValidateRemovePodsOnUnhealthyDevicesArgs(nil, unhealthyDevicesArgs); err != nil {
+		return nil, err
+	}
+
+	var includedNamespaces, excludedNamespaces sets.String
+	if unhealthyDevicesArgs.Namespaces != nil {
+		includedNamespaces = sets.NewString(unhealthyDevicesArgs.Namespaces.Include...)
+		excludedNamespaces = sets.NewString(unhealthyDevicesArgs.Namespaces.Exclude...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(unhealthyDevicesArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	koordClientSet, ok := handle.(koordclientset.Interface)
+	if !ok {
+		kubeConfig := *handle.KubeConfig()
+		kubeConfig.ContentType = runtime.ContentTypeJSON
+		kubeConfig.AcceptContentTypes = runtime.ContentTypeJSON
+		var err error
+		koordClientSet, err = koordclientset.NewForConfig(&kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
+	deviceInformer := koordSharedInformerFactory.Scheduling().V1alpha1().Devices()
+	deviceInformer.Informer()
+	koordSharedInformerFactory.Start(context.TODO().Done())
+	koordSharedInformerFactory.WaitForCacheSync(context.TODO().Done())
+
+	return &RemovePodsOnUnhealthyDevices{
+		handle:       handle,
+		args:         unhealthyDevicesArgs,
+		podFilter:    podFilter,
+		deviceLister: deviceInformer.Lister(),
+	}, nil
+}
+
+func (d *RemovePodsOnUnhealthyDevices) Name() string {
+	return PluginName
+}
+
+func (d *RemovePodsOnUnhealthyDevices) Deschedule(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	now := time.Now()
+	for _, node := range nodes {
+		device, err := d.deviceLister.Get(node.Name)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				klog.ErrorS(err, "Failed to get Device", "node", klog.KObj(node))
+			}
+			continue
+		}
+		failedDevices := getFailedDevices(device, d.args.UnhealthyGracePeriod.Duration, now)
+		if len(failedDevices) == 0 {
+			continue
+		}
+		klog.V(4).InfoS("Processing node with failed devices", "node", klog.KObj(node), "devices", failedDevices)
+
+		pods, err := podutil.ListPodsOnANode(
+			node.Name,
+			d.handle.GetPodsAssignedToNodeFunc(),
+			d.podFilter,
+		)
+		if err != nil {
+			klog.ErrorS(err, "Failed to get pods", "node", klog.KObj(node))
+			continue
+		}
+
+		for _, pod := range pods {
+			devices := getPodFailedDevices(pod, failedDevices)
+			if len(devices) == 0 {
+				continue
+			}
+			klog.V(1).InfoS("Evicting pod", "pod", klog.KObj(pod), "devices", devices)
+			d.handle.Evictor().Evict(ctx, pod, framework.EvictOptions{
+				PluginName: PluginName,
+				Reason:     fmt.Sprintf("Pod is using unhealthy devices %v", devices),
+			})
+		}
+	}
+	return nil
+}
+
+// getFailedDevices returns the unhealthy devices of the node except the ones which turn unhealthy within the grace period.
+// The grace period starts from the transition of the Healthy condition to False, so the devices without such a condition
+// are skipped until it is reported, unless the grace period is zero.
+func getFailedDevices(device *schedulingv1alpha1.Device, gracePeriod time.Duration, now time.Time) map[schedulingv1alpha1.DeviceType]sets.Int {
+	failedDevices := map[schedulingv1alpha1.DeviceType]sets.Int{}
+	for deviceType, minors := range util.GetUnhealthyDevices(device) {
+		for _, minor := range minors.UnsortedList() {
+			if gracePeriod > 0 && !isUnhealthyBeyondGracePeriod(device, deviceType, int32(minor), gracePeriod, now) {
+				continue
+			}
+			if failedDevices[deviceType] == nil {
+				failedDevices[deviceType] = sets.NewInt()
+			}
+			failedDevices[deviceType].Insert(minor)
+		}
+	}
+	return failedDevices
+}
+
+func isUnhealthyBeyondGracePeriod(device *schedulingv1alpha1.Device, deviceType schedulingv1alpha1.DeviceType, minor int32, gracePeriod time.Duration, now time.Time) bool {
+	conditions := util.GetDeviceConditions(device, deviceType, minor)
+	if conditions == nil {
+		return false
+	}
+	healthy := util.GetDeviceCondition(conditions.Conditions, schedulingv1alpha1.DeviceHealthy)
+	return healthy != nil && healthy.Status == metav1.ConditionFalse && now.Sub(healthy.LastTransitionTime.Time) >= gracePeriod
+}
+
+// getPodFailedDevices returns the failed devices allocated to the pod, formatted as "<type>-<minor>".
+func getPodFailedDevices(pod *corev1.Pod, failedDevices map[schedulingv1alpha1.DeviceType]sets.Int) []string {
+	allocations, err := apiext.GetDeviceAllocations(pod.Annotations)
+	if err != nil {
+		klog.V(4).InfoS("Failed to get device allocations", "pod", klog.KObj(pod), "err", err)
+		return nil
+	}
+	var devices []string
+	for deviceType, allocation := range allocations {
+		for _, d := range allocation {
+			if failedDevices[deviceType].Has(int(d.Minor)) {
+				devices = append(devices, fmt.Sprintf("%s-%d", deviceType, d.Minor))
+			}
+		}
+	}
+	sort.Strings(devices)
+	return devices
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2022 The Koordinator Authors.
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsonunhealthydevices
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordinatorclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+type fakeFrameworkHandle struct {
+	framework.Handle
+	koordinatorclientset.Interface
+}
+
+type fakeEvictor struct {
+	evictedPods []string
+}
+
+func (f *fakeEvictor) Name() string {
+	return "FakeEvictor"
+}
+
+func (f *fakeEvictor) Filter(pod *corev1.Pod) bool {
+	return true
+}
+
+func (f *fakeEvictor) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	f.evictedPods = append(f.evictedPods, pod.Name)
+	return true
+}
+
+func newDevice(nodeName string, transitionTime time.Time) *schedulingv1alpha1.Device {
+	return &schedulingv1alpha1.Device{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nodeName,
+		},
+		Spec: schedulingv1alpha1.DeviceSpec{
+			Devices: []schedulingv1alpha1.DeviceInfo{
+				{Minor: pointer.Int32(0), Type: schedulingv1alpha1.GPU, Health: true},
+				{Minor: pointer.Int32(1), Type: schedulingv1alpha1.GPU, Health: true},
+				{Minor: pointer.Int32(0), Type: schedulingv1alpha1.RDMA, Health: false},
+			},
+		},
+		Status: schedulingv1alpha1.DeviceStatus{
+			Conditions: []schedulingv1alpha1.DeviceConditions{
+				{
+					Minor:     1,
+					Type:      schedulingv1alpha1.GPU,
+					XIDErrors: []int64{79},
+					Conditions: []schedulingv1alpha1.DeviceCondition{
+						{
+							Type:               schedulingv1alpha1.DeviceHealthy,
+							Status:             metav1.ConditionFalse,
+							Reason:             "CriticalXIDError",
+							LastTransitionTime: metav1.NewTime(transitionTime),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_getFailedDevices(t *testing.T) {
+	now := time.Now()
+	device := newDevice("test-node", now.Add(-time.Minute))
+	assert.Equal(t, map[schedulingv1alpha1.DeviceType]sets.Int{
+		schedulingv1alpha1.GPU:  sets.NewInt(1),
+		schedulingv1alpha1.RDMA: sets.NewInt(0),
+	}, getFailedDevices(device, 0, now))
+	// the GPU turns unhealthy within the grace period, and the RDMA has no Healthy condition to start the grace period
+	assert.Equal(t, map[schedulingv1alpha1.DeviceType]sets.Int{}, getFailedDevices(device, 5*time.Minute, now))
+	// the RDMA is reported unhealthy beyond the grace period
+	device.Status.Conditions = append(device.Status.Conditions, schedulingv1alpha1.DeviceConditions{
+		Minor: 0,
+		Type:  schedulingv1alpha1.RDMA,
+		Conditions: []schedulingv1alpha1.DeviceCondition{
+			{
+				Type:               schedulingv1alpha1.DeviceHealthy,
+				Status:             metav1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(now.Add(-10 * time.Minute)),
+			},
+		},
+	})
+	assert.Equal(t, map[schedulingv1alpha1.DeviceType]sets.Int{
+		schedulingv1alpha1.RDMA: sets.NewInt(0),
+	}, getFailedDevices(device, 5*time.Minute, now))
+}
+
+func TestRemovePodsOnUnhealthyDevices(t *testing.T) {
+	withDevices := func(allocations string) func(*corev1.Pod) {
+		return func(pod *corev1.Pod) {
+			pod.Annotations = map[string]string{apiext.AnnotationDeviceAllocated: allocations}
+		}
+	}
+	node := test.BuildTestNode("test-node", 8000, 16*1024*1024*1024, 100, nil)
+	pods := []*corev1.Pod{
+		test.BuildTestPod("p1", 100, 0, node.Name, withDevices(`{"gpu": [{"minor": 0}]}`)),
+		test.BuildTestPod("p2", 100, 0, node.Name, withDevices(`{"gpu": [{"minor": 0}, {"minor": 1}]}`)),
+		test.BuildTestPod("p3", 100, 0, node.Name, withDevices(`{"rdma": [{"minor": 0}]}`)),
+		test.BuildTestPod("p4", 100, 0, node.Name, nil),
+	}
+
+	tests := []struct {
+		name        string
+		gracePeriod time.Duration
+		wantEvicted []string
+	}{
+		{
+			name:        "evict pods on unhealthy devices",
+			wantEvicted: []string{"p2", "p3"},
+		},
+		{
+			name:        "skip the devices within grace period or without Healthy condition",
+			gracePeriod: time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{node}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods()
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			assert.NoError(t, err)
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			evictor := &fakeEvictor{}
+			fh, err := frameworktesting.NewFramework(
+				[]frameworktesting.RegisterPluginFunc{
+					frameworktesting.RegisterEvictorPlugin(evictor.Name(), func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+						return evictor, nil
+					}),
+				},
+				"test",
+				frameworkruntime.WithClientSet(fakeClient),
+				frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+				frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+			)
+			assert.NoError(t, err)
+
+			koordClientSet := koordfake.NewSimpleClientset(newDevice(node.Name, time.Now().Add(-time.Minute)))
+			plugin, err := New(&deschedulerconfig.RemovePodsOnUnhealthyDevicesArgs{
+				UnhealthyGracePeriod: metav1.Duration{Duration: tt.gracePeriod},
+			}, &fakeFrameworkHandle{
+				Handle:    fh,
+				Interface: koordClientSet,
+			})
+			assert.NoError(t, err)
+			plugin.(framework.DeschedulePlugin).Deschedule(ctx, []*corev1.Node{node})
+			assert.ElementsMatch(t, tt.wantEvicted, evictor.evictedPods)
+		})
+	}
+}
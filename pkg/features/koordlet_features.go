@@ -57,6 +57,13 @@ const (
 	// BEMemoryEvict evict best-effort pod based on node memory usage.
 	BEMemoryEvict featuregate.Feature = "BEMemoryEvict"
 
+	// owner: @koordinator-sh
+	// alpha: v1.1
+	//
+	// BEMemorySuppress suppresses best-effort pods by lowering the memory.high of the best-effort cgroup according to
+	// node memory usage, which works before the memory eviction.
+	BEMemorySuppress featuregate.Feature = "BEMemorySuppress"
+
 	// owner: @saintube @zwzhang0107
 	// alpha: v0.2
 	// beta: v1.1
@@ -108,6 +115,26 @@ const (
 	//
 	// PSICollector enables psi collector feature of koordlet.
 	PSICollector featuregate.Feature = "PSICollector"
+
+	// owner: @koordinator-sh
+	// alpha: v1.1
+	//
+	// IOCollector enables the network and disk io collector of koordlet.
+	IOCollector featuregate.Feature = "IOCollector"
+
+	// owner: @koordinator-sh
+	// alpha: v1.1
+	//
+	// GPUMemoryEvict verifies the gpu memory usage of the containers against the allocation of koord-scheduler,
+	// and evicts the BE pods which use more gpu memory than allocated in the consecutive times of verification.
+	GPUMemoryEvict featuregate.Feature = "GPUMemoryEvict"
+
+	// owner: @koordinator-sh
+	// alpha: v1.1
+	//
+	// PDBHeadroomVictimPolicy enables the PDBHeadroom eviction victim policy, which list-watches the
+	// PodDisruptionBudgets of the cluster to rank the eviction victims.
+	PDBHeadroomVictimPolicy featuregate.Feature = "PDBHeadroomVictimPolicy"
 )
 
 func init() {
@@ -119,19 +146,23 @@ var (
 	DefaultKoordletFeatureGate        featuregate.FeatureGate        = DefaultMutableKoordletFeatureGate
 
 	defaultKoordletFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
-		AuditEvents:            {Default: false, PreRelease: featuregate.Alpha},
-		AuditEventsHTTPHandler: {Default: false, PreRelease: featuregate.Alpha},
-		BECPUSuppress:          {Default: true, PreRelease: featuregate.Beta},
-		BECPUEvict:             {Default: false, PreRelease: featuregate.Alpha},
-		BEMemoryEvict:          {Default: false, PreRelease: featuregate.Alpha},
-		CPUBurst:               {Default: true, PreRelease: featuregate.Beta},
-		SystemConfig:           {Default: false, PreRelease: featuregate.Alpha},
-		RdtResctrl:             {Default: true, PreRelease: featuregate.Beta},
-		CgroupReconcile:        {Default: false, PreRelease: featuregate.Alpha},
-		NodeTopologyReport:     {Default: true, PreRelease: featuregate.Beta},
-		Accelerators:           {Default: false, PreRelease: featuregate.Alpha},
-		CPICollector:           {Default: false, PreRelease: featuregate.Alpha},
-		PSICollector:           {Default: false, PreRelease: featuregate.Alpha},
+		AuditEvents:             {Default: false, PreRelease: featuregate.Alpha},
+		AuditEventsHTTPHandler:  {Default: false, PreRelease: featuregate.Alpha},
+		BECPUSuppress:           {Default: true, PreRelease: featuregate.Beta},
+		BECPUEvict:              {Default: false, PreRelease: featuregate.Alpha},
+		BEMemoryEvict:           {Default: false, PreRelease: featuregate.Alpha},
+		BEMemorySuppress:        {Default: false, PreRelease: featuregate.Alpha},
+		CPUBurst:                {Default: true, PreRelease: featuregate.Beta},
+		SystemConfig:            {Default: false, PreRelease: featuregate.Alpha},
+		RdtResctrl:              {Default: true, PreRelease: featuregate.Beta},
+		CgroupReconcile:         {Default: false, PreRelease: featuregate.Alpha},
+		NodeTopologyReport:      {Default: true, PreRelease: featuregate.Beta},
+		Accelerators:            {Default: false, PreRelease: featuregate.Alpha},
+		CPICollector:            {Default: false, PreRelease: featuregate.Alpha},
+		PSICollector:            {Default: false, PreRelease: featuregate.Alpha},
+		IOCollector:             {Default: false, PreRelease: featuregate.Alpha},
+		GPUMemoryEvict:          {Default: false, PreRelease: featuregate.Alpha},
+		PDBHeadroomVictimPolicy: {Default: false, PreRelease: featuregate.Alpha},
 	}
 )
 
@@ -143,7 +174,7 @@ func IsFeatureDisabled(nodeSLO *slov1alpha1.NodeSLO, feature featuregate.Feature
 
 	spec := nodeSLO.Spec
 	switch feature {
-	case BECPUSuppress, BEMemoryEvict, BECPUEvict:
+	case BECPUSuppress, BEMemoryEvict, BECPUEvict, BEMemorySuppress:
 		if spec.ResourceUsedThresholdWithBE == nil || spec.ResourceUsedThresholdWithBE.Enable == nil {
 			return true, fmt.Errorf("cannot parse feature config for invalid nodeSLO %v", nodeSLO)
 		}
@@ -132,3 +132,40 @@ type PodInterferenceQueryResult struct {
 	QueryResult
 	Metric *PodInterferenceMetric
 }
+
+// NetworkMetric is the network throughput in units per second
+type NetworkMetric struct {
+	RxBytes   float64
+	TxBytes   float64
+	RxPackets float64
+	TxPackets float64
+}
+
+// DiskIOMetric is the block io throughput in units per second
+type DiskIOMetric struct {
+	ReadBytes  float64
+	WriteBytes float64
+	ReadIOPS   float64
+	WriteIOPS  float64
+}
+
+type NodeIOMetric struct {
+	Network NetworkMetric
+	DiskIO  DiskIOMetric
+}
+
+type NodeIOQueryResult struct {
+	QueryResult
+	Metric *NodeIOMetric
+}
+
+type PodIOMetric struct {
+	PodUID  string
+	Network NetworkMetric
+	DiskIO  DiskIOMetric
+}
+
+type PodIOQueryResult struct {
+	QueryResult
+	Metric *PodIOMetric
+}
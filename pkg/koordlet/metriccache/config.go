@@ -21,16 +21,26 @@ import "flag"
 type Config struct {
 	MetricGCIntervalSeconds int
 	MetricExpireSeconds     int
+
+	StorageBackend                string
+	TSDBCheckpointDir             string
+	TSDBCheckpointIntervalSeconds int
 }
 
 func NewDefaultConfig() *Config {
 	return &Config{
 		MetricGCIntervalSeconds: 300,
 		MetricExpireSeconds:     1800,
+
+		StorageBackend:                string(StorageBackendSQLite),
+		TSDBCheckpointIntervalSeconds: 60,
 	}
 }
 
 func (c *Config) InitFlags(fs *flag.FlagSet) {
 	fs.IntVar(&c.MetricGCIntervalSeconds, "metric-gc-interval-seconds", c.MetricGCIntervalSeconds, "Collect node metrics interval by seconds")
 	fs.IntVar(&c.MetricExpireSeconds, "metric-expire-seconds", c.MetricExpireSeconds, "Collect pod metrics expire by seconds")
+	fs.StringVar(&c.StorageBackend, "metric-storage-backend", c.StorageBackend, "Storage backend of metric cache, sqlite or tsdb")
+	fs.StringVar(&c.TSDBCheckpointDir, "metric-tsdb-checkpoint-dir", c.TSDBCheckpointDir, "Directory to checkpoint the tsdb metric storage, checkpoint is disabled if empty")
+	fs.IntVar(&c.TSDBCheckpointIntervalSeconds, "metric-tsdb-checkpoint-interval-seconds", c.TSDBCheckpointIntervalSeconds, "Checkpoint tsdb metric storage interval by seconds")
 }
@@ -27,6 +27,9 @@ func Test_NewDefaultConfig(t *testing.T) {
 	expectConfig := &Config{
 		MetricGCIntervalSeconds: 300,
 		MetricExpireSeconds:     1800,
+
+		StorageBackend:                "sqlite",
+		TSDBCheckpointIntervalSeconds: 60,
 	}
 	defaultConfig := NewDefaultConfig()
 	assert.Equal(t, expectConfig, defaultConfig)
@@ -37,12 +40,18 @@ func Test_InitFlags(t *testing.T) {
 		"",
 		"--metric-gc-interval-seconds=100",
 		"--metric-expire-seconds=600",
+		"--metric-storage-backend=tsdb",
+		"--metric-tsdb-checkpoint-dir=/var/run/koordlet/metrics",
+		"--metric-tsdb-checkpoint-interval-seconds=30",
 	}
 	fs := flag.NewFlagSet(cmdArgs[0], flag.ExitOnError)
 
 	type fields struct {
-		MetricGCIntervalSeconds int
-		MetricExpireSeconds     int
+		MetricGCIntervalSeconds       int
+		MetricExpireSeconds           int
+		StorageBackend                string
+		TSDBCheckpointDir             string
+		TSDBCheckpointIntervalSeconds int
 	}
 	type args struct {
 		fs *flag.FlagSet
@@ -55,8 +64,11 @@ func Test_InitFlags(t *testing.T) {
 		{
 			name: "not default",
 			fields: fields{
-				MetricGCIntervalSeconds: 100,
-				MetricExpireSeconds:     600,
+				MetricGCIntervalSeconds:       100,
+				MetricExpireSeconds:           600,
+				StorageBackend:                "tsdb",
+				TSDBCheckpointDir:             "/var/run/koordlet/metrics",
+				TSDBCheckpointIntervalSeconds: 30,
 			},
 			args: args{fs: fs},
 		},
@@ -64,8 +76,11 @@ func Test_InitFlags(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			raw := &Config{
-				MetricGCIntervalSeconds: tt.fields.MetricGCIntervalSeconds,
-				MetricExpireSeconds:     tt.fields.MetricExpireSeconds,
+				MetricGCIntervalSeconds:       tt.fields.MetricGCIntervalSeconds,
+				MetricExpireSeconds:           tt.fields.MetricExpireSeconds,
+				StorageBackend:                tt.fields.StorageBackend,
+				TSDBCheckpointDir:             tt.fields.TSDBCheckpointDir,
+				TSDBCheckpointIntervalSeconds: tt.fields.TSDBCheckpointIntervalSeconds,
 			}
 			c := NewDefaultConfig()
 			c.InitFlags(tt.args.fs)
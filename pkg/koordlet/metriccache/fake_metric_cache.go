@@ -27,6 +27,6 @@ func NewCacheNotShareMetricCache(cfg *Config) (MetricCache, error) {
 	}, nil
 }
 
-func NewCacheNotShareStorage() (*storage, error) {
+func NewCacheNotShareStorage() (*sqliteStorage, error) {
 	return newStorage("file::memory:?mode=memory&loc=auto&_busy_timeout=5000")
 }
@@ -39,6 +39,7 @@ const (
 	AggregationTypeP50   AggregationType = "p50"
 	AggregationTypeLast  AggregationType = "last"
 	AggregationTypeCount AggregationType = "count"
+	AggregationTypeEWMA  AggregationType = "ewma"
 )
 
 type InterferenceMetricName string
@@ -98,6 +99,8 @@ type MetricCache interface {
 	GetContainerThrottledMetric(containerID *string, param *QueryParam) ContainerThrottledQueryResult
 	GetContainerInterferenceMetric(metricName InterferenceMetricName, podUID *string, containerID *string, param *QueryParam) ContainerInterferenceQueryResult
 	GetPodInterferenceMetric(metricName InterferenceMetricName, podUID *string, param *QueryParam) PodInterferenceQueryResult
+	GetNodeIOMetric(param *QueryParam) NodeIOQueryResult
+	GetPodIOMetric(podUID *string, param *QueryParam) PodIOQueryResult
 	InsertNodeResourceMetric(t time.Time, nodeResUsed *NodeResourceMetric) error
 	InsertPodResourceMetric(t time.Time, podResUsed *PodResourceMetric) error
 	InsertContainerResourceMetric(t time.Time, containerResUsed *ContainerResourceMetric) error
@@ -107,15 +110,17 @@ type MetricCache interface {
 	InsertContainerThrottledMetrics(t time.Time, metric *ContainerThrottledMetric) error
 	InsertContainerInterferenceMetrics(t time.Time, metric *ContainerInterferenceMetric) error
 	InsertPodInterferenceMetrics(t time.Time, metric *PodInterferenceMetric) error
+	InsertNodeIOMetric(t time.Time, metric *NodeIOMetric) error
+	InsertPodIOMetric(t time.Time, metric *PodIOMetric) error
 }
 
 type metricCache struct {
 	config *Config
-	db     *storage
+	db     storage
 }
 
 func NewMetricCache(cfg *Config) (MetricCache, error) {
-	database, err := NewStorage()
+	database, err := newStorageByConfig(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -132,6 +137,14 @@ func (m *metricCache) Run(stopCh <-chan struct{}) error {
 		m.recycleDB()
 	}, time.Duration(m.config.MetricGCIntervalSeconds)*time.Second, stopCh)
 
+	if c, ok := m.db.(checkpointer); ok && m.config.TSDBCheckpointDir != "" {
+		go wait.Until(func() {
+			if err := c.Checkpoint(); err != nil {
+				klog.Warningf("checkpoint metric storage failed, error %v", err)
+			}
+		}, time.Duration(m.config.TSDBCheckpointIntervalSeconds)*time.Second, stopCh)
+	}
+
 	return nil
 }
 
@@ -567,6 +580,104 @@ func (m *metricCache) GetPodInterferenceMetric(metricName InterferenceMetricName
 	return result
 }
 
+func (m *metricCache) GetNodeIOMetric(param *QueryParam) NodeIOQueryResult {
+	result := NodeIOQueryResult{}
+	if param == nil || param.Start == nil || param.End == nil {
+		result.Error = fmt.Errorf("node io query parameters are illegal %v", param)
+		return result
+	}
+	metrics, err := m.db.GetNodeIOMetric(param.Start, param.End)
+	if err != nil {
+		result.Error = fmt.Errorf("get node io metric failed, query params %v, error %v", param, err)
+		return result
+	}
+	if len(metrics) == 0 {
+		result.Error = fmt.Errorf("get node io metric not exist, query params %v", param)
+		return result
+	}
+
+	network, diskIO, err := aggregateIOMetric(metrics, getAggregateFunc(param.Aggregate))
+	if err != nil {
+		result.Error = fmt.Errorf("get node aggregate io metric failed, metrics %v, error %v", metrics, err)
+		return result
+	}
+
+	result.AggregateInfo, err = generateMetricAggregateInfo(metrics)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	result.Metric = &NodeIOMetric{
+		Network: *network,
+		DiskIO:  *diskIO,
+	}
+	return result
+}
+
+func (m *metricCache) GetPodIOMetric(podUID *string, param *QueryParam) PodIOQueryResult {
+	result := PodIOQueryResult{}
+	if podUID == nil || param == nil || param.Start == nil || param.End == nil {
+		result.Error = fmt.Errorf("pod %v io query parameters are illegal %v", podUID, param)
+		return result
+	}
+	metrics, err := m.db.GetPodIOMetric(podUID, param.Start, param.End)
+	if err != nil {
+		result.Error = fmt.Errorf("get pod %v io metric failed, query params %v, error %v", *podUID, param, err)
+		return result
+	}
+	if len(metrics) == 0 {
+		result.Error = fmt.Errorf("get pod %v io metric not exist, query params %v", *podUID, param)
+		return result
+	}
+
+	network, diskIO, err := aggregateIOMetric(metrics, getAggregateFunc(param.Aggregate))
+	if err != nil {
+		result.Error = fmt.Errorf("get pod %v aggregate io metric failed, metrics %v, error %v", *podUID, metrics, err)
+		return result
+	}
+
+	count, err := count(metrics)
+	if err != nil {
+		result.Error = fmt.Errorf("get pod %v aggregate count failed, metrics %v, error %v", *podUID, metrics, err)
+		return result
+	}
+
+	result.AggregateInfo = &AggregateInfo{MetricsCount: int64(count)}
+	result.Metric = &PodIOMetric{
+		PodUID:  *podUID,
+		Network: *network,
+		DiskIO:  *diskIO,
+	}
+	return result
+}
+
+// aggregateIOMetric aggregates the io fields of nodeIOMetric or podIOMetric list
+func aggregateIOMetric(metrics interface{}, aggregateFunc AggregationFunc) (*NetworkMetric, *DiskIOMetric, error) {
+	fieldNames := []string{"NetworkRxBytes", "NetworkTxBytes", "NetworkRxPackets", "NetworkTxPackets",
+		"DiskReadBytes", "DiskWriteBytes", "DiskReadIOPS", "DiskWriteIOPS"}
+	values := make([]float64, len(fieldNames))
+	for i, fieldName := range fieldNames {
+		v, err := aggregateFunc(metrics, AggregateParam{ValueFieldName: fieldName, TimeFieldName: "Timestamp"})
+		if err != nil {
+			return nil, nil, fmt.Errorf("aggregate %s failed, error %v", fieldName, err)
+		}
+		values[i] = v
+	}
+	network := &NetworkMetric{
+		RxBytes:   values[0],
+		TxBytes:   values[1],
+		RxPackets: values[2],
+		TxPackets: values[3],
+	}
+	diskIO := &DiskIOMetric{
+		ReadBytes:  values[4],
+		WriteBytes: values[5],
+		ReadIOPS:   values[6],
+		WriteIOPS:  values[7],
+	}
+	return network, diskIO, nil
+}
+
 func aggregateContainerInterferenceMetricByName(metricName InterferenceMetricName, metrics interface{}, aggregateFunc AggregationFunc) (interface{}, error) {
 	switch metricName {
 	case MetricNameContainerCPI:
@@ -772,6 +883,37 @@ func (m *metricCache) InsertPodInterferenceMetrics(t time.Time, metric *PodInter
 	return m.convertAndInsertPodInterferenceMetric(t, metric)
 }
 
+func (m *metricCache) InsertNodeIOMetric(t time.Time, metric *NodeIOMetric) error {
+	dbItem := &nodeIOMetric{
+		NetworkRxBytes:   metric.Network.RxBytes,
+		NetworkTxBytes:   metric.Network.TxBytes,
+		NetworkRxPackets: metric.Network.RxPackets,
+		NetworkTxPackets: metric.Network.TxPackets,
+		DiskReadBytes:    metric.DiskIO.ReadBytes,
+		DiskWriteBytes:   metric.DiskIO.WriteBytes,
+		DiskReadIOPS:     metric.DiskIO.ReadIOPS,
+		DiskWriteIOPS:    metric.DiskIO.WriteIOPS,
+		Timestamp:        t,
+	}
+	return m.db.InsertNodeIOMetric(dbItem)
+}
+
+func (m *metricCache) InsertPodIOMetric(t time.Time, metric *PodIOMetric) error {
+	dbItem := &podIOMetric{
+		PodUID:           metric.PodUID,
+		NetworkRxBytes:   metric.Network.RxBytes,
+		NetworkTxBytes:   metric.Network.TxBytes,
+		NetworkRxPackets: metric.Network.RxPackets,
+		NetworkTxPackets: metric.Network.TxPackets,
+		DiskReadBytes:    metric.DiskIO.ReadBytes,
+		DiskWriteBytes:   metric.DiskIO.WriteBytes,
+		DiskReadIOPS:     metric.DiskIO.ReadIOPS,
+		DiskWriteIOPS:    metric.DiskIO.WriteIOPS,
+		Timestamp:        t,
+	}
+	return m.db.InsertPodIOMetric(dbItem)
+}
+
 func (m *metricCache) aggregateGPUUsages(gpuResourceMetricsByTime [][]gpuResourceMetric, aggregateFunc AggregationFunc) ([]GPUMetric, error) {
 	if len(gpuResourceMetricsByTime) == 0 {
 		return nil, nil
@@ -847,6 +989,12 @@ func (m *metricCache) recycleDB() {
 	if err := m.db.DeletePodPSIMetric(&oldTime, &expiredTime); err != nil {
 		klog.Warningf("DeletePodPSIMetric failed during recycle, error %v", err)
 	}
+	if err := m.db.DeleteNodeIOMetric(&oldTime, &expiredTime); err != nil {
+		klog.Warningf("DeleteNodeIOMetric failed during recycle, error %v", err)
+	}
+	if err := m.db.DeletePodIOMetric(&oldTime, &expiredTime); err != nil {
+		klog.Warningf("DeletePodIOMetric failed during recycle, error %v", err)
+	}
 	// raw records do not need to cleanup
 	nodeResCount, _ := m.db.CountNodeResourceMetric()
 	podResCount, _ := m.db.CountPodResourceMetric()
@@ -854,10 +1002,12 @@ func (m *metricCache) recycleDB() {
 	beCPUResCount, _ := m.db.CountBECPUResourceMetric()
 	podThrottledResCount, _ := m.db.CountPodThrottledMetric()
 	containerThrottledResCount, _ := m.db.CountContainerThrottledMetric()
+	nodeIOCount, _ := m.db.CountNodeIOMetric()
+	podIOCount, _ := m.db.CountPodIOMetric()
 	klog.V(4).Infof("expired metric data before %v has been recycled, remaining in db size: "+
 		"nodeResCount=%v, podResCount=%v, containerResCount=%v, beCPUResCount=%v, podThrottledResCount=%v, "+
-		"containerThrottledResCount=%v", expiredTime, nodeResCount, podResCount, containerResCount, beCPUResCount,
-		podThrottledResCount, containerThrottledResCount)
+		"containerThrottledResCount=%v, nodeIOCount=%v, podIOCount=%v", expiredTime, nodeResCount, podResCount,
+		containerResCount, beCPUResCount, podThrottledResCount, containerThrottledResCount, nodeIOCount, podIOCount)
 }
 
 func getAggregateFunc(aggregationType AggregationType) AggregationFunc {
@@ -876,6 +1026,8 @@ func getAggregateFunc(aggregationType AggregationType) AggregationFunc {
 		return fieldLastOfMetricList
 	case AggregationTypeCount:
 		return fieldCountOfMetricList
+	case AggregationTypeEWMA:
+		return fieldEWMAOfMetricList
 	default:
 		return fieldAvgOfMetricList
 	}
@@ -2423,3 +2423,53 @@ func Test_metricCache_PodPSIMetric_CRUD(t *testing.T) {
 		})
 	}
 }
+
+func Test_metricCache_IOMetric_CRUD(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	uid := "test-pod-uid"
+	cfg := &Config{
+		MetricGCIntervalSeconds: 60,
+		MetricExpireSeconds:     60,
+	}
+	sqliteStore, err := NewCacheNotShareStorage()
+	assert.NoError(t, err)
+	defer sqliteStore.Close()
+	tsdbStore, err := NewTSDBStorage("")
+	assert.NoError(t, err)
+	defer tsdbStore.Close()
+	for _, s := range []storage{sqliteStore, tsdbStore} {
+		m := &metricCache{config: cfg, db: s}
+		for i := 1; i <= 4; i++ {
+			ts := now.Add(time.Duration(i-5) * 30 * time.Second)
+			network := NetworkMetric{RxBytes: float64(i * 1024), TxBytes: float64(i * 2048), RxPackets: float64(i), TxPackets: float64(i * 2)}
+			diskIO := DiskIOMetric{ReadBytes: float64(i * 4096), WriteBytes: float64(i * 8192), ReadIOPS: float64(i), WriteIOPS: float64(i * 2)}
+			assert.NoError(t, m.InsertNodeIOMetric(ts, &NodeIOMetric{Network: network, DiskIO: diskIO}))
+			assert.NoError(t, m.InsertPodIOMetric(ts, &PodIOMetric{PodUID: uid, Network: network, DiskIO: diskIO}))
+		}
+
+		start := now.Add(-time.Hour)
+		param := &QueryParam{Aggregate: AggregationTypeAVG, Start: &start, End: &now}
+		wantNetwork := NetworkMetric{RxBytes: 2.5 * 1024, TxBytes: 2.5 * 2048, RxPackets: 2.5, TxPackets: 5}
+		wantDiskIO := DiskIOMetric{ReadBytes: 2.5 * 4096, WriteBytes: 2.5 * 8192, ReadIOPS: 2.5, WriteIOPS: 5}
+		gotNode := m.GetNodeIOMetric(param)
+		assert.NoError(t, gotNode.Error)
+		assert.Equal(t, &NodeIOMetric{Network: wantNetwork, DiskIO: wantDiskIO}, gotNode.Metric)
+		assert.Equal(t, int64(4), gotNode.AggregateInfo.MetricsCount)
+		gotPod := m.GetPodIOMetric(&uid, param)
+		assert.NoError(t, gotPod.Error)
+		assert.Equal(t, &PodIOMetric{PodUID: uid, Network: wantNetwork, DiskIO: wantDiskIO}, gotPod.Metric)
+
+		otherUID := "other-pod-uid"
+		gotPod = m.GetPodIOMetric(&otherUID, param)
+		assert.Nil(t, gotPod.Metric)
+
+		// samples older than MetricExpireSeconds are recycled
+		m.recycleDB()
+		gotNode = m.GetNodeIOMetric(param)
+		assert.NoError(t, gotNode.Error)
+		assert.Equal(t, int64(1), gotNode.AggregateInfo.MetricsCount)
+		gotPod = m.GetPodIOMetric(&uid, param)
+		assert.NoError(t, gotPod.Error)
+		assert.Equal(t, int64(1), gotPod.AggregateInfo.MetricsCount)
+	}
+}
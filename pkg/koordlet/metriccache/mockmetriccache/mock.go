@@ -122,6 +122,20 @@ func (mr *MockMetricCacheMockRecorder) GetNodeCPUInfo(param interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeCPUInfo", reflect.TypeOf((*MockMetricCache)(nil).GetNodeCPUInfo), param)
 }
 
+// GetNodeIOMetric mocks base method.
+func (m *MockMetricCache) GetNodeIOMetric(param *metriccache.QueryParam) metriccache.NodeIOQueryResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeIOMetric", param)
+	ret0, _ := ret[0].(metriccache.NodeIOQueryResult)
+	return ret0
+}
+
+// GetNodeIOMetric indicates an expected call of GetNodeIOMetric.
+func (mr *MockMetricCacheMockRecorder) GetNodeIOMetric(param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeIOMetric", reflect.TypeOf((*MockMetricCache)(nil).GetNodeIOMetric), param)
+}
+
 // GetNodeResourceMetric mocks base method.
 func (m *MockMetricCache) GetNodeResourceMetric(param *metriccache.QueryParam) metriccache.NodeResourceQueryResult {
 	m.ctrl.T.Helper()
@@ -136,6 +150,20 @@ func (mr *MockMetricCacheMockRecorder) GetNodeResourceMetric(param interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeResourceMetric", reflect.TypeOf((*MockMetricCache)(nil).GetNodeResourceMetric), param)
 }
 
+// GetPodIOMetric mocks base method.
+func (m *MockMetricCache) GetPodIOMetric(podUID *string, param *metriccache.QueryParam) metriccache.PodIOQueryResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodIOMetric", podUID, param)
+	ret0, _ := ret[0].(metriccache.PodIOQueryResult)
+	return ret0
+}
+
+// GetPodIOMetric indicates an expected call of GetPodIOMetric.
+func (mr *MockMetricCacheMockRecorder) GetPodIOMetric(podUID, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodIOMetric", reflect.TypeOf((*MockMetricCache)(nil).GetPodIOMetric), podUID, param)
+}
+
 // GetPodInterferenceMetric mocks base method.
 func (m *MockMetricCache) GetPodInterferenceMetric(metricName metriccache.InterferenceMetricName, podUID *string, param *metriccache.QueryParam) metriccache.PodInterferenceQueryResult {
 	m.ctrl.T.Helper()
@@ -248,6 +276,20 @@ func (mr *MockMetricCacheMockRecorder) InsertNodeCPUInfo(info interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNodeCPUInfo", reflect.TypeOf((*MockMetricCache)(nil).InsertNodeCPUInfo), info)
 }
 
+// InsertNodeIOMetric mocks base method.
+func (m *MockMetricCache) InsertNodeIOMetric(t time.Time, metric *metriccache.NodeIOMetric) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNodeIOMetric", t, metric)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNodeIOMetric indicates an expected call of InsertNodeIOMetric.
+func (mr *MockMetricCacheMockRecorder) InsertNodeIOMetric(t, metric interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNodeIOMetric", reflect.TypeOf((*MockMetricCache)(nil).InsertNodeIOMetric), t, metric)
+}
+
 // InsertNodeResourceMetric mocks base method.
 func (m *MockMetricCache) InsertNodeResourceMetric(t time.Time, nodeResUsed *metriccache.NodeResourceMetric) error {
 	m.ctrl.T.Helper()
@@ -262,6 +304,20 @@ func (mr *MockMetricCacheMockRecorder) InsertNodeResourceMetric(t, nodeResUsed i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNodeResourceMetric", reflect.TypeOf((*MockMetricCache)(nil).InsertNodeResourceMetric), t, nodeResUsed)
 }
 
+// InsertPodIOMetric mocks base method.
+func (m *MockMetricCache) InsertPodIOMetric(t time.Time, metric *metriccache.PodIOMetric) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPodIOMetric", t, metric)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPodIOMetric indicates an expected call of InsertPodIOMetric.
+func (mr *MockMetricCacheMockRecorder) InsertPodIOMetric(t, metric interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPodIOMetric", reflect.TypeOf((*MockMetricCache)(nil).InsertPodIOMetric), t, metric)
+}
+
 // InsertPodInterferenceMetrics mocks base method.
 func (m *MockMetricCache) InsertPodInterferenceMetrics(t time.Time, metric *metriccache.PodInterferenceMetric) error {
 	m.ctrl.T.Helper()
@@ -19,231 +19,83 @@ package metriccache
 import (
 	"fmt"
 	"time"
-
-	"gorm.io/driver/sqlite"
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
-type storage struct {
-	db *gorm.DB
-}
-
-func NewStorage() (*storage, error) {
-	return newStorage("file::memory:?mode=memory&cache=shared&loc=auto&_busy_timeout=5000")
-}
-func newStorage(dsn string) (*storage, error) {
-	db, err := gorm.Open(sqlite.Open(dsn),
-		&gorm.Config{})
-	if err != nil {
-		return nil, fmt.Errorf("fail to create database, %v", err)
-	}
-
-	db.AutoMigrate(&nodeResourceMetric{}, &podResourceMetric{}, &containerResourceMetric{}, &beCPUResourceMetric{})
-	db.AutoMigrate(&rawRecord{})
-	db.AutoMigrate(&podThrottledMetric{}, &containerThrottledMetric{})
-	db.AutoMigrate(&containerCPIMetric{}, &containerPSIMetric{}, &podPSIMetric{})
+type StorageBackend string
 
-	database, err := db.DB()
-	if err != nil {
-		return nil, fmt.Errorf("fail to init database %v", err)
-	}
-	database.SetMaxOpenConns(1)
-
-	s := &storage{
-		db: db,
-	}
-	return s, nil
-}
+const (
+	// StorageBackendSQLite stores each sample as a row of an in-memory sqlite database.
+	StorageBackendSQLite StorageBackend = "sqlite"
+	// StorageBackendTSDB stores samples in gorilla-compressed chunks in memory, with optional disk checkpoints.
+	StorageBackendTSDB StorageBackend = "tsdb"
+)
 
-// for ut only, Close() is not necessary for gorm
-func (s *storage) Close() error {
-	d, err := s.db.DB()
-	if err != nil {
-		return err
+// storage is the backend of metricCache, which persists the raw samples and answers the range queries.
+// The aggregations are done by metricCache, so all backends should return the same samples for the same query.
+type storage interface {
+	InsertNodeResourceMetric(n *nodeResourceMetric) error
+	InsertPodResourceMetric(p *podResourceMetric) error
+	InsertContainerResourceMetric(m *containerResourceMetric) error
+	InsertBECPUResourceMetric(b *beCPUResourceMetric) error
+	InsertRawRecord(record *rawRecord) error
+	InsertPodThrottledMetric(m *podThrottledMetric) error
+	InsertContainerThrottledMetric(m *containerThrottledMetric) error
+	InsertContainerCPIMetric(m *containerCPIMetric) error
+	InsertContainerPSIMetric(m *containerPSIMetric) error
+	InsertPodPSIMetric(m *podPSIMetric) error
+	InsertNodeIOMetric(m *nodeIOMetric) error
+	InsertPodIOMetric(m *podIOMetric) error
+
+	GetNodeResourceMetric(start, end *time.Time) ([]nodeResourceMetric, error)
+	GetPodResourceMetric(uid *string, start, end *time.Time) ([]podResourceMetric, error)
+	GetContainerResourceMetric(containerID *string, start, end *time.Time) ([]containerResourceMetric, error)
+	GetBECPUResourceMetric(start, end *time.Time) ([]beCPUResourceMetric, error)
+	GetRawRecord(recordName string) (*rawRecord, error)
+	GetPodThrottledMetric(uid *string, start, end *time.Time) ([]podThrottledMetric, error)
+	GetContainerThrottledMetric(id *string, start, end *time.Time) ([]containerThrottledMetric, error)
+	GetContainerCPIMetric(containerID *string, start, end *time.Time) ([]containerCPIMetric, error)
+	GetContainerPSIMetric(containerID *string, start, end *time.Time) ([]containerPSIMetric, error)
+	GetPodPSIMetric(uid *string, start, end *time.Time) ([]podPSIMetric, error)
+	GetContainerCPIMetricByPodUid(podUid *string, start, end *time.Time) ([]containerCPIMetric, error)
+	GetNodeIOMetric(start, end *time.Time) ([]nodeIOMetric, error)
+	GetPodIOMetric(uid *string, start, end *time.Time) ([]podIOMetric, error)
+
+	DeleteNodeResourceMetric(start, end *time.Time) error
+	DeletePodResourceMetric(start, end *time.Time) error
+	DeleteContainerResourceMetric(start, end *time.Time) error
+	DeleteBECPUResourceMetric(start, end *time.Time) error
+	DeletePodThrottledMetric(start, end *time.Time) error
+	DeleteContainerThrottledMetric(start, end *time.Time) error
+	DeleteContainerCPIMetric(start, end *time.Time) error
+	DeleteContainerPSIMetric(start, end *time.Time) error
+	DeletePodPSIMetric(start, end *time.Time) error
+	DeleteNodeIOMetric(start, end *time.Time) error
+	DeletePodIOMetric(start, end *time.Time) error
+
+	CountNodeResourceMetric() (int64, error)
+	CountPodResourceMetric() (int64, error)
+	CountContainerResourceMetric() (int64, error)
+	CountBECPUResourceMetric() (int64, error)
+	CountPodThrottledMetric() (int64, error)
+	CountContainerThrottledMetric() (int64, error)
+	CountNodeIOMetric() (int64, error)
+	CountPodIOMetric() (int64, error)
+
+	Close() error
+}
+
+// checkpointer is implemented by the storage which keeps samples in memory and supports dumping them to disk.
+type checkpointer interface {
+	Checkpoint() error
+}
+
+func newStorageByConfig(cfg *Config) (storage, error) {
+	switch StorageBackend(cfg.StorageBackend) {
+	case StorageBackendSQLite, "":
+		return NewStorage()
+	case StorageBackendTSDB:
+		return NewTSDBStorage(cfg.TSDBCheckpointDir)
+	default:
+		return nil, fmt.Errorf("unknown metric storage backend %s", cfg.StorageBackend)
 	}
-	return d.Close()
-}
-
-func (s *storage) InsertNodeResourceMetric(n *nodeResourceMetric) error {
-	return s.db.Create(n).Error
-}
-
-func (s *storage) InsertPodResourceMetric(p *podResourceMetric) error {
-	return s.db.Create(p).Error
-}
-
-func (s *storage) InsertContainerResourceMetric(m *containerResourceMetric) error {
-	return s.db.Create(m).Error
-}
-
-func (s *storage) InsertBECPUResourceMetric(b *beCPUResourceMetric) error {
-	return s.db.Create(b).Error
-}
-
-// InsertRawRecord inserts a raw record into the db
-func (s *storage) InsertRawRecord(record *rawRecord) error {
-	return s.db.Clauses(clause.OnConflict{
-		UpdateAll: true,
-	}).Create(&record).Error
-}
-
-func (s *storage) InsertPodThrottledMetric(m *podThrottledMetric) error {
-	return s.db.Create(m).Error
-}
-
-func (s *storage) InsertContainerThrottledMetric(m *containerThrottledMetric) error {
-	return s.db.Create(m).Error
-}
-
-func (s *storage) InsertContainerCPIMetric(m *containerCPIMetric) error {
-	return s.db.Create(m).Error
-}
-
-func (s *storage) InsertContainerPSIMetric(m *containerPSIMetric) error {
-	return s.db.Create(m).Error
-}
-
-func (s *storage) InsertPodPSIMetric(m *podPSIMetric) error {
-	return s.db.Create(m).Error
-}
-
-func (s *storage) GetNodeResourceMetric(start, end *time.Time) ([]nodeResourceMetric, error) {
-	var nodeMetrics []nodeResourceMetric
-	err := s.db.Where("timestamp BETWEEN ? AND ? order by timestamp", start, end).Find(&nodeMetrics).Error
-	return nodeMetrics, err
-}
-
-func (s *storage) GetPodResourceMetric(uid *string, start, end *time.Time) ([]podResourceMetric, error) {
-	var podMetrics []podResourceMetric
-	err := s.db.Where("pod_uid = ? AND timestamp BETWEEN ? AND ?", uid, start, end).Find(&podMetrics).Error
-	return podMetrics, err
-}
-
-func (s *storage) GetContainerResourceMetric(containerID *string, start, end *time.Time) (
-	[]containerResourceMetric, error) {
-	var metrics []containerResourceMetric
-	err := s.db.Where("container_id = ? AND timestamp BETWEEN ? AND ?", containerID, start, end).Find(
-		&metrics).Error
-	return metrics, err
-}
-
-func (s *storage) GetBECPUResourceMetric(start, end *time.Time) ([]beCPUResourceMetric, error) {
-	var metrics []beCPUResourceMetric
-	err := s.db.Where("timestamp BETWEEN ? AND ?", start, end).Find(&metrics).Error
-	return metrics, err
-}
-
-func (s *storage) GetRawRecord(recordName string) (*rawRecord, error) {
-	record := &rawRecord{}
-	err := s.db.Where("record_type = ?", recordName).First(&record).Error
-	return record, err
-}
-
-func (s *storage) GetPodThrottledMetric(uid *string, start, end *time.Time) ([]podThrottledMetric, error) {
-	var metrics []podThrottledMetric
-	err := s.db.Where("pod_uid = ? AND timestamp BETWEEN ? AND ?", uid, start, end).Find(&metrics).Error
-	return metrics, err
-}
-
-func (s *storage) GetContainerThrottledMetric(id *string, start, end *time.Time) ([]containerThrottledMetric, error) {
-	var metrics []containerThrottledMetric
-	err := s.db.Where("container_id = ? AND timestamp BETWEEN ? AND ?", id, start, end).Find(&metrics).Error
-	return metrics, err
-}
-
-func (s *storage) GetContainerCPIMetric(containerID *string, start, end *time.Time) ([]containerCPIMetric, error) {
-	var metrics []containerCPIMetric
-	err := s.db.Where("container_id = ? AND timestamp BETWEEN ? AND ?", containerID, start, end).Find(&metrics).Error
-	return metrics, err
-}
-
-func (s *storage) GetContainerPSIMetric(containerID *string, start, end *time.Time) ([]containerPSIMetric, error) {
-	var metrics []containerPSIMetric
-	err := s.db.Where("container_id = ? AND timestamp BETWEEN ? AND ?", containerID, start, end).Find(&metrics).Error
-	return metrics, err
-}
-
-func (s *storage) GetPodPSIMetric(uid *string, start, end *time.Time) ([]podPSIMetric, error) {
-	var metrics []podPSIMetric
-	err := s.db.Where("pod_uid = ? AND timestamp BETWEEN ? AND ?", uid, start, end).Find(&metrics).Error
-	return metrics, err
-}
-
-func (s *storage) GetContainerCPIMetricByPodUid(podUid *string, start, end *time.Time) ([]containerCPIMetric, error) {
-	var metrics []containerCPIMetric
-	err := s.db.Where("pod_uid = ? AND timestamp BETWEEN ? AND ?", podUid, start, end).Find(&metrics).Error
-	return metrics, err
-}
-
-func (s *storage) DeleteNodeResourceMetric(start, end *time.Time) error {
-	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&nodeResourceMetric{}).Error
-}
-
-func (s *storage) DeletePodResourceMetric(start, end *time.Time) error {
-	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&podResourceMetric{}).Error
-}
-
-func (s *storage) DeleteContainerResourceMetric(start, end *time.Time) error {
-	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&containerResourceMetric{}).Error
-}
-
-func (s *storage) DeleteBECPUResourceMetric(start, end *time.Time) error {
-	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&beCPUResourceMetric{}).Error
-}
-
-func (s *storage) DeletePodThrottledMetric(start, end *time.Time) error {
-	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&podThrottledMetric{}).Error
-}
-
-func (s *storage) DeleteContainerThrottledMetric(start, end *time.Time) error {
-	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&containerThrottledMetric{}).Error
-}
-
-func (s *storage) DeleteContainerCPIMetric(start, end *time.Time) error {
-	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&containerCPIMetric{}).Error
-}
-
-func (s *storage) DeleteContainerPSIMetric(start, end *time.Time) error {
-	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&containerPSIMetric{}).Error
-}
-
-func (s *storage) DeletePodPSIMetric(start, end *time.Time) error {
-	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&podPSIMetric{}).Error
-}
-
-func (s *storage) CountNodeResourceMetric() (int64, error) {
-	count := int64(0)
-	err := s.db.Model(&nodeResourceMetric{}).Count(&count).Error
-	return count, err
-}
-
-func (s *storage) CountPodResourceMetric() (int64, error) {
-	count := int64(0)
-	err := s.db.Model(&podResourceMetric{}).Count(&count).Error
-	return count, err
-}
-
-func (s *storage) CountContainerResourceMetric() (int64, error) {
-	count := int64(0)
-	err := s.db.Model(&containerResourceMetric{}).Count(&count).Error
-	return count, err
-}
-
-func (s *storage) CountBECPUResourceMetric() (int64, error) {
-	count := int64(0)
-	err := s.db.Model(&beCPUResourceMetric{}).Count(&count).Error
-	return count, err
-}
-
-func (s *storage) CountPodThrottledMetric() (int64, error) {
-	count := int64(0)
-	err := s.db.Model(&podThrottledMetric{}).Count(&count).Error
-	return count, err
-}
-
-func (s *storage) CountContainerThrottledMetric() (int64, error) {
-	count := int64(0)
-	err := s.db.Model(&containerThrottledMetric{}).Count(&count).Error
-	return count, err
 }
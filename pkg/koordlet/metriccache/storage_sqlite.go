@@ -0,0 +1,290 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccache
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sqliteStorage struct {
+	db *gorm.DB
+}
+
+func NewStorage() (*sqliteStorage, error) {
+	return newStorage("file::memory:?mode=memory&cache=shared&loc=auto&_busy_timeout=5000")
+}
+func newStorage(dsn string) (*sqliteStorage, error) {
+	db, err := gorm.Open(sqlite.Open(dsn),
+		&gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("fail to create database, %v", err)
+	}
+
+	db.AutoMigrate(&nodeResourceMetric{}, &podResourceMetric{}, &containerResourceMetric{}, &beCPUResourceMetric{})
+	db.AutoMigrate(&rawRecord{})
+	db.AutoMigrate(&podThrottledMetric{}, &containerThrottledMetric{})
+	db.AutoMigrate(&containerCPIMetric{}, &containerPSIMetric{}, &podPSIMetric{})
+	db.AutoMigrate(&nodeIOMetric{}, &podIOMetric{})
+
+	database, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("fail to init database %v", err)
+	}
+	database.SetMaxOpenConns(1)
+
+	s := &sqliteStorage{
+		db: db,
+	}
+	return s, nil
+}
+
+// for ut only, Close() is not necessary for gorm
+func (s *sqliteStorage) Close() error {
+	d, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return d.Close()
+}
+
+func (s *sqliteStorage) InsertNodeResourceMetric(n *nodeResourceMetric) error {
+	return s.db.Create(n).Error
+}
+
+func (s *sqliteStorage) InsertPodResourceMetric(p *podResourceMetric) error {
+	return s.db.Create(p).Error
+}
+
+func (s *sqliteStorage) InsertContainerResourceMetric(m *containerResourceMetric) error {
+	return s.db.Create(m).Error
+}
+
+func (s *sqliteStorage) InsertBECPUResourceMetric(b *beCPUResourceMetric) error {
+	return s.db.Create(b).Error
+}
+
+// InsertRawRecord inserts a raw record into the db
+func (s *sqliteStorage) InsertRawRecord(record *rawRecord) error {
+	return s.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&record).Error
+}
+
+func (s *sqliteStorage) InsertPodThrottledMetric(m *podThrottledMetric) error {
+	return s.db.Create(m).Error
+}
+
+func (s *sqliteStorage) InsertContainerThrottledMetric(m *containerThrottledMetric) error {
+	return s.db.Create(m).Error
+}
+
+func (s *sqliteStorage) InsertContainerCPIMetric(m *containerCPIMetric) error {
+	return s.db.Create(m).Error
+}
+
+func (s *sqliteStorage) InsertContainerPSIMetric(m *containerPSIMetric) error {
+	return s.db.Create(m).Error
+}
+
+func (s *sqliteStorage) InsertPodPSIMetric(m *podPSIMetric) error {
+	return s.db.Create(m).Error
+}
+
+func (s *sqliteStorage) InsertNodeIOMetric(m *nodeIOMetric) error {
+	return s.db.Create(m).Error
+}
+
+func (s *sqliteStorage) InsertPodIOMetric(m *podIOMetric) error {
+	return s.db.Create(m).Error
+}
+
+func (s *sqliteStorage) GetNodeResourceMetric(start, end *time.Time) ([]nodeResourceMetric, error) {
+	var nodeMetrics []nodeResourceMetric
+	err := s.db.Where("timestamp BETWEEN ? AND ? order by timestamp", start, end).Find(&nodeMetrics).Error
+	return nodeMetrics, err
+}
+
+func (s *sqliteStorage) GetPodResourceMetric(uid *string, start, end *time.Time) ([]podResourceMetric, error) {
+	var podMetrics []podResourceMetric
+	err := s.db.Where("pod_uid = ? AND timestamp BETWEEN ? AND ?", uid, start, end).Find(&podMetrics).Error
+	return podMetrics, err
+}
+
+func (s *sqliteStorage) GetContainerResourceMetric(containerID *string, start, end *time.Time) (
+	[]containerResourceMetric, error) {
+	var metrics []containerResourceMetric
+	err := s.db.Where("container_id = ? AND timestamp BETWEEN ? AND ?", containerID, start, end).Find(
+		&metrics).Error
+	return metrics, err
+}
+
+func (s *sqliteStorage) GetBECPUResourceMetric(start, end *time.Time) ([]beCPUResourceMetric, error) {
+	var metrics []beCPUResourceMetric
+	err := s.db.Where("timestamp BETWEEN ? AND ?", start, end).Find(&metrics).Error
+	return metrics, err
+}
+
+func (s *sqliteStorage) GetRawRecord(recordName string) (*rawRecord, error) {
+	record := &rawRecord{}
+	err := s.db.Where("record_type = ?", recordName).First(&record).Error
+	return record, err
+}
+
+func (s *sqliteStorage) GetPodThrottledMetric(uid *string, start, end *time.Time) ([]podThrottledMetric, error) {
+	var metrics []podThrottledMetric
+	err := s.db.Where("pod_uid = ? AND timestamp BETWEEN ? AND ?", uid, start, end).Find(&metrics).Error
+	return metrics, err
+}
+
+func (s *sqliteStorage) GetContainerThrottledMetric(id *string, start, end *time.Time) ([]containerThrottledMetric, error) {
+	var metrics []containerThrottledMetric
+	err := s.db.Where("container_id = ? AND timestamp BETWEEN ? AND ?", id, start, end).Find(&metrics).Error
+	return metrics, err
+}
+
+func (s *sqliteStorage) GetContainerCPIMetric(containerID *string, start, end *time.Time) ([]containerCPIMetric, error) {
+	var metrics []containerCPIMetric
+	err := s.db.Where("container_id = ? AND timestamp BETWEEN ? AND ?", containerID, start, end).Find(&metrics).Error
+	return metrics, err
+}
+
+func (s *sqliteStorage) GetContainerPSIMetric(containerID *string, start, end *time.Time) ([]containerPSIMetric, error) {
+	var metrics []containerPSIMetric
+	err := s.db.Where("container_id = ? AND timestamp BETWEEN ? AND ?", containerID, start, end).Find(&metrics).Error
+	return metrics, err
+}
+
+func (s *sqliteStorage) GetPodPSIMetric(uid *string, start, end *time.Time) ([]podPSIMetric, error) {
+	var metrics []podPSIMetric
+	err := s.db.Where("pod_uid = ? AND timestamp BETWEEN ? AND ?", uid, start, end).Find(&metrics).Error
+	return metrics, err
+}
+
+func (s *sqliteStorage) GetContainerCPIMetricByPodUid(podUid *string, start, end *time.Time) ([]containerCPIMetric, error) {
+	var metrics []containerCPIMetric
+	err := s.db.Where("pod_uid = ? AND timestamp BETWEEN ? AND ?", podUid, start, end).Find(&metrics).Error
+	return metrics, err
+}
+
+func (s *sqliteStorage) GetNodeIOMetric(start, end *time.Time) ([]nodeIOMetric, error) {
+	var metrics []nodeIOMetric
+	err := s.db.Where("timestamp BETWEEN ? AND ? order by timestamp", start, end).Find(&metrics).Error
+	return metrics, err
+}
+
+func (s *sqliteStorage) GetPodIOMetric(uid *string, start, end *time.Time) ([]podIOMetric, error) {
+	var metrics []podIOMetric
+	err := s.db.Where("pod_uid = ? AND timestamp BETWEEN ? AND ?", uid, start, end).Find(&metrics).Error
+	return metrics, err
+}
+
+func (s *sqliteStorage) DeleteNodeResourceMetric(start, end *time.Time) error {
+	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&nodeResourceMetric{}).Error
+}
+
+func (s *sqliteStorage) DeletePodResourceMetric(start, end *time.Time) error {
+	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&podResourceMetric{}).Error
+}
+
+func (s *sqliteStorage) DeleteContainerResourceMetric(start, end *time.Time) error {
+	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&containerResourceMetric{}).Error
+}
+
+func (s *sqliteStorage) DeleteBECPUResourceMetric(start, end *time.Time) error {
+	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&beCPUResourceMetric{}).Error
+}
+
+func (s *sqliteStorage) DeletePodThrottledMetric(start, end *time.Time) error {
+	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&podThrottledMetric{}).Error
+}
+
+func (s *sqliteStorage) DeleteContainerThrottledMetric(start, end *time.Time) error {
+	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&containerThrottledMetric{}).Error
+}
+
+func (s *sqliteStorage) DeleteContainerCPIMetric(start, end *time.Time) error {
+	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&containerCPIMetric{}).Error
+}
+
+func (s *sqliteStorage) DeleteContainerPSIMetric(start, end *time.Time) error {
+	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&containerPSIMetric{}).Error
+}
+
+func (s *sqliteStorage) DeletePodPSIMetric(start, end *time.Time) error {
+	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&podPSIMetric{}).Error
+}
+
+func (s *sqliteStorage) DeleteNodeIOMetric(start, end *time.Time) error {
+	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&nodeIOMetric{}).Error
+}
+
+func (s *sqliteStorage) DeletePodIOMetric(start, end *time.Time) error {
+	return s.db.Where("timestamp BETWEEN ? AND ?", start, end).Delete(&podIOMetric{}).Error
+}
+
+func (s *sqliteStorage) CountNodeResourceMetric() (int64, error) {
+	count := int64(0)
+	err := s.db.Model(&nodeResourceMetric{}).Count(&count).Error
+	return count, err
+}
+
+func (s *sqliteStorage) CountPodResourceMetric() (int64, error) {
+	count := int64(0)
+	err := s.db.Model(&podResourceMetric{}).Count(&count).Error
+	return count, err
+}
+
+func (s *sqliteStorage) CountContainerResourceMetric() (int64, error) {
+	count := int64(0)
+	err := s.db.Model(&containerResourceMetric{}).Count(&count).Error
+	return count, err
+}
+
+func (s *sqliteStorage) CountBECPUResourceMetric() (int64, error) {
+	count := int64(0)
+	err := s.db.Model(&beCPUResourceMetric{}).Count(&count).Error
+	return count, err
+}
+
+func (s *sqliteStorage) CountPodThrottledMetric() (int64, error) {
+	count := int64(0)
+	err := s.db.Model(&podThrottledMetric{}).Count(&count).Error
+	return count, err
+}
+
+func (s *sqliteStorage) CountContainerThrottledMetric() (int64, error) {
+	count := int64(0)
+	err := s.db.Model(&containerThrottledMetric{}).Count(&count).Error
+	return count, err
+}
+
+func (s *sqliteStorage) CountNodeIOMetric() (int64, error) {
+	count := int64(0)
+	err := s.db.Model(&nodeIOMetric{}).Count(&count).Error
+	return count, err
+}
+
+func (s *sqliteStorage) CountPodIOMetric() (int64, error) {
+	count := int64(0)
+	err := s.db.Model(&podIOMetric{}).Count(&count).Error
+	return count, err
+}
@@ -145,6 +145,35 @@ type podPSIMetric struct {
 	Timestamp        time.Time
 }
 
+// nodeIOMetric keeps the network and disk io throughput of the node, in units per second
+type nodeIOMetric struct {
+	ID               uint64 `gorm:"primarykey"`
+	NetworkRxBytes   float64
+	NetworkTxBytes   float64
+	NetworkRxPackets float64
+	NetworkTxPackets float64
+	DiskReadBytes    float64
+	DiskWriteBytes   float64
+	DiskReadIOPS     float64
+	DiskWriteIOPS    float64
+	Timestamp        time.Time
+}
+
+// podIOMetric keeps the network and disk io throughput of the pod, in units per second
+type podIOMetric struct {
+	ID               uint64 `gorm:"primarykey"`
+	PodUID           string `gorm:"index:idx_pod_io_uid"`
+	NetworkRxBytes   float64
+	NetworkTxBytes   float64
+	NetworkRxPackets float64
+	NetworkTxPackets float64
+	DiskReadBytes    float64
+	DiskWriteBytes   float64
+	DiskReadIOPS     float64
+	DiskWriteIOPS    float64
+	Timestamp        time.Time
+}
+
 type rawRecord struct {
 	RecordType string `gorm:"primarykey"`
 	RecordStr  string
@@ -0,0 +1,797 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccache
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"k8s.io/klog/v2"
+)
+
+const (
+	tsdbCheckpointFileName        = "metrics.checkpoint"
+	tsdbCheckpointTempFilePattern = ".metrics.checkpoint.*"
+	tsdbCheckpointVersion         = 1
+
+	tsdbTableNodeResource       = "node_resource"
+	tsdbTablePodResource        = "pod_resource"
+	tsdbTableContainerResource  = "container_resource"
+	tsdbTableBECPUResource      = "be_cpu_resource"
+	tsdbTablePodThrottled       = "pod_throttled"
+	tsdbTableContainerThrottled = "container_throttled"
+	tsdbTableContainerCPI       = "container_cpi"
+	tsdbTableContainerPSI       = "container_psi"
+	tsdbTablePodPSI             = "pod_psi"
+	tsdbTableNodeIO             = "node_io"
+	tsdbTablePodIO              = "pod_io"
+
+	tsdbLabelPodUID = "pod_uid"
+	// tsdbSingletonSeriesKey is the series key of the node level tables
+	tsdbSingletonSeriesKey = ""
+)
+
+// tsdbStorage keeps the samples in gorilla-compressed chunks per series, e.g. one series for each pod.
+// Compared with the sqlite storage, it avoids the row overhead and the index maintenance, and expired
+// chunks are dropped as a whole. The timestamps are kept in millisecond precision.
+type tsdbStorage struct {
+	lock          sync.RWMutex
+	tables        map[string]*tsdbTable
+	rawRecords    map[string]string
+	checkpointDir string
+}
+
+// NewTSDBStorage creates a tsdb storage. If the checkpointDir is not empty, the samples are restored from
+// the last checkpoint in the dir, and Checkpoint() dumps the samples into it.
+func NewTSDBStorage(checkpointDir string) (*tsdbStorage, error) {
+	s := &tsdbStorage{
+		tables: map[string]*tsdbTable{
+			tsdbTableNodeResource:       newTSDBTable(2),
+			tsdbTablePodResource:        newTSDBTable(2),
+			tsdbTableContainerResource:  newTSDBTable(2),
+			tsdbTableBECPUResource:      newTSDBTable(3),
+			tsdbTablePodThrottled:       newTSDBTable(1),
+			tsdbTableContainerThrottled: newTSDBTable(1),
+			tsdbTableContainerCPI:       newTSDBTable(2),
+			tsdbTableContainerPSI:       newTSDBTable(7),
+			tsdbTablePodPSI:             newTSDBTable(7),
+			tsdbTableNodeIO:             newTSDBTable(8),
+			tsdbTablePodIO:              newTSDBTable(8),
+		},
+		rawRecords:    map[string]string{},
+		checkpointDir: checkpointDir,
+	}
+	if checkpointDir == "" {
+		return s, nil
+	}
+	if err := s.restore(); err != nil {
+		// metrics are not critical, start with an empty storage
+		klog.Warningf("failed to restore tsdb storage from checkpoint dir %s, error %v", checkpointDir, err)
+	}
+	return s, nil
+}
+
+func (s *tsdbStorage) Close() error {
+	if s.checkpointDir == "" {
+		return nil
+	}
+	return s.Checkpoint()
+}
+
+func (s *tsdbStorage) append(table, key string, labels map[string]string, t time.Time, values []float64, gpus GPUMetricsArray) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.tables[table].append(key, labels, t.UnixMilli(), values, gpus)
+}
+
+func (s *tsdbStorage) query(table, key string, start, end *time.Time, fn func(t time.Time, values []float64, gpus GPUMetricsArray)) error {
+	if start == nil || end == nil {
+		return fmt.Errorf("query time range is illegal, start %v, end %v", start, end)
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	series, ok := s.tables[table].series[key]
+	if !ok {
+		return nil
+	}
+	return series.query(start.UnixMilli(), end.UnixMilli(), fn)
+}
+
+func (s *tsdbStorage) delete(table string, start, end *time.Time) error {
+	if start == nil || end == nil {
+		return fmt.Errorf("delete time range is illegal, start %v, end %v", start, end)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.tables[table].deleteRange(start.UnixMilli(), end.UnixMilli())
+}
+
+func (s *tsdbStorage) count(table string) (int64, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.tables[table].count(), nil
+}
+
+func (s *tsdbStorage) InsertNodeResourceMetric(n *nodeResourceMetric) error {
+	return s.append(tsdbTableNodeResource, tsdbSingletonSeriesKey, nil, n.Timestamp,
+		[]float64{n.CPUUsedCores, n.MemoryUsedBytes}, n.GPUs)
+}
+
+func (s *tsdbStorage) InsertPodResourceMetric(p *podResourceMetric) error {
+	return s.append(tsdbTablePodResource, p.PodUID, nil, p.Timestamp,
+		[]float64{p.CPUUsedCores, p.MemoryUsedBytes}, p.GPUs)
+}
+
+func (s *tsdbStorage) InsertContainerResourceMetric(m *containerResourceMetric) error {
+	return s.append(tsdbTableContainerResource, m.ContainerID, nil, m.Timestamp,
+		[]float64{m.CPUUsedCores, m.MemoryUsedBytes}, m.GPUs)
+}
+
+func (s *tsdbStorage) InsertBECPUResourceMetric(b *beCPUResourceMetric) error {
+	return s.append(tsdbTableBECPUResource, tsdbSingletonSeriesKey, nil, b.Timestamp,
+		[]float64{b.CPUUsedCores, b.CPULimitCores, b.CPURequestCores}, nil)
+}
+
+func (s *tsdbStorage) InsertRawRecord(record *rawRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.rawRecords[record.RecordType] = record.RecordStr
+	return nil
+}
+
+func (s *tsdbStorage) InsertPodThrottledMetric(m *podThrottledMetric) error {
+	return s.append(tsdbTablePodThrottled, m.PodUID, nil, m.Timestamp, []float64{m.CPUThrottledRatio}, nil)
+}
+
+func (s *tsdbStorage) InsertContainerThrottledMetric(m *containerThrottledMetric) error {
+	return s.append(tsdbTableContainerThrottled, m.ContainerID, nil, m.Timestamp, []float64{m.CPUThrottledRatio}, nil)
+}
+
+func (s *tsdbStorage) InsertContainerCPIMetric(m *containerCPIMetric) error {
+	return s.append(tsdbTableContainerCPI, m.ContainerID, map[string]string{tsdbLabelPodUID: m.PodUID}, m.Timestamp,
+		[]float64{m.Cycles, m.Instructions}, nil)
+}
+
+func (s *tsdbStorage) InsertContainerPSIMetric(m *containerPSIMetric) error {
+	return s.append(tsdbTableContainerPSI, m.ContainerID, map[string]string{tsdbLabelPodUID: m.PodUID}, m.Timestamp,
+		psiValues(m.SomeCPUAvg10, m.SomeMemAvg10, m.SomeIOAvg10, m.FullCPUAvg10, m.FullMemAvg10, m.FullIOAvg10,
+			m.CPUFullSupported), nil)
+}
+
+func (s *tsdbStorage) InsertPodPSIMetric(m *podPSIMetric) error {
+	return s.append(tsdbTablePodPSI, m.PodUID, nil, m.Timestamp,
+		psiValues(m.SomeCPUAvg10, m.SomeMemAvg10, m.SomeIOAvg10, m.FullCPUAvg10, m.FullMemAvg10, m.FullIOAvg10,
+			m.CPUFullSupported), nil)
+}
+
+func (s *tsdbStorage) InsertNodeIOMetric(m *nodeIOMetric) error {
+	return s.append(tsdbTableNodeIO, tsdbSingletonSeriesKey, nil, m.Timestamp,
+		[]float64{m.NetworkRxBytes, m.NetworkTxBytes, m.NetworkRxPackets, m.NetworkTxPackets,
+			m.DiskReadBytes, m.DiskWriteBytes, m.DiskReadIOPS, m.DiskWriteIOPS}, nil)
+}
+
+func (s *tsdbStorage) InsertPodIOMetric(m *podIOMetric) error {
+	return s.append(tsdbTablePodIO, m.PodUID, nil, m.Timestamp,
+		[]float64{m.NetworkRxBytes, m.NetworkTxBytes, m.NetworkRxPackets, m.NetworkTxPackets,
+			m.DiskReadBytes, m.DiskWriteBytes, m.DiskReadIOPS, m.DiskWriteIOPS}, nil)
+}
+
+func (s *tsdbStorage) GetNodeResourceMetric(start, end *time.Time) ([]nodeResourceMetric, error) {
+	var metrics []nodeResourceMetric
+	err := s.query(tsdbTableNodeResource, tsdbSingletonSeriesKey, start, end, func(t time.Time, values []float64, gpus GPUMetricsArray) {
+		metrics = append(metrics, nodeResourceMetric{
+			CPUUsedCores:    values[0],
+			MemoryUsedBytes: values[1],
+			GPUs:            gpus,
+			Timestamp:       t,
+		})
+	})
+	// keep the same order as the sqlite storage
+	sort.SliceStable(metrics, func(i, j int) bool {
+		return metrics[i].Timestamp.Before(metrics[j].Timestamp)
+	})
+	return metrics, err
+}
+
+func (s *tsdbStorage) GetPodResourceMetric(uid *string, start, end *time.Time) ([]podResourceMetric, error) {
+	if uid == nil {
+		return nil, fmt.Errorf("pod uid is nil")
+	}
+	var metrics []podResourceMetric
+	err := s.query(tsdbTablePodResource, *uid, start, end, func(t time.Time, values []float64, gpus GPUMetricsArray) {
+		metrics = append(metrics, podResourceMetric{
+			PodUID:          *uid,
+			CPUUsedCores:    values[0],
+			MemoryUsedBytes: values[1],
+			GPUs:            gpus,
+			Timestamp:       t,
+		})
+	})
+	return metrics, err
+}
+
+func (s *tsdbStorage) GetContainerResourceMetric(containerID *string, start, end *time.Time) ([]containerResourceMetric, error) {
+	if containerID == nil {
+		return nil, fmt.Errorf("container id is nil")
+	}
+	var metrics []containerResourceMetric
+	err := s.query(tsdbTableContainerResource, *containerID, start, end, func(t time.Time, values []float64, gpus GPUMetricsArray) {
+		metrics = append(metrics, containerResourceMetric{
+			ContainerID:     *containerID,
+			CPUUsedCores:    values[0],
+			MemoryUsedBytes: values[1],
+			GPUs:            gpus,
+			Timestamp:       t,
+		})
+	})
+	return metrics, err
+}
+
+func (s *tsdbStorage) GetBECPUResourceMetric(start, end *time.Time) ([]beCPUResourceMetric, error) {
+	var metrics []beCPUResourceMetric
+	err := s.query(tsdbTableBECPUResource, tsdbSingletonSeriesKey, start, end, func(t time.Time, values []float64, _ GPUMetricsArray) {
+		metrics = append(metrics, beCPUResourceMetric{
+			CPUUsedCores:    values[0],
+			CPULimitCores:   values[1],
+			CPURequestCores: values[2],
+			Timestamp:       t,
+		})
+	})
+	return metrics, err
+}
+
+func (s *tsdbStorage) GetRawRecord(recordName string) (*rawRecord, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	recordStr, ok := s.rawRecords[recordName]
+	if !ok {
+		// keep the same error as the sqlite storage
+		return &rawRecord{}, gorm.ErrRecordNotFound
+	}
+	return &rawRecord{RecordType: recordName, RecordStr: recordStr}, nil
+}
+
+func (s *tsdbStorage) GetPodThrottledMetric(uid *string, start, end *time.Time) ([]podThrottledMetric, error) {
+	if uid == nil {
+		return nil, fmt.Errorf("pod uid is nil")
+	}
+	var metrics []podThrottledMetric
+	err := s.query(tsdbTablePodThrottled, *uid, start, end, func(t time.Time, values []float64, _ GPUMetricsArray) {
+		metrics = append(metrics, podThrottledMetric{
+			PodUID:            *uid,
+			CPUThrottledRatio: values[0],
+			Timestamp:         t,
+		})
+	})
+	return metrics, err
+}
+
+func (s *tsdbStorage) GetContainerThrottledMetric(id *string, start, end *time.Time) ([]containerThrottledMetric, error) {
+	if id == nil {
+		return nil, fmt.Errorf("container id is nil")
+	}
+	var metrics []containerThrottledMetric
+	err := s.query(tsdbTableContainerThrottled, *id, start, end, func(t time.Time, values []float64, _ GPUMetricsArray) {
+		metrics = append(metrics, containerThrottledMetric{
+			ContainerID:       *id,
+			CPUThrottledRatio: values[0],
+			Timestamp:         t,
+		})
+	})
+	return metrics, err
+}
+
+func (s *tsdbStorage) GetContainerCPIMetric(containerID *string, start, end *time.Time) ([]containerCPIMetric, error) {
+	if containerID == nil {
+		return nil, fmt.Errorf("container id is nil")
+	}
+	podUID := s.seriesLabel(tsdbTableContainerCPI, *containerID, tsdbLabelPodUID)
+	var metrics []containerCPIMetric
+	err := s.query(tsdbTableContainerCPI, *containerID, start, end, func(t time.Time, values []float64, _ GPUMetricsArray) {
+		metrics = append(metrics, containerCPIMetric{
+			PodUID:       podUID,
+			ContainerID:  *containerID,
+			Cycles:       values[0],
+			Instructions: values[1],
+			Timestamp:    t,
+		})
+	})
+	return metrics, err
+}
+
+func (s *tsdbStorage) GetContainerPSIMetric(containerID *string, start, end *time.Time) ([]containerPSIMetric, error) {
+	if containerID == nil {
+		return nil, fmt.Errorf("container id is nil")
+	}
+	podUID := s.seriesLabel(tsdbTableContainerPSI, *containerID, tsdbLabelPodUID)
+	var metrics []containerPSIMetric
+	err := s.query(tsdbTableContainerPSI, *containerID, start, end, func(t time.Time, values []float64, _ GPUMetricsArray) {
+		metrics = append(metrics, containerPSIMetric{
+			PodUID:           podUID,
+			ContainerID:      *containerID,
+			SomeCPUAvg10:     values[0],
+			SomeMemAvg10:     values[1],
+			SomeIOAvg10:      values[2],
+			FullCPUAvg10:     values[3],
+			FullMemAvg10:     values[4],
+			FullIOAvg10:      values[5],
+			CPUFullSupported: values[6] != 0,
+			Timestamp:        t,
+		})
+	})
+	return metrics, err
+}
+
+func (s *tsdbStorage) GetPodPSIMetric(uid *string, start, end *time.Time) ([]podPSIMetric, error) {
+	if uid == nil {
+		return nil, fmt.Errorf("pod uid is nil")
+	}
+	var metrics []podPSIMetric
+	err := s.query(tsdbTablePodPSI, *uid, start, end, func(t time.Time, values []float64, _ GPUMetricsArray) {
+		metrics = append(metrics, podPSIMetric{
+			PodUID:           *uid,
+			SomeCPUAvg10:     values[0],
+			SomeMemAvg10:     values[1],
+			SomeIOAvg10:      values[2],
+			FullCPUAvg10:     values[3],
+			FullMemAvg10:     values[4],
+			FullIOAvg10:      values[5],
+			CPUFullSupported: values[6] != 0,
+			Timestamp:        t,
+		})
+	})
+	return metrics, err
+}
+
+func (s *tsdbStorage) GetContainerCPIMetricByPodUid(podUid *string, start, end *time.Time) ([]containerCPIMetric, error) {
+	if podUid == nil {
+		return nil, fmt.Errorf("pod uid is nil")
+	}
+	var metrics []containerCPIMetric
+	for _, containerID := range s.seriesKeysByLabel(tsdbTableContainerCPI, tsdbLabelPodUID, *podUid) {
+		containerID := containerID
+		containerMetrics, err := s.GetContainerCPIMetric(&containerID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, containerMetrics...)
+	}
+	return metrics, nil
+}
+
+func (s *tsdbStorage) GetNodeIOMetric(start, end *time.Time) ([]nodeIOMetric, error) {
+	var metrics []nodeIOMetric
+	err := s.query(tsdbTableNodeIO, tsdbSingletonSeriesKey, start, end, func(t time.Time, values []float64, _ GPUMetricsArray) {
+		metrics = append(metrics, nodeIOMetric{
+			NetworkRxBytes:   values[0],
+			NetworkTxBytes:   values[1],
+			NetworkRxPackets: values[2],
+			NetworkTxPackets: values[3],
+			DiskReadBytes:    values[4],
+			DiskWriteBytes:   values[5],
+			DiskReadIOPS:     values[6],
+			DiskWriteIOPS:    values[7],
+			Timestamp:        t,
+		})
+	})
+	// keep the same order as the sqlite storage
+	sort.SliceStable(metrics, func(i, j int) bool {
+		return metrics[i].Timestamp.Before(metrics[j].Timestamp)
+	})
+	return metrics, err
+}
+
+func (s *tsdbStorage) GetPodIOMetric(uid *string, start, end *time.Time) ([]podIOMetric, error) {
+	if uid == nil {
+		return nil, fmt.Errorf("pod uid is nil")
+	}
+	var metrics []podIOMetric
+	err := s.query(tsdbTablePodIO, *uid, start, end, func(t time.Time, values []float64, _ GPUMetricsArray) {
+		metrics = append(metrics, podIOMetric{
+			PodUID:           *uid,
+			NetworkRxBytes:   values[0],
+			NetworkTxBytes:   values[1],
+			NetworkRxPackets: values[2],
+			NetworkTxPackets: values[3],
+			DiskReadBytes:    values[4],
+			DiskWriteBytes:   values[5],
+			DiskReadIOPS:     values[6],
+			DiskWriteIOPS:    values[7],
+			Timestamp:        t,
+		})
+	})
+	return metrics, err
+}
+
+func (s *tsdbStorage) DeleteNodeResourceMetric(start, end *time.Time) error {
+	return s.delete(tsdbTableNodeResource, start, end)
+}
+
+func (s *tsdbStorage) DeletePodResourceMetric(start, end *time.Time) error {
+	return s.delete(tsdbTablePodResource, start, end)
+}
+
+func (s *tsdbStorage) DeleteContainerResourceMetric(start, end *time.Time) error {
+	return s.delete(tsdbTableContainerResource, start, end)
+}
+
+func (s *tsdbStorage) DeleteBECPUResourceMetric(start, end *time.Time) error {
+	return s.delete(tsdbTableBECPUResource, start, end)
+}
+
+func (s *tsdbStorage) DeletePodThrottledMetric(start, end *time.Time) error {
+	return s.delete(tsdbTablePodThrottled, start, end)
+}
+
+func (s *tsdbStorage) DeleteContainerThrottledMetric(start, end *time.Time) error {
+	return s.delete(tsdbTableContainerThrottled, start, end)
+}
+
+func (s *tsdbStorage) DeleteContainerCPIMetric(start, end *time.Time) error {
+	return s.delete(tsdbTableContainerCPI, start, end)
+}
+
+func (s *tsdbStorage) DeleteContainerPSIMetric(start, end *time.Time) error {
+	return s.delete(tsdbTableContainerPSI, start, end)
+}
+
+func (s *tsdbStorage) DeletePodPSIMetric(start, end *time.Time) error {
+	return s.delete(tsdbTablePodPSI, start, end)
+}
+
+func (s *tsdbStorage) DeleteNodeIOMetric(start, end *time.Time) error {
+	return s.delete(tsdbTableNodeIO, start, end)
+}
+
+func (s *tsdbStorage) DeletePodIOMetric(start, end *time.Time) error {
+	return s.delete(tsdbTablePodIO, start, end)
+}
+
+func (s *tsdbStorage) CountNodeResourceMetric() (int64, error) {
+	return s.count(tsdbTableNodeResource)
+}
+
+func (s *tsdbStorage) CountPodResourceMetric() (int64, error) {
+	return s.count(tsdbTablePodResource)
+}
+
+func (s *tsdbStorage) CountContainerResourceMetric() (int64, error) {
+	return s.count(tsdbTableContainerResource)
+}
+
+func (s *tsdbStorage) CountBECPUResourceMetric() (int64, error) {
+	return s.count(tsdbTableBECPUResource)
+}
+
+func (s *tsdbStorage) CountPodThrottledMetric() (int64, error) {
+	return s.count(tsdbTablePodThrottled)
+}
+
+func (s *tsdbStorage) CountContainerThrottledMetric() (int64, error) {
+	return s.count(tsdbTableContainerThrottled)
+}
+
+func (s *tsdbStorage) CountNodeIOMetric() (int64, error) {
+	return s.count(tsdbTableNodeIO)
+}
+
+func (s *tsdbStorage) CountPodIOMetric() (int64, error) {
+	return s.count(tsdbTablePodIO)
+}
+
+func (s *tsdbStorage) seriesLabel(table, key, label string) string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	series, ok := s.tables[table].series[key]
+	if !ok {
+		return ""
+	}
+	return series.labels[label]
+}
+
+func (s *tsdbStorage) seriesKeysByLabel(table, label, value string) []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var keys []string
+	for key, series := range s.tables[table].series {
+		if series.labels[label] == value {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func psiValues(someCPU, someMem, someIO, fullCPU, fullMem, fullIO float64, cpuFullSupported bool) []float64 {
+	supported := 0.0
+	if cpuFullSupported {
+		supported = 1.0
+	}
+	return []float64{someCPU, someMem, someIO, fullCPU, fullMem, fullIO, supported}
+}
+
+// tsdbCheckpoint is the gob format of the checkpoint file.
+type tsdbCheckpoint struct {
+	Version    int
+	Tables     map[string]map[string]*seriesCheckpoint
+	RawRecords map[string]string
+}
+
+type seriesCheckpoint struct {
+	Labels map[string]string
+	Chunks []chunkCheckpoint
+}
+
+type chunkCheckpoint struct {
+	Data  []byte
+	Count int
+	MinT  int64
+	MaxT  int64
+	GPUs  map[int]GPUMetricsArray
+}
+
+// Checkpoint dumps all samples into the checkpoint dir. The file is written to a temporary file and then renamed,
+// so a crash during the checkpoint never leaves a broken file.
+func (s *tsdbStorage) Checkpoint() error {
+	if s.checkpointDir == "" {
+		return fmt.Errorf("checkpoint dir is not specified")
+	}
+	s.lock.RLock()
+	cp := &tsdbCheckpoint{
+		Version:    tsdbCheckpointVersion,
+		Tables:     map[string]map[string]*seriesCheckpoint{},
+		RawRecords: map[string]string{},
+	}
+	for name, table := range s.tables {
+		cp.Tables[name] = table.checkpoint()
+	}
+	for k, v := range s.rawRecords {
+		cp.RawRecords[k] = v
+	}
+	s.lock.RUnlock()
+
+	if err := os.MkdirAll(s.checkpointDir, 0755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(s.checkpointDir, tsdbCheckpointTempFilePattern)
+	if err != nil {
+		return err
+	}
+	tmpName := f.Name()
+	defer os.Remove(tmpName)
+	if err := gob.NewEncoder(f).Encode(cp); err != nil {
+		f.Close()
+		return fmt.Errorf("encode checkpoint failed, error %v", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, filepath.Join(s.checkpointDir, tsdbCheckpointFileName))
+}
+
+func (s *tsdbStorage) restore() error {
+	f, err := os.Open(filepath.Join(s.checkpointDir, tsdbCheckpointFileName))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cp := &tsdbCheckpoint{}
+	if err := gob.NewDecoder(f).Decode(cp); err != nil {
+		return fmt.Errorf("decode checkpoint failed, error %v", err)
+	}
+	if cp.Version != tsdbCheckpointVersion {
+		return fmt.Errorf("unsupported checkpoint version %d", cp.Version)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for name, seriesMap := range cp.Tables {
+		table, ok := s.tables[name]
+		if !ok {
+			continue
+		}
+		table.restore(seriesMap)
+	}
+	for k, v := range cp.RawRecords {
+		s.rawRecords[k] = v
+	}
+	klog.V(4).Infof("tsdb storage restored from checkpoint dir %s", s.checkpointDir)
+	return nil
+}
+
+type tsdbTable struct {
+	columns int
+	series  map[string]*tsdbSeries
+}
+
+func newTSDBTable(columns int) *tsdbTable {
+	return &tsdbTable{
+		columns: columns,
+		series:  map[string]*tsdbSeries{},
+	}
+}
+
+func (t *tsdbTable) append(key string, labels map[string]string, ts int64, values []float64, gpus GPUMetricsArray) error {
+	series, ok := t.series[key]
+	if !ok {
+		series = &tsdbSeries{columns: t.columns}
+		t.series[key] = series
+	}
+	if labels != nil {
+		series.labels = labels
+	}
+	return series.append(ts, values, gpus)
+}
+
+func (t *tsdbTable) deleteRange(start, end int64) error {
+	for key, series := range t.series {
+		if err := series.deleteRange(start, end); err != nil {
+			return err
+		}
+		if len(series.chunks) == 0 {
+			delete(t.series, key)
+		}
+	}
+	return nil
+}
+
+func (t *tsdbTable) count() int64 {
+	var count int64
+	for _, series := range t.series {
+		for _, c := range series.chunks {
+			count += int64(c.count)
+		}
+	}
+	return count
+}
+
+func (t *tsdbTable) checkpoint() map[string]*seriesCheckpoint {
+	seriesMap := make(map[string]*seriesCheckpoint, len(t.series))
+	for key, series := range t.series {
+		sc := &seriesCheckpoint{Labels: series.labels}
+		for _, c := range series.chunks {
+			data := make([]byte, len(c.stream.data))
+			copy(data, c.stream.data)
+			sc.Chunks = append(sc.Chunks, chunkCheckpoint{
+				Data:  data,
+				Count: c.count,
+				MinT:  c.minT,
+				MaxT:  c.maxT,
+				GPUs:  c.gpus,
+			})
+		}
+		seriesMap[key] = sc
+	}
+	return seriesMap
+}
+
+func (t *tsdbTable) restore(seriesMap map[string]*seriesCheckpoint) {
+	for key, sc := range seriesMap {
+		series := &tsdbSeries{columns: t.columns, labels: sc.Labels}
+		for _, cc := range sc.Chunks {
+			// restored chunks are read-only since the appender states are not checkpointed
+			series.chunks = append(series.chunks, &seriesChunk{
+				chunk: &chunk{
+					stream:  bstream{data: cc.Data, nbits: len(cc.Data) * 8},
+					columns: t.columns,
+					count:   cc.Count,
+					minT:    cc.MinT,
+					maxT:    cc.MaxT,
+				},
+				gpus:   cc.GPUs,
+				sealed: true,
+			})
+		}
+		t.series[key] = series
+	}
+}
+
+type seriesChunk struct {
+	*chunk
+	// gpus keeps the gpu metrics by the sample index, since they are not float columns
+	gpus map[int]GPUMetricsArray
+	// sealed chunk can not be appended
+	sealed bool
+}
+
+type tsdbSeries struct {
+	columns int
+	labels  map[string]string
+	chunks  []*seriesChunk
+}
+
+func (s *tsdbSeries) append(ts int64, values []float64, gpus GPUMetricsArray) error {
+	if len(s.chunks) == 0 || s.chunks[len(s.chunks)-1].sealed || s.chunks[len(s.chunks)-1].full() {
+		s.chunks = append(s.chunks, &seriesChunk{chunk: newChunk(s.columns)})
+	}
+	head := s.chunks[len(s.chunks)-1]
+	if len(gpus) > 0 {
+		if head.gpus == nil {
+			head.gpus = map[int]GPUMetricsArray{}
+		}
+		head.gpus[head.count] = gpus
+	}
+	return head.append(ts, values)
+}
+
+func (s *tsdbSeries) query(start, end int64, fn func(t time.Time, values []float64, gpus GPUMetricsArray)) error {
+	for _, c := range s.chunks {
+		if c.maxT < start || c.minT > end {
+			continue
+		}
+		err := c.iterate(func(idx int, t int64, values []float64) {
+			if t < start || t > end {
+				return
+			}
+			fn(time.UnixMilli(t), values, c.gpus[idx])
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *tsdbSeries) deleteRange(start, end int64) error {
+	var chunks []*seriesChunk
+	for _, c := range s.chunks {
+		if c.maxT < start || c.minT > end {
+			chunks = append(chunks, c)
+			continue
+		}
+		if c.minT >= start && c.maxT <= end {
+			continue
+		}
+		// the chunk is partially deleted, re-encode the remaining samples
+		remained := &tsdbSeries{columns: s.columns}
+		var appendErr error
+		err := c.iterate(func(idx int, t int64, values []float64) {
+			if appendErr != nil || (t >= start && t <= end) {
+				return
+			}
+			appendErr = remained.append(t, values, c.gpus[idx])
+		})
+		if err != nil {
+			return err
+		}
+		if appendErr != nil {
+			return appendErr
+		}
+		for _, rc := range remained.chunks {
+			rc.sealed = true
+		}
+		chunks = append(chunks, remained.chunks...)
+	}
+	s.chunks = chunks
+	return nil
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func Test_tsdbStorage_PodResourceMetric_CRUD(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	uid := "test-pod-uid"
+	s, err := NewTSDBStorage("")
+	assert.NoError(t, err)
+	defer s.Close()
+
+	// more samples than a chunk to cover the chunk rotation
+	for i := 0; i < maxSamplesPerChunk+10; i++ {
+		err = s.InsertPodResourceMetric(&podResourceMetric{
+			PodUID:          uid,
+			CPUUsedCores:    float64(i),
+			MemoryUsedBytes: float64(i * 1024),
+			Timestamp:       now.Add(time.Duration(i-maxSamplesPerChunk-10) * time.Second),
+		})
+		assert.NoError(t, err)
+	}
+	err = s.InsertPodResourceMetric(&podResourceMetric{
+		PodUID:          "other-pod-uid",
+		CPUUsedCores:    1,
+		MemoryUsedBytes: 1024,
+		GPUs:            GPUMetricsArray{{Minor: 0, DeviceUUID: "1", SMUtil: 10, MemoryUsed: 100, MemoryTotal: 1000}},
+		Timestamp:       now,
+	})
+	assert.NoError(t, err)
+	count, err := s.CountPodResourceMetric()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(maxSamplesPerChunk+11), count)
+
+	start, end := now.Add(-5*time.Second), now
+	got, err := s.GetPodResourceMetric(&uid, &start, &end)
+	assert.NoError(t, err)
+	assert.Equal(t, []podResourceMetric{
+		{PodUID: uid, CPUUsedCores: 125, MemoryUsedBytes: 125 * 1024, Timestamp: now.Add(-5 * time.Second)},
+		{PodUID: uid, CPUUsedCores: 126, MemoryUsedBytes: 126 * 1024, Timestamp: now.Add(-4 * time.Second)},
+		{PodUID: uid, CPUUsedCores: 127, MemoryUsedBytes: 127 * 1024, Timestamp: now.Add(-3 * time.Second)},
+		{PodUID: uid, CPUUsedCores: 128, MemoryUsedBytes: 128 * 1024, Timestamp: now.Add(-2 * time.Second)},
+		{PodUID: uid, CPUUsedCores: 129, MemoryUsedBytes: 129 * 1024, Timestamp: now.Add(-1 * time.Second)},
+	}, got)
+
+	otherUID := "other-pod-uid"
+	got, err = s.GetPodResourceMetric(&otherUID, &start, &end)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(got))
+	assert.Equal(t, GPUMetricsArray{{Minor: 0, DeviceUUID: "1", SMUtil: 10, MemoryUsed: 100, MemoryTotal: 1000}}, got[0].GPUs)
+
+	// delete across the chunk boundary
+	oldTime := time.Unix(0, 0)
+	expiredTime := now.Add(-3 * time.Second)
+	assert.NoError(t, s.DeletePodResourceMetric(&oldTime, &expiredTime))
+	count, err = s.CountPodResourceMetric()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	got, err = s.GetPodResourceMetric(&uid, &start, &end)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(got))
+
+	// appending after a partial deletion
+	err = s.InsertPodResourceMetric(&podResourceMetric{PodUID: uid, CPUUsedCores: 130, MemoryUsedBytes: 130 * 1024, Timestamp: now})
+	assert.NoError(t, err)
+	got, err = s.GetPodResourceMetric(&uid, &start, &end)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(got))
+
+	// series are removed when all samples expire
+	assert.NoError(t, s.DeletePodResourceMetric(&oldTime, &end))
+	assert.Equal(t, 0, len(s.tables[tsdbTablePodResource].series))
+}
+
+func Test_tsdbStorage_ContainerInterferenceMetric(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	start := now.Add(-time.Minute)
+	podUID := "test-pod-uid"
+	s, err := NewTSDBStorage("")
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.InsertContainerCPIMetric(&containerCPIMetric{PodUID: podUID, ContainerID: "c1", Cycles: 100, Instructions: 50, Timestamp: now}))
+	assert.NoError(t, s.InsertContainerCPIMetric(&containerCPIMetric{PodUID: podUID, ContainerID: "c2", Cycles: 200, Instructions: 50, Timestamp: now}))
+	assert.NoError(t, s.InsertContainerCPIMetric(&containerCPIMetric{PodUID: "other", ContainerID: "c3", Cycles: 300, Instructions: 50, Timestamp: now}))
+	gotCPI, err := s.GetContainerCPIMetricByPodUid(&podUID, &start, &now)
+	assert.NoError(t, err)
+	assert.Equal(t, []containerCPIMetric{
+		{PodUID: podUID, ContainerID: "c1", Cycles: 100, Instructions: 50, Timestamp: now},
+		{PodUID: podUID, ContainerID: "c2", Cycles: 200, Instructions: 50, Timestamp: now},
+	}, gotCPI)
+
+	psi := containerPSIMetric{PodUID: podUID, ContainerID: "c1", SomeCPUAvg10: 1, SomeMemAvg10: 2, SomeIOAvg10: 3,
+		FullCPUAvg10: 4, FullMemAvg10: 5, FullIOAvg10: 6, CPUFullSupported: true, Timestamp: now}
+	assert.NoError(t, s.InsertContainerPSIMetric(&psi))
+	containerID := "c1"
+	gotPSI, err := s.GetContainerPSIMetric(&containerID, &start, &now)
+	assert.NoError(t, err)
+	assert.Equal(t, []containerPSIMetric{psi}, gotPSI)
+}
+
+func Test_tsdbStorage_RawRecord(t *testing.T) {
+	s, err := NewTSDBStorage("")
+	assert.NoError(t, err)
+	_, err = s.GetRawRecord(NodeCPUInfoRecordType)
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	assert.NoError(t, s.InsertRawRecord(&rawRecord{RecordType: NodeCPUInfoRecordType, RecordStr: "v1"}))
+	assert.NoError(t, s.InsertRawRecord(&rawRecord{RecordType: NodeCPUInfoRecordType, RecordStr: "v2"}))
+	got, err := s.GetRawRecord(NodeCPUInfoRecordType)
+	assert.NoError(t, err)
+	assert.Equal(t, &rawRecord{RecordType: NodeCPUInfoRecordType, RecordStr: "v2"}, got)
+}
+
+func Test_tsdbStorage_Checkpoint(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	start := now.Add(-time.Hour)
+	dir := t.TempDir()
+
+	s, err := NewTSDBStorage(dir)
+	assert.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, s.InsertNodeResourceMetric(&nodeResourceMetric{
+			CPUUsedCores:    float64(i),
+			MemoryUsedBytes: 1024,
+			Timestamp:       now.Add(time.Duration(i-10) * time.Second),
+		}))
+	}
+	assert.NoError(t, s.InsertRawRecord(&rawRecord{RecordType: NodeCPUInfoRecordType, RecordStr: "{}"}))
+	assert.NoError(t, s.Close())
+	want, err := s.GetNodeResourceMetric(&start, &now)
+	assert.NoError(t, err)
+
+	restored, err := NewTSDBStorage(dir)
+	assert.NoError(t, err)
+	got, err := restored.GetNodeResourceMetric(&start, &now)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+	record, err := restored.GetRawRecord(NodeCPUInfoRecordType)
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", record.RecordStr)
+
+	// restored chunks are sealed, new samples go into a new chunk
+	assert.NoError(t, restored.InsertNodeResourceMetric(&nodeResourceMetric{CPUUsedCores: 10, MemoryUsedBytes: 1024, Timestamp: now}))
+	got, err = restored.GetNodeResourceMetric(&start, &now)
+	assert.NoError(t, err)
+	assert.Equal(t, 11, len(got))
+	assert.Equal(t, 2, len(restored.tables[tsdbTableNodeResource].series[tsdbSingletonSeriesKey].chunks))
+}
+
+func Test_metricCache_TSDBBackend_Aggregation(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	uid := "test-pod-uid"
+	cfg := NewDefaultConfig()
+	sqliteStore, err := NewCacheNotShareStorage()
+	assert.NoError(t, err)
+	defer sqliteStore.Close()
+	tsdbStore, err := NewTSDBStorage("")
+	assert.NoError(t, err)
+	caches := []*metricCache{
+		{config: cfg, db: sqliteStore},
+		{config: cfg, db: tsdbStore},
+	}
+	for _, m := range caches {
+		for i := 0; i < 30; i++ {
+			ts := now.Add(time.Duration(i-30) * time.Second)
+			assert.NoError(t, m.InsertNodeResourceMetric(ts, &NodeResourceMetric{
+				CPUUsed:    CPUMetric{CPUUsed: *resource.NewMilliQuantity(int64(1000+i*37%11*100), resource.DecimalSI)},
+				MemoryUsed: MemoryMetric{MemoryWithoutCache: *resource.NewQuantity(int64(1<<30), resource.BinarySI)},
+			}))
+			assert.NoError(t, m.InsertPodResourceMetric(ts, &PodResourceMetric{
+				PodUID:     uid,
+				CPUUsed:    CPUMetric{CPUUsed: *resource.NewMilliQuantity(int64(500+i*13%7*100), resource.DecimalSI)},
+				MemoryUsed: MemoryMetric{MemoryWithoutCache: *resource.NewQuantity(int64(i<<20), resource.BinarySI)},
+			}))
+		}
+	}
+	start := now.Add(-time.Hour)
+	for _, aggregation := range []AggregationType{AggregationTypeAVG, AggregationTypeP50, AggregationTypeP90,
+		AggregationTypeP99, AggregationTypeLast, AggregationTypeCount} {
+		param := &QueryParam{Aggregate: aggregation, Start: &start, End: &now}
+		wantNode := caches[0].GetNodeResourceMetric(param)
+		gotNode := caches[1].GetNodeResourceMetric(param)
+		assert.NoError(t, gotNode.Error)
+		assert.Equal(t, wantNode.Metric, gotNode.Metric, string(aggregation))
+		assert.Equal(t, wantNode.AggregateInfo.MetricsCount, gotNode.AggregateInfo.MetricsCount, string(aggregation))
+
+		wantPod := caches[0].GetPodResourceMetric(&uid, param)
+		gotPod := caches[1].GetPodResourceMetric(&uid, param)
+		assert.NoError(t, gotPod.Error)
+		assert.Equal(t, wantPod.Metric, gotPod.Metric, string(aggregation))
+	}
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccache
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// maxSamplesPerChunk limits the samples of a chunk, so a chunk can be dropped as a whole when it expires.
+const maxSamplesPerChunk = 120
+
+// bstream is an append-only bit stream.
+type bstream struct {
+	data  []byte
+	nbits int
+}
+
+func (b *bstream) writeBit(bit bool) {
+	if b.nbits%8 == 0 {
+		b.data = append(b.data, 0)
+	}
+	if bit {
+		b.data[len(b.data)-1] |= 1 << (7 - uint(b.nbits%8))
+	}
+	b.nbits++
+}
+
+// writeBits writes the lowest nbits of v, from the most significant one.
+func (b *bstream) writeBits(v uint64, nbits int) {
+	for i := nbits - 1; i >= 0; i-- {
+		b.writeBit((v>>uint(i))&1 == 1)
+	}
+}
+
+type bstreamReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bstreamReader) readBit() (bool, error) {
+	if r.pos >= len(r.data)*8 {
+		return false, fmt.Errorf("bstream is exhausted")
+	}
+	bit := r.data[r.pos/8]&(1<<(7-uint(r.pos%8))) != 0
+	r.pos++
+	return bit, nil
+}
+
+func (r *bstreamReader) readBits(nbits int) (uint64, error) {
+	var v uint64
+	for i := 0; i < nbits; i++ {
+		bit, err := r.readBit()
+		if err != nil {
+			return 0, err
+		}
+		v <<= 1
+		if bit {
+			v |= 1
+		}
+	}
+	return v, nil
+}
+
+// dodBuckets are the bit widths of the delta-of-delta timestamp encoding, prefixed by '10', '110', '1110', '11110'.
+// The timestamps are in milliseconds, so a 2^13ms jitter of the collect interval still fits in the first bucket.
+var dodBuckets = []int{14, 17, 20, 32}
+
+func bitRange(x int64, nbits int) bool {
+	return -(int64(1)<<(nbits-1)) <= x && x < int64(1)<<(nbits-1)
+}
+
+// xorState keeps the previous value of a column for the xor encoding.
+type xorState struct {
+	value    uint64
+	leading  int
+	trailing int
+}
+
+// chunk holds samples of one series compressed with the gorilla encoding, which is delta-of-delta for the
+// timestamps and xor for the values. All samples in a chunk have the same number of values.
+type chunk struct {
+	stream  bstream
+	columns int
+	count   int
+	minT    int64
+	maxT    int64
+
+	// appender states
+	lastT     int64
+	lastDelta int64
+	states    []xorState
+}
+
+func newChunk(columns int) *chunk {
+	return &chunk{
+		columns: columns,
+		states:  make([]xorState, columns),
+	}
+}
+
+func (c *chunk) full() bool {
+	return c.count >= maxSamplesPerChunk
+}
+
+func (c *chunk) append(t int64, values []float64) error {
+	if len(values) != c.columns {
+		return fmt.Errorf("chunk expects %d values, got %d", c.columns, len(values))
+	}
+	if c.count == 0 {
+		c.stream.writeBits(uint64(t), 64)
+		for i, v := range values {
+			c.stream.writeBits(math.Float64bits(v), 64)
+			c.states[i] = xorState{value: math.Float64bits(v), leading: 0xff}
+		}
+		c.minT, c.maxT = t, t
+	} else {
+		delta := t - c.lastT
+		c.writeDoD(delta - c.lastDelta)
+		c.lastDelta = delta
+		for i, v := range values {
+			c.writeXOR(&c.states[i], math.Float64bits(v))
+		}
+	}
+	c.lastT = t
+	if t < c.minT {
+		c.minT = t
+	}
+	if t > c.maxT {
+		c.maxT = t
+	}
+	c.count++
+	return nil
+}
+
+func (c *chunk) writeDoD(dod int64) {
+	if dod == 0 {
+		c.stream.writeBit(false)
+		return
+	}
+	for i, nbits := range dodBuckets {
+		c.stream.writeBit(true)
+		if bitRange(dod, nbits) {
+			c.stream.writeBit(false)
+			c.stream.writeBits(uint64(dod), nbits)
+			return
+		}
+		if i == len(dodBuckets)-1 {
+			// prefix '11111' is followed by the raw 64 bits
+			c.stream.writeBit(true)
+			c.stream.writeBits(uint64(dod), 64)
+		}
+	}
+}
+
+func (c *chunk) writeXOR(s *xorState, v uint64) {
+	delta := v ^ s.value
+	if delta == 0 {
+		c.stream.writeBit(false)
+		return
+	}
+	c.stream.writeBit(true)
+
+	leading := bits.LeadingZeros64(delta)
+	trailing := bits.TrailingZeros64(delta)
+	// leading zeros are stored in 5 bits
+	if leading > 31 {
+		leading = 31
+	}
+	if s.leading != 0xff && leading >= s.leading && trailing >= s.trailing {
+		// reuse the previous meaningful window
+		c.stream.writeBit(false)
+		c.stream.writeBits(delta>>uint(s.trailing), 64-s.leading-s.trailing)
+	} else {
+		s.leading, s.trailing = leading, trailing
+		sigbits := 64 - leading - trailing
+		c.stream.writeBit(true)
+		c.stream.writeBits(uint64(leading), 5)
+		// 64 significant bits overflows 6 bits and is stored as 0
+		c.stream.writeBits(uint64(sigbits), 6)
+		c.stream.writeBits(delta>>uint(trailing), sigbits)
+	}
+	s.value = v
+}
+
+// iterate decodes the samples in order and calls fn with the timestamp and the values of each sample.
+// The values slice is reused across the calls.
+func (c *chunk) iterate(fn func(idx int, t int64, values []float64)) error {
+	return decodeChunk(c.stream.data, c.columns, c.count, fn)
+}
+
+func decodeChunk(data []byte, columns, count int, fn func(idx int, t int64, values []float64)) error {
+	r := &bstreamReader{data: data}
+	values := make([]float64, columns)
+	states := make([]xorState, columns)
+	var t, delta int64
+	for idx := 0; idx < count; idx++ {
+		if idx == 0 {
+			raw, err := r.readBits(64)
+			if err != nil {
+				return err
+			}
+			t = int64(raw)
+			for i := range states {
+				v, err := r.readBits(64)
+				if err != nil {
+					return err
+				}
+				states[i].value = v
+			}
+		} else {
+			dod, err := readDoD(r)
+			if err != nil {
+				return err
+			}
+			delta += dod
+			t += delta
+			for i := range states {
+				if err := readXOR(r, &states[i]); err != nil {
+					return err
+				}
+			}
+		}
+		for i := range states {
+			values[i] = math.Float64frombits(states[i].value)
+		}
+		fn(idx, t, values)
+	}
+	return nil
+}
+
+func readDoD(r *bstreamReader) (int64, error) {
+	bit, err := r.readBit()
+	if err != nil {
+		return 0, err
+	}
+	if !bit {
+		return 0, nil
+	}
+	for _, nbits := range dodBuckets {
+		bit, err := r.readBit()
+		if err != nil {
+			return 0, err
+		}
+		if bit {
+			continue
+		}
+		raw, err := r.readBits(nbits)
+		if err != nil {
+			return 0, err
+		}
+		// sign extension
+		if raw >= uint64(1)<<uint(nbits-1) {
+			return int64(raw) - int64(1)<<uint(nbits), nil
+		}
+		return int64(raw), nil
+	}
+	raw, err := r.readBits(64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(raw), nil
+}
+
+func readXOR(r *bstreamReader, s *xorState) error {
+	bit, err := r.readBit()
+	if err != nil {
+		return err
+	}
+	if !bit {
+		return nil
+	}
+	bit, err = r.readBit()
+	if err != nil {
+		return err
+	}
+	if bit {
+		var leading, sigbits uint64
+		leading, err = r.readBits(5)
+		if err != nil {
+			return err
+		}
+		sigbits, err = r.readBits(6)
+		if err != nil {
+			return err
+		}
+		if sigbits == 0 {
+			sigbits = 64
+		}
+		s.leading = int(leading)
+		s.trailing = 64 - int(leading) - int(sigbits)
+	}
+	delta, err := r.readBits(64 - s.leading - s.trailing)
+	if err != nil {
+		return err
+	}
+	s.value ^= delta << uint(s.trailing)
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccache
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_chunk_appendAndIterate(t *testing.T) {
+	type sample struct {
+		t      int64
+		values []float64
+	}
+	rnd := rand.New(rand.NewSource(1))
+	randomSamples := make([]sample, 0, maxSamplesPerChunk)
+	ts := int64(1660000000000)
+	for i := 0; i < maxSamplesPerChunk; i++ {
+		// jitter in all dod buckets, including the negative ones and the raw 64 bits
+		switch i % 5 {
+		case 0:
+			ts += 1000
+		case 1:
+			ts += 1000 + rnd.Int63n(100000)
+		case 2:
+			ts += 1 << 35
+		case 3:
+			ts -= 10
+		default:
+			ts += rnd.Int63n(1 << 18)
+		}
+		randomSamples = append(randomSamples, sample{
+			t:      ts,
+			values: []float64{rnd.Float64() * 100, float64(rnd.Int63n(1 << 40)), math.Inf(1)},
+		})
+	}
+	tests := []struct {
+		name    string
+		samples []sample
+	}{
+		{
+			name: "single sample",
+			samples: []sample{
+				{t: 1660000000000, values: []float64{1.5, 1024, 0}},
+			},
+		},
+		{
+			name: "constant values with regular interval",
+			samples: []sample{
+				{t: 1660000000000, values: []float64{1.5, 1024, 0}},
+				{t: 1660000001000, values: []float64{1.5, 1024, 0}},
+				{t: 1660000002000, values: []float64{1.5, 1024, 0}},
+				{t: 1660000003000, values: []float64{1.5, 1024, 0}},
+			},
+		},
+		{
+			name: "changing values",
+			samples: []sample{
+				{t: 1660000000000, values: []float64{1.5, 1024, 0}},
+				{t: 1660000001000, values: []float64{2.25, 2048, -1}},
+				{t: 1660000002050, values: []float64{0.001, 4096, math.MaxFloat64}},
+				{t: 1660000002990, values: []float64{0.001, 1, math.SmallestNonzeroFloat64}},
+			},
+		},
+		{
+			name:    "random samples",
+			samples: randomSamples,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newChunk(3)
+			for _, s := range tt.samples {
+				assert.NoError(t, c.append(s.t, s.values))
+			}
+			assert.Equal(t, len(tt.samples), c.count)
+
+			var got []sample
+			err := c.iterate(func(idx int, t int64, values []float64) {
+				v := make([]float64, len(values))
+				copy(v, values)
+				got = append(got, sample{t: t, values: v})
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.samples, got)
+		})
+	}
+}
+
+func Test_chunk_appendWrongColumns(t *testing.T) {
+	c := newChunk(2)
+	assert.Error(t, c.append(1660000000000, []float64{1}))
+	assert.Equal(t, 0, c.count)
+}
+
+func Test_chunk_compression(t *testing.T) {
+	c := newChunk(2)
+	ts := int64(1660000000000)
+	for i := 0; i < maxSamplesPerChunk; i++ {
+		ts += 1000
+		assert.NoError(t, c.append(ts, []float64{2, float64(1 << 30)}))
+	}
+	assert.True(t, c.full())
+	// the raw samples take 24 bytes each
+	assert.Less(t, len(c.stream.data), maxSamplesPerChunk*24/10)
+}
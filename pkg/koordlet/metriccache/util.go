@@ -18,6 +18,7 @@ package metriccache
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
 	"time"
@@ -134,6 +135,72 @@ func fieldLastOfMetricList(metricsList interface{}, aggregateParam AggregatePara
 	return lastValue, nil
 }
 
+// ewmaHalfLifeRatio is the ratio of the time span between the oldest and the latest sample to the half-life of the
+// EWMA weights, i.e. the weight of the oldest sample is (1/2)^ewmaHalfLifeRatio of the latest one. The span of the
+// samples rather than the query time range is used, since the samples may not fill the range, e.g. after a restart.
+const ewmaHalfLifeRatio = 4
+
+// fieldEWMAOfMetricList calculates the exponentially weighted moving average of the metrics. The weights decay by
+// the time elapsed between samples rather than the sample index, so that the missing samples do not skew the result.
+func fieldEWMAOfMetricList(metricsList interface{}, aggregateParam AggregateParam) (float64, error) {
+	inputType := reflect.TypeOf(metricsList).Kind()
+	if inputType != reflect.Slice && inputType != reflect.Array {
+		return 0, fmt.Errorf("metrics input type must be slice or array, %v is illegal", inputType.String())
+	}
+
+	metrics := reflect.ValueOf(metricsList)
+	if metrics.Len() == 0 {
+		return 0, fmt.Errorf("metric input is empty")
+	}
+
+	type sample struct {
+		value     float64
+		timestamp time.Time
+	}
+	samples := make([]sample, metrics.Len())
+	for i := 0; i < metrics.Len(); i++ {
+		metricStruct := metrics.Index(i)
+		fieldValue := metricStruct.FieldByName(aggregateParam.ValueFieldName)
+		if !fieldValue.IsValid() {
+			return 0, fmt.Errorf("fieldValue not Valid, metricStruct: %v ", metricStruct)
+		}
+		fieldType := fieldValue.Type().Kind()
+		if fieldType != reflect.Float32 && fieldType != reflect.Float64 {
+			return 0, fmt.Errorf("field type must be float32 or float64, %v is illegal", fieldType.String())
+		}
+
+		fieldTimeValue := metricStruct.FieldByName(aggregateParam.TimeFieldName)
+		if !fieldTimeValue.IsValid() {
+			return 0, fmt.Errorf("fieldTimeValue not Valid, metricStruct: %v ", metricStruct)
+		}
+		if !fieldTimeValue.CanInterface() {
+			return 0, fmt.Errorf("fieldTimeValue can not Interface, metricStruct: %v ", metricStruct)
+		}
+		timestamp, ok := fieldTimeValue.Interface().(time.Time)
+		if !ok {
+			return 0, fmt.Errorf("timestamp field type must be time.Time, %v is illegal", fieldTimeValue)
+		}
+		samples[i] = sample{value: fieldValue.Float(), timestamp: timestamp}
+	}
+
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].timestamp.Before(samples[j].timestamp)
+	})
+
+	halfLife := samples[len(samples)-1].timestamp.Sub(samples[0].timestamp) / ewmaHalfLifeRatio
+	result := samples[0].value
+	for i := 1; i < len(samples); i++ {
+		if halfLife <= 0 {
+			result = samples[i].value
+			continue
+		}
+		elapsed := samples[i].timestamp.Sub(samples[i-1].timestamp)
+		alpha := 1 - math.Exp2(-float64(elapsed)/float64(halfLife))
+		result += alpha * (samples[i].value - result)
+	}
+	return result, nil
+}
+
 func fieldCountOfMetricList(metricsList interface{}, aggregateParam AggregateParam) (float64, error) {
 	inputType := reflect.TypeOf(metricsList).Kind()
 	if inputType != reflect.Slice && inputType != reflect.Array {
@@ -386,3 +386,107 @@ func Test_fieldLastOfMetricListBool(t *testing.T) {
 		})
 	}
 }
+
+func Test_fieldEWMAOfMetricList(t *testing.T) {
+	now := time.Now()
+	type args struct {
+		metricsList interface{}
+		param       AggregateParam
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    float64
+		wantErr bool
+	}{
+		{
+			name: "do not panic for invalid metrics",
+			args: args{
+				metricsList: 1,
+				param:       AggregateParam{ValueFieldName: "v", TimeFieldName: "T"},
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "trow error for illegal time",
+			args: args{
+				metricsList: []struct {
+					v float64
+					t time.Time
+				}{
+					{v: 1.0, t: now.Add(-5 * time.Second)},
+					{v: 3.0, t: now},
+				},
+				param: AggregateParam{ValueFieldName: "v", TimeFieldName: "t"},
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "trow error for illegal list length",
+			args: args{
+				metricsList: []struct {
+					v float64
+					T time.Time
+				}{},
+				param: AggregateParam{ValueFieldName: "v", TimeFieldName: "T"},
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "calculate single-element list",
+			args: args{
+				metricsList: []struct {
+					v float64
+					T time.Time
+				}{
+					{v: 3.0, T: now},
+				},
+				param: AggregateParam{ValueFieldName: "v", TimeFieldName: "T"},
+			},
+			want:    3.0,
+			wantErr: false,
+		},
+		{
+			name: "calculate constant list",
+			args: args{
+				metricsList: []struct {
+					v float64
+					T time.Time
+				}{
+					{v: 2.0, T: now.Add(-20 * time.Second)},
+					{v: 2.0, T: now.Add(-10 * time.Second)},
+					{v: 2.0, T: now},
+				},
+				param: AggregateParam{ValueFieldName: "v", TimeFieldName: "T"},
+			},
+			want:    2.0,
+			wantErr: false,
+		},
+		{
+			name: "calculate unordered list with the half-life of a quarter of the samples time span",
+			args: args{
+				metricsList: []struct {
+					v float64
+					T time.Time
+				}{
+					{v: 8.0, T: now},
+					{v: 0.0, T: now.Add(-8 * time.Second)},
+				},
+				param: AggregateParam{ValueFieldName: "v", TimeFieldName: "T"},
+			},
+			// 0 + (1 - 2^-4) * (8 - 0)
+			want:    7.5,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fieldEWMAOfMetricList(tt.args.metricsList, tt.args.param)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
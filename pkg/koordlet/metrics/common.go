@@ -39,6 +39,12 @@ var (
 		Help:      "Number of eviction launched by koordlet",
 	}, []string{NodeKey, EvictionReasonKey})
 
+	PodEvictionDryRun = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: KoordletSubsystem,
+		Name:      "pod_eviction_dry_run",
+		Help:      "Number of eviction which would have been launched by koordlet in the dry-run mode",
+	}, []string{NodeKey, EvictionReasonKey})
+
 	NodeUsedCPU = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Subsystem: KoordletSubsystem,
 		Name:      "node_used_cpu_cores",
@@ -49,6 +55,7 @@ var (
 		KoordletStartTime,
 		CollectNodeCPUInfoStatus,
 		PodEviction,
+		PodEvictionDryRun,
 		NodeUsedCPU,
 	}
 )
@@ -81,6 +88,15 @@ func RecordPodEviction(reasonType string) {
 	PodEviction.With(labels).Inc()
 }
 
+func RecordPodEvictionDryRun(reasonType string) {
+	labels := genNodeLabels()
+	if labels == nil {
+		return
+	}
+	labels[EvictionReasonKey] = reasonType
+	PodEvictionDryRun.With(labels).Inc()
+}
+
 func RecordNodeUsedCPU(value float64) {
 	labels := genNodeLabels()
 	if labels == nil {
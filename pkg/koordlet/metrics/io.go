@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	IODirection = "io_direction"
+	IOUnit      = "io_unit"
+)
+
+const (
+	DirectionReceive  = "receive"
+	DirectionTransmit = "transmit"
+	DirectionRead     = "read"
+	DirectionWrite    = "write"
+
+	UnitBytes   = "bytes"
+	UnitPackets = "packets"
+	UnitOps     = "ops"
+)
+
+var (
+	NodeNetworkIO = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: KoordletSubsystem,
+		Name:      "node_network_io",
+		Help:      "Node network io per second collected by koordlet",
+	}, []string{NodeKey, IODirection, IOUnit})
+
+	PodNetworkIO = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: KoordletSubsystem,
+		Name:      "pod_network_io",
+		Help:      "Pod network io per second collected by koordlet",
+	}, []string{NodeKey, PodUID, PodName, PodNamespace, IODirection, IOUnit})
+
+	NodeDiskIO = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: KoordletSubsystem,
+		Name:      "node_disk_io",
+		Help:      "Node disk io per second collected by koordlet",
+	}, []string{NodeKey, IODirection, IOUnit})
+
+	PodDiskIO = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: KoordletSubsystem,
+		Name:      "pod_disk_io",
+		Help:      "Pod disk io per second collected by koordlet",
+	}, []string{NodeKey, PodUID, PodName, PodNamespace, IODirection, IOUnit})
+
+	IOCollectors = []prometheus.Collector{
+		NodeNetworkIO,
+		PodNetworkIO,
+		NodeDiskIO,
+		PodDiskIO,
+	}
+)
+
+func RecordNodeNetworkIO(rxBytes, txBytes, rxPackets, txPackets float64) {
+	labels := genNodeLabels()
+	if labels == nil {
+		return
+	}
+	recordNetworkIO(NodeNetworkIO, labels, rxBytes, txBytes, rxPackets, txPackets)
+}
+
+func RecordPodNetworkIO(pod *corev1.Pod, rxBytes, txBytes, rxPackets, txPackets float64) {
+	labels := genNodeLabels()
+	if labels == nil {
+		return
+	}
+	labels[PodUID] = string(pod.UID)
+	labels[PodName] = pod.Name
+	labels[PodNamespace] = pod.Namespace
+	recordNetworkIO(PodNetworkIO, labels, rxBytes, txBytes, rxPackets, txPackets)
+}
+
+func RecordNodeDiskIO(readBytes, writeBytes, readOps, writeOps float64) {
+	labels := genNodeLabels()
+	if labels == nil {
+		return
+	}
+	recordDiskIO(NodeDiskIO, labels, readBytes, writeBytes, readOps, writeOps)
+}
+
+func RecordPodDiskIO(pod *corev1.Pod, readBytes, writeBytes, readOps, writeOps float64) {
+	labels := genNodeLabels()
+	if labels == nil {
+		return
+	}
+	labels[PodUID] = string(pod.UID)
+	labels[PodName] = pod.Name
+	labels[PodNamespace] = pod.Namespace
+	recordDiskIO(PodDiskIO, labels, readBytes, writeBytes, readOps, writeOps)
+}
+
+func ResetPodNetworkIO() {
+	PodNetworkIO.Reset()
+}
+
+func ResetPodDiskIO() {
+	PodDiskIO.Reset()
+}
+
+func recordNetworkIO(gauge *prometheus.GaugeVec, labels prometheus.Labels, rxBytes, txBytes, rxPackets, txPackets float64) {
+	labels[IODirection] = DirectionReceive
+	labels[IOUnit] = UnitBytes
+	gauge.With(labels).Set(rxBytes)
+	labels[IOUnit] = UnitPackets
+	gauge.With(labels).Set(rxPackets)
+
+	labels[IODirection] = DirectionTransmit
+	labels[IOUnit] = UnitBytes
+	gauge.With(labels).Set(txBytes)
+	labels[IOUnit] = UnitPackets
+	gauge.With(labels).Set(txPackets)
+}
+
+func recordDiskIO(gauge *prometheus.GaugeVec, labels prometheus.Labels, readBytes, writeBytes, readOps, writeOps float64) {
+	labels[IODirection] = DirectionRead
+	labels[IOUnit] = UnitBytes
+	gauge.With(labels).Set(readBytes)
+	labels[IOUnit] = UnitOps
+	gauge.With(labels).Set(readOps)
+
+	labels[IODirection] = DirectionWrite
+	labels[IOUnit] = UnitBytes
+	gauge.With(labels).Set(writeBytes)
+	labels[IOUnit] = UnitOps
+	gauge.With(labels).Set(writeOps)
+}
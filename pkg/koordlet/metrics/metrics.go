@@ -29,6 +29,7 @@ func init() {
 	prometheus.MustRegister(ResourceSummaryCollectors...)
 	prometheus.MustRegister(CPICollectors...)
 	prometheus.MustRegister(PSICollectors...)
+	prometheus.MustRegister(IOCollectors...)
 	prometheus.MustRegister(CPUSuppressCollector...)
 	prometheus.MustRegister(CPUBurstCollector...)
 }
@@ -137,12 +137,19 @@ func TestCommonCollectors(t *testing.T) {
 		RecordContainerScaledCFSBurstUS(testingPod.Namespace, testingPod.Name, testingContainer.ContainerID, testingContainer.Name, 1000000)
 		RecordContainerScaledCFSQuotaUS(testingPod.Namespace, testingPod.Name, testingContainer.ContainerID, testingContainer.Name, 1000000)
 		RecordPodEviction("evictByCPU")
+		RecordPodEvictionDryRun("evictByCPU")
 		ResetContainerCPI()
 		RecordContainerCPI(testingContainer, testingPod, 1, 1)
 		ResetContainerPSI()
 		RecordContainerPSI(testingContainer, testingPod, testingPSI)
 		ResetPodPSI()
 		RecordPodPSI(testingPod, testingPSI)
+		RecordNodeNetworkIO(1000, 2000, 10, 20)
+		RecordNodeDiskIO(4096, 8192, 1, 2)
+		ResetPodNetworkIO()
+		RecordPodNetworkIO(testingPod, 1000, 2000, 10, 20)
+		ResetPodDiskIO()
+		RecordPodDiskIO(testingPod, 4096, 8192, 1, 2)
 	})
 }
 
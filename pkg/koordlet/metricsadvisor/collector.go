@@ -54,9 +54,11 @@ type Collector interface {
 }
 
 type contextRecord struct {
-	cpuTick  uint64
-	cpuUsage uint64
-	ts       time.Time
+	cpuTick   uint64
+	cpuUsage  uint64
+	netStat   *system.NetDevStatRaw
+	blkIOStat *system.BlkIOStatRaw
+	ts        time.Time
 }
 
 type collectContext struct {
@@ -69,6 +71,10 @@ type collectContext struct {
 	lastPodCPUThrottled       sync.Map
 	lastContainerCPUThrottled sync.Map
 
+	// record latest network and block io stat for calculate io throughput
+	lastNodeIOStat contextRecord
+	lastPodIOStat  sync.Map
+
 	gpuDeviceManager GPUDeviceManager
 }
 
@@ -78,6 +84,7 @@ func newCollectContext() *collectContext {
 		lastContainerCPUStat:      sync.Map{},
 		lastPodCPUThrottled:       sync.Map{},
 		lastContainerCPUThrottled: sync.Map{},
+		lastPodIOStat:             sync.Map{},
 		gpuDeviceManager:          initGPUDeviceManager(),
 	}
 }
@@ -174,6 +181,18 @@ func (c *collector) Run(stopCh <-chan struct{}) error {
 		ic.collectPodPSI()
 	}, []featuregate.Feature{features.PSICollector}, c.config.PSICollectorIntervalSeconds, stopCh)
 
+	util.RunFeature(func() {
+		c.collectNodeIO()
+		// add sync statesInformer cache check before collect pod information
+		// because collect function will get all pods.
+		if !cache.WaitForCacheSync(stopCh, c.statesInformer.HasSynced) {
+			// Koordlet exit because of statesInformer sync failed.
+			klog.Fatalf("timed out waiting for states informer caches to sync")
+			return
+		}
+		c.collectPodIO()
+	}, []featuregate.Feature{features.IOCollector}, c.config.CollectResUsedIntervalSeconds, stopCh)
+
 	go wait.Until(c.cleanupContext, cleanupInterval, stopCh)
 
 	klog.Info("Starting successfully")
@@ -529,7 +548,8 @@ func (c *collector) cleanupContext() {
 	lastContainerCPUStatSize := cleanFunc(&c.context.lastContainerCPUStat)
 	lastPodCPUThrottledSize := cleanFunc(&c.context.lastPodCPUThrottled)
 	lastContainerCPUThrottledSize := cleanFunc(&c.context.lastContainerCPUThrottled)
+	lastPodIOStatSize := cleanFunc(&c.context.lastPodIOStat)
 	klog.V(4).Infof("clear outdated last stat, remaining size: lastPodCPUStat=%v, lastContainerCPUStat=%v, "+
-		"lastPodCPUThrottled=%v, lastContainerCPUThrottled=%v", lastPodCPUStatSize, lastContainerCPUStatSize,
-		lastPodCPUThrottledSize, lastContainerCPUThrottledSize)
+		"lastPodCPUThrottled=%v, lastContainerCPUThrottled=%v, lastPodIOStat=%v", lastPodCPUStatSize,
+		lastContainerCPUStatSize, lastPodCPUThrottledSize, lastContainerCPUThrottledSize, lastPodIOStatSize)
 }
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsadvisor
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metrics"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+func (c *collector) collectNodeIO() {
+	klog.V(6).Info("collectNodeIO start")
+	collectTime := time.Now()
+	netStat, err0 := system.GetNetDevStat("")
+	blkIOStat, err1 := system.GetDiskStats()
+	if err0 != nil || err1 != nil {
+		klog.Warningf("failed to collect node io, network err: %s, disk err: %s", err0, err1)
+		return
+	}
+	lastIOStat := c.context.lastNodeIOStat
+	c.context.lastNodeIOStat = contextRecord{
+		netStat:   netStat,
+		blkIOStat: blkIOStat,
+		ts:        collectTime,
+	}
+	if lastIOStat.netStat == nil || lastIOStat.blkIOStat == nil {
+		klog.V(6).Infof("ignore the first io stat collection")
+		return
+	}
+
+	seconds := collectTime.Sub(lastIOStat.ts).Seconds()
+	nodeMetric := metriccache.NodeIOMetric{
+		Network: calcNetworkMetric(netStat, lastIOStat.netStat, seconds),
+		DiskIO:  calcDiskIOMetric(blkIOStat, lastIOStat.blkIOStat, seconds),
+	}
+	if err := c.metricCache.InsertNodeIOMetric(collectTime, &nodeMetric); err != nil {
+		klog.Errorf("insert node io metric error: %v", err)
+	}
+	metrics.RecordNodeNetworkIO(nodeMetric.Network.RxBytes, nodeMetric.Network.TxBytes,
+		nodeMetric.Network.RxPackets, nodeMetric.Network.TxPackets)
+	metrics.RecordNodeDiskIO(nodeMetric.DiskIO.ReadBytes, nodeMetric.DiskIO.WriteBytes,
+		nodeMetric.DiskIO.ReadIOPS, nodeMetric.DiskIO.WriteIOPS)
+
+	klog.V(5).Infof("collectNodeIO finished %+v", nodeMetric)
+}
+
+func (c *collector) collectPodIO() {
+	klog.V(6).Info("start collectPodIO")
+	podMetas := c.statesInformer.GetAllPods()
+	metrics.ResetPodNetworkIO()
+	metrics.ResetPodDiskIO()
+	for _, meta := range podMetas {
+		pod := meta.Pod
+		uid := string(pod.UID) // types.UID
+		collectTime := time.Now()
+		podCgroupDir := koordletutil.GetPodCgroupDirWithKube(meta.CgroupDir)
+
+		blkIOStat, err := c.cgroupReader.ReadBlkIOStat(podCgroupDir)
+		if err != nil {
+			// higher verbosity for probably non-running pods
+			if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
+				klog.V(6).Infof("failed to collect non-running pod io for %s/%s, err: %s",
+					pod.Namespace, pod.Name, err)
+			} else {
+				klog.V(4).Infof("failed to collect pod io for %s/%s, err: %s", pod.Namespace, pod.Name, err)
+			}
+			continue
+		}
+		// the network stat of the host network pod is the same as the node, so skip it
+		var netStat *system.NetDevStatRaw
+		if !pod.Spec.HostNetwork {
+			netStat, err = c.getPodNetDevStat(meta)
+			if err != nil {
+				klog.V(5).Infof("failed to collect pod network io for %s/%s, err: %s", pod.Namespace, pod.Name, err)
+			}
+		}
+
+		lastIOStatValue, ok := c.context.lastPodIOStat.Load(uid)
+		c.context.lastPodIOStat.Store(uid, contextRecord{
+			netStat:   netStat,
+			blkIOStat: blkIOStat,
+			ts:        collectTime,
+		})
+		if !ok {
+			klog.V(5).Infof("ignore the first io stat collection for pod %s/%s", pod.Namespace, pod.Name)
+			continue
+		}
+		lastIOStat := lastIOStatValue.(contextRecord)
+		seconds := collectTime.Sub(lastIOStat.ts).Seconds()
+
+		podMetric := metriccache.PodIOMetric{
+			PodUID: uid,
+			DiskIO: calcDiskIOMetric(blkIOStat, lastIOStat.blkIOStat, seconds),
+		}
+		if netStat != nil && lastIOStat.netStat != nil {
+			podMetric.Network = calcNetworkMetric(netStat, lastIOStat.netStat, seconds)
+			metrics.RecordPodNetworkIO(pod, podMetric.Network.RxBytes, podMetric.Network.TxBytes,
+				podMetric.Network.RxPackets, podMetric.Network.TxPackets)
+		}
+		metrics.RecordPodDiskIO(pod, podMetric.DiskIO.ReadBytes, podMetric.DiskIO.WriteBytes,
+			podMetric.DiskIO.ReadIOPS, podMetric.DiskIO.WriteIOPS)
+
+		klog.V(6).Infof("collect pod %s/%s, uid %s io finished, metric %+v",
+			pod.Namespace, pod.Name, uid, podMetric)
+		if err := c.metricCache.InsertPodIOMetric(collectTime, &podMetric); err != nil {
+			klog.Errorf("insert pod %s/%s, uid %s io metric failed, metric %v, err %v",
+				pod.Namespace, pod.Name, uid, podMetric, err)
+		}
+	}
+	klog.V(5).Infof("collectPodIO finished, pod num %d", len(podMetas))
+}
+
+// getPodNetDevStat reads the network stat in the pod netns through any process of the pod containers.
+func (c *collector) getPodNetDevStat(meta *statesinformer.PodMeta) (*system.NetDevStatRaw, error) {
+	pod := meta.Pod
+	for i := range pod.Status.ContainerStatuses {
+		containerStat := &pod.Status.ContainerStatuses[i]
+		if len(containerStat.ContainerID) == 0 || containerStat.State.Running == nil {
+			continue
+		}
+		containerCgroupDir, err := koordletutil.GetContainerCgroupPathWithKube(meta.CgroupDir, containerStat)
+		if err != nil {
+			continue
+		}
+		pids, err := c.cgroupReader.ReadCPUTasks(containerCgroupDir)
+		if err != nil || len(pids) == 0 {
+			continue
+		}
+		return system.GetNetDevStat(strconv.FormatInt(int64(pids[0]), 10))
+	}
+	return nil, fmt.Errorf("no running process found")
+}
+
+func calcNetworkMetric(cur, last *system.NetDevStatRaw, seconds float64) metriccache.NetworkMetric {
+	return metriccache.NetworkMetric{
+		RxBytes:   calcCounterRate(cur.RxBytes, last.RxBytes, seconds),
+		TxBytes:   calcCounterRate(cur.TxBytes, last.TxBytes, seconds),
+		RxPackets: calcCounterRate(cur.RxPackets, last.RxPackets, seconds),
+		TxPackets: calcCounterRate(cur.TxPackets, last.TxPackets, seconds),
+	}
+}
+
+func calcDiskIOMetric(cur, last *system.BlkIOStatRaw, seconds float64) metriccache.DiskIOMetric {
+	return metriccache.DiskIOMetric{
+		ReadBytes:  calcCounterRate(cur.ReadBytes, last.ReadBytes, seconds),
+		WriteBytes: calcCounterRate(cur.WriteBytes, last.WriteBytes, seconds),
+		ReadIOPS:   calcCounterRate(cur.ReadIOs, last.ReadIOs, seconds),
+		WriteIOPS:  calcCounterRate(cur.WriteIOs, last.WriteIOs, seconds),
+	}
+}
+
+// calcCounterRate returns the increase per second of an accumulated counter, the counter can be reset when the
+// pod sandbox or the device is recreated, so a decrease is regarded as no increase.
+func calcCounterRate(cur, last uint64, seconds float64) float64 {
+	if cur <= last || seconds <= 0 {
+		return 0
+	}
+	return float64(cur-last) / seconds
+}
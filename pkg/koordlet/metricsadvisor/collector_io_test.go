@@ -0,0 +1,228 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsadvisor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	mock_metriccache "github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache/mockmetriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+	mock_statesinformer "github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer/mockstatesinformer"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+const testNetDevContent = `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo: 1000      10    0    0    0     0          0         0     1000      10    0    0    0     0       0          0
+  eth0: 2000      20    0    0    0     0          0         0     3000      30    0    0    0     0       0          0
+`
+
+func Test_collector_collectNodeIO(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	oldSysRootDir := system.Conf.SysRootDir
+	system.Conf.SysRootDir = filepath.Join(helper.TempDir, "sys")
+	defer func() {
+		system.Conf.SysRootDir = oldSysRootDir
+	}()
+	assert.NoError(t, os.MkdirAll(filepath.Join(system.Conf.SysRootDir, "block", "vda", "device"), 0755))
+	helper.WriteProcSubFileContents(system.ProcNetDevName, testNetDevContent)
+	helper.WriteProcSubFileContents(system.ProcDiskStatsName, " 253 0 vda 100 0 200 0 300 0 400 0 0 0 0\n")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	metricCache := mock_metriccache.NewMockMetricCache(ctrl)
+	var got *metriccache.NodeIOMetric
+	metricCache.EXPECT().InsertNodeIOMetric(gomock.Any(), gomock.Not(nil)).DoAndReturn(
+		func(t time.Time, metric *metriccache.NodeIOMetric) error {
+			got = metric
+			return nil
+		}).Times(1)
+
+	c := &collector{
+		config:      &Config{CollectResUsedIntervalSeconds: 1},
+		metricCache: metricCache,
+		context:     newCollectContext(),
+		state:       newCollectState(),
+	}
+	c.context.lastNodeIOStat = contextRecord{
+		netStat: &system.NetDevStatRaw{
+			RxBytes:   1000,
+			RxPackets: 10,
+			TxBytes:   1000,
+			TxPackets: 10,
+		},
+		blkIOStat: &system.BlkIOStatRaw{
+			ReadBytes:  100 * system.DiskSectorSize,
+			WriteBytes: 200 * system.DiskSectorSize,
+			ReadIOs:    50,
+			WriteIOs:   100,
+		},
+		ts: time.Now().Add(-time.Second),
+	}
+	c.collectNodeIO()
+	assert.NotNil(t, got)
+	assert.InDelta(t, 1000, got.Network.RxBytes, 10)
+	assert.InDelta(t, 2000, got.Network.TxBytes, 20)
+	assert.InDelta(t, 10, got.Network.RxPackets, 1)
+	assert.InDelta(t, 20, got.Network.TxPackets, 1)
+	assert.InDelta(t, 100*system.DiskSectorSize, got.DiskIO.ReadBytes, 1000)
+	assert.InDelta(t, 200*system.DiskSectorSize, got.DiskIO.WriteBytes, 2000)
+	assert.InDelta(t, 50, got.DiskIO.ReadIOPS, 1)
+	assert.InDelta(t, 200, got.DiskIO.WriteIOPS, 2)
+}
+
+func Test_collector_collectPodIO(t *testing.T) {
+	testContainerID := "containerd://123abc"
+	testPodMetaDir := "/kubepods-podxxxxxxxx.slice"
+	testPodParentDir := "/kubepods.slice/kubepods-podxxxxxxxx.slice"
+	testContainerParentDir := "/kubepods.slice/kubepods-podxxxxxxxx.slice/cri-containerd-123abc.scope"
+	testPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "test",
+			UID:       "xxxxxxxx",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:        "test-container",
+					ContainerID: testContainerID,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		setSysUtil  func(helper *system.FileTestUtil)
+		lastIOStat  *contextRecord
+		wantMetric  bool
+		wantNetwork bool
+	}{
+		{
+			name: "ignore the first collection",
+			setSysUtil: func(helper *system.FileTestUtil) {
+				helper.WriteCgroupFileContents(testPodParentDir, system.BlkioIOServiceBytesRecursive, "253:0 Read 1024\n253:0 Write 2048\n")
+				helper.WriteCgroupFileContents(testPodParentDir, system.BlkioIOServicedRecursive, "253:0 Read 1\n253:0 Write 2\n")
+			},
+			wantMetric: false,
+		},
+		{
+			name: "cgroups v1 with network",
+			setSysUtil: func(helper *system.FileTestUtil) {
+				helper.WriteCgroupFileContents(testPodParentDir, system.BlkioIOServiceBytesRecursive, "253:0 Read 1024\n253:0 Write 2048\n")
+				helper.WriteCgroupFileContents(testPodParentDir, system.BlkioIOServicedRecursive, "253:0 Read 1\n253:0 Write 2\n")
+				helper.WriteCgroupFileContents(testContainerParentDir, system.CPUTasks, "1000\n1001\n")
+				helper.WriteProcSubFileContents(filepath.Join("1000", system.ProcNetDevName), testNetDevContent)
+			},
+			lastIOStat: &contextRecord{
+				netStat:   &system.NetDevStatRaw{},
+				blkIOStat: &system.BlkIOStatRaw{},
+				ts:        time.Now().Add(-time.Second),
+			},
+			wantMetric:  true,
+			wantNetwork: true,
+		},
+		{
+			name: "cgroups v2 without network",
+			setSysUtil: func(helper *system.FileTestUtil) {
+				helper.SetCgroupsV2(true)
+				helper.WriteCgroupFileContents(testPodParentDir, system.IOStatV2, "253:0 rbytes=1024 wbytes=2048 rios=1 wios=2\n")
+			},
+			lastIOStat: &contextRecord{
+				blkIOStat: &system.BlkIOStatRaw{},
+				ts:        time.Now().Add(-time.Second),
+			},
+			wantMetric:  true,
+			wantNetwork: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helper := system.NewFileTestUtil(t)
+			defer helper.Cleanup()
+			if tt.setSysUtil != nil {
+				tt.setSysUtil(helper)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			statesInformer := mock_statesinformer.NewMockStatesInformer(ctrl)
+			metricCache := mock_metriccache.NewMockMetricCache(ctrl)
+			statesInformer.EXPECT().GetAllPods().Return([]*statesinformer.PodMeta{{
+				CgroupDir: testPodMetaDir,
+				Pod:       testPod,
+			}}).Times(1)
+			var got *metriccache.PodIOMetric
+			if tt.wantMetric {
+				metricCache.EXPECT().InsertPodIOMetric(gomock.Any(), gomock.Not(nil)).DoAndReturn(
+					func(t time.Time, metric *metriccache.PodIOMetric) error {
+						got = metric
+						return nil
+					}).Times(1)
+			}
+
+			c := &collector{
+				config:         &Config{CollectResUsedIntervalSeconds: 1},
+				statesInformer: statesInformer,
+				metricCache:    metricCache,
+				cgroupReader:   resourceexecutor.NewCgroupReader(),
+				context:        newCollectContext(),
+				state:          newCollectState(),
+			}
+			if tt.lastIOStat != nil {
+				c.context.lastPodIOStat.Store(string(testPod.UID), *tt.lastIOStat)
+			}
+			c.collectPodIO()
+
+			if !tt.wantMetric {
+				assert.Nil(t, got)
+				return
+			}
+			assert.NotNil(t, got)
+			assert.Equal(t, string(testPod.UID), got.PodUID)
+			assert.InDelta(t, 1024, got.DiskIO.ReadBytes, 10)
+			assert.InDelta(t, 2048, got.DiskIO.WriteBytes, 20)
+			if tt.wantNetwork {
+				assert.InDelta(t, 2000, got.Network.RxBytes, 20)
+				assert.InDelta(t, 3000, got.Network.TxBytes, 30)
+			} else {
+				assert.Equal(t, metriccache.NetworkMetric{}, got.Network)
+			}
+		})
+	}
+}
+
+func Test_calcCounterRate(t *testing.T) {
+	assert.Equal(t, float64(50), calcCounterRate(200, 100, 2))
+	assert.Equal(t, float64(0), calcCounterRate(100, 200, 2))
+	assert.Equal(t, float64(0), calcCounterRate(200, 100, 0))
+}
@@ -23,24 +23,30 @@ import (
 )
 
 type Config struct {
-	ReconcileIntervalSeconds   int
-	CPUSuppressIntervalSeconds int
-	CPUEvictIntervalSeconds    int
-	MemoryEvictIntervalSeconds int
-	MemoryEvictCoolTimeSeconds int
-	CPUEvictCoolTimeSeconds    int
-	QOSExtensionCfg            *plugins.QOSExtensionConfig
+	ReconcileIntervalSeconds       int
+	CPUSuppressIntervalSeconds     int
+	CPUEvictIntervalSeconds        int
+	MemoryEvictIntervalSeconds     int
+	MemoryEvictCoolTimeSeconds     int
+	MemorySuppressIntervalSeconds  int
+	CPUEvictCoolTimeSeconds        int
+	GPUMemoryEvictIntervalSeconds  int
+	GPUMemoryEvictConsecutiveTimes int
+	QOSExtensionCfg                *plugins.QOSExtensionConfig
 }
 
 func NewDefaultConfig() *Config {
 	return &Config{
-		ReconcileIntervalSeconds:   1,
-		CPUSuppressIntervalSeconds: 1,
-		CPUEvictIntervalSeconds:    1,
-		MemoryEvictIntervalSeconds: 1,
-		MemoryEvictCoolTimeSeconds: 4,
-		CPUEvictCoolTimeSeconds:    20,
-		QOSExtensionCfg:            &plugins.QOSExtensionConfig{FeatureGates: map[string]bool{}},
+		ReconcileIntervalSeconds:       1,
+		CPUSuppressIntervalSeconds:     1,
+		CPUEvictIntervalSeconds:        1,
+		MemoryEvictIntervalSeconds:     1,
+		MemoryEvictCoolTimeSeconds:     4,
+		MemorySuppressIntervalSeconds:  1,
+		CPUEvictCoolTimeSeconds:        20,
+		GPUMemoryEvictIntervalSeconds:  10,
+		GPUMemoryEvictConsecutiveTimes: 3,
+		QOSExtensionCfg:                &plugins.QOSExtensionConfig{FeatureGates: map[string]bool{}},
 	}
 }
 
@@ -50,6 +56,9 @@ func (c *Config) InitFlags(fs *flag.FlagSet) {
 	fs.IntVar(&c.CPUEvictIntervalSeconds, "cpu-evict-interval-seconds", c.CPUEvictIntervalSeconds, "evict be pod(cpu) interval by seconds")
 	fs.IntVar(&c.MemoryEvictIntervalSeconds, "memory-evict-interval-seconds", c.MemoryEvictIntervalSeconds, "evict be pod(memory) interval by seconds")
 	fs.IntVar(&c.MemoryEvictCoolTimeSeconds, "memory-evict-cool-time-seconds", c.MemoryEvictCoolTimeSeconds, "cooling time: memory next evict time should after lastEvictTime + MemoryEvictCoolTimeSeconds")
+	fs.IntVar(&c.MemorySuppressIntervalSeconds, "memory-suppress-interval-seconds", c.MemorySuppressIntervalSeconds, "suppress be pod memory resource interval by seconds")
 	fs.IntVar(&c.CPUEvictCoolTimeSeconds, "cpu-evict-cool-time-seconds", c.CPUEvictCoolTimeSeconds, "cooltime: CPU next evict time should after lastEvictTime + CPUEvictCoolTimeSeconds")
+	fs.IntVar(&c.GPUMemoryEvictIntervalSeconds, "gpu-memory-evict-interval-seconds", c.GPUMemoryEvictIntervalSeconds, "verify container gpu memory usage and evict the overused pods interval by seconds")
+	fs.IntVar(&c.GPUMemoryEvictConsecutiveTimes, "gpu-memory-evict-consecutive-times", c.GPUMemoryEvictConsecutiveTimes, "evict the pod only if its gpu memory usage exceeds the allocation in the consecutive times of verification")
 	c.QOSExtensionCfg.InitFlags(fs)
 }
@@ -27,13 +27,16 @@ import (
 
 func Test_NewDefaultConfig(t *testing.T) {
 	expectConfig := &Config{
-		ReconcileIntervalSeconds:   1,
-		CPUSuppressIntervalSeconds: 1,
-		CPUEvictIntervalSeconds:    1,
-		MemoryEvictIntervalSeconds: 1,
-		MemoryEvictCoolTimeSeconds: 4,
-		CPUEvictCoolTimeSeconds:    20,
-		QOSExtensionCfg:            &plugins.QOSExtensionConfig{FeatureGates: map[string]bool{}},
+		ReconcileIntervalSeconds:       1,
+		CPUSuppressIntervalSeconds:     1,
+		CPUEvictIntervalSeconds:        1,
+		MemoryEvictIntervalSeconds:     1,
+		MemoryEvictCoolTimeSeconds:     4,
+		MemorySuppressIntervalSeconds:  1,
+		CPUEvictCoolTimeSeconds:        20,
+		GPUMemoryEvictIntervalSeconds:  10,
+		GPUMemoryEvictConsecutiveTimes: 3,
+		QOSExtensionCfg:                &plugins.QOSExtensionConfig{FeatureGates: map[string]bool{}},
 	}
 	defaultConfig := NewDefaultConfig()
 	assert.Equal(t, expectConfig, defaultConfig)
@@ -47,19 +50,25 @@ func Test_InitFlags(t *testing.T) {
 		"--cpu-evict-interval-seconds=2",
 		"--memory-evict-interval-seconds=2",
 		"--memory-evict-cool-time-seconds=8",
+		"--memory-suppress-interval-seconds=2",
 		"--cpu-evict-cool-time-seconds=40",
+		"--gpu-memory-evict-interval-seconds=20",
+		"--gpu-memory-evict-consecutive-times=5",
 		"--qos-extension-plugins=test-plugin=true",
 	}
 	fs := flag.NewFlagSet(cmdArgs[0], flag.ExitOnError)
 
 	type fields struct {
-		ReconcileIntervalSeconds   int
-		CPUSuppressIntervalSeconds int
-		CPUEvictIntervalSeconds    int
-		MemoryEvictIntervalSeconds int
-		MemoryEvictCoolTimeSeconds int
-		CPUEvictCoolTimeSeconds    int
-		QOSExtensionCfg            *plugins.QOSExtensionConfig
+		ReconcileIntervalSeconds       int
+		CPUSuppressIntervalSeconds     int
+		CPUEvictIntervalSeconds        int
+		MemoryEvictIntervalSeconds     int
+		MemoryEvictCoolTimeSeconds     int
+		MemorySuppressIntervalSeconds  int
+		CPUEvictCoolTimeSeconds        int
+		GPUMemoryEvictIntervalSeconds  int
+		GPUMemoryEvictConsecutiveTimes int
+		QOSExtensionCfg                *plugins.QOSExtensionConfig
 	}
 	type args struct {
 		fs *flag.FlagSet
@@ -72,13 +81,16 @@ func Test_InitFlags(t *testing.T) {
 		{
 			name: "not default",
 			fields: fields{
-				ReconcileIntervalSeconds:   2,
-				CPUSuppressIntervalSeconds: 2,
-				CPUEvictIntervalSeconds:    2,
-				MemoryEvictIntervalSeconds: 2,
-				MemoryEvictCoolTimeSeconds: 8,
-				CPUEvictCoolTimeSeconds:    40,
-				QOSExtensionCfg:            &plugins.QOSExtensionConfig{FeatureGates: map[string]bool{"test-plugin": true}},
+				ReconcileIntervalSeconds:       2,
+				CPUSuppressIntervalSeconds:     2,
+				CPUEvictIntervalSeconds:        2,
+				MemoryEvictIntervalSeconds:     2,
+				MemoryEvictCoolTimeSeconds:     8,
+				MemorySuppressIntervalSeconds:  2,
+				CPUEvictCoolTimeSeconds:        40,
+				GPUMemoryEvictIntervalSeconds:  20,
+				GPUMemoryEvictConsecutiveTimes: 5,
+				QOSExtensionCfg:                &plugins.QOSExtensionConfig{FeatureGates: map[string]bool{"test-plugin": true}},
 			},
 			args: args{fs: fs},
 		},
@@ -86,13 +98,16 @@ func Test_InitFlags(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			raw := &Config{
-				ReconcileIntervalSeconds:   tt.fields.ReconcileIntervalSeconds,
-				CPUSuppressIntervalSeconds: tt.fields.CPUSuppressIntervalSeconds,
-				CPUEvictIntervalSeconds:    tt.fields.CPUEvictIntervalSeconds,
-				MemoryEvictIntervalSeconds: tt.fields.MemoryEvictIntervalSeconds,
-				MemoryEvictCoolTimeSeconds: tt.fields.MemoryEvictCoolTimeSeconds,
-				CPUEvictCoolTimeSeconds:    tt.fields.CPUEvictCoolTimeSeconds,
-				QOSExtensionCfg:            tt.fields.QOSExtensionCfg,
+				ReconcileIntervalSeconds:       tt.fields.ReconcileIntervalSeconds,
+				CPUSuppressIntervalSeconds:     tt.fields.CPUSuppressIntervalSeconds,
+				CPUEvictIntervalSeconds:        tt.fields.CPUEvictIntervalSeconds,
+				MemoryEvictIntervalSeconds:     tt.fields.MemoryEvictIntervalSeconds,
+				MemoryEvictCoolTimeSeconds:     tt.fields.MemoryEvictCoolTimeSeconds,
+				MemorySuppressIntervalSeconds:  tt.fields.MemorySuppressIntervalSeconds,
+				CPUEvictCoolTimeSeconds:        tt.fields.CPUEvictCoolTimeSeconds,
+				GPUMemoryEvictIntervalSeconds:  tt.fields.GPUMemoryEvictIntervalSeconds,
+				GPUMemoryEvictConsecutiveTimes: tt.fields.GPUMemoryEvictConsecutiveTimes,
+				QOSExtensionCfg:                tt.fields.QOSExtensionCfg,
 			}
 			c := NewDefaultConfig()
 			c.InitFlags(tt.args.fs)
@@ -145,12 +145,12 @@ func (c *CPUEvictor) evictByResourceSatisfaction(node *corev1.Node, thresholdCon
 	}
 	currentBECPU, milliRelease := c.calculateMilliRelease(thresholdConfig, windowSeconds)
 	if milliRelease > 0 {
-		bePodInfos := c.getPodEvictInfoAndSort(currentBECPU)
-		c.killAndEvictBEPodsRelease(node, bePodInfos, milliRelease)
+		bePodInfos := c.getPodEvictInfoAndSort(currentBECPU, getEvictionVictimPolicy(thresholdConfig))
+		c.killAndEvictBEPodsRelease(node, bePodInfos, milliRelease, isEvictDryRun(thresholdConfig))
 	}
 }
 
-func (c *CPUEvictor) killAndEvictBEPodsRelease(node *corev1.Node, bePodInfos []*podEvictCPUInfo, cpuNeedMilliRelease int64) {
+func (c *CPUEvictor) killAndEvictBEPodsRelease(node *corev1.Node, bePodInfos []*podEvictCPUInfo, cpuNeedMilliRelease int64, dryRun bool) {
 	message := fmt.Sprintf("killAndEvictBEPodsRelease for node(%s), need realase CPU : %d", c.resmanager.nodeName, cpuNeedMilliRelease)
 
 	cpuMilliReleased := int64(0)
@@ -160,23 +160,30 @@ func (c *CPUEvictor) killAndEvictBEPodsRelease(node *corev1.Node, bePodInfos []*
 			break
 		}
 
-		podKillMsg := fmt.Sprintf("%s, kill pod : %s", message, bePod.pod.Name)
-		killContainers(bePod.pod, podKillMsg)
+		if !dryRun {
+			podKillMsg := fmt.Sprintf("%s, kill pod : %s", message, bePod.pod.Name)
+			killContainers(bePod.pod, podKillMsg)
+		}
 
 		killedPods = append(killedPods, bePod.pod)
 		cpuMilliReleased = cpuMilliReleased + bePod.milliRequest
 	}
 
-	c.resmanager.evictPodsIfNotEvicted(killedPods, node, resourceexecutor.EvictPodByBECPUSatisfaction, message)
+	if dryRun {
+		c.resmanager.recordPodsEvictDryRun(killedPods, node, resourceexecutor.EvictPodByBECPUSatisfaction, message)
+	} else {
+		c.resmanager.evictPodsIfNotEvicted(killedPods, node, resourceexecutor.EvictPodByBECPUSatisfaction, message)
+	}
 
 	if len(killedPods) > 0 {
 		c.lastEvictTime = time.Now()
 	}
-	klog.V(5).Infof("killAndEvictBEPodsRelease finished!cpuNeedMilliRelease(%d) cpuMilliReleased(%d)", cpuNeedMilliRelease, cpuMilliReleased)
+	klog.V(5).Infof("killAndEvictBEPodsRelease finished!dryRun(%v) cpuNeedMilliRelease(%d) cpuMilliReleased(%d)", dryRun, cpuNeedMilliRelease, cpuMilliReleased)
 }
 
-func (c *CPUEvictor) getPodEvictInfoAndSort(beMetric *metriccache.BECPUResourceMetric) []*podEvictCPUInfo {
+func (c *CPUEvictor) getPodEvictInfoAndSort(beMetric *metriccache.BECPUResourceMetric, policy slov1alpha1.EvictionVictimPolicy) []*podEvictCPUInfo {
 	var bePodInfos []*podEvictCPUInfo
+	var bePods []*corev1.Pod
 
 	for _, podMeta := range c.resmanager.statesInformer.GetAllPods() {
 		pod := podMeta.Pod
@@ -203,19 +210,26 @@ func (c *CPUEvictor) getPodEvictInfoAndSort(beMetric *metriccache.BECPUResourceM
 			}
 
 			bePodInfos = append(bePodInfos, bePodInfo)
+			bePods = append(bePods, pod)
 		}
 	}
 
+	sorters := newVictimSorters(c.resmanager.statesInformer, policy, bePods)
 	sort.Slice(bePodInfos, func(i, j int) bool {
-		if bePodInfos[i].pod.Spec.Priority == nil || bePodInfos[j].pod.Spec.Priority == nil ||
-			*bePodInfos[i].pod.Spec.Priority == *bePodInfos[j].pod.Spec.Priority {
-			return bePodInfos[i].cpuUsage > bePodInfos[j].cpuUsage
-		}
-		return *bePodInfos[i].pod.Spec.Priority < *bePodInfos[j].pod.Spec.Priority
+		return sorters.Less(bePodInfos[i].victim(), bePodInfos[j].victim())
 	})
 	return bePodInfos
 }
 
+func (p *podEvictCPUInfo) victim() *victimInfo {
+	return &victimInfo{
+		pod:        p.pod,
+		usage:      p.cpuUsage,
+		hasUsage:   true,
+		usageRatio: p.cpuUsage,
+	}
+}
+
 func calculateResourceMilliToRelease(metric *metriccache.BECPUResourceMetric, thresholdConfig *slov1alpha1.ResourceThresholdStrategy) int64 {
 	if metric.CPURequest.IsZero() {
 		klog.Warningf("cpuEvict by ResourceSatisfaction skipped! be pods requests is zero!")
@@ -342,7 +342,7 @@ func Test_getPodEvictInfoAndSort(t *testing.T) {
 
 			resmanager := &resmanager{statesInformer: mockStatesInformer, metricCache: mockMetricCache}
 			cpuEvictor := NewCPUEvictor(resmanager)
-			got := cpuEvictor.getPodEvictInfoAndSort(&tt.beMetric)
+			got := cpuEvictor.getPodEvictInfoAndSort(&tt.beMetric, slov1alpha1.EvictionVictimPolicyDefault)
 			assert.Equal(t, len(tt.expect), len(got), "checkLen")
 			for i, expectPodInfo := range tt.expect {
 				gotPodInfo := got[i]
@@ -401,7 +401,7 @@ func Test_killAndEvictBEPodsRelease(t *testing.T) {
 
 	cpuEvictor := &CPUEvictor{resmanager: resmanager, lastEvictTime: time.Now().Add(-5 * time.Minute)}
 
-	cpuEvictor.killAndEvictBEPodsRelease(node, podEvictInfosSorted, 18)
+	cpuEvictor.killAndEvictBEPodsRelease(node, podEvictInfosSorted, 18, false)
 
 	getEvictObject, err := client.Tracker().Get(podsResource, podEvictInfosSorted[0].pod.Namespace, podEvictInfosSorted[0].pod.Name)
 	assert.NotNil(t, getEvictObject, "evictPod Fail", err)
@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resmanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/audit"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+)
+
+// GPUMemoryEvictor verifies the gpu memory used by the containers against the gpu memory allocated by koord-scheduler,
+// and evicts the BE pods which exceed the allocation in the consecutive times of verification, e.g. the isolation
+// provider is missing or bypassed. The other pods exceeding the allocation are only reported like the other evictors,
+// and so are the BE pods when the eviction runs in the dry-run mode of the NodeSLO.
+type GPUMemoryEvictor struct {
+	resManager *resmanager
+	// overusedTimes records the consecutive times of the pods found exceeding the allocation
+	overusedTimes map[types.UID]int
+}
+
+func NewGPUMemoryEvictor(mgr *resmanager) *GPUMemoryEvictor {
+	return &GPUMemoryEvictor{
+		resManager:    mgr,
+		overusedTimes: map[types.UID]int{},
+	}
+}
+
+func (g *GPUMemoryEvictor) gpuMemoryEvict() {
+	klog.V(5).Infof("starting gpu memory evict process")
+	defer klog.V(5).Infof("gpu memory evict process completed")
+
+	node := g.resManager.statesInformer.GetNode()
+	if node == nil {
+		klog.Warningf("skip gpu memory evict, Node %v is nil", g.resManager.nodeName)
+		return
+	}
+
+	var thresholdConfig *slov1alpha1.ResourceThresholdStrategy
+	if nodeSLO := g.resManager.getNodeSLOCopy(); nodeSLO != nil {
+		thresholdConfig = nodeSLO.Spec.ResourceUsedThresholdWithBE
+	}
+	dryRun := isEvictDryRun(thresholdConfig)
+
+	// the pods within the allocation or gone are dropped, so the times are always consecutive
+	overusedTimes := map[types.UID]int{}
+	defer func() { g.overusedTimes = overusedTimes }()
+	for _, podMeta := range g.resManager.statesInformer.GetAllPods() {
+		pod := podMeta.Pod
+		if pod == nil || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		allocated := getPodGPUMemoryAllocated(pod)
+		if allocated == nil {
+			continue
+		}
+		used := g.getPodGPUMemoryUsed(pod)
+		violations := getGPUMemoryViolations(allocated, used)
+		if len(violations) == 0 {
+			continue
+		}
+		times := g.overusedTimes[pod.UID] + 1
+		overusedTimes[pod.UID] = times
+		message := fmt.Sprintf("gpu memory used exceeds the allocation %d times consecutively, %s", times, strings.Join(violations, ", "))
+		if times < g.resManager.config.GPUMemoryEvictConsecutiveTimes {
+			klog.V(4).Infof("pod %s/%s %s", pod.Namespace, pod.Name, message)
+			continue
+		}
+		_ = audit.V(1).Pod(pod.Namespace, pod.Name).Reason(resourceexecutor.EvictPodByGPUMemoryUsage).Message(message).Do()
+		if extension.GetPodQoSClass(pod) != extension.QoSBE {
+			klog.V(4).Infof("skip evicting non-BE pod %s/%s, %s", pod.Namespace, pod.Name, message)
+			continue
+		}
+		if dryRun {
+			g.resManager.recordPodsEvictDryRun([]*corev1.Pod{pod}, node, resourceexecutor.EvictPodByGPUMemoryUsage, message)
+			continue
+		}
+		klog.Infof("pod %s/%s %s", pod.Namespace, pod.Name, message)
+		g.resManager.evictPodIfNotEvicted(pod, node, resourceexecutor.EvictPodByGPUMemoryUsage, message)
+	}
+}
+
+// getPodGPUMemoryAllocated returns the gpu memory allocated to the pod by minor, or nil if no gpu is allocated.
+func getPodGPUMemoryAllocated(pod *corev1.Pod) map[int32]int64 {
+	allocations, err := extension.GetDeviceAllocations(pod.Annotations)
+	if err != nil {
+		klog.V(5).Infof("failed to get device allocations of pod %s/%s, err: %v", pod.Namespace, pod.Name, err)
+		return nil
+	}
+	gpus := allocations[schedulingv1alpha1.GPU]
+	if len(gpus) == 0 {
+		return nil
+	}
+	allocated := make(map[int32]int64, len(gpus))
+	for _, gpu := range gpus {
+		memory := gpu.Resources[extension.GPUMemory]
+		allocated[gpu.Minor] += memory.Value()
+	}
+	return allocated
+}
+
+// getPodGPUMemoryUsed sums up the gpu memory used by the containers of the pod by minor.
+func (g *GPUMemoryEvictor) getPodGPUMemoryUsed(pod *corev1.Pod) map[int32]int64 {
+	used := map[int32]int64{}
+	for i := range pod.Status.ContainerStatuses {
+		containerStat := &pod.Status.ContainerStatuses[i]
+		if len(containerStat.ContainerID) == 0 || containerStat.State.Running == nil {
+			continue
+		}
+		queryResult := g.resManager.collectContainerResMetricLast(&containerStat.ContainerID)
+		if queryResult.Error != nil || queryResult.Metric == nil {
+			continue
+		}
+		for _, gpu := range queryResult.Metric.GPUs {
+			used[gpu.Minor] += gpu.MemoryUsed.Value()
+		}
+	}
+	return used
+}
+
+// getGPUMemoryViolations returns the descriptions of the gpus whose used memory exceeds the allocated.
+func getGPUMemoryViolations(allocated, used map[int32]int64) []string {
+	var violations []string
+	for minor, usedMemory := range used {
+		if usedMemory <= 0 {
+			continue
+		}
+		allocatedMemory, ok := allocated[minor]
+		if !ok {
+			violations = append(violations, fmt.Sprintf("gpu %d used %d bytes but not allocated", minor, usedMemory))
+		} else if usedMemory > allocatedMemory {
+			violations = append(violations, fmt.Sprintf("gpu %d used %d bytes but allocated %d bytes", minor, usedMemory, allocatedMemory))
+		}
+	}
+	sort.Strings(violations)
+	return violations
+}
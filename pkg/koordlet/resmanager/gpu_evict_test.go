@@ -0,0 +1,211 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resmanager
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientsetfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	mock_metriccache "github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache/mockmetriccache"
+	mock_statesinformer "github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer/mockstatesinformer"
+	"github.com/koordinator-sh/koordinator/pkg/util/cache"
+)
+
+func Test_getGPUMemoryViolations(t *testing.T) {
+	tests := []struct {
+		name      string
+		allocated map[int32]int64
+		used      map[int32]int64
+		want      []string
+	}{
+		{
+			name:      "within allocation",
+			allocated: map[int32]int64{0: 1024},
+			used:      map[int32]int64{0: 1024},
+			want:      nil,
+		},
+		{
+			name:      "exceed allocation",
+			allocated: map[int32]int64{0: 1024, 1: 1024},
+			used:      map[int32]int64{0: 2048, 1: 512},
+			want:      []string{"gpu 0 used 2048 bytes but allocated 1024 bytes"},
+		},
+		{
+			name:      "use gpu not allocated",
+			allocated: map[int32]int64{0: 1024},
+			used:      map[int32]int64{1: 512, 2: 0},
+			want:      []string{"gpu 1 used 512 bytes but not allocated"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getGPUMemoryViolations(tt.allocated, tt.used))
+		})
+	}
+}
+
+func Test_gpuMemoryEvict(t *testing.T) {
+	tests := []struct {
+		name        string
+		evictDryRun *bool
+	}{
+		{
+			name: "evict the overused BE pod",
+		},
+		{
+			name:        "only record the overused BE pod in dry-run mode",
+			evictDryRun: pointer.Bool(true),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testGPUMemoryEvict(t, tt.evictDryRun)
+		})
+	}
+}
+
+func testGPUMemoryEvict(t *testing.T, evictDryRun *bool) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+	overusedPod := createGPUMemoryEvictTestPod("test-overused-pod", 0, "4Gi")
+	overusedLSPod := createGPUMemoryEvictTestPod("test-overused-ls-pod", 1, "4Gi")
+	overusedLSPod.Labels[apiext.LabelPodQoS] = string(apiext.QoSLS)
+	recoveredPod := createGPUMemoryEvictTestPod("test-recovered-pod", 2, "4Gi")
+	normalPod := createGPUMemoryEvictTestPod("test-normal-pod", 3, "4Gi")
+	noGPUPod := createGPUMemoryEvictTestPod("test-no-gpu-pod", 4, "")
+	pods := []*corev1.Pod{overusedPod, overusedLSPod, recoveredPod, normalPod, noGPUPod}
+	containerMetrics := map[string]*metriccache.ContainerResourceMetric{
+		overusedPod.Status.ContainerStatuses[0].ContainerID: {
+			GPUs: []metriccache.GPUMetric{{Minor: 0, MemoryUsed: resource.MustParse("6Gi")}},
+		},
+		overusedLSPod.Status.ContainerStatuses[0].ContainerID: {
+			GPUs: []metriccache.GPUMetric{{Minor: 1, MemoryUsed: resource.MustParse("6Gi")}},
+		},
+		recoveredPod.Status.ContainerStatuses[0].ContainerID: {
+			GPUs: []metriccache.GPUMetric{{Minor: 2, MemoryUsed: resource.MustParse("6Gi")}},
+		},
+		normalPod.Status.ContainerStatuses[0].ContainerID: {
+			GPUs: []metriccache.GPUMetric{{Minor: 3, MemoryUsed: resource.MustParse("3Gi")}},
+		},
+	}
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	mockStatesInformer := mock_statesinformer.NewMockStatesInformer(ctl)
+	mockStatesInformer.EXPECT().GetAllPods().Return(getPodMetas(pods)).AnyTimes()
+	mockStatesInformer.EXPECT().GetNode().Return(node).AnyTimes()
+	mockStatesInformer.EXPECT().GetNodeSLO().Return(getNodeSLOByThreshold(&slov1alpha1.ResourceThresholdStrategy{
+		EvictDryRun: evictDryRun,
+	})).AnyTimes()
+	mockMetricCache := mock_metriccache.NewMockMetricCache(ctl)
+	mockMetricCache.EXPECT().GetContainerResourceMetric(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(containerID *string, param *metriccache.QueryParam) metriccache.ContainerResourceQueryResult {
+			return metriccache.ContainerResourceQueryResult{Metric: containerMetrics[*containerID]}
+		}).AnyTimes()
+
+	client := clientsetfake.NewSimpleClientset()
+	r := &resmanager{
+		statesInformer: mockStatesInformer,
+		podsEvicted:    cache.NewCacheDefault(),
+		eventRecorder:  &FakeRecorder{},
+		metricCache:    mockMetricCache,
+		kubeClient:     client,
+		config:         NewDefaultConfig(),
+	}
+	r.config.GPUMemoryEvictConsecutiveTimes = 2
+	stop := make(chan struct{})
+	_ = r.podsEvicted.Run(stop)
+	defer func() { stop <- struct{}{} }()
+	for _, pod := range pods {
+		_, err := client.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	assertEvicted := func(evictedPods ...*corev1.Pod) {
+		evicted := map[string]bool{}
+		for _, pod := range evictedPods {
+			evicted[pod.Name] = true
+		}
+		for _, pod := range pods {
+			getObject, err := client.Tracker().Get(podsResource, pod.Namespace, pod.Name)
+			assert.NoError(t, err)
+			if evicted[pod.Name] {
+				assert.IsType(t, &policyv1beta1.Eviction{}, getObject, pod.Name)
+			} else {
+				assert.IsType(t, &corev1.Pod{}, getObject, pod.Name)
+			}
+		}
+	}
+
+	evictor := NewGPUMemoryEvictor(r)
+	// a single sample exceeding the allocation doesn't evict any pod
+	evictor.gpuMemoryEvict()
+	assertEvicted()
+	assert.Equal(t, map[types.UID]int{overusedPod.UID: 1, overusedLSPod.UID: 1, recoveredPod.UID: 1}, evictor.overusedTimes)
+
+	// the recovered pod is not counted consecutively, and only the BE pod is evicted unless in dry-run mode
+	containerMetrics[recoveredPod.Status.ContainerStatuses[0].ContainerID].GPUs[0].MemoryUsed = resource.MustParse("3Gi")
+	evictor.gpuMemoryEvict()
+	if evictDryRun != nil && *evictDryRun {
+		assertEvicted()
+	} else {
+		assertEvicted(overusedPod)
+	}
+	assert.Equal(t, map[types.UID]int{overusedPod.UID: 2, overusedLSPod.UID: 2}, evictor.overusedTimes)
+}
+
+func createGPUMemoryEvictTestPod(name string, minor int32, gpuMemory string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			UID:         types.UID(name),
+			Labels:      map[string]string{apiext.LabelPodQoS: string(apiext.QoSBE)},
+			Annotations: map[string]string{},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: name + "-container"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:        name + "-container",
+					ContainerID: fmt.Sprintf("containerd://%s-container", name),
+					State:       corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				},
+			},
+		},
+	}
+	if gpuMemory != "" {
+		pod.Annotations[apiext.AnnotationDeviceAllocated] = fmt.Sprintf(`{"gpu":[{"minor":%d,"resources":{"%s":"50","%s":"%s"}}]}`,
+			minor, apiext.GPUCore, apiext.GPUMemory, gpuMemory)
+	}
+	return pod
+}
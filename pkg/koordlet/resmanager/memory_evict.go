@@ -25,9 +25,11 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
 const (
@@ -124,11 +126,12 @@ func (m *MemoryEvictor) memoryEvict() {
 	)
 
 	memoryNeedRelease := memoryCapacity * (nodeMemoryUsage - lowerPercent) / 100
-	m.killAndEvictBEPods(node, podMetrics, memoryNeedRelease)
+	m.killAndEvictBEPods(node, podMetrics, memoryNeedRelease, getEvictionVictimPolicy(thresholdConfig), isEvictDryRun(thresholdConfig))
 }
 
-func (m *MemoryEvictor) killAndEvictBEPods(node *corev1.Node, podMetrics []*metriccache.PodResourceMetric, memoryNeedRelease int64) {
-	bePodInfos := m.getSortedBEPodInfos(podMetrics)
+func (m *MemoryEvictor) killAndEvictBEPods(node *corev1.Node, podMetrics []*metriccache.PodResourceMetric, memoryNeedRelease int64,
+	policy slov1alpha1.EvictionVictimPolicy, dryRun bool) {
+	bePodInfos := m.getSortedBEPodInfos(podMetrics, policy)
 	message := fmt.Sprintf("killAndEvictBEPods for node(%v), need to release memory: %v", m.resManager.nodeName, memoryNeedRelease)
 	memoryReleased := int64(0)
 
@@ -138,27 +141,34 @@ func (m *MemoryEvictor) killAndEvictBEPods(node *corev1.Node, podMetrics []*metr
 			break
 		}
 
-		killMsg := fmt.Sprintf("%v, kill pod: %v", message, bePod.pod.Name)
-		killContainers(bePod.pod, killMsg)
+		if !dryRun {
+			killMsg := fmt.Sprintf("%v, kill pod: %v", message, bePod.pod.Name)
+			killContainers(bePod.pod, killMsg)
+		}
 		killedPods = append(killedPods, bePod.pod)
 		if bePod.podMetric != nil {
 			memoryReleased += bePod.podMetric.MemoryUsed.MemoryWithoutCache.Value()
 		}
 	}
 
-	m.resManager.evictPodsIfNotEvicted(killedPods, node, resourceexecutor.EvictPodByNodeMemoryUsage, message)
+	if dryRun {
+		m.resManager.recordPodsEvictDryRun(killedPods, node, resourceexecutor.EvictPodByNodeMemoryUsage, message)
+	} else {
+		m.resManager.evictPodsIfNotEvicted(killedPods, node, resourceexecutor.EvictPodByNodeMemoryUsage, message)
+	}
 
 	m.lastEvictTime = time.Now()
-	klog.Infof("killAndEvictBEPods completed, memoryNeedRelease(%v) memoryReleased(%v)", memoryNeedRelease, memoryReleased)
+	klog.Infof("killAndEvictBEPods completed, dryRun(%v) memoryNeedRelease(%v) memoryReleased(%v)", dryRun, memoryNeedRelease, memoryReleased)
 }
 
-func (m *MemoryEvictor) getSortedBEPodInfos(podMetrics []*metriccache.PodResourceMetric) []*podInfo {
+func (m *MemoryEvictor) getSortedBEPodInfos(podMetrics []*metriccache.PodResourceMetric, policy slov1alpha1.EvictionVictimPolicy) []*podInfo {
 	podMetricMap := make(map[string]*metriccache.PodResourceMetric, len(podMetrics))
 	for _, podMetric := range podMetrics {
 		podMetricMap[podMetric.PodUID] = podMetric
 	}
 
 	var bePodInfos []*podInfo
+	var bePods []*corev1.Pod
 	for _, podMeta := range m.resManager.statesInformer.GetAllPods() {
 		pod := podMeta.Pod
 		if extension.GetPodQoSClass(pod) == extension.QoSBE {
@@ -167,22 +177,33 @@ func (m *MemoryEvictor) getSortedBEPodInfos(podMetrics []*metriccache.PodResourc
 				podMetric: podMetricMap[string(pod.UID)],
 			}
 			bePodInfos = append(bePodInfos, info)
+			bePods = append(bePods, pod)
 		}
 	}
 
+	sorters := newVictimSorters(m.resManager.statesInformer, policy, bePods)
 	sort.Slice(bePodInfos, func(i, j int) bool {
-		// TODO: https://github.com/koordinator-sh/koordinator/pull/65#discussion_r849048467
-		// compare priority > podMetric > name
-		if bePodInfos[i].pod.Spec.Priority != nil && bePodInfos[j].pod.Spec.Priority != nil && *bePodInfos[i].pod.Spec.Priority != *bePodInfos[j].pod.Spec.Priority {
-			return *bePodInfos[i].pod.Spec.Priority < *bePodInfos[j].pod.Spec.Priority
-		}
-		if bePodInfos[i].podMetric != nil && bePodInfos[j].podMetric != nil {
-			return bePodInfos[i].podMetric.MemoryUsed.MemoryWithoutCache.Value() > bePodInfos[j].podMetric.MemoryUsed.MemoryWithoutCache.Value()
-		} else if bePodInfos[i].podMetric == nil && bePodInfos[j].podMetric == nil {
-			return bePodInfos[i].pod.Name > bePodInfos[j].pod.Name
-		}
-		return bePodInfos[j].podMetric == nil
+		return sorters.Less(bePodInfos[i].victim(), bePodInfos[j].victim())
 	})
 
 	return bePodInfos
 }
+
+func (p *podInfo) victim() *victimInfo {
+	v := &victimInfo{pod: p.pod}
+	if p.podMetric == nil {
+		return v
+	}
+	v.usage = float64(p.podMetric.MemoryUsed.MemoryWithoutCache.Value())
+	v.hasUsage = true
+	requestSum := int64(0)
+	for i := range p.pod.Spec.Containers {
+		if containerReq := util.GetContainerBatchMemoryByteRequest(&p.pod.Spec.Containers[i]); containerReq > 0 {
+			requestSum += containerReq
+		}
+	}
+	if requestSum > 0 {
+		v.usageRatio = v.usage / float64(requestSum)
+	}
+	return v
+}
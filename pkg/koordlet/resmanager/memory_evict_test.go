@@ -166,6 +166,39 @@ func Test_memoryEvict(t *testing.T) {
 				createMemoryEvictTestPod("test_be_pod_priority120", apiext.QoSBE, 120),
 			},
 		},
+		{
+			name: "test_memoryevict_dry_run",
+			node: getNode("80", "120G"),
+			pods: []*corev1.Pod{
+				createMemoryEvictTestPod("test_lsr_pod", apiext.QoSLSR, 1000),
+				createMemoryEvictTestPod("test_ls_pod", apiext.QoSLS, 500),
+				createMemoryEvictTestPod("test_be_pod_priority100_1", apiext.QoSBE, 100),
+				createMemoryEvictTestPod("test_be_pod_priority100_2", apiext.QoSBE, 100),
+			},
+			nodeMetric: &metriccache.NodeResourceMetric{
+				MemoryUsed: metriccache.MemoryMetric{
+					MemoryWithoutCache: resource.MustParse("115G"),
+				},
+			},
+			podMetrics: []*metriccache.PodResourceMetric{
+				createPodResourceMetric("test_lsr_pod", "40G"),
+				createPodResourceMetric("test_ls_pod", "30G"),
+				createPodResourceMetric("test_be_pod_priority100_1", "5G"),
+				createPodResourceMetric("test_be_pod_priority100_2", "20G"), // evict in dry-run
+			},
+			thresholdConfig: &slov1alpha1.ResourceThresholdStrategy{
+				Enable:                      pointer.BoolPtr(true),
+				MemoryEvictThresholdPercent: pointer.Int64Ptr(82),
+				EvictDryRun:                 pointer.BoolPtr(true),
+			},
+			expectEvictPods: []*corev1.Pod{},
+			expectNotEvictPods: []*corev1.Pod{
+				createMemoryEvictTestPod("test_lsr_pod", apiext.QoSLSR, 1000),
+				createMemoryEvictTestPod("test_ls_pod", apiext.QoSLS, 500),
+				createMemoryEvictTestPod("test_be_pod_priority100_1", apiext.QoSBE, 100),
+				createMemoryEvictTestPod("test_be_pod_priority100_2", apiext.QoSBE, 100),
+			},
+		},
 		{
 			name: "test_memoryevict_MemoryEvictThresholdPercent_80",
 			node: getNode("80", "120G"),
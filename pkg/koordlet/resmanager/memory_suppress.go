@@ -0,0 +1,224 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resmanager
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/audit"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+const (
+	// defaultMemorySuppressMinPercent is the default lower bound of the suppressed memory.high for BE pods, which
+	// avoids the BE pods being throttled too hard to make any progress.
+	defaultMemorySuppressMinPercent int64 = 5
+)
+
+// MemorySuppress throttles the memory usage of BE pods by lowering the memory.high of the best-effort cgroup when the
+// node memory usage exceeds the suppress threshold. It works before the memory eviction, so the BE pods get a chance
+// to reclaim their memory instead of being killed.
+type MemorySuppress struct {
+	resmanager   *resmanager
+	executor     resourceexecutor.ResourceUpdateExecutor
+	policyStatus suppressPolicyStatus
+}
+
+func NewMemorySuppress(resmanager *resmanager) *MemorySuppress {
+	return &MemorySuppress{
+		resmanager:   resmanager,
+		executor:     resourceexecutor.NewResourceUpdateExecutor(),
+		policyStatus: policyUsing,
+	}
+}
+
+func (m *MemorySuppress) RunInit(stopCh <-chan struct{}) error {
+	m.executor.Run(stopCh)
+	return nil
+}
+
+// suppressBEMemory adjusts the memory.high of the best-effort cgroup to suppress BE memory usage
+func (m *MemorySuppress) suppressBEMemory() {
+	// 1. check if the memory suppress is enabled and supported
+	// 2. retrieve the latest node and pod memory usage
+	// 3. calculate the memory.high of BE pods and apply it to the best-effort cgroup
+	nodeSLO := m.resmanager.getNodeSLOCopy()
+	if disabled, err := isFeatureDisabled(nodeSLO, features.BEMemorySuppress); err != nil {
+		klog.Warningf("suppressBEMemory failed, cannot check the featuregate, err: %s", err)
+		return
+	} else if disabled {
+		m.recoverMemoryHighIfNeed()
+		klog.V(5).Infof("suppressBEMemory skipped, nodeSLO disable the featuregate")
+		return
+	}
+
+	thresholdConfig := nodeSLO.Spec.ResourceUsedThresholdWithBE
+	thresholdPercent := thresholdConfig.MemorySuppressThresholdPercent
+	if thresholdPercent == nil || *thresholdPercent <= 0 {
+		m.recoverMemoryHighIfNeed()
+		klog.V(5).Infof("suppressBEMemory skipped, threshold percent is not set")
+		return
+	}
+	// the suppression should take effect earlier than the eviction
+	if thresholdConfig.MemoryEvictThresholdPercent != nil && *thresholdPercent >= *thresholdConfig.MemoryEvictThresholdPercent {
+		klog.Warningf("suppressBEMemory skipped, threshold percent(%v) should less than evict threshold percent(%v)",
+			*thresholdPercent, *thresholdConfig.MemoryEvictThresholdPercent)
+		return
+	}
+	minPercent := defaultMemorySuppressMinPercent
+	if thresholdConfig.MemorySuppressMinPercent != nil {
+		minPercent = *thresholdConfig.MemorySuppressMinPercent
+	}
+
+	if !isBEMemoryHighSupported() {
+		klog.V(5).Infof("suppressBEMemory skipped, memory.high is not supported by the system")
+		return
+	}
+
+	node := m.resmanager.statesInformer.GetNode()
+	if node == nil {
+		klog.Warningf("suppressBEMemory failed, got nil node %s", m.resmanager.nodeName)
+		return
+	}
+	memoryCapacity := node.Status.Capacity.Memory().Value()
+	if memoryCapacity <= 0 {
+		klog.Warningf("suppressBEMemory failed, memory capacity(%v) should greater than 0", memoryCapacity)
+		return
+	}
+
+	nodeMetric, podMetrics := m.resmanager.collectNodeAndPodMetricLast()
+	if nodeMetric == nil {
+		klog.Warningf("suppressBEMemory failed, got nil node metric")
+		return
+	}
+
+	// keep applying the suppressed memory.high even if the node usage is below the threshold, it grows with the
+	// headroom left by the non-BE pods, so the memory.high won't oscillate between the suppressed value and unlimited
+	nodeMemoryUsed := nodeMetric.MemoryUsed.MemoryWithoutCache.Value()
+	beMemoryHigh := calculateBESuppressMemory(memoryCapacity, nodeMemoryUsed, m.getBEMemoryUsed(podMetrics),
+		*thresholdPercent, minPercent)
+	m.adjustByMemoryHigh(beMemoryHigh)
+}
+
+// getBEMemoryUsed sums up the memory usage of the BE pods
+func (m *MemorySuppress) getBEMemoryUsed(podMetrics []*metriccache.PodResourceMetric) int64 {
+	podMetricMap := make(map[string]*metriccache.PodResourceMetric, len(podMetrics))
+	for _, podMetric := range podMetrics {
+		podMetricMap[podMetric.PodUID] = podMetric
+	}
+
+	beMemoryUsed := int64(0)
+	for _, podMeta := range m.resmanager.statesInformer.GetAllPods() {
+		pod := podMeta.Pod
+		if apiext.GetPodQoSClass(pod) != apiext.QoSBE {
+			continue
+		}
+		if podMetric, ok := podMetricMap[string(pod.UID)]; ok {
+			beMemoryUsed += podMetric.MemoryUsed.MemoryWithoutCache.Value()
+		}
+	}
+	return beMemoryUsed
+}
+
+// calculateBESuppressMemory calculates the memory.high of BE pods, which is limited to the memory left by the non-BE
+// usage under the threshold:
+// suppress(BE) := node.Capacity * SLOPercent - (node.Used - pod(BE).Used)
+func calculateBESuppressMemory(memoryCapacity, nodeMemoryUsed, beMemoryUsed, thresholdPercent, minPercent int64) int64 {
+	nonBEMemoryUsed := nodeMemoryUsed - beMemoryUsed
+	if nonBEMemoryUsed < 0 {
+		nonBEMemoryUsed = 0
+	}
+	beMemoryHigh := memoryCapacity*thresholdPercent/100 - nonBEMemoryUsed
+	beMemoryHighMin := memoryCapacity * minPercent / 100
+	if beMemoryHigh < beMemoryHighMin {
+		beMemoryHigh = beMemoryHighMin
+	}
+	klog.V(4).Infof("nodeSuppressBE[Memory]:%v = node.Capacity:%v * SLOPercent:%v%% - nonBEUsed:%v, min:%v",
+		beMemoryHigh, memoryCapacity, thresholdPercent, nonBEMemoryUsed, beMemoryHighMin)
+	return beMemoryHigh
+}
+
+func (m *MemorySuppress) adjustByMemoryHigh(beMemoryHigh int64) {
+	beCgroupPath := koordletutil.GetKubeQosRelativePath(corev1.PodQOSBestEffort)
+	updater, err := resourceexecutor.DefaultCgroupUpdaterFactory.New(system.MemoryHighName, beCgroupPath,
+		strconv.FormatInt(beMemoryHigh, 10))
+	if err != nil {
+		klog.V(4).Infof("suppressBEMemory: failed to get be memory.high updater, err: %v", err)
+		return
+	}
+	isUpdated, err := m.executor.Update(true, updater)
+	if err != nil {
+		klog.Errorf("suppressBEMemory: failed to write memory.high for be pods, error: %v", err)
+		return
+	}
+	m.policyStatus = policyUsing
+	if isUpdated {
+		_ = audit.V(1).Node().Reason(resourceexecutor.AdjustBEByNodeMemoryUsage).Message("update BE group to memory.high: %v", beMemoryHigh).Do()
+	}
+	klog.V(4).Infof("suppressBEMemory: succeeded to write memory.high for be pods, isUpdated %v, new value: %d",
+		isUpdated, beMemoryHigh)
+}
+
+func (m *MemorySuppress) recoverMemoryHighIfNeed() {
+	if m.policyStatus == policyRecovered {
+		return
+	}
+	if !isBEMemoryHighSupported() {
+		m.policyStatus = policyRecovered
+		return
+	}
+
+	beCgroupPath := koordletutil.GetKubeQosRelativePath(corev1.PodQOSBestEffort)
+	updater, err := resourceexecutor.DefaultCgroupUpdaterFactory.New(system.MemoryHighName, beCgroupPath,
+		system.CgroupMaxValueStr)
+	if err != nil {
+		klog.V(4).Infof("failed to get be memory.high updater, err: %v", err)
+		return
+	}
+	isUpdated, err := m.executor.Update(true, updater)
+	if err != nil {
+		klog.Errorf("recover bestEffort memory.high err: %v", err)
+		return
+	}
+	if isUpdated {
+		_ = audit.V(1).Node().Reason(resourceexecutor.AdjustBEByNodeMemoryUsage).Message("recover BE group memory.high to unlimited").Do()
+	}
+	klog.V(5).Infof("successfully recover bestEffort memory.high, isUpdated %v", isUpdated)
+	m.policyStatus = policyRecovered
+}
+
+// isBEMemoryHighSupported checks if the memory.high is supported by the best-effort cgroup, which is only available
+// on cgroups-v2 or the Anolis OS.
+func isBEMemoryHighSupported() bool {
+	memoryHigh, err := system.GetCgroupResource(system.MemoryHighName)
+	if err != nil {
+		return false
+	}
+	beCgroupPath := koordletutil.GetKubeQosRelativePath(corev1.PodQOSBestEffort)
+	if supported, _ := memoryHigh.IsSupported(beCgroupPath); !supported {
+		return false
+	}
+	return system.FileExists(memoryHigh.Path(beCgroupPath))
+}
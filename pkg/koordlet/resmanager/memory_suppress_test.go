@@ -0,0 +1,258 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resmanager
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	mock_metriccache "github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache/mockmetriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	mock_statesinformer "github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer/mockstatesinformer"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+	"github.com/koordinator-sh/koordinator/pkg/util/cache"
+)
+
+func newTestMemorySuppress(r *resmanager) *MemorySuppress {
+	return &MemorySuppress{
+		resmanager: r,
+		executor: &resourceexecutor.ResourceUpdateExecutorImpl{
+			Config:        resourceexecutor.NewDefaultConfig(),
+			ResourceCache: cache.NewCacheDefault(),
+		},
+		policyStatus: policyUsing,
+	}
+}
+
+func Test_memorySuppress_suppressBEMemory(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+		},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("100Gi"),
+			},
+		},
+	}
+	pods := []*corev1.Pod{
+		createMemoryEvictTestPod("test_ls_pod", apiext.QoSLS, 1000),
+		createMemoryEvictTestPod("test_be_pod", apiext.QoSBE, 100),
+	}
+	podMetrics := []*metriccache.PodResourceMetric{
+		createPodResourceMetric("test_ls_pod", "40Gi"),
+		createPodResourceMetric("test_be_pod", "30Gi"),
+	}
+	tests := []struct {
+		name            string
+		useCgroupsV2    bool
+		withMemoryHigh  bool
+		thresholdConfig *slov1alpha1.ResourceThresholdStrategy
+		nodeMemoryUsed  string
+		prepareValue    string
+		wantValue       string
+	}{
+		{
+			name:           "recover since feature is disabled",
+			useCgroupsV2:   true,
+			withMemoryHigh: true,
+			thresholdConfig: &slov1alpha1.ResourceThresholdStrategy{
+				Enable:                         pointer.Bool(false),
+				MemorySuppressThresholdPercent: pointer.Int64(60),
+			},
+			nodeMemoryUsed: "75Gi",
+			prepareValue:   "1024",
+			wantValue:      system.CgroupMaxValueStr,
+		},
+		{
+			name:           "recover since threshold is not set",
+			useCgroupsV2:   true,
+			withMemoryHigh: true,
+			thresholdConfig: &slov1alpha1.ResourceThresholdStrategy{
+				Enable: pointer.Bool(true),
+			},
+			nodeMemoryUsed: "75Gi",
+			prepareValue:   "1024",
+			wantValue:      system.CgroupMaxValueStr,
+		},
+		{
+			name:           "skip since threshold is not less than the evict threshold",
+			useCgroupsV2:   true,
+			withMemoryHigh: true,
+			thresholdConfig: &slov1alpha1.ResourceThresholdStrategy{
+				Enable:                         pointer.Bool(true),
+				MemorySuppressThresholdPercent: pointer.Int64(70),
+				MemoryEvictThresholdPercent:    pointer.Int64(70),
+			},
+			nodeMemoryUsed: "75Gi",
+			prepareValue:   "1024",
+			wantValue:      "1024",
+		},
+		{
+			name:         "skip since memory.high is not supported",
+			useCgroupsV2: false,
+			thresholdConfig: &slov1alpha1.ResourceThresholdStrategy{
+				Enable:                         pointer.Bool(true),
+				MemorySuppressThresholdPercent: pointer.Int64(60),
+			},
+			nodeMemoryUsed: "75Gi",
+		},
+		{
+			name:           "keep suppressing with the headroom when node usage is below the threshold",
+			useCgroupsV2:   true,
+			withMemoryHigh: true,
+			thresholdConfig: &slov1alpha1.ResourceThresholdStrategy{
+				Enable:                         pointer.Bool(true),
+				MemorySuppressThresholdPercent: pointer.Int64(80),
+			},
+			// 100Gi * 80% - (75Gi - 30Gi) = 35Gi
+			nodeMemoryUsed: "75Gi",
+			prepareValue:   "1024",
+			wantValue:      "37580963840",
+		},
+		{
+			name:           "suppress be memory on cgroups-v2",
+			useCgroupsV2:   true,
+			withMemoryHigh: true,
+			thresholdConfig: &slov1alpha1.ResourceThresholdStrategy{
+				Enable:                         pointer.Bool(true),
+				MemorySuppressThresholdPercent: pointer.Int64(60),
+				MemoryEvictThresholdPercent:    pointer.Int64(70),
+			},
+			// 100Gi * 60% - (75Gi - 30Gi) = 15Gi
+			nodeMemoryUsed: "75Gi",
+			prepareValue:   "max",
+			wantValue:      "16106127360",
+		},
+		{
+			name:           "suppress be memory no less than the min percent",
+			useCgroupsV2:   true,
+			withMemoryHigh: true,
+			thresholdConfig: &slov1alpha1.ResourceThresholdStrategy{
+				Enable:                         pointer.Bool(true),
+				MemorySuppressThresholdPercent: pointer.Int64(50),
+				MemorySuppressMinPercent:       pointer.Int64(10),
+			},
+			// max(100Gi * 50% - (75Gi - 30Gi), 100Gi * 10%) = 10Gi
+			nodeMemoryUsed: "75Gi",
+			prepareValue:   "max",
+			wantValue:      "10737418240",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helper := system.NewFileTestUtil(t)
+			defer helper.Cleanup()
+			helper.SetCgroupsV2(tt.useCgroupsV2)
+			beQosDir := koordletutil.GetKubeQosRelativePath(corev1.PodQOSBestEffort)
+			if tt.withMemoryHigh {
+				memoryHigh, err := system.GetCgroupResource(system.MemoryHighName)
+				assert.NoError(t, err)
+				helper.WriteCgroupFileContents(beQosDir, memoryHigh, tt.prepareValue)
+			}
+
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockStatesInformer := mock_statesinformer.NewMockStatesInformer(ctl)
+			mockStatesInformer.EXPECT().GetAllPods().Return(getPodMetas(pods)).AnyTimes()
+			mockStatesInformer.EXPECT().GetNode().Return(node).AnyTimes()
+			mockStatesInformer.EXPECT().GetNodeSLO().Return(getNodeSLOByThreshold(tt.thresholdConfig)).AnyTimes()
+			mockMetricCache := mock_metriccache.NewMockMetricCache(ctl)
+			mockNodeQueryResult := metriccache.NodeResourceQueryResult{Metric: &metriccache.NodeResourceMetric{
+				MemoryUsed: metriccache.MemoryMetric{MemoryWithoutCache: resource.MustParse(tt.nodeMemoryUsed)},
+			}}
+			mockMetricCache.EXPECT().GetNodeResourceMetric(gomock.Any()).Return(mockNodeQueryResult).AnyTimes()
+			for _, podMetric := range podMetrics {
+				mockPodQueryResult := metriccache.PodResourceQueryResult{Metric: podMetric}
+				mockMetricCache.EXPECT().GetPodResourceMetric(&podMetric.PodUID, gomock.Any()).Return(mockPodQueryResult).AnyTimes()
+			}
+
+			r := &resmanager{
+				statesInformer: mockStatesInformer,
+				metricCache:    mockMetricCache,
+				config:         NewDefaultConfig(),
+			}
+			m := newTestMemorySuppress(r)
+			stop := make(chan struct{})
+			defer close(stop)
+			assert.NoError(t, m.RunInit(stop))
+			m.suppressBEMemory()
+
+			if tt.withMemoryHigh {
+				memoryHigh, _ := system.GetCgroupResource(system.MemoryHighName)
+				assert.Equal(t, tt.wantValue, helper.ReadCgroupFileContents(beQosDir, memoryHigh))
+			}
+		})
+	}
+}
+
+func Test_calculateBESuppressMemory(t *testing.T) {
+	tests := []struct {
+		name             string
+		memoryCapacity   int64
+		nodeMemoryUsed   int64
+		beMemoryUsed     int64
+		thresholdPercent int64
+		minPercent       int64
+		want             int64
+	}{
+		{
+			name:             "limit be memory to the left of the threshold",
+			memoryCapacity:   1000,
+			nodeMemoryUsed:   700,
+			beMemoryUsed:     300,
+			thresholdPercent: 60,
+			minPercent:       5,
+			want:             200,
+		},
+		{
+			name:             "be memory no less than the min percent",
+			memoryCapacity:   1000,
+			nodeMemoryUsed:   700,
+			beMemoryUsed:     100,
+			thresholdPercent: 60,
+			minPercent:       5,
+			want:             50,
+		},
+		{
+			name:             "be memory used larger than node used",
+			memoryCapacity:   1000,
+			nodeMemoryUsed:   600,
+			beMemoryUsed:     700,
+			thresholdPercent: 60,
+			minPercent:       5,
+			want:             600,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateBESuppressMemory(tt.memoryCapacity, tt.nodeMemoryUsed, tt.beMemoryUsed,
+				tt.thresholdPercent, tt.minPercent)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -51,6 +51,7 @@ import (
 const (
 	evictPodSuccess = "evictPodSuccess"
 	evictPodFail    = "evictPodFail"
+	evictPodDryRun  = "evictPodDryRun"
 )
 
 type ResManager interface {
@@ -105,7 +106,7 @@ func isFeatureDisabled(nodeSLO *slov1alpha1.NodeSLO, feature featuregate.Feature
 
 	spec := nodeSLO.Spec
 	switch feature {
-	case features.BECPUSuppress, features.BEMemoryEvict, features.BECPUEvict:
+	case features.BECPUSuppress, features.BEMemoryEvict, features.BECPUEvict, features.BEMemorySuppress:
 		if spec.ResourceUsedThresholdWithBE == nil || spec.ResourceUsedThresholdWithBE.Enable == nil {
 			return true, fmt.Errorf("cannot parse feature config for invalid nodeSLO %v", nodeSLO)
 		}
@@ -145,9 +146,16 @@ func (r *resmanager) Run(stopCh <-chan struct{}) error {
 	cpuEvictor := NewCPUEvictor(r)
 	util.RunFeature(cpuEvictor.cpuEvict, []featuregate.Feature{features.BECPUEvict}, r.config.CPUEvictIntervalSeconds, stopCh)
 
+	memorySuppress := NewMemorySuppress(r)
+	util.RunFeatureWithInit(func() error { return memorySuppress.RunInit(stopCh) }, memorySuppress.suppressBEMemory,
+		[]featuregate.Feature{features.BEMemorySuppress}, r.config.MemorySuppressIntervalSeconds, stopCh)
+
 	memoryEvictor := NewMemoryEvictor(r)
 	util.RunFeature(memoryEvictor.memoryEvict, []featuregate.Feature{features.BEMemoryEvict}, r.config.MemoryEvictIntervalSeconds, stopCh)
 
+	gpuMemoryEvictor := NewGPUMemoryEvictor(r)
+	util.RunFeature(gpuMemoryEvictor.gpuMemoryEvict, []featuregate.Feature{features.GPUMemoryEvict}, r.config.GPUMemoryEvictIntervalSeconds, stopCh)
+
 	rdtResCtrl := NewResctrlReconcile(r)
 	util.RunFeatureWithInit(func() error { return rdtResCtrl.RunInit(stopCh) }, rdtResCtrl.reconcile,
 		[]featuregate.Feature{features.RdtResctrl}, r.config.ReconcileIntervalSeconds, stopCh)
@@ -204,6 +212,22 @@ func (r *resmanager) evictPod(evictPod *corev1.Pod, node *corev1.Node, reason st
 	return true
 }
 
+// recordPodsEvictDryRun records the pods which would have been evicted, without killing or evicting them
+func (r *resmanager) recordPodsEvictDryRun(evictPods []*corev1.Pod, node *corev1.Node, reason string, message string) {
+	for _, evictPod := range evictPods {
+		podEvictMessage := fmt.Sprintf("dry-run evict Pod:%s, reason: %s, message: %v", evictPod.Name, reason, message)
+		_ = audit.V(0).Pod(evictPod.Namespace, evictPod.Name).Reason(reason).Message("dry-run: %v", message).Do()
+		r.eventRecorder.Eventf(node, corev1.EventTypeNormal, evictPodDryRun, podEvictMessage)
+		metrics.RecordPodEvictionDryRun(reason)
+		klog.Infof("dry-run evict pod %v/%v, reason: %v", evictPod.Namespace, evictPod.Name, reason)
+	}
+}
+
+// isEvictDryRun returns whether the eviction of the BE pods runs in the dry-run mode
+func isEvictDryRun(thresholdConfig *slov1alpha1.ResourceThresholdStrategy) bool {
+	return thresholdConfig != nil && thresholdConfig.EvictDryRun != nil && *thresholdConfig.EvictDryRun
+}
+
 // killContainers kills containers inside the pod
 func killContainers(pod *corev1.Pod, message string) {
 	for _, container := range pod.Spec.Containers {
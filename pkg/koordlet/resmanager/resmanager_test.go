@@ -208,6 +208,27 @@ func Test_evictPod(t *testing.T) {
 	assert.Equal(t, evictPodSuccess, fakeRecorder.eventReason, "expect evict success event! but got %s", fakeRecorder.eventReason)
 }
 
+func Test_recordPodsEvictDryRun(t *testing.T) {
+	pod := createTestPod(apiext.QoSBE, "test_be_pod")
+	node := getNode("80", "120G")
+
+	fakeRecorder := &FakeRecorder{}
+	client := clientsetfake.NewSimpleClientset()
+	r := &resmanager{eventRecorder: fakeRecorder, kubeClient: client, podsEvicted: expireCache.NewCacheDefault()}
+
+	_, err := client.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	r.recordPodsEvictDryRun([]*corev1.Pod{pod}, node, "evict pod in dry-run", "")
+	gotPod, err := client.Tracker().Get(podsResource, pod.Namespace, pod.Name)
+	assert.NoError(t, err)
+	assert.IsType(t, &corev1.Pod{}, gotPod, "pod should not be evicted in dry-run")
+	assert.Equal(t, evictPodDryRun, fakeRecorder.eventReason, "expect evict dry-run event! but got %s", fakeRecorder.eventReason)
+
+	_, found := r.podsEvicted.Get(string(pod.UID))
+	assert.False(t, found, "check PodEvicted not cached")
+}
+
 func createTestPod(qosClass apiext.QoSClass, name string) *corev1.Pod {
 	return &corev1.Pod{
 		TypeMeta: metav1.TypeMeta{Kind: "Pod"},
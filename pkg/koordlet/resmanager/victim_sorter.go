@@ -0,0 +1,265 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resmanager
+
+import (
+	"math"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+)
+
+// victimInfo is the information of an eviction candidate used to rank the victims.
+type victimInfo struct {
+	pod *corev1.Pod
+	// usage is the usage of the resource to release, which is unknown when hasUsage is false
+	usage    float64
+	hasUsage bool
+	// usageRatio is the ratio of the resource usage over the request
+	usageRatio float64
+}
+
+// VictimSorter ranks the eviction candidates, the evictors kill the pods in the front first.
+type VictimSorter interface {
+	Name() string
+	// Compare returns a negative number if the victim a should be evicted before b, a positive number if b should be
+	// evicted before a, and zero if they are ranked equally.
+	Compare(a, b *victimInfo) int
+}
+
+type victimSorters []VictimSorter
+
+// Less reports whether the victim a should be evicted before b, which is decided by the first sorter that can
+// distinguish them.
+func (s victimSorters) Less(a, b *victimInfo) bool {
+	for _, sorter := range s {
+		if cmp := sorter.Compare(a, b); cmp != 0 {
+			return cmp < 0
+		}
+	}
+	return false
+}
+
+// newVictimSorters returns the sorters of the eviction victim policy. The pods with lower priority are always evicted
+// first, then the pods are ranked by the policy, and finally by the resource usage to release.
+func newVictimSorters(statesInformer statesinformer.StatesInformer, policy slov1alpha1.EvictionVictimPolicy, pods []*corev1.Pod) victimSorters {
+	sorters := victimSorters{&prioritySorter{}}
+	switch policy {
+	case "", slov1alpha1.EvictionVictimPolicyDefault:
+	case slov1alpha1.EvictionVictimPolicyEvictionCost:
+		sorters = append(sorters, &evictionCostSorter{})
+	case slov1alpha1.EvictionVictimPolicyRunningTime:
+		sorters = append(sorters, &runningTimeSorter{})
+	case slov1alpha1.EvictionVictimPolicyUsageRatio:
+		sorters = append(sorters, &usageRatioSorter{})
+	case slov1alpha1.EvictionVictimPolicyPDBHeadroom:
+		if !features.DefaultKoordletFeatureGate.Enabled(features.PDBHeadroomVictimPolicy) {
+			klog.Warningf("eviction victim policy %s requires the feature gate %s, use the default policy",
+				policy, features.PDBHeadroomVictimPolicy)
+			break
+		}
+		sorters = append(sorters, newPDBHeadroomSorter(statesInformer, pods))
+	default:
+		klog.Warningf("unknown eviction victim policy %s, use the default policy", policy)
+	}
+	return append(sorters, &usageSorter{}, &nameSorter{})
+}
+
+func getEvictionVictimPolicy(thresholdConfig *slov1alpha1.ResourceThresholdStrategy) slov1alpha1.EvictionVictimPolicy {
+	if thresholdConfig == nil {
+		return slov1alpha1.EvictionVictimPolicyDefault
+	}
+	return thresholdConfig.EvictionVictimPolicy
+}
+
+// prioritySorter evicts the pods with lower priority first.
+type prioritySorter struct{}
+
+func (p *prioritySorter) Name() string {
+	return "Priority"
+}
+
+func (p *prioritySorter) Compare(a, b *victimInfo) int {
+	if a.pod.Spec.Priority == nil || b.pod.Spec.Priority == nil || *a.pod.Spec.Priority == *b.pod.Spec.Priority {
+		return 0
+	}
+	if *a.pod.Spec.Priority < *b.pod.Spec.Priority {
+		return -1
+	}
+	return 1
+}
+
+// usageSorter evicts the pods with higher resource usage first, and the pods with unknown usage last.
+type usageSorter struct{}
+
+func (u *usageSorter) Name() string {
+	return "Usage"
+}
+
+func (u *usageSorter) Compare(a, b *victimInfo) int {
+	if !a.hasUsage || !b.hasUsage {
+		if a.hasUsage == b.hasUsage {
+			return 0
+		} else if a.hasUsage {
+			return -1
+		}
+		return 1
+	}
+	return compareFloat64Desc(a.usage, b.usage)
+}
+
+// nameSorter makes the order stable for the pods ranked equally by other sorters.
+type nameSorter struct{}
+
+func (n *nameSorter) Name() string {
+	return "Name"
+}
+
+func (n *nameSorter) Compare(a, b *victimInfo) int {
+	return -strings.Compare(a.pod.Name, b.pod.Name)
+}
+
+// evictionCostSorter evicts the pods with lower eviction cost first.
+type evictionCostSorter struct{}
+
+func (e *evictionCostSorter) Name() string {
+	return string(slov1alpha1.EvictionVictimPolicyEvictionCost)
+}
+
+func (e *evictionCostSorter) Compare(a, b *victimInfo) int {
+	costA, _ := apiext.GetEvictionCost(a.pod.Annotations)
+	costB, _ := apiext.GetEvictionCost(b.pod.Annotations)
+	if costA == costB {
+		return 0
+	} else if costA < costB {
+		return -1
+	}
+	return 1
+}
+
+// runningTimeSorter evicts the youngest pods first, so the long-running pods which are probably about to finish are
+// kept. The pods not started yet are regarded as the youngest.
+type runningTimeSorter struct{}
+
+func (r *runningTimeSorter) Name() string {
+	return string(slov1alpha1.EvictionVictimPolicyRunningTime)
+}
+
+func (r *runningTimeSorter) Compare(a, b *victimInfo) int {
+	startA, startB := a.pod.Status.StartTime, b.pod.Status.StartTime
+	if startA == nil || startB == nil {
+		if startA == startB {
+			return 0
+		} else if startA == nil {
+			return -1
+		}
+		return 1
+	}
+	if startA.Equal(startB) {
+		return 0
+	} else if startB.Before(startA) {
+		return -1
+	}
+	return 1
+}
+
+// usageRatioSorter evicts the pods with higher ratio of the usage over the request first.
+type usageRatioSorter struct{}
+
+func (u *usageRatioSorter) Name() string {
+	return string(slov1alpha1.EvictionVictimPolicyUsageRatio)
+}
+
+func (u *usageRatioSorter) Compare(a, b *victimInfo) int {
+	return compareFloat64Desc(a.usageRatio, b.usageRatio)
+}
+
+// pdbHeadroomSorter evicts the pods whose PodDisruptionBudgets allow more disruptions first. The pods not covered by
+// any PodDisruptionBudget are regarded as having unlimited headroom.
+type pdbHeadroomSorter struct {
+	// headroom is the minimal allowed disruptions of the PodDisruptionBudgets covering the pod, indexed by the pod uid
+	headroom map[string]int32
+}
+
+func newPDBHeadroomSorter(statesInformer statesinformer.StatesInformer, pods []*corev1.Pod) *pdbHeadroomSorter {
+	sorter := &pdbHeadroomSorter{headroom: map[string]int32{}}
+	if statesInformer == nil {
+		return sorter
+	}
+	podsByNamespace := map[string][]*corev1.Pod{}
+	for _, pod := range pods {
+		podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
+	}
+	for namespace, namespacedPods := range podsByNamespace {
+		for _, pdb := range statesInformer.GetPodDisruptionBudgets(namespace) {
+			// an empty selector of the policy/v1 PodDisruptionBudget matches all pods in the namespace,
+			// while a nil one matches no pod
+			selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil {
+				continue
+			}
+			for _, pod := range namespacedPods {
+				if !selector.Matches(labels.Set(pod.Labels)) {
+					continue
+				}
+				uid := string(pod.UID)
+				if headroom, ok := sorter.headroom[uid]; !ok || pdb.Status.DisruptionsAllowed < headroom {
+					sorter.headroom[uid] = pdb.Status.DisruptionsAllowed
+				}
+			}
+		}
+	}
+	return sorter
+}
+
+func (p *pdbHeadroomSorter) Name() string {
+	return string(slov1alpha1.EvictionVictimPolicyPDBHeadroom)
+}
+
+func (p *pdbHeadroomSorter) Compare(a, b *victimInfo) int {
+	headroomA, headroomB := p.getHeadroom(a.pod), p.getHeadroom(b.pod)
+	if headroomA == headroomB {
+		return 0
+	} else if headroomA > headroomB {
+		return -1
+	}
+	return 1
+}
+
+func (p *pdbHeadroomSorter) getHeadroom(pod *corev1.Pod) int32 {
+	if headroom, ok := p.headroom[string(pod.UID)]; ok {
+		return headroom
+	}
+	return math.MaxInt32
+}
+
+func compareFloat64Desc(a, b float64) int {
+	if a == b {
+		return 0
+	} else if a > b {
+		return -1
+	}
+	return 1
+}
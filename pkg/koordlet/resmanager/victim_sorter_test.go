@@ -0,0 +1,247 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resmanager
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	mock_statesinformer "github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer/mockstatesinformer"
+)
+
+func newTestVictim(name string, priority int32, usage float64, usageRatio float64) *victimInfo {
+	return &victimInfo{
+		pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				UID:         types.UID(name),
+				Labels:      map[string]string{"app": name},
+				Annotations: map[string]string{},
+			},
+			Spec: corev1.PodSpec{
+				Priority: pointer.Int32(priority),
+			},
+		},
+		usage:      usage,
+		hasUsage:   true,
+		usageRatio: usageRatio,
+	}
+}
+
+func getVictimNames(victims []*victimInfo) []string {
+	var names []string
+	for _, v := range victims {
+		names = append(names, v.pod.Name)
+	}
+	return names
+}
+
+func Test_newVictimSorters(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		policy     slov1alpha1.EvictionVictimPolicy
+		newVictims func() []*victimInfo
+		pdbs       []*policyv1.PodDisruptionBudget
+		disablePDB bool
+		want       []string
+	}{
+		{
+			name:   "default policy sorts by priority and usage",
+			policy: "",
+			newVictims: func() []*victimInfo {
+				noUsage := newTestVictim("pod-d", 100, 0, 0)
+				noUsage.hasUsage = false
+				return []*victimInfo{
+					newTestVictim("pod-a", 100, 10, 0.1),
+					newTestVictim("pod-b", 100, 20, 0.2),
+					newTestVictim("pod-c", 50, 1, 0.5),
+					noUsage,
+				}
+			},
+			want: []string{"pod-c", "pod-b", "pod-a", "pod-d"},
+		},
+		{
+			name:   "eviction cost policy",
+			policy: slov1alpha1.EvictionVictimPolicyEvictionCost,
+			newVictims: func() []*victimInfo {
+				a := newTestVictim("pod-a", 100, 10, 0.1)
+				a.pod.Annotations[apiext.AnnotationEvictionCost] = "-10"
+				b := newTestVictim("pod-b", 100, 20, 0.2)
+				b.pod.Annotations[apiext.AnnotationEvictionCost] = "100"
+				c := newTestVictim("pod-c", 100, 5, 0.5)
+				return []*victimInfo{a, b, c}
+			},
+			want: []string{"pod-a", "pod-c", "pod-b"},
+		},
+		{
+			name:   "running time policy evicts the youngest first",
+			policy: slov1alpha1.EvictionVictimPolicyRunningTime,
+			newVictims: func() []*victimInfo {
+				a := newTestVictim("pod-a", 100, 30, 0.1)
+				a.pod.Status.StartTime = &metav1.Time{Time: now.Add(-10 * time.Hour)}
+				b := newTestVictim("pod-b", 100, 20, 0.2)
+				b.pod.Status.StartTime = &metav1.Time{Time: now.Add(-time.Minute)}
+				c := newTestVictim("pod-c", 100, 10, 0.5)
+				d := newTestVictim("pod-d", 0, 10, 0.5)
+				d.pod.Status.StartTime = &metav1.Time{Time: now.Add(-20 * time.Hour)}
+				return []*victimInfo{a, b, c, d}
+			},
+			want: []string{"pod-d", "pod-c", "pod-b", "pod-a"},
+		},
+		{
+			name:   "usage ratio policy",
+			policy: slov1alpha1.EvictionVictimPolicyUsageRatio,
+			newVictims: func() []*victimInfo {
+				return []*victimInfo{
+					newTestVictim("pod-a", 100, 30, 0.5),
+					newTestVictim("pod-b", 100, 20, 2),
+					newTestVictim("pod-c", 100, 10, 1),
+				}
+			},
+			want: []string{"pod-b", "pod-c", "pod-a"},
+		},
+		{
+			name:   "pdb headroom policy",
+			policy: slov1alpha1.EvictionVictimPolicyPDBHeadroom,
+			newVictims: func() []*victimInfo {
+				return []*victimInfo{
+					newTestVictim("pod-a", 100, 30, 0.5),
+					newTestVictim("pod-b", 100, 20, 2),
+					newTestVictim("pod-c", 100, 10, 1),
+				}
+			},
+			pdbs: []*policyv1.PodDisruptionBudget{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pdb-a", Namespace: "default"},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "pod-a"}},
+					},
+					Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pdb-b", Namespace: "default"},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "pod-b"}},
+					},
+					Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 2},
+				},
+			},
+			want: []string{"pod-c", "pod-b", "pod-a"},
+		},
+		{
+			name:   "pdb headroom policy with empty selector covering all pods",
+			policy: slov1alpha1.EvictionVictimPolicyPDBHeadroom,
+			newVictims: func() []*victimInfo {
+				return []*victimInfo{
+					newTestVictim("pod-a", 100, 30, 0.5),
+					newTestVictim("pod-b", 100, 10, 2),
+					newTestVictim("pod-c", 100, 20, 1),
+				}
+			},
+			pdbs: []*policyv1.PodDisruptionBudget{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pdb-a", Namespace: "default"},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "pod-a"}},
+					},
+					Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pdb-all", Namespace: "default"},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{},
+					},
+					Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+				},
+			},
+			want: []string{"pod-c", "pod-b", "pod-a"},
+		},
+		{
+			name:       "pdb headroom policy falls back to the default when the feature gate is disabled",
+			policy:     slov1alpha1.EvictionVictimPolicyPDBHeadroom,
+			disablePDB: true,
+			newVictims: func() []*victimInfo {
+				return []*victimInfo{
+					newTestVictim("pod-a", 100, 30, 0.5),
+					newTestVictim("pod-b", 100, 20, 2),
+					newTestVictim("pod-c", 100, 10, 1),
+				}
+			},
+			pdbs: []*policyv1.PodDisruptionBudget{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pdb-a", Namespace: "default"},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "pod-a"}},
+					},
+					Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+				},
+			},
+			want: []string{"pod-a", "pod-b", "pod-c"},
+		},
+		{
+			name:   "unknown policy falls back to the default",
+			policy: "Unknown",
+			newVictims: func() []*victimInfo {
+				return []*victimInfo{
+					newTestVictim("pod-a", 100, 10, 2),
+					newTestVictim("pod-b", 100, 20, 1),
+				}
+			},
+			want: []string{"pod-b", "pod-a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			si := mock_statesinformer.NewMockStatesInformer(ctl)
+			si.EXPECT().GetPodDisruptionBudgets("default").Return(tt.pdbs).AnyTimes()
+			err := features.DefaultMutableKoordletFeatureGate.SetFromMap(map[string]bool{
+				string(features.PDBHeadroomVictimPolicy): !tt.disablePDB,
+			})
+			assert.NoError(t, err)
+			defer features.DefaultMutableKoordletFeatureGate.SetFromMap(map[string]bool{
+				string(features.PDBHeadroomVictimPolicy): false,
+			})
+			victims := tt.newVictims()
+			var pods []*corev1.Pod
+			for _, v := range victims {
+				pods = append(pods, v.pod)
+			}
+
+			sorters := newVictimSorters(si, tt.policy, pods)
+			sort.Slice(victims, func(i, j int) bool {
+				return sorters.Less(victims[i], victims[j])
+			})
+			assert.Equal(t, tt.want, getVictimNames(victims))
+		})
+	}
+}
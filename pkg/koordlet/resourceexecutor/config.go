@@ -25,8 +25,10 @@ const (
 
 	EvictPodByNodeMemoryUsage   = "EvictPodByNodeMemoryUsage"
 	EvictPodByBECPUSatisfaction = "EvictPodByBECPUSatisfaction"
+	EvictPodByGPUMemoryUsage    = "EvictPodByGPUMemoryUsage"
 
-	AdjustBEByNodeCPUUsage = "AdjustBEByNodeCPUUsage"
+	AdjustBEByNodeCPUUsage    = "AdjustBEByNodeCPUUsage"
+	AdjustBEByNodeMemoryUsage = "AdjustBEByNodeMemoryUsage"
 )
 
 var Conf = NewDefaultConfig()
@@ -37,6 +37,7 @@ type CgroupReader interface {
 	ReadMemoryStat(parentDir string) (*sysutil.MemoryStatRaw, error)
 	ReadCPUTasks(parentDir string) ([]int32, error)
 	ReadPSI(parentDir string) (*sysutil.PSIByResource, error)
+	ReadBlkIOStat(parentDir string) (*sysutil.BlkIOStatRaw, error)
 }
 
 var _ CgroupReader = &CgroupV1Reader{}
@@ -179,6 +180,36 @@ func (r *CgroupV1Reader) ReadCPUTasks(parentDir string) ([]int32, error) {
 	return sysutil.ReadCgroupAndParseInt32Slice(parentDir, resource)
 }
 
+func (r *CgroupV1Reader) ReadBlkIOStat(parentDir string) (*sysutil.BlkIOStatRaw, error) {
+	bytesResource, ok := sysutil.DefaultRegistry.Get(sysutil.CgroupVersionV1, sysutil.BlkioIOServiceBytesRecursiveName)
+	if !ok {
+		return nil, ErrResourceNotRegistered
+	}
+	iosResource, ok := sysutil.DefaultRegistry.Get(sysutil.CgroupVersionV1, sysutil.BlkioIOServicedRecursiveName)
+	if !ok {
+		return nil, ErrResourceNotRegistered
+	}
+	// content: `253:16 Read 4096\n253:16 Write 8192\n253:16 Sync 0\n253:16 Async 12288\n253:16 Total 12288\nTotal 12288\n`
+	s, err := sysutil.CgroupFileRead(parentDir, bytesResource)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read cgroup file, err: %v", err)
+	}
+	stat := &sysutil.BlkIOStatRaw{}
+	stat.ReadBytes, stat.WriteBytes, err = sysutil.ParseBlkioThrottleStat(s)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse cgroup value %s, err: %v", s, err)
+	}
+	s, err = sysutil.CgroupFileRead(parentDir, iosResource)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read cgroup file, err: %v", err)
+	}
+	stat.ReadIOs, stat.WriteIOs, err = sysutil.ParseBlkioThrottleStat(s)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse cgroup value %s, err: %v", s, err)
+	}
+	return stat, nil
+}
+
 var _ CgroupReader = &CgroupV2Reader{}
 
 type CgroupV2Reader struct{}
@@ -348,6 +379,23 @@ func (r *CgroupV2Reader) ReadPSI(parentDir string) (*sysutil.PSIByResource, erro
 	return psi, nil
 }
 
+func (r *CgroupV2Reader) ReadBlkIOStat(parentDir string) (*sysutil.BlkIOStatRaw, error) {
+	resource, ok := sysutil.DefaultRegistry.Get(sysutil.CgroupVersionV2, sysutil.IOStatName)
+	if !ok {
+		return nil, ErrResourceNotRegistered
+	}
+	s, err := sysutil.CgroupFileRead(parentDir, resource)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read cgroup file, err: %v", err)
+	}
+	// content: `253:16 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0\n...`
+	v, err := sysutil.ParseIOStatV2(s)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse cgroup value %s, err: %v", s, err)
+	}
+	return v, nil
+}
+
 func NewCgroupReader() CgroupReader {
 	if sysutil.GetCurrentCgroupVersion() == sysutil.CgroupVersionV2 {
 		return &CgroupV2Reader{}
@@ -1208,3 +1208,136 @@ func TestCgroupReader_ReadPSI(t *testing.T) {
 		})
 	}
 }
+
+func TestCgroupReader_ReadBlkIOStat(t *testing.T) {
+	type fields struct {
+		UseCgroupsV2  bool
+		BytesValue    string
+		ServicedValue string
+		IOStatV2Value string
+	}
+	type args struct {
+		parentDir string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *sysutil.BlkIOStatRaw
+		wantErr bool
+	}{
+		{
+			name:   "v1 path not exist",
+			fields: fields{},
+			args: args{
+				parentDir: "/kubepods.slice",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "parse v1 value successfully",
+			fields: fields{
+				BytesValue: `253:16 Read 4096
+253:16 Write 8192
+253:16 Sync 0
+253:16 Async 12288
+253:16 Total 12288
+253:0 Read 1024
+253:0 Write 0
+253:0 Total 1024
+Total 13312`,
+				ServicedValue: `253:16 Read 1
+253:16 Write 2
+253:16 Total 3
+253:0 Read 1
+253:0 Write 0
+253:0 Total 1
+Total 4`,
+			},
+			args: args{
+				parentDir: "/kubepods.slice",
+			},
+			want: &sysutil.BlkIOStatRaw{
+				ReadBytes:  5120,
+				WriteBytes: 8192,
+				ReadIOs:    2,
+				WriteIOs:   2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "parse v1 value failed",
+			fields: fields{
+				BytesValue:    `253:16 Read abc`,
+				ServicedValue: `253:16 Read 1`,
+			},
+			args: args{
+				parentDir: "/kubepods.slice",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "v2 path not exist",
+			fields: fields{
+				UseCgroupsV2: true,
+			},
+			args: args{
+				parentDir: "/kubepods.slice",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "parse v2 value successfully",
+			fields: fields{
+				UseCgroupsV2: true,
+				IOStatV2Value: `253:16 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0
+253:0 rbytes=1024 wbytes=0 rios=1 wios=0 dbytes=0 dios=0`,
+			},
+			args: args{
+				parentDir: "/kubepods.slice",
+			},
+			want: &sysutil.BlkIOStatRaw{
+				ReadBytes:  5120,
+				WriteBytes: 8192,
+				ReadIOs:    2,
+				WriteIOs:   2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "parse v2 value failed",
+			fields: fields{
+				UseCgroupsV2:  true,
+				IOStatV2Value: `253:16 rbytes=abc`,
+			},
+			args: args{
+				parentDir: "/kubepods.slice",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helper := sysutil.NewFileTestUtil(t)
+			defer helper.Cleanup()
+			helper.SetCgroupsV2(tt.fields.UseCgroupsV2)
+			if tt.fields.BytesValue != "" {
+				helper.WriteCgroupFileContents(tt.args.parentDir, sysutil.BlkioIOServiceBytesRecursive, tt.fields.BytesValue)
+			}
+			if tt.fields.ServicedValue != "" {
+				helper.WriteCgroupFileContents(tt.args.parentDir, sysutil.BlkioIOServicedRecursive, tt.fields.ServicedValue)
+			}
+			if tt.fields.IOStatV2Value != "" {
+				helper.WriteCgroupFileContents(tt.args.parentDir, sysutil.IOStatV2, tt.fields.IOStatV2Value)
+			}
+
+			got, gotErr := NewCgroupReader().ReadBlkIOStat(tt.args.parentDir)
+			assert.Equal(t, tt.wantErr, gotErr != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -52,13 +52,16 @@ func init() {
 		sysutil.MemoryPriorityName,
 		sysutil.MemoryUsePriorityOomName,
 		sysutil.MemoryOomGroupName,
+	)
+	// special cases
+	DefaultCgroupUpdaterFactory.Register(NewCPUSharesCgroupUpdater, sysutil.CPUSharesName)
+	DefaultCgroupUpdaterFactory.Register(NewBlkioThrottleCgroupUpdater,
 		sysutil.BlkioTRIopsName,
 		sysutil.BlkioTRBpsName,
 		sysutil.BlkioTWIopsName,
 		sysutil.BlkioTWBpsName,
 	)
-	// special cases
-	DefaultCgroupUpdaterFactory.Register(NewCPUSharesCgroupUpdater, sysutil.CPUSharesName)
+	DefaultCgroupUpdaterFactory.Register(NewBlkioWeightCgroupUpdater, sysutil.BlkioWeightName)
 	DefaultCgroupUpdaterFactory.Register(NewMergeableCgroupUpdaterIfValueLarger,
 		sysutil.MemoryMinName,
 		sysutil.MemoryLowName,
@@ -66,6 +69,7 @@ func init() {
 	)
 	DefaultCgroupUpdaterFactory.Register(NewMergeableCgroupUpdaterIfCPUSetLooser,
 		sysutil.CPUSetCPUSName,
+		sysutil.CPUSetMemsName,
 	)
 }
 
@@ -255,6 +259,14 @@ func NewCPUSharesCgroupUpdater(resourceType sysutil.ResourceType, parentDir stri
 	return NewCgroupUpdater(resourceType, parentDir, value, CgroupUpdateCPUSharesFunc)
 }
 
+func NewBlkioThrottleCgroupUpdater(resourceType sysutil.ResourceType, parentDir string, value string) (ResourceUpdater, error) {
+	return NewCgroupUpdater(resourceType, parentDir, value, CgroupUpdateBlkioThrottleFunc)
+}
+
+func NewBlkioWeightCgroupUpdater(resourceType sysutil.ResourceType, parentDir string, value string) (ResourceUpdater, error) {
+	return NewCgroupUpdater(resourceType, parentDir, value, CgroupUpdateBlkioWeightFunc)
+}
+
 func NewMergeableCgroupUpdaterWithCondition(resourceType sysutil.ResourceType, parentDir string, value string, mergeCondition MergeConditionFunc) (ResourceUpdater, error) {
 	r, err := sysutil.GetCgroupResource(resourceType)
 	if err != nil {
@@ -362,6 +374,36 @@ func CgroupUpdateCPUSharesFunc(resource ResourceUpdater) error {
 	return sysutil.CgroupFileWriteIfDifferent(c.parentDir, c.file, c.value)
 }
 
+func CgroupUpdateBlkioThrottleFunc(resource ResourceUpdater) error {
+	c := resource.(*CgroupResourceUpdater)
+	// convert values in `blkio.throttle.*` (v1) like "253:0 1048576" into values in `io.max` (v2) like "253:0 rbps=1048576"
+	if sysutil.GetCurrentCgroupVersion() == sysutil.CgroupVersionV2 {
+		v, err := sysutil.ConvertBlkioThrottleToIOMax(c.file.ResourceType(), c.value)
+		if err != nil {
+			return err
+		}
+		c.value = v
+	}
+	_ = audit.V(5).Reason(ReasonUpdateCgroups).Message("update %v to %v", resource.Path(), resource.Value()).Do()
+	// NOTE: the throttle files contain the limits of all devices, so the write cannot be skipped by comparing with
+	//       the current content.
+	return sysutil.CgroupFileWrite(c.parentDir, c.file, c.value)
+}
+
+func CgroupUpdateBlkioWeightFunc(resource ResourceUpdater) error {
+	c := resource.(*CgroupResourceUpdater)
+	// convert values in `blkio.weight` (v1) into values in `io.weight` (v2)
+	if sysutil.GetCurrentCgroupVersion() == sysutil.CgroupVersionV2 {
+		v, err := sysutil.ConvertBlkioWeightToIOWeight(c.value)
+		if err != nil {
+			return err
+		}
+		c.value = strconv.FormatInt(v, 10)
+	}
+	_ = audit.V(5).Reason(ReasonUpdateCgroups).Message("update %v to %v", resource.Path(), resource.Value()).Do()
+	return sysutil.CgroupFileWriteIfDifferent(c.parentDir, c.file, c.value)
+}
+
 type MergeConditionFunc func(oldValue, newValue string) (mergedValue string, needMerge bool, err error)
 
 func MergeFuncUpdateCgroup(resource ResourceUpdater, mergeCondition MergeConditionFunc) (ResourceUpdater, error) {
@@ -179,6 +179,77 @@ func TestCgroupResourceUpdater_Update(t *testing.T) {
 	}
 }
 
+func TestBlkioCgroupUpdater_Update(t *testing.T) {
+	tests := []struct {
+		name         string
+		useCgroupsV2 bool
+		initialValue string
+		resourceType sysutil.ResourceType
+		value        string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "update blkio throttle on cgroups-v1",
+			initialValue: "",
+			resourceType: sysutil.BlkioTRBpsName,
+			value:        "253:0 1048576",
+			want:         "253:0 1048576",
+		},
+		{
+			name:         "update io.max on cgroups-v2",
+			useCgroupsV2: true,
+			initialValue: "",
+			resourceType: sysutil.BlkioTWIopsName,
+			value:        "253:0 0",
+			want:         "253:0 wiops=max",
+		},
+		{
+			name:         "update blkio weight on cgroups-v1",
+			initialValue: "500",
+			resourceType: sysutil.BlkioWeightName,
+			value:        "1000",
+			want:         "1000",
+		},
+		{
+			name:         "update io.weight on cgroups-v2",
+			useCgroupsV2: true,
+			initialValue: "100",
+			resourceType: sysutil.BlkioWeightName,
+			value:        "1000",
+			want:         "200",
+		},
+		{
+			name:         "failed to update invalid blkio weight",
+			useCgroupsV2: true,
+			initialValue: "100",
+			resourceType: sysutil.BlkioWeightName,
+			value:        "1",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helper := sysutil.NewFileTestUtil(t)
+			defer helper.Cleanup()
+			helper.SetCgroupsV2(tt.useCgroupsV2)
+			parentDir := "/kubepods.slice/kubepods.slice-podxxx"
+
+			u, gotErr := DefaultCgroupUpdaterFactory.New(tt.resourceType, parentDir, tt.value)
+			assert.NoError(t, gotErr)
+			c, ok := u.(*CgroupResourceUpdater)
+			assert.True(t, ok)
+			helper.WriteCgroupFileContents(parentDir, c.file, tt.initialValue)
+
+			gotErr = u.Update()
+			assert.Equal(t, tt.wantErr, gotErr != nil, gotErr)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, helper.ReadCgroupFileContents(parentDir, c.file))
+			}
+		})
+	}
+}
+
 func TestDefaultResourceUpdater_Update(t *testing.T) {
 	type fields struct {
 		initialValue string
@@ -25,7 +25,9 @@ import (
 
 	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks/batchresource"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks/blkio"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks/cpuset"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks/device"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks/gpu"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks/groupidentity"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
@@ -58,6 +60,18 @@ const (
 	//
 	// BatchResource set request and limits of cpu and memory on cgroup file.
 	BatchResource featuregate.Feature = "BatchResource"
+
+	// owner: @koordinator-sh
+	// alpha: v1.1
+	//
+	// BlkIOQOS set blkio weight and throttling limits of pods according to QoS.
+	BlkIOQOS featuregate.Feature = "BlkIOQOS"
+
+	// owner: @koordinator-sh
+	// alpha: v1.1
+	//
+	// DeviceInject injects the RDMA and FPGA devices allocated by koord-scheduler into containers.
+	DeviceInject featuregate.Feature = "DeviceInject"
 )
 
 var (
@@ -66,6 +80,8 @@ var (
 		CPUSetAllocator: {Default: true, PreRelease: featuregate.Beta},
 		GPUEnvInject:    {Default: false, PreRelease: featuregate.Alpha},
 		BatchResource:   {Default: true, PreRelease: featuregate.Beta},
+		BlkIOQOS:        {Default: false, PreRelease: featuregate.Alpha},
+		DeviceInject:    {Default: false, PreRelease: featuregate.Alpha},
 	}
 
 	runtimeHookPlugins = map[featuregate.Feature]HookPlugin{
@@ -73,6 +89,8 @@ var (
 		CPUSetAllocator: cpuset.Object(),
 		GPUEnvInject:    gpu.Object(),
 		BatchResource:   batchresource.Object(),
+		BlkIOQOS:        blkio.Object(),
+		DeviceInject:    device.Object(),
 	}
 )
 
@@ -84,6 +102,9 @@ type Config struct {
 	RuntimeHookConfigFilePath       string
 	RuntimeHookHostEndpoint         string
 	RuntimeHookDisableStages        []string
+	RuntimeHooksNRI                 bool
+	RuntimeHooksNRISocketPath       string
+	GPUIsolationProvider            string
 	FeatureGates                    map[string]bool // Deprecated
 }
 
@@ -96,6 +117,9 @@ func NewDefaultConfig() *Config {
 		RuntimeHookConfigFilePath:       system.Conf.RuntimeHooksConfigDir,
 		RuntimeHookHostEndpoint:         "/var/run/koordlet/koordlet.sock",
 		RuntimeHookDisableStages:        []string{},
+		RuntimeHooksNRI:                 false,
+		RuntimeHooksNRISocketPath:       "/var/run/nri/nri.sock",
+		GPUIsolationProvider:            gpu.IsolationProviderNone,
 		FeatureGates:                    map[string]bool{},
 	}
 }
@@ -108,6 +132,9 @@ func (c *Config) InitFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.RuntimeHookConfigFilePath, "runtime-hooks-config-path", c.RuntimeHookConfigFilePath, "config file path for runtime hooks")
 	fs.StringVar(&c.RuntimeHookHostEndpoint, "runtime-hooks-host-endpoint", c.RuntimeHookHostEndpoint, "host endpoint of runtime proxy")
 	fs.Var(cliflag.NewStringSlice(&c.RuntimeHookDisableStages), "runtime-hooks-disable-stages", "disable stages for runtime hooks")
+	fs.BoolVar(&c.RuntimeHooksNRI, "runtime-hooks-nri", c.RuntimeHooksNRI, "register runtime hooks as a containerd NRI plugin, which should not be enabled together with runtime proxy")
+	fs.StringVar(&c.RuntimeHooksNRISocketPath, "runtime-hooks-nri-socket-path", c.RuntimeHooksNRISocketPath, "socket path of containerd NRI")
+	fs.StringVar(&c.GPUIsolationProvider, "gpu-isolation-provider", c.GPUIsolationProvider, "provider to limit the core and memory of shared gpus inside the container, e.g. none, hami-core")
 	fs.Var(cliflag.NewMapStringBool(&c.FeatureGates), "runtime-hooks", "Deprecated because all settings have been moved to --feature-gates parameters")
 }
 
@@ -34,6 +34,9 @@ func Test_NewDefaultConfig(t *testing.T) {
 		RuntimeHookConfigFilePath:       system.Conf.RuntimeHooksConfigDir,
 		RuntimeHookHostEndpoint:         "/var/run/koordlet/koordlet.sock",
 		RuntimeHookDisableStages:        []string{},
+		RuntimeHooksNRI:                 false,
+		RuntimeHooksNRISocketPath:       "/var/run/nri/nri.sock",
+		GPUIsolationProvider:            "none",
 		FeatureGates:                    map[string]bool{},
 	}
 	defaultConfig := NewDefaultConfig()
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blkio
+
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+
+	ext "github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/reconciler"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/rule"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	sysutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+	rmconfig "github.com/koordinator-sh/koordinator/pkg/runtimeproxy/config"
+)
+
+const (
+	name        = "BlkIOQOS"
+	description = "set blkio weight and throttling limits by qos class"
+)
+
+type blkIOPlugin struct {
+	rule         *blkIORule
+	ruleRWMutex  sync.RWMutex
+	sysSupported *bool
+}
+
+func (b *blkIOPlugin) Register() {
+	klog.V(5).Infof("register hook %v", name)
+	hooks.Register(rmconfig.PreRunPodSandbox, name, description, b.SetPodBlkIO)
+	rule.Register(name, description,
+		rule.WithParseFunc(statesinformer.RegisterTypeNodeSLOSpec, b.parseRule),
+		rule.WithUpdateCallback(b.ruleUpdateCb),
+		rule.WithSystemSupported(b.SystemSupported))
+	reconciler.RegisterCgroupReconciler(reconciler.PodLevel, sysutil.BlkioReadBps, "reconcile pod level blkio qos",
+		b.SetPodBlkIO, reconciler.NoneFilter())
+}
+
+// SystemSupported checks if the blkio throttling is supported by the kubepods cgroup, i.e. the blkio subsystem is
+// mounted on cgroups-v1, or the io controller is enabled on cgroups-v2.
+func (b *blkIOPlugin) SystemSupported() bool {
+	if b.sysSupported == nil {
+		isSupported, msg := false, "resource not found"
+		throttleResource, err := sysutil.GetCgroupResource(sysutil.BlkioTRBpsName)
+		if err == nil {
+			isSupported = sysutil.FileExists(throttleResource.Path(util.GetKubeQosRelativePath(corev1.PodQOSGuaranteed)))
+			if !isSupported {
+				msg = "file not exist"
+			}
+		}
+		b.sysSupported = pointer.Bool(isSupported)
+		klog.Infof("update system supported info to %v for plugin %v, supported msg %s",
+			*b.sysSupported, name, msg)
+	}
+	return *b.sysSupported
+}
+
+func (b *blkIOPlugin) SetPodBlkIO(p protocol.HooksProtocol) error {
+	if !b.SystemSupported() {
+		klog.V(5).Infof("plugin %s is not supported by system", name)
+		return nil
+	}
+	r := b.getRule()
+	if r == nil || !r.getEnable() {
+		klog.V(5).Infof("hook plugin rule is nil or disabled, nothing to do for plugin %v", name)
+		return nil
+	}
+	podCtx := p.(*protocol.PodContext)
+	req := podCtx.Request
+	podQOS := ext.GetQoSClassByAttrs(req.Labels, req.Annotations)
+	blkIO := r.getPodBlkIO(podQOS)
+	if blkIO == nil {
+		return nil
+	}
+	// the weight is not supported when the io scheduler of the block devices does not support proportional weight,
+	// so just keep the throttling limits in that case
+	if blkIO.Weight != nil && !isBlkIOWeightSupported(req.CgroupParent) {
+		klog.V(5).Infof("blkio weight is not supported for pod %s/%s, skip setting weight",
+			req.PodMeta.Namespace, req.PodMeta.Name)
+		blkIO.Weight = nil
+	}
+	podCtx.Response.Resources.BlkIO = blkIO
+	return nil
+}
+
+func isBlkIOWeightSupported(cgroupParent string) bool {
+	weightResource, err := sysutil.GetCgroupResource(sysutil.BlkioWeightName)
+	if err != nil {
+		return false
+	}
+	supported, _ := weightResource.IsSupported(cgroupParent)
+	return supported
+}
+
+var singleton *blkIOPlugin
+
+func Object() *blkIOPlugin {
+	if singleton == nil {
+		singleton = &blkIOPlugin{rule: &blkIORule{}}
+	}
+	return singleton
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blkio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	ext "github.com/koordinator-sh/koordinator/apis/extension"
+	runtimeapi "github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+func initBlkIOCgroups(dir string, helper *system.FileTestUtil, withWeight bool) {
+	if withWeight {
+		weightResource, _ := system.GetCgroupResource(system.BlkioWeightName)
+		helper.WriteCgroupFileContents(dir, weightResource, "500")
+	}
+	for _, resourceType := range []system.ResourceType{system.BlkioTRBpsName, system.BlkioTWBpsName,
+		system.BlkioTRIopsName, system.BlkioTWIopsName} {
+		r, _ := system.GetCgroupResource(resourceType)
+		helper.WriteCgroupFileContents(dir, r, "")
+	}
+}
+
+func Test_blkIOPlugin_SystemSupported(t *testing.T) {
+	kubeRootDir := util.GetKubeQosRelativePath(corev1.PodQOSGuaranteed)
+	tests := []struct {
+		name         string
+		useCgroupsV2 bool
+		initCgroups  bool
+		want         bool
+	}{
+		{
+			name:        "system support since blkio throttle file exist",
+			initCgroups: true,
+			want:        true,
+		},
+		{
+			name: "system not support since blkio throttle file not exist",
+			want: false,
+		},
+		{
+			name:         "system support since io.max exist (cgroups-v2)",
+			useCgroupsV2: true,
+			initCgroups:  true,
+			want:         true,
+		},
+		{
+			name:         "system not support since io.max not exist (cgroups-v2)",
+			useCgroupsV2: true,
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helper := system.NewFileTestUtil(t)
+			defer helper.Cleanup()
+			helper.SetCgroupsV2(tt.useCgroupsV2)
+			if tt.initCgroups {
+				initBlkIOCgroups(kubeRootDir, helper, false)
+			}
+			b := &blkIOPlugin{}
+			assert.Equal(t, tt.want, b.SystemSupported())
+		})
+	}
+}
+
+func Test_blkIOPlugin_Register(t *testing.T) {
+	t.Run("register blkio plugin", func(t *testing.T) {
+		b := &blkIOPlugin{}
+		b.Register()
+	})
+}
+
+func Test_blkIOPlugin_SetPodBlkIO(t *testing.T) {
+	testRule := &blkIORule{
+		enable: true,
+		podQOSParams: map[ext.QoSClass]*protocol.BlkIOResources{
+			ext.QoSLS: {
+				Weight: pointer.Int64(1000),
+			},
+			ext.QoSBE: {
+				Weight: pointer.Int64(100),
+				DeviceLimits: []protocol.BlkIODeviceLimit{
+					{
+						Device:   "253:0",
+						ReadBPS:  1048576,
+						WriteBPS: 1048576,
+					},
+				},
+			},
+		},
+	}
+	testBEPodCgroupParent := "/kubepods-besteffort.slice/kubepods-besteffort-pod123.slice"
+	tests := []struct {
+		name         string
+		useCgroupsV2 bool
+		sysSupported bool
+		withWeight   bool
+		rule         *blkIORule
+		podQOS       ext.QoSClass
+		want         *protocol.BlkIOResources
+		wantWeight   string
+		wantReadBPS  string
+	}{
+		{
+			name:         "skip since system not support",
+			sysSupported: false,
+			rule:         testRule,
+			podQOS:       ext.QoSBE,
+			want:         nil,
+		},
+		{
+			name:         "skip since rule is disabled",
+			sysSupported: true,
+			rule:         &blkIORule{},
+			podQOS:       ext.QoSBE,
+			want:         nil,
+		},
+		{
+			name:         "skip since qos has no params",
+			sysSupported: true,
+			rule:         testRule,
+			podQOS:       ext.QoSLSR,
+			want:         nil,
+		},
+		{
+			name:         "set be pod blkio",
+			sysSupported: true,
+			withWeight:   true,
+			rule:         testRule,
+			podQOS:       ext.QoSBE,
+			want:         testRule.podQOSParams[ext.QoSBE],
+			wantWeight:   "100",
+			wantReadBPS:  "253:0 1048576",
+		},
+		{
+			name:         "set be pod blkio without weight",
+			sysSupported: true,
+			withWeight:   false,
+			rule:         testRule,
+			podQOS:       ext.QoSBE,
+			want: &protocol.BlkIOResources{
+				DeviceLimits: testRule.podQOSParams[ext.QoSBE].DeviceLimits,
+			},
+			wantReadBPS: "253:0 1048576",
+		},
+		{
+			name:         "set be pod blkio on cgroups-v2",
+			useCgroupsV2: true,
+			sysSupported: true,
+			withWeight:   true,
+			rule:         testRule,
+			podQOS:       ext.QoSBE,
+			want:         testRule.podQOSParams[ext.QoSBE],
+			wantWeight:   "20",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helper := system.NewFileTestUtil(t)
+			defer helper.Cleanup()
+			helper.SetCgroupsV2(tt.useCgroupsV2)
+			initBlkIOCgroups(testBEPodCgroupParent, helper, tt.withWeight)
+
+			b := &blkIOPlugin{
+				rule:         tt.rule,
+				sysSupported: pointer.Bool(tt.sysSupported),
+			}
+			podCtx := &protocol.PodContext{}
+			podCtx.FromProxy(&runtimeapi.PodSandboxHookRequest{
+				PodMeta: &runtimeapi.PodSandboxMetadata{
+					Name:      "test-pod",
+					Namespace: "test-ns",
+				},
+				Labels: map[string]string{
+					ext.LabelPodQoS: string(tt.podQOS),
+				},
+				CgroupParent: testBEPodCgroupParent,
+			})
+			assert.NoError(t, b.SetPodBlkIO(podCtx))
+			assert.Equal(t, tt.want, podCtx.Response.Resources.BlkIO)
+
+			podCtx.ProxyDone(&runtimeapi.PodSandboxHookResponse{})
+			if tt.wantWeight != "" {
+				weightResource, _ := system.GetCgroupResource(system.BlkioWeightName)
+				assert.Equal(t, tt.wantWeight, helper.ReadCgroupFileContents(testBEPodCgroupParent, weightResource))
+			}
+			if tt.wantReadBPS != "" {
+				assert.Equal(t, tt.wantReadBPS, helper.ReadCgroupFileContents(testBEPodCgroupParent, system.BlkioReadBps))
+			}
+		})
+	}
+}
+
+func Test_blkIOPlugin_ruleUpdateCb(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	testPodMeta := &statesinformer.PodMeta{
+		Pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "be-pod",
+				Labels: map[string]string{
+					ext.LabelPodQoS: string(ext.QoSBE),
+				},
+			},
+			Status: corev1.PodStatus{
+				QOSClass: corev1.PodQOSBestEffort,
+			},
+		},
+		CgroupDir: "/kubepods-besteffort.slice/kubepods-test-be-pod.slice",
+	}
+	podCgroupDir := util.GetPodCgroupDirWithKube(testPodMeta.CgroupDir)
+	initBlkIOCgroups(podCgroupDir, helper, true)
+
+	b := &blkIOPlugin{
+		rule: &blkIORule{
+			enable: true,
+			podQOSParams: map[ext.QoSClass]*protocol.BlkIOResources{
+				ext.QoSBE: {
+					Weight: pointer.Int64(100),
+					DeviceLimits: []protocol.BlkIODeviceLimit{
+						{
+							Device:    "253:0",
+							WriteIOPS: 1000,
+						},
+					},
+				},
+			},
+		},
+		sysSupported: pointer.Bool(true),
+	}
+	assert.NoError(t, b.ruleUpdateCb([]*statesinformer.PodMeta{testPodMeta}))
+	assert.Equal(t, "100", helper.ReadCgroupFileContents(podCgroupDir, system.BlkioWeight))
+	assert.Equal(t, "253:0 1000", helper.ReadCgroupFileContents(podCgroupDir, system.BlkioWriteIops))
+	assert.Equal(t, "253:0 0", helper.ReadCgroupFileContents(podCgroupDir, system.BlkioReadIops))
+}
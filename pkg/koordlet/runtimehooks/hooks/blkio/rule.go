@@ -0,0 +1,210 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blkio
+
+import (
+	"reflect"
+
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+
+	ext "github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+	sysutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+const (
+	// defaultBlkIOWeight is the default value of `blkio.weight`, which is used to reset the weight of the pods
+	// after the io qos is disabled.
+	defaultBlkIOWeight int64 = 500
+)
+
+type blkIORule struct {
+	enable       bool
+	podQOSParams map[ext.QoSClass]*protocol.BlkIOResources
+}
+
+func (r *blkIORule) getEnable() bool {
+	if r == nil {
+		return false
+	}
+	return r.enable
+}
+
+// getPodBlkIO returns a copy of the blkio resources of the qos class, or nil if the qos class has no io qos.
+func (r *blkIORule) getPodBlkIO(podQoSClass ext.QoSClass) *protocol.BlkIOResources {
+	params, exist := r.podQOSParams[podQoSClass]
+	if !exist || params == nil {
+		return nil
+	}
+	out := &protocol.BlkIOResources{}
+	if params.Weight != nil {
+		out.Weight = pointer.Int64(*params.Weight)
+	}
+	if params.DeviceLimits != nil {
+		out.DeviceLimits = make([]protocol.BlkIODeviceLimit, len(params.DeviceLimits))
+		copy(out.DeviceLimits, params.DeviceLimits)
+	}
+	return out
+}
+
+func (b *blkIOPlugin) parseRule(mergedNodeSLOIf interface{}) (bool, error) {
+	mergedNodeSLO := mergedNodeSLOIf.(*slov1alpha1.NodeSLOSpec)
+	oldRule := b.getRule()
+
+	newRule := &blkIORule{
+		podQOSParams: map[ext.QoSClass]*protocol.BlkIOResources{},
+	}
+	for qos, resourceQOS := range map[ext.QoSClass]*slov1alpha1.ResourceQOS{
+		ext.QoSLSR: mergedNodeSLO.ResourceQOSStrategy.LSRClass,
+		ext.QoSLS:  mergedNodeSLO.ResourceQOSStrategy.LSClass,
+		ext.QoSBE:  mergedNodeSLO.ResourceQOSStrategy.BEClass,
+	} {
+		var ioQOS *slov1alpha1.IOQOSCfg
+		if resourceQOS != nil {
+			ioQOS = resourceQOS.IOQOS
+		}
+		var oldParams *protocol.BlkIOResources
+		if oldRule != nil {
+			oldParams = oldRule.podQOSParams[qos]
+		}
+		params := parseBlkIOResources(ioQOS, oldParams)
+		if params != nil {
+			newRule.podQOSParams[qos] = params
+			newRule.enable = true
+		}
+	}
+
+	updated := b.updateRule(newRule)
+	klog.Infof("runtime hook plugin %s update rule %v, new rule %+v", name, updated, newRule)
+	return updated, nil
+}
+
+// parseBlkIOResources generates the blkio resources of a qos class according to the io qos config.
+// Since the cgroups keep the limits after the config is removed, the weight and the limits set by the old params but
+// not in the new config are reset to the defaults.
+func parseBlkIOResources(ioQOS *slov1alpha1.IOQOSCfg, oldParams *protocol.BlkIOResources) *protocol.BlkIOResources {
+	params := &protocol.BlkIOResources{}
+	configuredDevices := map[string]struct{}{}
+	if ioQOS != nil && ioQOS.Enable != nil && *ioQOS.Enable {
+		if ioQOS.IOWeightPercent != nil {
+			params.Weight = pointer.Int64(calculateBlkIOWeight(*ioQOS.IOWeightPercent))
+		}
+		for _, block := range ioQOS.Blocks {
+			device, err := sysutil.GetBlockDeviceNumber(block.Device)
+			if err != nil {
+				klog.Warningf("failed to get device number of block %s for plugin %s, err: %v", block.Device, name, err)
+				continue
+			}
+			if _, ok := configuredDevices[device]; ok {
+				klog.V(4).Infof("skip duplicated block %s for plugin %s", block.Device, name)
+				continue
+			}
+			configuredDevices[device] = struct{}{}
+			params.DeviceLimits = append(params.DeviceLimits, protocol.BlkIODeviceLimit{
+				Device:    device,
+				ReadBPS:   getLimitValue(block.ReadBPS),
+				WriteBPS:  getLimitValue(block.WriteBPS),
+				ReadIOPS:  getLimitValue(block.ReadIOPS),
+				WriteIOPS: getLimitValue(block.WriteIOPS),
+			})
+		}
+	}
+
+	if oldParams != nil {
+		if params.Weight == nil && oldParams.Weight != nil && *oldParams.Weight != defaultBlkIOWeight {
+			params.Weight = pointer.Int64(defaultBlkIOWeight)
+		}
+		for _, oldLimit := range oldParams.DeviceLimits {
+			if _, ok := configuredDevices[oldLimit.Device]; ok || isUnlimited(oldLimit) {
+				continue
+			}
+			params.DeviceLimits = append(params.DeviceLimits, protocol.BlkIODeviceLimit{Device: oldLimit.Device})
+		}
+	}
+
+	if params.Weight == nil && len(params.DeviceLimits) <= 0 {
+		return nil
+	}
+	return params
+}
+
+// calculateBlkIOWeight maps the weight percent in [1, 100] into the value of `blkio.weight` in [10, 1000].
+func calculateBlkIOWeight(percent int64) int64 {
+	weight := percent * sysutil.BlkioWeightMaxValue / 100
+	if weight < sysutil.BlkioWeightMinValue {
+		weight = sysutil.BlkioWeightMinValue
+	} else if weight > sysutil.BlkioWeightMaxValue {
+		weight = sysutil.BlkioWeightMaxValue
+	}
+	return weight
+}
+
+func getLimitValue(limit *int64) int64 {
+	if limit == nil || *limit < 0 {
+		return 0
+	}
+	return *limit
+}
+
+func isUnlimited(limit protocol.BlkIODeviceLimit) bool {
+	return limit.ReadBPS == 0 && limit.WriteBPS == 0 && limit.ReadIOPS == 0 && limit.WriteIOPS == 0
+}
+
+func (b *blkIOPlugin) ruleUpdateCb(pods []*statesinformer.PodMeta) error {
+	if !b.SystemSupported() {
+		klog.V(5).Infof("plugin %s is not supported by system", name)
+		return nil
+	}
+	r := b.getRule()
+	if r == nil || !r.getEnable() {
+		klog.V(5).Infof("hook plugin rule is nil or disabled, nothing to do for plugin %v", name)
+		return nil
+	}
+	for _, podMeta := range pods {
+		podCtx := &protocol.PodContext{}
+		podCtx.FromReconciler(podMeta)
+		if err := b.SetPodBlkIO(podCtx); err != nil {
+			klog.V(4).Infof("failed to set pod blkio during callback %v, err: %v", name, err)
+			continue
+		}
+		podCtx.ReconcilerDone()
+	}
+	return nil
+}
+
+func (b *blkIOPlugin) getRule() *blkIORule {
+	b.ruleRWMutex.RLock()
+	defer b.ruleRWMutex.RUnlock()
+	if b.rule == nil {
+		return nil
+	}
+	rule := *b.rule
+	return &rule
+}
+
+func (b *blkIOPlugin) updateRule(newRule *blkIORule) bool {
+	b.ruleRWMutex.Lock()
+	defer b.ruleRWMutex.Unlock()
+	if !reflect.DeepEqual(newRule, b.rule) {
+		b.rule = newRule
+		return true
+	}
+	return false
+}
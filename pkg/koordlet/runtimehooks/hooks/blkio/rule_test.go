@@ -0,0 +1,188 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blkio
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	ext "github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+func Test_blkIOPlugin_parseRule(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	oldSysRootDir := system.Conf.SysRootDir
+	system.Conf.SysRootDir = filepath.Join(helper.TempDir, "sys")
+	defer func() {
+		system.Conf.SysRootDir = oldSysRootDir
+	}()
+	assert.NoError(t, os.MkdirAll(filepath.Join(system.Conf.SysRootDir, "block", "vda"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(system.Conf.SysRootDir, "block", "vda", "dev"), []byte("253:0\n"), 0644))
+
+	enabledBEIOQOS := util.DefaultResourceQOSStrategy()
+	enabledBEIOQOS.BEClass.IOQOS.Enable = pointer.Bool(true)
+	enabledBEIOQOS.BEClass.IOQOS.Blocks = []slov1alpha1.BlockIOCfg{
+		{
+			Device:   "vda",
+			ReadBPS:  pointer.Int64(1048576),
+			WriteBPS: pointer.Int64(2097152),
+		},
+		{
+			Device:    "253:16",
+			ReadIOPS:  pointer.Int64(100),
+			WriteIOPS: pointer.Int64(-1),
+		},
+		{
+			Device:   "vdx", // not exist
+			ReadIOPS: pointer.Int64(100),
+		},
+	}
+	tests := []struct {
+		name        string
+		oldRule     *blkIORule
+		strategy    *slov1alpha1.ResourceQOSStrategy
+		wantUpdated bool
+		wantRule    *blkIORule
+	}{
+		{
+			name:        "parse default rule",
+			oldRule:     &blkIORule{},
+			strategy:    util.DefaultResourceQOSStrategy(),
+			wantUpdated: true,
+			wantRule: &blkIORule{
+				podQOSParams: map[ext.QoSClass]*protocol.BlkIOResources{},
+			},
+		},
+		{
+			name:        "parse be rule enabled",
+			oldRule:     &blkIORule{},
+			strategy:    enabledBEIOQOS,
+			wantUpdated: true,
+			wantRule: &blkIORule{
+				enable: true,
+				podQOSParams: map[ext.QoSClass]*protocol.BlkIOResources{
+					ext.QoSBE: {
+						Weight: pointer.Int64(500),
+						DeviceLimits: []protocol.BlkIODeviceLimit{
+							{
+								Device:   "253:0",
+								ReadBPS:  1048576,
+								WriteBPS: 2097152,
+							},
+							{
+								Device:   "253:16",
+								ReadIOPS: 100,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reset the old limits after disabled",
+			oldRule: &blkIORule{
+				enable: true,
+				podQOSParams: map[ext.QoSClass]*protocol.BlkIOResources{
+					ext.QoSLS: {
+						Weight: pointer.Int64(1000),
+					},
+					ext.QoSBE: {
+						Weight: pointer.Int64(100),
+						DeviceLimits: []protocol.BlkIODeviceLimit{
+							{
+								Device:   "253:0",
+								ReadBPS:  1048576,
+								WriteBPS: 2097152,
+							},
+							{
+								Device: "253:16",
+							},
+						},
+					},
+				},
+			},
+			strategy:    util.DefaultResourceQOSStrategy(),
+			wantUpdated: true,
+			wantRule: &blkIORule{
+				enable: true,
+				podQOSParams: map[ext.QoSClass]*protocol.BlkIOResources{
+					ext.QoSLS: {
+						Weight: pointer.Int64(defaultBlkIOWeight),
+					},
+					ext.QoSBE: {
+						Weight: pointer.Int64(defaultBlkIOWeight),
+						DeviceLimits: []protocol.BlkIODeviceLimit{
+							{
+								Device: "253:0",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &blkIOPlugin{rule: tt.oldRule}
+			gotUpdated, gotErr := b.parseRule(&slov1alpha1.NodeSLOSpec{
+				ResourceQOSStrategy: tt.strategy,
+			})
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tt.wantUpdated, gotUpdated)
+			assert.Equal(t, tt.wantRule, b.getRule())
+		})
+	}
+}
+
+func Test_calculateBlkIOWeight(t *testing.T) {
+	assert.Equal(t, int64(1000), calculateBlkIOWeight(100))
+	assert.Equal(t, int64(500), calculateBlkIOWeight(50))
+	assert.Equal(t, int64(10), calculateBlkIOWeight(0))
+	assert.Equal(t, int64(1000), calculateBlkIOWeight(200))
+}
+
+func Test_blkIORule_getPodBlkIO(t *testing.T) {
+	r := &blkIORule{
+		enable: true,
+		podQOSParams: map[ext.QoSClass]*protocol.BlkIOResources{
+			ext.QoSBE: {
+				Weight: pointer.Int64(100),
+				DeviceLimits: []protocol.BlkIODeviceLimit{
+					{
+						Device:  "253:0",
+						ReadBPS: 1048576,
+					},
+				},
+			},
+		},
+	}
+	assert.Nil(t, r.getPodBlkIO(ext.QoSLS))
+	got := r.getPodBlkIO(ext.QoSBE)
+	assert.Equal(t, r.podQOSParams[ext.QoSBE], got)
+	// the returned value is a copy
+	got.DeviceLimits[0].ReadBPS = 0
+	assert.Equal(t, int64(1048576), r.podQOSParams[ext.QoSBE].DeviceLimits[0].ReadBPS)
+}
@@ -94,6 +94,12 @@ func (p *cpusetPlugin) SetContainerCPUSet(proto protocol.HooksProtocol) error {
 		return err
 	} else if cpusetVal != "" {
 		containerCtx.Response.Resources.CPUSet = pointer.StringPtr(cpusetVal)
+		// bind the memory to the NUMA Nodes of the allocated cpus if the scheduler specified
+		if cpusetMemsVal, err := util.GetCPUSetMemsFromPod(containerReq.PodAnnotations); err != nil {
+			return err
+		} else if cpusetMemsVal != "" {
+			containerCtx.Response.Resources.CPUSetMems = pointer.StringPtr(cpusetMemsVal)
+		}
 		return nil
 	}
 
@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/pointer"
 
 	ext "github.com/koordinator-sh/koordinator/apis/extension"
@@ -37,6 +39,14 @@ func getCPUSet(dirWithKube string, helper *system.FileTestUtil) string {
 	return helper.ReadCgroupFileContents(dirWithKube, system.CPUSet)
 }
 
+func initCPUSetMems(dirWithKube string, value string, helper *system.FileTestUtil) {
+	helper.WriteCgroupFileContents(dirWithKube, system.CPUSetMems, value)
+}
+
+func getCPUSetMems(dirWithKube string, helper *system.FileTestUtil) string {
+	return helper.ReadCgroupFileContents(dirWithKube, system.CPUSetMems)
+}
+
 func initCPUQuota(dirWithKube string, value string, helper *system.FileTestUtil) {
 	helper.WriteCgroupFileContents(dirWithKube, system.CPUCFSQuota, value)
 }
@@ -123,11 +133,12 @@ func Test_cpusetPlugin_SetContainerCPUSet(t *testing.T) {
 		proto    protocol.HooksProtocol
 	}
 	tests := []struct {
-		name       string
-		fields     fields
-		args       args
-		wantErr    bool
-		wantCPUSet *string
+		name           string
+		fields         fields
+		args           args
+		wantErr        bool
+		wantCPUSet     *string
+		wantCPUSetMems *string
 	}{
 		{
 			name: "set cpu with nil protocol",
@@ -176,6 +187,33 @@ func Test_cpusetPlugin_SetContainerCPUSet(t *testing.T) {
 			wantErr:    false,
 			wantCPUSet: pointer.StringPtr("2-4"),
 		},
+		{
+			name: "set cpu and mems by pod allocated",
+			fields: fields{
+				rule: nil,
+			},
+			args: args{
+				podAlloc: &ext.ResourceStatus{
+					CPUSet: "2-4",
+					NUMANodeResources: []ext.NUMANodeResource{
+						{
+							Node: 0,
+							Resources: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("3"),
+							},
+						},
+					},
+				},
+				proto: &protocol.ContainerContext{
+					Request: protocol.ContainerRequest{
+						CgroupParent: "kubepods/test-pod/test-container/",
+					},
+				},
+			},
+			wantErr:        false,
+			wantCPUSet:     pointer.StringPtr("2-4"),
+			wantCPUSetMems: pointer.StringPtr("0"),
+		},
 		{
 			name: "set cpu by pod allocated share pool with nil rule",
 			fields: fields{
@@ -275,6 +313,7 @@ func Test_cpusetPlugin_SetContainerCPUSet(t *testing.T) {
 			if tt.args.proto != nil {
 				containerCtx = tt.args.proto.(*protocol.ContainerContext)
 				initCPUSet(containerCtx.Request.CgroupParent, "", testHelper)
+				initCPUSetMems(containerCtx.Request.CgroupParent, "0-1", testHelper)
 				if tt.args.podAlloc != nil {
 					podAllocJson := util.DumpJSON(tt.args.podAlloc)
 					containerCtx.Request.PodAnnotations = map[string]string{
@@ -299,6 +338,13 @@ func Test_cpusetPlugin_SetContainerCPUSet(t *testing.T) {
 				gotCPUSet := getCPUSet(containerCtx.Request.CgroupParent, testHelper)
 				assert.Equal(t, *tt.wantCPUSet, gotCPUSet, "container cpuset should be equal")
 			}
+			if tt.wantCPUSetMems == nil {
+				assert.Nil(t, containerCtx.Response.Resources.CPUSetMems, "cpuset mems value should be nil")
+			} else {
+				assert.Equal(t, *tt.wantCPUSetMems, *containerCtx.Response.Resources.CPUSetMems, "container cpuset mems should be equal")
+				gotCPUSetMems := getCPUSetMems(containerCtx.Request.CgroupParent, testHelper)
+				assert.Equal(t, *tt.wantCPUSetMems, gotCPUSetMems, "container cpuset mems should be equal")
+			}
 		})
 	}
 }
@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package device
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	ext "github.com/koordinator-sh/koordinator/apis/extension"
+	runtimeapi "github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+	rmconfig "github.com/koordinator-sh/koordinator/pkg/runtimeproxy/config"
+)
+
+const (
+	RDMAAllocEnv = "KOORDINATOR_RDMA_VISIBLE_DEVICES"
+	FPGAAllocEnv = "KOORDINATOR_FPGA_VISIBLE_DEVICES"
+
+	devicePermissions = "rwm"
+)
+
+type devicePlugin struct{}
+
+func (p *devicePlugin) Register() {
+	klog.V(5).Infof("register hook %v", "device inject")
+	hooks.Register(rmconfig.PreCreateContainer, "device inject", "inject the allocated rdma and fpga devices into container", p.InjectContainerDevices)
+}
+
+var singleton *devicePlugin
+
+func Object() *devicePlugin {
+	if singleton == nil {
+		singleton = &devicePlugin{}
+	}
+	return singleton
+}
+
+// InjectContainerDevices injects the device nodes and the bus ids of the RDMA and FPGA devices allocated by
+// koord-scheduler into the container. The minor of the allocation is the index of the device in the sorted bus ids,
+// which is consistent with the devices reported by koordlet.
+func (p *devicePlugin) InjectContainerDevices(proto protocol.HooksProtocol) error {
+	containerCtx := proto.(*protocol.ContainerContext)
+	if containerCtx == nil {
+		return fmt.Errorf("container protocol is nil for plugin device")
+	}
+	containerReq := containerCtx.Request
+	alloc, err := ext.GetDeviceAllocations(containerReq.PodAnnotations)
+	if err != nil {
+		return err
+	}
+	if rdmaDevices := alloc[schedulingv1alpha1.RDMA]; len(rdmaDevices) > 0 {
+		if err := injectRDMADevices(containerCtx, rdmaDevices); err != nil {
+			return err
+		}
+	}
+	if fpgaDevices := alloc[schedulingv1alpha1.FPGA]; len(fpgaDevices) > 0 {
+		if err := injectFPGADevices(containerCtx, fpgaDevices); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func injectRDMADevices(containerCtx *protocol.ContainerContext, devices []*ext.DeviceAllocation) error {
+	pfBusIDs, err := system.GetRDMADeviceBusIDs()
+	if err != nil {
+		return fmt.Errorf("failed to get rdma devices, err: %v", err)
+	}
+	var busIDs []string
+	for _, d := range devices {
+		if int(d.Minor) >= len(pfBusIDs) || d.Minor < 0 {
+			return fmt.Errorf("rdma device %d not found on node", d.Minor)
+		}
+		extension, err := ext.GetDeviceAllocationExtension(d)
+		if err != nil {
+			return fmt.Errorf("failed to parse extension of rdma device %d, err: %v", d.Minor, err)
+		}
+		if extension != nil && len(extension.VirtualFunctions) > 0 {
+			for _, vf := range extension.VirtualFunctions {
+				busIDs = append(busIDs, vf.BusID)
+			}
+		} else {
+			busIDs = append(busIDs, pfBusIDs[d.Minor])
+		}
+	}
+
+	for _, busID := range busIDs {
+		uverbsDevices, err := system.GetRDMAUVerbsDevices(busID)
+		if err != nil {
+			return fmt.Errorf("failed to get uverbs devices of rdma device %s, err: %v", busID, err)
+		}
+		for _, devicePath := range uverbsDevices {
+			addContainerDevice(containerCtx, devicePath)
+		}
+	}
+	addContainerDevice(containerCtx, system.InfinibandRDMACMDevice)
+	addContainerEnv(containerCtx, RDMAAllocEnv, strings.Join(busIDs, ","))
+	klog.V(5).Infof("inject rdma devices %v into container %s/%s/%s", busIDs,
+		containerCtx.Request.PodMeta.Namespace, containerCtx.Request.PodMeta.Name, containerCtx.Request.ContainerMeta.Name)
+	return nil
+}
+
+func injectFPGADevices(containerCtx *protocol.ContainerContext, devices []*ext.DeviceAllocation) error {
+	allBusIDs, err := system.GetFPGADeviceBusIDs()
+	if err != nil {
+		return fmt.Errorf("failed to get fpga devices, err: %v", err)
+	}
+	var busIDs []string
+	for _, d := range devices {
+		if int(d.Minor) >= len(allBusIDs) || d.Minor < 0 {
+			return fmt.Errorf("fpga device %d not found on node", d.Minor)
+		}
+		busIDs = append(busIDs, allBusIDs[d.Minor])
+	}
+
+	for _, busID := range busIDs {
+		deviceNodes, err := system.GetFPGADeviceNodes(busID)
+		if err != nil {
+			return fmt.Errorf("failed to get device nodes of fpga device %s, err: %v", busID, err)
+		}
+		for _, devicePath := range deviceNodes {
+			addContainerDevice(containerCtx, devicePath)
+		}
+	}
+	addContainerEnv(containerCtx, FPGAAllocEnv, strings.Join(busIDs, ","))
+	klog.V(5).Infof("inject fpga devices %v into container %s/%s/%s", busIDs,
+		containerCtx.Request.PodMeta.Namespace, containerCtx.Request.PodMeta.Name, containerCtx.Request.ContainerMeta.Name)
+	return nil
+}
+
+func addContainerEnv(containerCtx *protocol.ContainerContext, key, value string) {
+	if containerCtx.Response.AddContainerEnvs == nil {
+		containerCtx.Response.AddContainerEnvs = make(map[string]string)
+	}
+	containerCtx.Response.AddContainerEnvs[key] = value
+}
+
+func addContainerDevice(containerCtx *protocol.ContainerContext, devicePath string) {
+	for _, device := range containerCtx.Response.AddContainerDevices {
+		if device.ContainerPath == devicePath {
+			return
+		}
+	}
+	containerCtx.Response.AddContainerDevices = append(containerCtx.Response.AddContainerDevices, &runtimeapi.LinuxDevice{
+		ContainerPath: devicePath,
+		HostPath:      devicePath,
+		Permissions:   devicePermissions,
+	})
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package device
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ext "github.com/koordinator-sh/koordinator/apis/extension"
+	runtimeapi "github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+func prepareRDMAAndFPGADevices(t *testing.T) {
+	tempDir := t.TempDir()
+	oldSysRootDir := system.Conf.SysRootDir
+	system.Conf.SysRootDir = tempDir
+	t.Cleanup(func() {
+		system.Conf.SysRootDir = oldSysRootDir
+	})
+
+	newPCIDevice := func(busID, class string) string {
+		deviceDir := filepath.Join(tempDir, system.SysPCIDevicesSubDir, busID)
+		assert.NoError(t, os.MkdirAll(deviceDir, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(deviceDir, system.SysPCIClassName), []byte(class+"\n"), 0644))
+		return deviceDir
+	}
+	newRDMADevice := func(name, busID, uverbs string) {
+		deviceDir := newPCIDevice(busID, "0x020000")
+		assert.NoError(t, os.MkdirAll(filepath.Join(deviceDir, system.SysPCIInfinibandVerbsDir, uverbs), 0755))
+		rdmaDir := filepath.Join(tempDir, system.SysInfinibandSubDir, name)
+		assert.NoError(t, os.MkdirAll(rdmaDir, 0755))
+		assert.NoError(t, os.Symlink(deviceDir, filepath.Join(rdmaDir, system.SysInfinibandDeviceName)))
+	}
+	newRDMADevice("mlx5_0", "0000:1f:00.0", "uverbs0")
+	newRDMADevice("mlx5_1", "0000:90:00.0", "uverbs1")
+	// the virtual function of mlx5_0
+	vfDir := newPCIDevice("0000:1f:00.2", "0x020000")
+	assert.NoError(t, os.MkdirAll(filepath.Join(vfDir, system.SysPCIInfinibandVerbsDir, "uverbs2"), 0755))
+	assert.NoError(t, os.Symlink("../0000:1f:00.0", filepath.Join(vfDir, system.SysPCIPhysFnName)))
+	newFPGADevice := func(busID, devName string) {
+		deviceDir := newPCIDevice(busID, "0x120000")
+		devDir := filepath.Join(deviceDir, "fpga_region", "region0", devName)
+		assert.NoError(t, os.MkdirAll(devDir, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(devDir, system.SysUEventName), []byte("DEVNAME="+devName+"\n"), 0644))
+	}
+	newFPGADevice("0000:af:00.0", "dfl-port.0")
+	newFPGADevice("0000:d8:00.0", "dfl-port.1")
+}
+
+func newDevice(path string) *runtimeapi.LinuxDevice {
+	return &runtimeapi.LinuxDevice{
+		ContainerPath: path,
+		HostPath:      path,
+		Permissions:   devicePermissions,
+	}
+}
+
+func Test_InjectContainerDevices(t *testing.T) {
+	prepareRDMAAndFPGADevices(t)
+	tests := []struct {
+		name            string
+		annotation      string
+		expectedErr     bool
+		expectedEnvs    map[string]string
+		expectedDevices []*runtimeapi.LinuxDevice
+	}{
+		{
+			name:       "no device allocated",
+			annotation: `{"gpu": [{"minor": 0}]}`,
+		},
+		{
+			name:       "allocate whole rdma devices",
+			annotation: `{"rdma": [{"minor": 0}, {"minor": 1}]}`,
+			expectedEnvs: map[string]string{
+				RDMAAllocEnv: "0000:1f:00.0,0000:90:00.0",
+			},
+			expectedDevices: []*runtimeapi.LinuxDevice{
+				newDevice("/dev/infiniband/uverbs0"),
+				newDevice("/dev/infiniband/uverbs1"),
+				newDevice(system.InfinibandRDMACMDevice),
+			},
+		},
+		{
+			name:       "allocate rdma virtual function and fpga",
+			annotation: `{"rdma": [{"minor": 0, "extension": {"vfs": [{"minor": 0, "busID": "0000:1f:00.2"}]}}], "fpga": [{"minor": 1}]}`,
+			expectedEnvs: map[string]string{
+				RDMAAllocEnv: "0000:1f:00.2",
+				FPGAAllocEnv: "0000:d8:00.0",
+			},
+			expectedDevices: []*runtimeapi.LinuxDevice{
+				newDevice("/dev/infiniband/uverbs2"),
+				newDevice(system.InfinibandRDMACMDevice),
+				newDevice("/dev/dfl-port.1"),
+			},
+		},
+		{
+			name:        "allocated rdma device not found",
+			annotation:  `{"rdma": [{"minor": 2}]}`,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			containerCtx := &protocol.ContainerContext{
+				Request: protocol.ContainerRequest{
+					PodAnnotations: map[string]string{
+						ext.AnnotationDeviceAllocated: tt.annotation,
+					},
+				},
+			}
+			err := Object().InjectContainerDevices(containerCtx)
+			assert.Equal(t, tt.expectedErr, err != nil)
+			if tt.expectedErr {
+				return
+			}
+			assert.Equal(t, tt.expectedEnvs, containerCtx.Response.AddContainerEnvs)
+			assert.Equal(t, tt.expectedDevices, containerCtx.Response.AddContainerDevices)
+		})
+	}
+}
@@ -31,13 +31,26 @@ import (
 
 const GpuAllocEnv = "NVIDIA_VISIBLE_DEVICES"
 
-type gpuPlugin struct{}
+type gpuPlugin struct {
+	isolationProvider IsolationProvider
+}
 
 func (p *gpuPlugin) Register() {
 	klog.V(5).Infof("register hook %v", "gpu env inject")
 	hooks.Register(rmconfig.PreCreateContainer, "gpu env inject", "inject NVIDIA_VISIBLE_DEVICES env into container", p.InjectContainerGPUEnv)
 }
 
+// SetIsolationProvider sets the provider which limits the shared GPUs inside the container.
+func (p *gpuPlugin) SetIsolationProvider(name string) error {
+	provider, err := getIsolationProvider(name)
+	if err != nil {
+		return err
+	}
+	p.isolationProvider = provider
+	klog.V(4).Infof("gpu isolation provider is set to %s", provider.Name())
+	return nil
+}
+
 var singleton *gpuPlugin
 
 func Object() *gpuPlugin {
@@ -70,5 +83,10 @@ func (p *gpuPlugin) InjectContainerGPUEnv(proto protocol.HooksProtocol) error {
 		containerCtx.Response.AddContainerEnvs = make(map[string]string)
 	}
 	containerCtx.Response.AddContainerEnvs[GpuAllocEnv] = strings.Join(gpuIDs, ",")
+	if p.isolationProvider != nil {
+		for k, v := range p.isolationProvider.GetContainerEnvs(devices) {
+			containerCtx.Response.AddContainerEnvs[k] = v
+		}
+	}
 	return nil
 }
@@ -77,3 +77,25 @@ func Test_InjectContainerGPUEnv(t *testing.T) {
 		}
 	}
 }
+
+func Test_InjectContainerGPUEnvWithIsolation(t *testing.T) {
+	plugin := gpuPlugin{}
+	assert.Error(t, plugin.SetIsolationProvider("unknown"))
+	assert.NoError(t, plugin.SetIsolationProvider(IsolationProviderHAMiCore))
+
+	containerCtx := &protocol.ContainerContext{
+		Request: protocol.ContainerRequest{
+			PodAnnotations: map[string]string{
+				ext.AnnotationDeviceAllocated: `{"gpu": [{"minor": 1, "resources": {"kubernetes.io/gpu-core": "50", "kubernetes.io/gpu-memory-ratio": "50", "kubernetes.io/gpu-memory": "8Gi"}}]}`,
+			},
+		},
+	}
+	err := plugin.InjectContainerGPUEnv(containerCtx)
+	assert.NoError(t, err)
+	expected := map[string]string{
+		GpuAllocEnv:                  "1",
+		"CUDA_DEVICE_MEMORY_LIMIT_0": "8192m",
+		"CUDA_DEVICE_SM_LIMIT":       "50",
+	}
+	assert.Equal(t, expected, containerCtx.Response.AddContainerEnvs)
+}
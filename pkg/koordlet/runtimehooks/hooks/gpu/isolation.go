@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpu
+
+import (
+	"fmt"
+	"strconv"
+
+	ext "github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+const (
+	// IsolationProviderNone only exposes the allocated GPUs to the container, the GPU core and memory are not limited.
+	IsolationProviderNone = "none"
+	// IsolationProviderHAMiCore limits the GPU core and memory by the environment variables of the HAMi-core
+	// interposer (libvgpu.so), which should be preloaded in the container.
+	IsolationProviderHAMiCore = "hami-core"
+
+	// HAMiCoreMemoryLimitEnvPrefix is the prefix of the per-device memory limit env, suffixed by the index in the visible devices.
+	HAMiCoreMemoryLimitEnvPrefix = "CUDA_DEVICE_MEMORY_LIMIT_"
+	// HAMiCoreSMLimitEnv is the env of the percent of streaming multiprocessors the container can use.
+	HAMiCoreSMLimitEnv = "CUDA_DEVICE_SM_LIMIT"
+)
+
+// IsolationProvider generates the container envs which limit the shared GPUs to the resources allocated by koord-scheduler.
+type IsolationProvider interface {
+	Name() string
+	GetContainerEnvs(devices []*ext.DeviceAllocation) map[string]string
+}
+
+var isolationProviders = map[string]IsolationProvider{
+	IsolationProviderNone:     &noneIsolationProvider{},
+	IsolationProviderHAMiCore: &hamiCoreIsolationProvider{},
+}
+
+func getIsolationProvider(name string) (IsolationProvider, error) {
+	if name == "" {
+		name = IsolationProviderNone
+	}
+	provider, ok := isolationProviders[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported gpu isolation provider %s", name)
+	}
+	return provider, nil
+}
+
+type noneIsolationProvider struct{}
+
+func (p *noneIsolationProvider) Name() string {
+	return IsolationProviderNone
+}
+
+func (p *noneIsolationProvider) GetContainerEnvs(devices []*ext.DeviceAllocation) map[string]string {
+	return nil
+}
+
+type hamiCoreIsolationProvider struct{}
+
+func (p *hamiCoreIsolationProvider) Name() string {
+	return IsolationProviderHAMiCore
+}
+
+func (p *hamiCoreIsolationProvider) GetContainerEnvs(devices []*ext.DeviceAllocation) map[string]string {
+	if !isSharedGPU(devices) {
+		return nil
+	}
+	envs := map[string]string{}
+	// HAMi-core only supports a single SM limit for all the visible devices, so use the minimum one.
+	smLimit := int64(-1)
+	for i, d := range devices {
+		if mem, ok := d.Resources[ext.GPUMemory]; ok && !mem.IsZero() {
+			envs[HAMiCoreMemoryLimitEnvPrefix+strconv.Itoa(i)] = fmt.Sprintf("%dm", mem.Value()/(1024*1024))
+		}
+		if core, ok := d.Resources[ext.GPUCore]; ok && (smLimit < 0 || core.Value() < smLimit) {
+			smLimit = core.Value()
+		}
+	}
+	if smLimit > 0 && smLimit < 100 {
+		envs[HAMiCoreSMLimitEnv] = strconv.FormatInt(smLimit, 10)
+	}
+	return envs
+}
+
+// isSharedGPU returns whether any of the allocated GPUs is not exclusively allocated.
+func isSharedGPU(devices []*ext.DeviceAllocation) bool {
+	for _, d := range devices {
+		core, coreOK := d.Resources[ext.GPUCore]
+		ratio, ratioOK := d.Resources[ext.GPUMemoryRatio]
+		if (coreOK && core.Value() < 100) || (ratioOK && ratio.Value() < 100) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	ext "github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+func Test_getIsolationProvider(t *testing.T) {
+	provider, err := getIsolationProvider("")
+	assert.NoError(t, err)
+	assert.Equal(t, IsolationProviderNone, provider.Name())
+
+	provider, err = getIsolationProvider(IsolationProviderHAMiCore)
+	assert.NoError(t, err)
+	assert.Equal(t, IsolationProviderHAMiCore, provider.Name())
+
+	_, err = getIsolationProvider("unknown")
+	assert.Error(t, err)
+}
+
+func Test_hamiCoreIsolationProvider_GetContainerEnvs(t *testing.T) {
+	tests := []struct {
+		name    string
+		devices []*ext.DeviceAllocation
+		want    map[string]string
+	}{
+		{
+			name: "exclusive gpu",
+			devices: []*ext.DeviceAllocation{
+				{
+					Minor: 0,
+					Resources: corev1.ResourceList{
+						ext.GPUCore:        resource.MustParse("100"),
+						ext.GPUMemoryRatio: resource.MustParse("100"),
+						ext.GPUMemory:      resource.MustParse("16Gi"),
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "shared gpu",
+			devices: []*ext.DeviceAllocation{
+				{
+					Minor: 1,
+					Resources: corev1.ResourceList{
+						ext.GPUCore:        resource.MustParse("50"),
+						ext.GPUMemoryRatio: resource.MustParse("25"),
+						ext.GPUMemory:      resource.MustParse("4Gi"),
+					},
+				},
+			},
+			want: map[string]string{
+				"CUDA_DEVICE_MEMORY_LIMIT_0": "4096m",
+				"CUDA_DEVICE_SM_LIMIT":       "50",
+			},
+		},
+		{
+			name: "shared gpu memory only",
+			devices: []*ext.DeviceAllocation{
+				{
+					Minor: 2,
+					Resources: corev1.ResourceList{
+						ext.GPUCore:        resource.MustParse("100"),
+						ext.GPUMemoryRatio: resource.MustParse("50"),
+						ext.GPUMemory:      resource.MustParse("8Gi"),
+					},
+				},
+			},
+			want: map[string]string{
+				"CUDA_DEVICE_MEMORY_LIMIT_0": "8192m",
+			},
+		},
+	}
+	p := &hamiCoreIsolationProvider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, p.GetContainerEnvs(tt.devices))
+		})
+	}
+}
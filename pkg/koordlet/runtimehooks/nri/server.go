@@ -0,0 +1,200 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nri
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/stub"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
+	rmconfig "github.com/koordinator-sh/koordinator/pkg/runtimeproxy/config"
+)
+
+const (
+	DefaultPluginName = "koordlet"
+	DefaultPluginIdx  = "00"
+
+	// reconnectInterval is the interval to reconnect NRI after the connection is lost, e.g. containerd restarts.
+	reconnectInterval = 5 * time.Second
+)
+
+// nriEventStages maps the subscribed NRI events to the runtime hook stages.
+var nriEventStages = map[api.Event]rmconfig.RuntimeHookType{
+	api.Event_RUN_POD_SANDBOX:  rmconfig.PreRunPodSandbox,
+	api.Event_CREATE_CONTAINER: rmconfig.PreCreateContainer,
+	api.Event_UPDATE_CONTAINER: rmconfig.PreUpdateContainerResources,
+}
+
+type Options struct {
+	SocketPath string
+	PluginName string
+	PluginIdx  string
+	// the NRI request fails if hooks failed with PolicyFail
+	FailurePolicy rmconfig.FailurePolicyType
+	// support stop running other hooks once someone failed
+	PluginFailurePolicy rmconfig.FailurePolicyType
+	DisableStages       map[string]struct{}
+}
+
+// NriServer registers koordlet as an NRI plugin, so the runtime hooks are called by containerd directly.
+type NriServer struct {
+	options Options
+	mask    stub.EventMask
+
+	lock    sync.Mutex
+	stub    stub.Stub
+	stopped bool
+}
+
+func NewNriServer(opt Options) (*NriServer, error) {
+	if opt.PluginName == "" {
+		opt.PluginName = DefaultPluginName
+	}
+	if opt.PluginIdx == "" {
+		opt.PluginIdx = DefaultPluginIdx
+	}
+	var mask stub.EventMask
+	for event, stage := range nriEventStages {
+		if _, ok := opt.DisableStages[string(stage)]; ok {
+			continue
+		}
+		mask.Set(event)
+	}
+	if mask == 0 {
+		return nil, fmt.Errorf("failed to create nri server, all stages are disabled")
+	}
+	return &NriServer{
+		options: opt,
+		mask:    mask,
+	}, nil
+}
+
+// Start connects to NRI in background and reconnects once the connection is lost until stopCh is closed.
+func (s *NriServer) Start(stopCh <-chan struct{}) {
+	klog.Infof("starting nri server on %s", s.options.SocketPath)
+	go wait.Until(s.run, reconnectInterval, stopCh)
+	go func() {
+		<-stopCh
+		s.Stop()
+	}()
+}
+
+func (s *NriServer) Stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stopped = true
+	if s.stub != nil {
+		klog.Infof("stopping nri server")
+		s.stub.Stop()
+	}
+}
+
+func (s *NriServer) run() {
+	s.lock.Lock()
+	if s.stopped {
+		s.lock.Unlock()
+		return
+	}
+	st, err := stub.New(s,
+		stub.WithPluginName(s.options.PluginName),
+		stub.WithPluginIdx(s.options.PluginIdx),
+		stub.WithSocketPath(s.options.SocketPath),
+		// the stub exits the process on connection lost by default
+		stub.WithOnClose(func() {
+			klog.Warningf("nri connection of plugin %s-%s is closed", s.options.PluginIdx, s.options.PluginName)
+		}),
+	)
+	if err != nil {
+		s.lock.Unlock()
+		klog.Errorf("failed to create nri plugin stub, error: %v", err)
+		return
+	}
+	s.stub = st
+	s.lock.Unlock()
+
+	if err = st.Run(context.Background()); err != nil {
+		klog.Errorf("nri plugin %s-%s exited, error: %v", s.options.PluginIdx, s.options.PluginName, err)
+		st.Stop()
+	}
+}
+
+func (s *NriServer) Configure(config, runtime, version string) (stub.EventMask, error) {
+	klog.Infof("nri plugin is configured by runtime %s %s, subscribe events %s", runtime, version, s.mask.PrettyString())
+	return s.mask, nil
+}
+
+func (s *NriServer) RunPodSandbox(pod *api.PodSandbox) error {
+	klog.V(5).Infof("receive nri RunPodSandbox event for pod %s/%s", pod.GetNamespace(), pod.GetName())
+	podCtx := &protocol.PodContext{}
+	podCtx.FromNri(pod)
+	err := hooks.RunHooks(s.options.PluginFailurePolicy, rmconfig.PreRunPodSandbox, podCtx)
+	podCtx.NriDone()
+	return s.handleError(err, rmconfig.PreRunPodSandbox, pod)
+}
+
+func (s *NriServer) CreateContainer(pod *api.PodSandbox, container *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+	klog.V(5).Infof("receive nri CreateContainer request for pod %s/%s container %s",
+		pod.GetNamespace(), pod.GetName(), container.GetName())
+	containerCtx := &protocol.ContainerContext{}
+	containerCtx.FromNri(pod, container)
+	err := hooks.RunHooks(s.options.PluginFailurePolicy, rmconfig.PreCreateContainer, containerCtx)
+	if err = s.handleError(err, rmconfig.PreCreateContainer, pod); err != nil {
+		return nil, nil, err
+	}
+	adjust := containerCtx.NriDone()
+	klog.V(5).Infof("send nri CreateContainer adjustment for pod %s/%s container %s: %v",
+		pod.GetNamespace(), pod.GetName(), container.GetName(), adjust)
+	return adjust, nil, nil
+}
+
+func (s *NriServer) UpdateContainer(pod *api.PodSandbox, container *api.Container) ([]*api.ContainerUpdate, error) {
+	klog.V(5).Infof("receive nri UpdateContainer request for pod %s/%s container %s",
+		pod.GetNamespace(), pod.GetName(), container.GetName())
+	containerCtx := &protocol.ContainerContext{}
+	containerCtx.FromNri(pod, container)
+	err := hooks.RunHooks(s.options.PluginFailurePolicy, rmconfig.PreUpdateContainerResources, containerCtx)
+	if err = s.handleError(err, rmconfig.PreUpdateContainerResources, pod); err != nil {
+		return nil, err
+	}
+	update := containerCtx.NriUpdateDone(container.GetId())
+	if update == nil {
+		return nil, nil
+	}
+	klog.V(5).Infof("send nri UpdateContainer update for pod %s/%s container %s: %v",
+		pod.GetNamespace(), pod.GetName(), container.GetName(), update)
+	return []*api.ContainerUpdate{update}, nil
+}
+
+// handleError returns the hooks error to the runtime only if the failure policy is PolicyFail.
+func (s *NriServer) handleError(err error, stage rmconfig.RuntimeHookType, pod *api.PodSandbox) error {
+	if err == nil {
+		return nil
+	}
+	if s.options.FailurePolicy == rmconfig.PolicyFail {
+		return fmt.Errorf("failed to run hooks at %s for pod %s/%s, error: %w", stage, pod.GetNamespace(), pod.GetName(), err)
+	}
+	klog.Warningf("failed to run hooks at %s for pod %s/%s, ignored, error: %v", stage, pod.GetNamespace(), pod.GetName(), err)
+	return nil
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nri
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containerd/nri/pkg/adaptation"
+	"github.com/containerd/nri/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
+	rmconfig "github.com/koordinator-sh/koordinator/pkg/runtimeproxy/config"
+)
+
+const testFailAnnotation = "test.koordinator.sh/hook-fail"
+
+var testRunPodSandboxCalled = make(chan string, 10)
+
+func init() {
+	hooks.Register(rmconfig.PreRunPodSandbox, "nri-test-pod", "record pod", func(p protocol.HooksProtocol) error {
+		podCtx := p.(*protocol.PodContext)
+		testRunPodSandboxCalled <- podCtx.Request.PodMeta.Name
+		return nil
+	})
+	hooks.Register(rmconfig.PreCreateContainer, "nri-test-container", "set cpuset and envs", func(p protocol.HooksProtocol) error {
+		containerCtx := p.(*protocol.ContainerContext)
+		if _, ok := containerCtx.Request.PodAnnotations[testFailAnnotation]; ok {
+			return fmt.Errorf("expected failure")
+		}
+		containerCtx.Response.Resources.CPUSet = pointer.String("0-1")
+		containerCtx.Response.AddContainerEnvs = map[string]string{
+			"TEST_ENV": containerCtx.Request.ContainerEnvs["ORIGIN_ENV"],
+		}
+		return nil
+	})
+	hooks.Register(rmconfig.PreUpdateContainerResources, "nri-test-update", "set cfs quota", func(p protocol.HooksProtocol) error {
+		containerCtx := p.(*protocol.ContainerContext)
+		containerCtx.Response.Resources.CFSQuota = pointer.Int64(200000)
+		return nil
+	})
+}
+
+type fakeRuntime struct {
+	runtime *adaptation.Adaptation
+	synced  chan struct{}
+}
+
+func newFakeRuntime(t *testing.T, dir string) *fakeRuntime {
+	f := &fakeRuntime{synced: make(chan struct{}, 1)}
+	syncFn := func(ctx context.Context, cb adaptation.SyncCB) error {
+		_, err := cb(ctx, nil, nil)
+		f.synced <- struct{}{}
+		return err
+	}
+	updateFn := func(ctx context.Context, updates []*api.ContainerUpdate) ([]*api.ContainerUpdate, error) {
+		return nil, nil
+	}
+	r, err := adaptation.New("fake-runtime", "v0.0.1", syncFn, updateFn,
+		adaptation.WithPluginPath(filepath.Join(dir, "plugins")),
+		adaptation.WithPluginConfigPath(filepath.Join(dir, "conf.d")),
+		adaptation.WithSocketPath(filepath.Join(dir, "nri.sock")))
+	assert.NoError(t, err)
+	assert.NoError(t, r.Start())
+	f.runtime = r
+	return f
+}
+
+func (f *fakeRuntime) waitSynced(t *testing.T) {
+	select {
+	case <-f.synced:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for nri plugin synchronized")
+	}
+}
+
+func newTestPod(annotations map[string]string) *api.PodSandbox {
+	return &api.PodSandbox{
+		Id:          "pod-sandbox-id",
+		Name:        "test-pod",
+		Uid:         "test-pod-uid",
+		Namespace:   "default",
+		Annotations: annotations,
+		Linux: &api.LinuxPodSandbox{
+			CgroupParent: "kubepods/besteffort/podtest-pod-uid",
+		},
+	}
+}
+
+func newTestContainer() *api.Container {
+	return &api.Container{
+		Id:           "test-container-id",
+		PodSandboxId: "pod-sandbox-id",
+		Name:         "test-container",
+		Env:          []string{"ORIGIN_ENV=origin"},
+	}
+}
+
+func TestNewNriServer(t *testing.T) {
+	s, err := NewNriServer(Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPluginName, s.options.PluginName)
+	assert.Equal(t, DefaultPluginIdx, s.options.PluginIdx)
+	assert.True(t, s.mask.IsSet(api.Event_RUN_POD_SANDBOX))
+	assert.True(t, s.mask.IsSet(api.Event_CREATE_CONTAINER))
+	assert.True(t, s.mask.IsSet(api.Event_UPDATE_CONTAINER))
+
+	s, err = NewNriServer(Options{
+		DisableStages: map[string]struct{}{string(rmconfig.PreRunPodSandbox): {}},
+	})
+	assert.NoError(t, err)
+	assert.False(t, s.mask.IsSet(api.Event_RUN_POD_SANDBOX))
+	assert.True(t, s.mask.IsSet(api.Event_CREATE_CONTAINER))
+
+	_, err = NewNriServer(Options{
+		DisableStages: map[string]struct{}{
+			string(rmconfig.PreRunPodSandbox):            {},
+			string(rmconfig.PreCreateContainer):          {},
+			string(rmconfig.PreUpdateContainerResources): {},
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestNriServer(t *testing.T) {
+	tests := []struct {
+		name           string
+		failurePolicy  rmconfig.FailurePolicyType
+		podAnnotations map[string]string
+		wantCreateErr  bool
+		wantAdjust     bool
+	}{
+		{
+			name:          "hooks adjust container",
+			failurePolicy: rmconfig.PolicyFail,
+			wantAdjust:    true,
+		},
+		{
+			name:           "hooks failed with policy fail",
+			failurePolicy:  rmconfig.PolicyFail,
+			podAnnotations: map[string]string{testFailAnnotation: "true"},
+			wantCreateErr:  true,
+		},
+		{
+			name:           "hooks failed with policy ignore",
+			failurePolicy:  rmconfig.PolicyIgnore,
+			podAnnotations: map[string]string{testFailAnnotation: "true"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			r := newFakeRuntime(t, dir)
+			defer r.runtime.Stop()
+
+			s, err := NewNriServer(Options{
+				SocketPath:          filepath.Join(dir, "nri.sock"),
+				FailurePolicy:       tt.failurePolicy,
+				PluginFailurePolicy: rmconfig.PolicyFail,
+			})
+			assert.NoError(t, err)
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			s.Start(stopCh)
+			r.waitSynced(t)
+
+			ctx := context.Background()
+			pod := newTestPod(tt.podAnnotations)
+			err = r.runtime.RunPodSandbox(ctx, &api.StateChangeEvent{Pod: pod})
+			assert.NoError(t, err)
+			select {
+			case name := <-testRunPodSandboxCalled:
+				assert.Equal(t, pod.Name, name)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timeout waiting for RunPodSandbox hooks")
+			}
+
+			createResp, err := r.runtime.CreateContainer(ctx, &api.CreateContainerRequest{
+				Pod:       pod,
+				Container: newTestContainer(),
+			})
+			if tt.wantCreateErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.wantAdjust {
+				assert.Equal(t, "0-1", createResp.GetAdjust().GetLinux().GetResources().GetCpu().GetCpus())
+				assert.Equal(t, []*api.KeyValue{{Key: "TEST_ENV", Value: "origin"}}, createResp.GetAdjust().GetEnv())
+			} else {
+				assert.Empty(t, createResp.GetAdjust().GetLinux().GetResources().GetCpu().GetCpus())
+			}
+
+			updateResp, err := r.runtime.UpdateContainer(ctx, &api.UpdateContainerRequest{
+				Pod:            pod,
+				Container:      newTestContainer(),
+				LinuxResources: &api.LinuxResources{},
+			})
+			assert.NoError(t, err)
+			assert.Len(t, updateResp.GetUpdate(), 1)
+			assert.Equal(t, "test-container-id", updateResp.GetUpdate()[0].GetContainerId())
+			assert.Equal(t, int64(200000), updateResp.GetUpdate()[0].GetLinux().GetResources().GetCpu().GetQuota().GetValue())
+		})
+	}
+}
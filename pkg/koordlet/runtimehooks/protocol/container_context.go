@@ -18,7 +18,9 @@ package protocol
 
 import (
 	"fmt"
+	"strings"
 
+	nriapi "github.com/containerd/nri/pkg/api"
 	"k8s.io/klog/v2"
 
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
@@ -41,6 +43,12 @@ func (c *ContainerMeta) FromProxy(containerMeta *runtimeapi.ContainerMetadata, p
 	c.ID = getContainerID(podAnnotations, uid)
 }
 
+func (c *ContainerMeta) FromNri(container *nriapi.Container) {
+	c.Name = container.GetName()
+	// NRI is only supported by containerd
+	c.ID = fmt.Sprintf("containerd://%s", container.GetId())
+}
+
 type ContainerRequest struct {
 	PodMeta           PodMeta
 	ContainerMeta     ContainerMeta
@@ -71,6 +79,26 @@ func (c *ContainerRequest) FromProxy(req *runtimeapi.ContainerResourceHookReques
 	}
 }
 
+func (c *ContainerRequest) FromNri(pod *nriapi.PodSandbox, container *nriapi.Container) {
+	c.PodMeta.FromNri(pod)
+	c.ContainerMeta.FromNri(container)
+	c.PodLabels = pod.GetLabels()
+	c.PodAnnotations = pod.GetAnnotations()
+	c.CgroupParent, _ = koordletutil.GetContainerCgroupPathWithKubeByID(pod.GetLinux().GetCgroupParent(), c.ContainerMeta.ID)
+	c.ContainerEnvs = parseNriEnvs(container.GetEnv())
+	// retrieve ExtendedResources from pod annotations
+	spec, err := apiext.GetExtendedResourceSpec(pod.GetAnnotations())
+	if err != nil {
+		klog.V(4).Infof("failed to get ExtendedResourceSpec from nri via annotation, container %s/%s/%s, err: %s",
+			c.PodMeta.Namespace, c.PodMeta.Name, c.ContainerMeta.Name, err)
+	}
+	if spec != nil && spec.Containers != nil {
+		if containerSpec, ok := spec.Containers[c.ContainerMeta.Name]; ok {
+			c.ExtendedResources = &containerSpec
+		}
+	}
+}
+
 func (c *ContainerRequest) FromReconciler(podMeta *statesinformer.PodMeta, containerName string) {
 	c.PodMeta.FromReconciler(podMeta.Pod.ObjectMeta)
 	c.ContainerMeta.Name = containerName
@@ -112,8 +140,9 @@ func (c *ContainerRequest) FromReconciler(podMeta *statesinformer.PodMeta, conta
 }
 
 type ContainerResponse struct {
-	Resources        Resources
-	AddContainerEnvs map[string]string
+	Resources           Resources
+	AddContainerEnvs    map[string]string
+	AddContainerDevices []*runtimeapi.LinuxDevice
 }
 
 func (c *ContainerResponse) ProxyDone(resp *runtimeapi.ContainerResourceHookResponse) {
@@ -121,26 +150,116 @@ func (c *ContainerResponse) ProxyDone(resp *runtimeapi.ContainerResourceHookResp
 		// resource value is injected but origin request is nil, init resource response
 		resp.ContainerResources = &runtimeapi.LinuxContainerResources{}
 	}
+	if c.Resources.IsOriginResSet() {
+		c.Resources.proxyDone(resp.ContainerResources)
+	}
+	if c.AddContainerEnvs != nil {
+		if resp.ContainerEnvs == nil {
+			resp.ContainerEnvs = make(map[string]string)
+		}
+		for k, v := range c.AddContainerEnvs {
+			resp.ContainerEnvs[k] = v
+		}
+	}
+	for _, device := range c.AddContainerDevices {
+		if !containsDevice(resp.ContainerDevices, device.ContainerPath) {
+			resp.ContainerDevices = append(resp.ContainerDevices, device)
+		}
+	}
+}
+
+func (c *ContainerResponse) NriDone() *nriapi.ContainerAdjustment {
+	adjust := &nriapi.ContainerAdjustment{}
 	if c.Resources.CPUSet != nil {
-		resp.ContainerResources.CpusetCpus = *c.Resources.CPUSet
+		adjust.SetLinuxCPUSetCPUs(*c.Resources.CPUSet)
 	}
 	if c.Resources.CFSQuota != nil {
-		resp.ContainerResources.CpuQuota = *c.Resources.CFSQuota
+		adjust.SetLinuxCPUQuota(*c.Resources.CFSQuota)
 	}
 	if c.Resources.CPUShares != nil {
-		resp.ContainerResources.CpuShares = *c.Resources.CPUShares
+		adjust.SetLinuxCPUShares(uint64(*c.Resources.CPUShares))
 	}
 	if c.Resources.MemoryLimit != nil {
-		resp.ContainerResources.MemoryLimitInBytes = *c.Resources.MemoryLimit
+		adjust.SetLinuxMemoryLimit(*c.Resources.MemoryLimit)
 	}
-	if c.AddContainerEnvs != nil {
-		if resp.ContainerEnvs == nil {
-			resp.ContainerEnvs = make(map[string]string)
+	if c.Resources.CPUSetMems != nil {
+		adjust.SetLinuxCPUSetMems(*c.Resources.CPUSetMems)
+	}
+	for _, limit := range c.Resources.HugepageLimits {
+		adjust.AddLinuxHugepageLimit(limit.PageSize, limit.Limit)
+	}
+	for k, v := range c.Resources.nriUnified() {
+		adjust.AddLinuxUnified(k, v)
+	}
+	for k, v := range c.AddContainerEnvs {
+		adjust.AddEnv(k, v)
+	}
+	for _, device := range c.AddContainerDevices {
+		devType, major, minor, err := system.GetDeviceNumber(device.HostPath)
+		if err != nil {
+			klog.Warningf("failed to get device number of %v, skip adding device, error %v", device.HostPath, err)
+			continue
 		}
-		for k, v := range c.AddContainerEnvs {
-			resp.ContainerEnvs[k] = v
+		adjust.AddDevice(&nriapi.LinuxDevice{
+			Path:  device.ContainerPath,
+			Type:  devType,
+			Major: major,
+			Minor: minor,
+		})
+		if adjust.Linux == nil {
+			adjust.Linux = &nriapi.LinuxContainerAdjustment{}
+		}
+		if adjust.Linux.Resources == nil {
+			adjust.Linux.Resources = &nriapi.LinuxResources{}
+		}
+		adjust.Linux.Resources.Devices = append(adjust.Linux.Resources.Devices, &nriapi.LinuxDeviceCgroup{
+			Allow:  true,
+			Type:   devType,
+			Major:  nriapi.Int64(major),
+			Minor:  nriapi.Int64(minor),
+			Access: device.Permissions,
+		})
+	}
+	return adjust
+}
+
+func containsDevice(devices []*runtimeapi.LinuxDevice, containerPath string) bool {
+	for _, device := range devices {
+		if device.GetContainerPath() == containerPath {
+			return true
 		}
 	}
+	return false
+}
+
+func (c *ContainerResponse) NriUpdateDone(containerID string) *nriapi.ContainerUpdate {
+	if !c.Resources.IsOriginResSet() {
+		return nil
+	}
+	update := &nriapi.ContainerUpdate{}
+	update.SetContainerId(containerID)
+	if c.Resources.CPUSet != nil {
+		update.SetLinuxCPUSetCPUs(*c.Resources.CPUSet)
+	}
+	if c.Resources.CFSQuota != nil {
+		update.SetLinuxCPUQuota(*c.Resources.CFSQuota)
+	}
+	if c.Resources.CPUShares != nil {
+		update.SetLinuxCPUShares(uint64(*c.Resources.CPUShares))
+	}
+	if c.Resources.MemoryLimit != nil {
+		update.SetLinuxMemoryLimit(*c.Resources.MemoryLimit)
+	}
+	if c.Resources.CPUSetMems != nil {
+		update.SetLinuxCPUSetMems(*c.Resources.CPUSetMems)
+	}
+	for _, limit := range c.Resources.HugepageLimits {
+		update.AddLinuxHugepageLimit(limit.PageSize, limit.Limit)
+	}
+	for k, v := range c.Resources.nriUnified() {
+		update.AddLinuxUnified(k, v)
+	}
+	return update
 }
 
 type ContainerContext struct {
@@ -157,6 +276,22 @@ func (c *ContainerContext) ProxyDone(resp *runtimeapi.ContainerResourceHookRespo
 	c.Response.ProxyDone(resp)
 }
 
+func (c *ContainerContext) FromNri(pod *nriapi.PodSandbox, container *nriapi.Container) {
+	c.Request.FromNri(pod, container)
+}
+
+// NriDone returns the adjustment of the container to create.
+func (c *ContainerContext) NriDone() *nriapi.ContainerAdjustment {
+	c.injectForExt()
+	return c.Response.NriDone()
+}
+
+// NriUpdateDone returns the update of the container whose resources are updating, or nil if nothing to update.
+func (c *ContainerContext) NriUpdateDone(containerID string) *nriapi.ContainerUpdate {
+	c.injectForExt()
+	return c.Response.NriUpdateDone(containerID)
+}
+
 func (c *ContainerContext) FromReconciler(podMeta *statesinformer.PodMeta, containerName string) {
 	c.Request.FromReconciler(podMeta, containerName)
 }
@@ -219,6 +354,33 @@ func (c *ContainerContext) injectForOrigin() {
 				"set container memory limit to %v", *c.Response.Resources.MemoryLimit).Do()
 		}
 	}
+	if c.Response.Resources.CPUSetMems != nil {
+		err := injectCPUSetMems(c.Request.CgroupParent, *c.Response.Resources.CPUSetMems)
+		if err != nil && system.IsCgroupDirErr(err) {
+			klog.V(5).Infof("set container %v/%v/%v cpuset mems %v on cgroup parent %v failed, error %v", c.Request.PodMeta.Namespace,
+				c.Request.PodMeta.Name, c.Request.ContainerMeta.Name, *c.Response.Resources.CPUSetMems, c.Request.CgroupParent, err)
+		} else if err != nil {
+			klog.Infof("set container %v/%v/%v cpuset mems %v on cgroup parent %v failed, error %v", c.Request.PodMeta.Namespace,
+				c.Request.PodMeta.Name, c.Request.ContainerMeta.Name, *c.Response.Resources.CPUSetMems, c.Request.CgroupParent, err)
+		} else {
+			klog.V(5).Infof("set container %v/%v/%v cpuset mems %v on cgroup parent %v",
+				c.Request.PodMeta.Namespace, c.Request.PodMeta.Name, c.Request.ContainerMeta.Name,
+				*c.Response.Resources.CPUSetMems, c.Request.CgroupParent)
+			audit.V(2).Container(c.Request.ContainerMeta.ID).Reason("runtime-hooks").Message(
+				"set container cpuset mems to %v", *c.Response.Resources.CPUSetMems).Do()
+		}
+	}
+	if c.Response.Resources.MemoryMin != nil || c.Response.Resources.MemoryLow != nil || c.Response.Resources.MemoryHigh != nil {
+		if err := injectMemoryQOS(c.Request.CgroupParent, &c.Response.Resources); err != nil {
+			klog.Infof("set container %v/%v/%v memory qos on cgroup parent %v failed, error %v", c.Request.PodMeta.Namespace,
+				c.Request.PodMeta.Name, c.Request.ContainerMeta.Name, c.Request.CgroupParent, err)
+		} else {
+			klog.V(5).Infof("set container %v/%v/%v memory qos on cgroup parent %v",
+				c.Request.PodMeta.Namespace, c.Request.PodMeta.Name, c.Request.ContainerMeta.Name, c.Request.CgroupParent)
+			audit.V(2).Container(c.Request.ContainerMeta.ID).Reason("runtime-hooks").Message(
+				"set container memory qos").Do()
+		}
+	}
 	// TODO other fields
 }
 
@@ -234,3 +396,19 @@ func getContainerID(podAnnotations map[string]string, containerUID string) strin
 	}
 	return fmt.Sprintf("%s://%s", runtimeType, containerUID)
 }
+
+// parseNriEnvs parses the container envs in the format of "key=value".
+func parseNriEnvs(envs []string) map[string]string {
+	if envs == nil {
+		return nil
+	}
+	envMap := make(map[string]string, len(envs))
+	for _, env := range envs {
+		kv := strings.SplitN(env, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		envMap[kv[0]] = kv[1]
+	}
+	return envMap
+}
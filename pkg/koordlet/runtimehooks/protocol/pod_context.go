@@ -17,6 +17,7 @@ limitations under the License.
 package protocol
 
 import (
+	nriapi "github.com/containerd/nri/pkg/api"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 
@@ -40,6 +41,12 @@ func (p *PodMeta) FromProxy(meta *runtimeapi.PodSandboxMetadata) {
 	p.UID = meta.GetUid()
 }
 
+func (p *PodMeta) FromNri(pod *nriapi.PodSandbox) {
+	p.Namespace = pod.GetNamespace()
+	p.Name = pod.GetName()
+	p.UID = pod.GetUid()
+}
+
 func (p *PodMeta) FromReconciler(meta metav1.ObjectMeta) {
 	p.Namespace = meta.Namespace
 	p.Name = meta.Name
@@ -70,6 +77,22 @@ func (p *PodRequest) FromProxy(req *runtimeapi.PodSandboxHookRequest) {
 	}
 }
 
+func (p *PodRequest) FromNri(pod *nriapi.PodSandbox) {
+	p.PodMeta.FromNri(pod)
+	p.Labels = pod.GetLabels()
+	p.Annotations = pod.GetAnnotations()
+	p.CgroupParent = pod.GetLinux().GetCgroupParent()
+	// retrieve ExtendedResources from pod annotations
+	spec, err := apiext.GetExtendedResourceSpec(pod.GetAnnotations())
+	if err != nil {
+		klog.V(4).Infof("failed to get ExtendedResourceSpec from nri via annotation, pod %s/%s, err: %s",
+			p.PodMeta.Namespace, p.PodMeta.Name, err)
+	}
+	if spec != nil && spec.Containers != nil {
+		p.ExtendedResources = spec
+	}
+}
+
 func (p *PodRequest) FromReconciler(podMeta *statesinformer.PodMeta) {
 	p.PodMeta.FromReconciler(podMeta.Pod.ObjectMeta)
 	p.Labels = podMeta.Pod.Labels
@@ -103,17 +126,8 @@ func (p *PodResponse) ProxyDone(resp *runtimeapi.PodSandboxHookResponse) {
 		// resource value is injected but origin request is nil, init resource response
 		resp.Resources = &runtimeapi.LinuxContainerResources{}
 	}
-	if p.Resources.CPUSet != nil {
-		resp.Resources.CpusetCpus = *p.Resources.CPUSet
-	}
-	if p.Resources.CPUShares != nil {
-		resp.Resources.CpuShares = *p.Resources.CPUShares
-	}
-	if p.Resources.CFSQuota != nil {
-		resp.Resources.CpuQuota = *p.Resources.CFSQuota
-	}
-	if p.Resources.MemoryLimit != nil {
-		resp.Resources.MemoryLimitInBytes = *p.Resources.MemoryLimit
+	if p.Resources.IsOriginResSet() {
+		p.Resources.proxyDone(resp.Resources)
 	}
 }
 
@@ -126,6 +140,16 @@ func (p *PodContext) ProxyDone(resp *runtimeapi.PodSandboxHookResponse) {
 	p.Response.ProxyDone(resp)
 }
 
+func (p *PodContext) FromNri(pod *nriapi.PodSandbox) {
+	p.Request.FromNri(pod)
+}
+
+// NriDone applies the pod response on the cgroup parent directly, since the RunPodSandbox event of NRI
+// cannot adjust the pod-level resources.
+func (p *PodContext) NriDone() {
+	p.injectForExt()
+}
+
 func (p *PodContext) FromReconciler(podMeta *statesinformer.PodMeta) {
 	p.Request.FromReconciler(podMeta)
 }
@@ -151,6 +175,17 @@ func (p *PodContext) injectForExt() {
 				"set pod bvt to %v", *p.Response.Resources.CPUBvt).Do()
 		}
 	}
+	if p.Response.Resources.BlkIO != nil {
+		if err := injectBlkIO(p.Request.CgroupParent, p.Response.Resources.BlkIO); err != nil {
+			klog.Infof("set pod %v/%v blkio %+v on cgroup parent %v failed, error %v", p.Request.PodMeta.Namespace,
+				p.Request.PodMeta.Name, *p.Response.Resources.BlkIO, p.Request.CgroupParent, err)
+		} else {
+			klog.V(5).Infof("set pod %v/%v blkio %+v on cgroup parent %v", p.Request.PodMeta.Namespace,
+				p.Request.PodMeta.Name, *p.Response.Resources.BlkIO, p.Request.CgroupParent)
+			audit.V(2).Pod(p.Request.PodMeta.Namespace, p.Request.PodMeta.Name).Reason("runtime-hooks").Message(
+				"set pod blkio to %+v", *p.Response.Resources.BlkIO).Do()
+		}
+	}
 	// some of pod-level cgroups are manually updated since pod-stage hooks do not support it;
 	// kubelet may set the cgroups when pod is created or restarted, so we need to update the cgroups repeatedly
 	if p.Response.Resources.CPUShares != nil {
@@ -186,4 +221,15 @@ func (p *PodContext) injectForExt() {
 				"set pod memory limit to %v", *p.Response.Resources.MemoryLimit).Do()
 		}
 	}
+	if p.Response.Resources.MemoryMin != nil || p.Response.Resources.MemoryLow != nil || p.Response.Resources.MemoryHigh != nil {
+		if err := injectMemoryQOS(p.Request.CgroupParent, &p.Response.Resources); err != nil {
+			klog.Infof("set pod %v/%v memory qos on cgroup parent %v failed, error %v", p.Request.PodMeta.Namespace,
+				p.Request.PodMeta.Name, p.Request.CgroupParent, err)
+		} else {
+			klog.V(5).Infof("set pod %v/%v memory qos on cgroup parent %v",
+				p.Request.PodMeta.Namespace, p.Request.PodMeta.Name, p.Request.CgroupParent)
+			audit.V(2).Pod(p.Request.PodMeta.Namespace, p.Request.PodMeta.Name).Reason("runtime-hooks").Message(
+				"set pod memory qos").Do()
+		}
+	}
 }
@@ -17,10 +17,13 @@ limitations under the License.
 package protocol
 
 import (
+	"fmt"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
+	runtimeapi "github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
 	sysutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
@@ -59,14 +62,117 @@ type Resources struct {
 	CPUShares   *int64
 	CFSQuota    *int64
 	CPUSet      *string
+	CPUSetMems  *string
 	MemoryLimit *int64
+	// memory qos, which only works on cgroups-v2 or anolis os
+	MemoryMin      *int64
+	MemoryLow      *int64
+	MemoryHigh     *int64
+	HugepageLimits []HugepageLimit
+	// Unified is the cgroups-v2 resources, e.g. "memory.oom.group": "1"
+	Unified map[string]string
 
 	// extended resources
 	CPUBvt *int64
+	BlkIO  *BlkIOResources
+}
+
+// HugepageLimit is the HugeTLB limit of a page size, e.g. `PageSize=2MB` for `hugetlb.2MB.limit_in_bytes`.
+type HugepageLimit struct {
+	PageSize string
+	Limit    uint64
+}
+
+// BlkIOResources is the block io qos of a cgroup.
+type BlkIOResources struct {
+	// Weight is the io weight in the range of `blkio.weight` (cgroups-v1), which is converted on cgroups-v2.
+	Weight *int64
+	// DeviceLimits are the io throttling limits of the block devices.
+	DeviceLimits []BlkIODeviceLimit
+}
+
+// BlkIODeviceLimit is the io throttling limits of a block device, where the zero value means unlimited.
+type BlkIODeviceLimit struct {
+	// Device is the device number in the format of "major:minor".
+	Device    string
+	ReadBPS   int64
+	WriteBPS  int64
+	ReadIOPS  int64
+	WriteIOPS int64
 }
 
 func (r *Resources) IsOriginResSet() bool {
-	return r.CPUShares != nil || r.CFSQuota != nil || r.CPUSet != nil || r.MemoryLimit != nil
+	return r.CPUShares != nil || r.CFSQuota != nil || r.CPUSet != nil || r.CPUSetMems != nil || r.MemoryLimit != nil ||
+		r.MemoryMin != nil || r.MemoryLow != nil || r.MemoryHigh != nil || len(r.HugepageLimits) > 0 || len(r.Unified) > 0
+}
+
+// proxyDone fills the origin resources into the resources of the proxy response.
+func (r *Resources) proxyDone(resources *runtimeapi.LinuxContainerResources) {
+	if r.CPUSet != nil {
+		resources.CpusetCpus = *r.CPUSet
+	}
+	if r.CPUSetMems != nil {
+		resources.CpusetMems = *r.CPUSetMems
+	}
+	if r.CPUShares != nil {
+		resources.CpuShares = *r.CPUShares
+	}
+	if r.CFSQuota != nil {
+		resources.CpuQuota = *r.CFSQuota
+	}
+	if r.MemoryLimit != nil {
+		resources.MemoryLimitInBytes = *r.MemoryLimit
+	}
+	// the memory qos is carried as unified resources by the runtime, which fails on cgroups-v1,
+	// so it is set by the reconciler on cgroups-v1
+	if sysutil.GetCurrentCgroupVersion() == sysutil.CgroupVersionV2 {
+		if r.MemoryMin != nil {
+			resources.MemoryMinInBytes = *r.MemoryMin
+		}
+		if r.MemoryLow != nil {
+			resources.MemoryLowInBytes = *r.MemoryLow
+		}
+		if r.MemoryHigh != nil {
+			resources.MemoryHighInBytes = *r.MemoryHigh
+		}
+	}
+	if len(r.HugepageLimits) > 0 {
+		resources.HugepageLimits = make([]*runtimeapi.HugepageLimit, 0, len(r.HugepageLimits))
+		for _, limit := range r.HugepageLimits {
+			resources.HugepageLimits = append(resources.HugepageLimits, &runtimeapi.HugepageLimit{
+				PageSize: limit.PageSize,
+				Limit:    limit.Limit,
+			})
+		}
+	}
+	if len(r.Unified) > 0 {
+		if resources.Unified == nil {
+			resources.Unified = map[string]string{}
+		}
+		for k, v := range r.Unified {
+			resources.Unified[k] = v
+		}
+	}
+}
+
+// nriUnified returns the unified resources for NRI, including the memory qos on cgroups-v2.
+func (r *Resources) nriUnified() map[string]string {
+	unified := map[string]string{}
+	if sysutil.GetCurrentCgroupVersion() == sysutil.CgroupVersionV2 {
+		for key, value := range map[string]*int64{
+			sysutil.MemoryMinName:  r.MemoryMin,
+			sysutil.MemoryLowName:  r.MemoryLow,
+			sysutil.MemoryHighName: r.MemoryHigh,
+		} {
+			if value != nil {
+				unified[key] = strconv.FormatInt(*value, 10)
+			}
+		}
+	}
+	for k, v := range r.Unified {
+		unified[k] = v
+	}
+	return unified
 }
 
 func injectCPUShares(cgroupParent string, cpuShares int64) error {
@@ -86,6 +192,33 @@ func injectCPUSet(cgroupParent string, cpuset string) error {
 	return updater.Update()
 }
 
+func injectCPUSetMems(cgroupParent string, cpusetMems string) error {
+	updater, err := resourceexecutor.DefaultCgroupUpdaterFactory.New(sysutil.CPUSetMemsName, cgroupParent, cpusetMems)
+	if err != nil {
+		return err
+	}
+	return updater.Update()
+}
+
+func injectMemoryQOS(cgroupParent string, resources *Resources) error {
+	var errs []error
+	for resourceType, value := range map[sysutil.ResourceType]*int64{
+		sysutil.MemoryMinName:  resources.MemoryMin,
+		sysutil.MemoryLowName:  resources.MemoryLow,
+		sysutil.MemoryHighName: resources.MemoryHigh,
+	} {
+		if value == nil {
+			continue
+		}
+		updater, err := resourceexecutor.DefaultCgroupUpdaterFactory.New(resourceType, cgroupParent, strconv.FormatInt(*value, 10))
+		if err == nil {
+			err = updater.Update()
+		}
+		errs = append(errs, err)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 func injectCPUQuota(cgroupParent string, cpuQuota int64) error {
 	cpuQuotaStr := strconv.FormatInt(cpuQuota, 10)
 	updater, err := resourceexecutor.DefaultCgroupUpdaterFactory.New(sysutil.CPUCFSQuotaName, cgroupParent, cpuQuotaStr)
@@ -112,3 +245,31 @@ func injectCPUBvt(cgroupParent string, bvtValue int64) error {
 	}
 	return updater.Update()
 }
+
+func injectBlkIO(cgroupParent string, blkIO *BlkIOResources) error {
+	var errs []error
+	if blkIO.Weight != nil {
+		weightStr := strconv.FormatInt(*blkIO.Weight, 10)
+		updater, err := resourceexecutor.DefaultCgroupUpdaterFactory.New(sysutil.BlkioWeightName, cgroupParent, weightStr)
+		if err == nil {
+			err = updater.Update()
+		}
+		errs = append(errs, err)
+	}
+	for _, limit := range blkIO.DeviceLimits {
+		for resourceType, value := range map[sysutil.ResourceType]int64{
+			sysutil.BlkioTRBpsName:  limit.ReadBPS,
+			sysutil.BlkioTWBpsName:  limit.WriteBPS,
+			sysutil.BlkioTRIopsName: limit.ReadIOPS,
+			sysutil.BlkioTWIopsName: limit.WriteIOPS,
+		} {
+			limitStr := fmt.Sprintf("%s %d", limit.Device, value)
+			updater, err := resourceexecutor.DefaultCgroupUpdaterFactory.New(resourceType, cgroupParent, limitStr)
+			if err == nil {
+				err = updater.Update()
+			}
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -23,6 +23,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	runtimeapi "github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	sysutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
 )
 
 func TestResources_IsOriginResSet(t *testing.T) {
@@ -113,6 +114,26 @@ func TestContainerResponse_ProxyDone(t *testing.T) {
 	}
 }
 
+func TestContainerResponse_ProxyDoneWithDevices(t *testing.T) {
+	c := &ContainerResponse{
+		AddContainerDevices: []*runtimeapi.LinuxDevice{
+			{ContainerPath: "/dev/infiniband/uverbs0", HostPath: "/dev/infiniband/uverbs0", Permissions: "rwm"},
+			{ContainerPath: "/dev/infiniband/rdma_cm", HostPath: "/dev/infiniband/rdma_cm", Permissions: "rwm"},
+		},
+	}
+	resp := &runtimeapi.ContainerResourceHookResponse{
+		ContainerDevices: []*runtimeapi.LinuxDevice{
+			{ContainerPath: "/dev/infiniband/rdma_cm", HostPath: "/dev/infiniband/rdma_cm", Permissions: "rw"},
+		},
+	}
+	c.ProxyDone(resp)
+	expected := []*runtimeapi.LinuxDevice{
+		{ContainerPath: "/dev/infiniband/rdma_cm", HostPath: "/dev/infiniband/rdma_cm", Permissions: "rw"},
+		{ContainerPath: "/dev/infiniband/uverbs0", HostPath: "/dev/infiniband/uverbs0", Permissions: "rwm"},
+	}
+	assert.Equal(t, expected, resp.ContainerDevices)
+}
+
 func TestPodResponse_ProxyDone(t *testing.T) {
 	type fields struct {
 		Resources Resources
@@ -156,3 +177,133 @@ func TestPodResponse_ProxyDone(t *testing.T) {
 		})
 	}
 }
+
+func Test_injectBlkIO(t *testing.T) {
+	testCgroupParent := "/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-podxxx.slice"
+	blkIO := &BlkIOResources{
+		Weight: pointer.Int64(100),
+		DeviceLimits: []BlkIODeviceLimit{
+			{
+				Device:   "253:0",
+				ReadBPS:  1048576,
+				WriteBPS: 2097152,
+			},
+		},
+	}
+	t.Run("inject blkio on cgroups-v1", func(t *testing.T) {
+		helper := sysutil.NewFileTestUtil(t)
+		defer helper.Cleanup()
+		helper.WriteCgroupFileContents(testCgroupParent, sysutil.BlkioWeight, "500")
+		for _, r := range []sysutil.Resource{sysutil.BlkioReadBps, sysutil.BlkioWriteBps, sysutil.BlkioReadIops,
+			sysutil.BlkioWriteIops} {
+			helper.WriteCgroupFileContents(testCgroupParent, r, "")
+		}
+		assert.NoError(t, injectBlkIO(testCgroupParent, blkIO))
+		assert.Equal(t, "100", helper.ReadCgroupFileContents(testCgroupParent, sysutil.BlkioWeight))
+		assert.Equal(t, "253:0 1048576", helper.ReadCgroupFileContents(testCgroupParent, sysutil.BlkioReadBps))
+		assert.Equal(t, "253:0 2097152", helper.ReadCgroupFileContents(testCgroupParent, sysutil.BlkioWriteBps))
+		assert.Equal(t, "253:0 0", helper.ReadCgroupFileContents(testCgroupParent, sysutil.BlkioReadIops))
+	})
+	t.Run("inject blkio on cgroups-v2", func(t *testing.T) {
+		helper := sysutil.NewFileTestUtil(t)
+		defer helper.Cleanup()
+		helper.SetCgroupsV2(true)
+		helper.WriteCgroupFileContents(testCgroupParent, sysutil.BlkioWeightV2, "100")
+		helper.WriteCgroupFileContents(testCgroupParent, sysutil.BlkioReadBpsV2, "")
+		assert.NoError(t, injectBlkIO(testCgroupParent, blkIO))
+		assert.Equal(t, "20", helper.ReadCgroupFileContents(testCgroupParent, sysutil.BlkioWeightV2))
+		// io.max in the test is a regular file, so only the last write is kept
+		assert.Contains(t, helper.ReadCgroupFileContents(testCgroupParent, sysutil.BlkioReadBpsV2), "253:0 ")
+	})
+	t.Run("failed to inject blkio since cgroup not exist", func(t *testing.T) {
+		helper := sysutil.NewFileTestUtil(t)
+		defer helper.Cleanup()
+		assert.Error(t, injectBlkIO(testCgroupParent, blkIO))
+	})
+}
+
+func TestResources_proxyDone(t *testing.T) {
+	r := &Resources{
+		CPUSet:     pointer.String("0-3"),
+		CPUSetMems: pointer.String("0"),
+		MemoryMin:  pointer.Int64(1048576),
+		MemoryHigh: pointer.Int64(4194304),
+		HugepageLimits: []HugepageLimit{
+			{PageSize: "2MB", Limit: 1 << 30},
+		},
+		Unified: map[string]string{
+			"memory.oom.group": "1",
+		},
+	}
+	t.Run("memory qos is skipped on cgroups-v1", func(t *testing.T) {
+		helper := sysutil.NewFileTestUtil(t)
+		defer helper.Cleanup()
+		resources := &runtimeapi.LinuxContainerResources{}
+		r.proxyDone(resources)
+		assert.Equal(t, &runtimeapi.LinuxContainerResources{
+			CpusetCpus: "0-3",
+			CpusetMems: "0",
+			HugepageLimits: []*runtimeapi.HugepageLimit{
+				{PageSize: "2MB", Limit: 1 << 30},
+			},
+			Unified: map[string]string{
+				"memory.oom.group": "1",
+			},
+		}, resources)
+	})
+	t.Run("memory qos is set on cgroups-v2", func(t *testing.T) {
+		helper := sysutil.NewFileTestUtil(t)
+		defer helper.Cleanup()
+		helper.SetCgroupsV2(true)
+		resources := &runtimeapi.LinuxContainerResources{}
+		r.proxyDone(resources)
+		assert.Equal(t, int64(1048576), resources.MemoryMinInBytes)
+		assert.Equal(t, int64(0), resources.MemoryLowInBytes)
+		assert.Equal(t, int64(4194304), resources.MemoryHighInBytes)
+		assert.Equal(t, map[string]string{
+			"memory.min":       "1048576",
+			"memory.high":      "4194304",
+			"memory.oom.group": "1",
+		}, r.nriUnified())
+	})
+}
+
+func Test_injectMemoryQOSAndCPUSetMems(t *testing.T) {
+	testCgroupParent := "/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-podxxx.slice"
+	r := &Resources{
+		MemoryMin: pointer.Int64(1048576),
+		MemoryLow: pointer.Int64(2097152),
+	}
+	t.Run("inject on cgroups-v1", func(t *testing.T) {
+		helper := sysutil.NewFileTestUtil(t)
+		defer helper.Cleanup()
+		helper.SetAnolisOSResourcesSupported(true)
+		helper.WriteCgroupFileContents(testCgroupParent, sysutil.MemoryMin, "0")
+		helper.WriteCgroupFileContents(testCgroupParent, sysutil.MemoryLow, "0")
+		helper.WriteCgroupFileContents(testCgroupParent, sysutil.CPUSetMems, "0-1")
+		assert.NoError(t, injectMemoryQOS(testCgroupParent, r))
+		assert.NoError(t, injectCPUSetMems(testCgroupParent, "1"))
+		assert.Equal(t, "1048576", helper.ReadCgroupFileContents(testCgroupParent, sysutil.MemoryMin))
+		assert.Equal(t, "2097152", helper.ReadCgroupFileContents(testCgroupParent, sysutil.MemoryLow))
+		assert.Equal(t, "1", helper.ReadCgroupFileContents(testCgroupParent, sysutil.CPUSetMems))
+	})
+	t.Run("inject on cgroups-v2", func(t *testing.T) {
+		helper := sysutil.NewFileTestUtil(t)
+		defer helper.Cleanup()
+		helper.SetCgroupsV2(true)
+		helper.WriteCgroupFileContents(testCgroupParent, sysutil.MemoryMinV2, "0")
+		helper.WriteCgroupFileContents(testCgroupParent, sysutil.MemoryLowV2, "0")
+		helper.WriteCgroupFileContents(testCgroupParent, sysutil.CPUSetMemsV2, "0-1")
+		assert.NoError(t, injectMemoryQOS(testCgroupParent, r))
+		assert.NoError(t, injectCPUSetMems(testCgroupParent, "0"))
+		assert.Equal(t, "1048576", helper.ReadCgroupFileContents(testCgroupParent, sysutil.MemoryMinV2))
+		assert.Equal(t, "2097152", helper.ReadCgroupFileContents(testCgroupParent, sysutil.MemoryLowV2))
+		assert.Equal(t, "0", helper.ReadCgroupFileContents(testCgroupParent, sysutil.CPUSetMemsV2))
+	})
+	t.Run("failed to inject since cgroup not exist", func(t *testing.T) {
+		helper := sysutil.NewFileTestUtil(t)
+		defer helper.Cleanup()
+		assert.Error(t, injectMemoryQOS(testCgroupParent, r))
+		assert.Error(t, injectCPUSetMems(testCgroupParent, "0"))
+	})
+}
@@ -69,6 +69,7 @@ func (s *server) PreCreateContainerHook(ctx context.Context,
 		ContainerResources:   req.GetContainerResources(),
 		PodCgroupParent:      req.GetPodCgroupParent(),
 		ContainerEnvs:        req.GetContainerEnvs(),
+		ContainerDevices:     req.GetContainerDevices(),
 	}
 	containerCtx := &protocol.ContainerContext{}
 	containerCtx.FromProxy(req)
@@ -87,6 +88,7 @@ func (s *server) PreStartContainerHook(ctx context.Context,
 		ContainerResources:   req.GetContainerResources(),
 		PodCgroupParent:      req.GetPodCgroupParent(),
 		ContainerEnvs:        req.GetContainerEnvs(),
+		ContainerDevices:     req.GetContainerDevices(),
 	}
 	containerCtx := &protocol.ContainerContext{}
 	containerCtx.FromProxy(req)
@@ -105,6 +107,7 @@ func (s *server) PostStartContainerHook(ctx context.Context,
 		ContainerResources:   req.GetContainerResources(),
 		PodCgroupParent:      req.GetPodCgroupParent(),
 		ContainerEnvs:        req.GetContainerEnvs(),
+		ContainerDevices:     req.GetContainerDevices(),
 	}
 	containerCtx := &protocol.ContainerContext{}
 	containerCtx.FromProxy(req)
@@ -123,6 +126,7 @@ func (s *server) PostStopContainerHook(ctx context.Context,
 		ContainerResources:   req.GetContainerResources(),
 		PodCgroupParent:      req.GetPodCgroupParent(),
 		ContainerEnvs:        req.GetContainerEnvs(),
+		ContainerDevices:     req.GetContainerDevices(),
 	}
 	containerCtx := &protocol.ContainerContext{}
 	containerCtx.FromProxy(req)
@@ -141,6 +145,7 @@ func (s *server) PreUpdateContainerResourcesHook(ctx context.Context,
 		ContainerResources:   req.GetContainerResources(),
 		PodCgroupParent:      req.GetPodCgroupParent(),
 		ContainerEnvs:        req.GetContainerEnvs(),
+		ContainerDevices:     req.GetContainerDevices(),
 	}
 	containerCtx := &protocol.ContainerContext{}
 	containerCtx.FromProxy(req)
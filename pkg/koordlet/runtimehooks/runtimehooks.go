@@ -20,6 +20,8 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks/gpu"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/nri"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/proxyserver"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/reconciler"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/rule"
@@ -38,6 +40,7 @@ type RuntimeHook interface {
 type runtimeHook struct {
 	statesInformer statesinformer.StatesInformer
 	server         proxyserver.Server
+	nriServer      *nri.NriServer
 	reconciler     reconciler.Reconciler
 }
 
@@ -46,6 +49,9 @@ func (r *runtimeHook) Run(stopCh <-chan struct{}) error {
 	if err := r.server.Start(); err != nil {
 		return err
 	}
+	if r.nriServer != nil {
+		r.nriServer.Start(stopCh)
+	}
 	if err := r.reconciler.Run(stopCh); err != nil {
 		return err
 	}
@@ -80,9 +86,25 @@ func NewRuntimeHook(si statesinformer.StatesInformer, cfg *Config) (RuntimeHook,
 	if err != nil {
 		return nil, err
 	}
+	var nriServer *nri.NriServer
+	if cfg.RuntimeHooksNRI {
+		nriServer, err = nri.NewNriServer(nri.Options{
+			SocketPath:          cfg.RuntimeHooksNRISocketPath,
+			FailurePolicy:       failurePolicy,
+			PluginFailurePolicy: pluginFailurePolicy,
+			DisableStages:       getDisableStagesMap(cfg.RuntimeHookDisableStages),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := gpu.Object().SetIsolationProvider(cfg.GPUIsolationProvider); err != nil {
+		return nil, err
+	}
 	r := &runtimeHook{
 		statesInformer: si,
 		server:         s,
+		nriServer:      nriServer,
 		reconciler:     reconciler.NewReconciler(si),
 	}
 	registerPlugins()
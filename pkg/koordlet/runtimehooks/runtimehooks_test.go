@@ -101,6 +101,28 @@ func Test_runtimeHook_Run(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "run with nri enabled",
+			fields: fields{
+				config: &Config{
+					RuntimeHooksNetwork:             "unix",
+					RuntimeHooksAddr:                path.Join(tmpDir, "kooordlet.sock"),
+					RuntimeHooksFailurePolicy:       "Fail",
+					RuntimeHooksPluginFailurePolicy: "Ignore",
+					RuntimeHookDisableStages:        []string{"PreRunPodSandbox"},
+					RuntimeHookConfigFilePath:       tmpDir,
+					RuntimeHooksNRI:                 true,
+					RuntimeHooksNRISocketPath:       path.Join(tmpDir, "nri.sock"),
+				},
+				fg: map[string]bool{
+					string(GroupIdentity):   false,
+					string(CPUSetAllocator): false,
+					string(GPUEnvInject):    false,
+					string(BatchResource):   false,
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -28,6 +28,7 @@ import (
 	v1alpha10 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	statesinformer "github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
 	v1 "k8s.io/api/core/v1"
+	v10 "k8s.io/api/policy/v1"
 )
 
 // MockStatesInformer is a mock of StatesInformer interface.
@@ -109,6 +110,20 @@ func (mr *MockStatesInformerMockRecorder) GetNodeTopo() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeTopo", reflect.TypeOf((*MockStatesInformer)(nil).GetNodeTopo))
 }
 
+// GetPodDisruptionBudgets mocks base method.
+func (m *MockStatesInformer) GetPodDisruptionBudgets(namespace string) []*v10.PodDisruptionBudget {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodDisruptionBudgets", namespace)
+	ret0, _ := ret[0].([]*v10.PodDisruptionBudget)
+	return ret0
+}
+
+// GetPodDisruptionBudgets indicates an expected call of GetPodDisruptionBudgets.
+func (mr *MockStatesInformerMockRecorder) GetPodDisruptionBudgets(namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodDisruptionBudgets", reflect.TypeOf((*MockStatesInformer)(nil).GetPodDisruptionBudgets), namespace)
+}
+
 // HasSynced mocks base method.
 func (m *MockStatesInformer) HasSynced() bool {
 	m.ctrl.T.Helper()
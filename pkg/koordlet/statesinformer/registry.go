@@ -16,6 +16,10 @@ limitations under the License.
 
 package statesinformer
 
+import (
+	"github.com/koordinator-sh/koordinator/pkg/features"
+)
+
 func (s *statesInformer) initInformerPlugins() {
 	s.states.informerPlugins = map[pluginName]informerPlugin{
 		nodeSLOInformerName:    NewNodeSLOInformer(),
@@ -24,4 +28,8 @@ func (s *statesInformer) initInformerPlugins() {
 		podsInformerName:       NewPodsInformer(),
 		nodeMetricInformerName: NewNodeMetricInformer(),
 	}
+	// the PodDisruptionBudgets of the whole cluster are only watched when the PDBHeadroom victim policy is enabled
+	if features.DefaultKoordletFeatureGate.Enabled(features.PDBHeadroomVictimPolicy) {
+		s.states.informerPlugins[pdbInformerName] = NewPDBInformer()
+	}
 }
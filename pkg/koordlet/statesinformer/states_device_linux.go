@@ -18,6 +18,7 @@ package statesinformer
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"sort"
 	"strings"
@@ -34,9 +35,18 @@ import (
 	"github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
+const (
+	// maxRecentXIDErrors is the max number of the recent critical XID errors recorded for each GPU
+	maxRecentXIDErrors = 10
+	// deviceLastSeenUpdateInterval is the minimal interval to refresh the LastSeenTime of a device whose
+	// conditions are not changed, so that the Device is not updated at every report
+	deviceLastSeenUpdateInterval = time.Minute
+)
+
 func generateQueryParam() *metriccache.QueryParam {
 	end := time.Now()
 	start := end.Add(-time.Duration(60) * time.Second)
@@ -50,14 +60,25 @@ func generateQueryParam() *metriccache.QueryParam {
 func (s *statesInformer) reportDevice() {
 	node := s.GetNode()
 	gpuDevices := s.buildGPUDevice()
-	if len(gpuDevices) == 0 {
+	var pciDevices []schedulingv1alpha1.DeviceInfo
+	if s.getPCIDevicesFunc != nil {
+		pciDevices = s.getPCIDevicesFunc()
+	}
+	if len(gpuDevices) == 0 && len(pciDevices) == 0 {
 		return
 	}
 
-	gpuModel, gpuDriverVer := s.getGPUDriverAndModelFunc()
-
 	device := s.buildBasicDevice(node)
-	s.fillGPUDevice(device, gpuDevices, gpuModel, gpuDriverVer)
+	if len(gpuDevices) > 0 {
+		gpuModel, gpuDriverVer := s.getGPUDriverAndModelFunc()
+		s.fillGPUDevice(device, gpuDevices, gpuModel, gpuDriverVer)
+	}
+	device.Spec.Devices = append(device.Spec.Devices, pciDevices...)
+	var eccErrors map[string]*schedulingv1alpha1.DeviceECCErrors
+	if len(gpuDevices) > 0 && s.getGPUECCErrorsFunc != nil {
+		eccErrors = s.getGPUECCErrorsFunc()
+	}
+	device.Status.Conditions = s.buildDeviceConditions(device.Spec.Devices, eccErrors, metav1.Now().Rfc3339Copy())
 
 	err := s.updateDevice(device)
 	if err == nil {
@@ -121,29 +142,185 @@ func (s *statesInformer) createDevice(device *schedulingv1alpha1.Device) error {
 func (s *statesInformer) updateDevice(deviceNew *schedulingv1alpha1.Device) error {
 	sorter := func(devices []schedulingv1alpha1.DeviceInfo) {
 		sort.Slice(devices, func(i, j int) bool {
+			if devices[i].Type != devices[j].Type {
+				return devices[i].Type < devices[j].Type
+			}
 			return *(devices[i].Minor) < *(devices[j].Minor)
 		})
 	}
 	sorter(deviceNew.Spec.Devices)
 
+	newConditions := deviceNew.Status.Conditions
 	return util.RetryOnConflictOrTooManyRequests(func() error {
 		deviceOld, err := s.deviceClient.Get(context.TODO(), deviceNew.Name, metav1.GetOptions{ResourceVersion: "0"})
 		if err != nil {
 			return err
 		}
 		sorter(deviceOld.Spec.Devices)
+		deviceNew.Status.Allocations = deviceOld.Status.Allocations
+		deviceNew.Status.Conditions = mergeDeviceConditions(newConditions, deviceOld.Status.Conditions, time.Now())
 
 		if apiequality.Semantic.DeepEqual(deviceNew.Spec.Devices, deviceOld.Spec.Devices) &&
-			apiequality.Semantic.DeepEqual(deviceNew.Labels, deviceOld.Labels) {
+			apiequality.Semantic.DeepEqual(deviceNew.Labels, deviceOld.Labels) &&
+			apiequality.Semantic.DeepEqual(deviceNew.Status.Conditions, deviceOld.Status.Conditions) {
 			klog.V(4).Infof("Device %s has not changed and does not need to be updated", deviceNew.Name)
 			return nil
 		}
 
+		deviceNew.ResourceVersion = deviceOld.ResourceVersion
 		_, err = s.deviceClient.Update(context.TODO(), deviceNew, metav1.UpdateOptions{})
 		return err
 	})
 }
 
+// buildDeviceConditions builds the health conditions of the devices reported in this round.
+func (s *statesInformer) buildDeviceConditions(devices []schedulingv1alpha1.DeviceInfo,
+	eccErrors map[string]*schedulingv1alpha1.DeviceECCErrors, now metav1.Time) []schedulingv1alpha1.DeviceConditions {
+	conditions := make([]schedulingv1alpha1.DeviceConditions, 0, len(devices))
+	for i := range devices {
+		deviceInfo := &devices[i]
+		if deviceInfo.Minor == nil {
+			continue
+		}
+		deviceConditions := schedulingv1alpha1.DeviceConditions{
+			UUID:         deviceInfo.UUID,
+			Minor:        *deviceInfo.Minor,
+			Type:         deviceInfo.Type,
+			LastSeenTime: now,
+		}
+		healthy := schedulingv1alpha1.DeviceCondition{
+			Type:               schedulingv1alpha1.DeviceHealthy,
+			Status:             metav1.ConditionTrue,
+			Reason:             "DeviceHealthy",
+			LastTransitionTime: now,
+		}
+		if !deviceInfo.Health {
+			healthy.Status = metav1.ConditionFalse
+			healthy.Reason = "HealthCheckFailed"
+			healthy.Message = "device health check failed"
+		}
+		if deviceInfo.Type == schedulingv1alpha1.GPU {
+			s.gpuMutex.RLock()
+			if xids := s.gpuXIDErrors[deviceInfo.UUID]; len(xids) > 0 {
+				deviceConditions.XIDErrors = append([]int64{}, xids...)
+			}
+			s.gpuMutex.RUnlock()
+			if eccError := eccErrors[deviceInfo.UUID]; eccError != nil {
+				deviceConditions.ECCErrors = eccError.DeepCopy()
+			}
+
+			xidCondition := schedulingv1alpha1.DeviceCondition{
+				Type:               schedulingv1alpha1.DeviceXIDError,
+				Status:             metav1.ConditionFalse,
+				Reason:             "NoCriticalXIDError",
+				LastTransitionTime: now,
+			}
+			if len(deviceConditions.XIDErrors) > 0 {
+				xidCondition.Status = metav1.ConditionTrue
+				xidCondition.Reason = "CriticalXIDError"
+				xidCondition.Message = fmt.Sprintf("critical xid errors %v", deviceConditions.XIDErrors)
+				healthy.Status = metav1.ConditionFalse
+				healthy.Reason = xidCondition.Reason
+				healthy.Message = xidCondition.Message
+			}
+			eccCondition := schedulingv1alpha1.DeviceCondition{
+				Type:               schedulingv1alpha1.DeviceECCError,
+				Status:             metav1.ConditionFalse,
+				Reason:             "NoUncorrectableECCError",
+				LastTransitionTime: now,
+			}
+			if deviceConditions.ECCErrors != nil && deviceConditions.ECCErrors.Uncorrected > 0 {
+				eccCondition.Status = metav1.ConditionTrue
+				eccCondition.Reason = "UncorrectableECCError"
+				eccCondition.Message = fmt.Sprintf("%d uncorrectable ecc errors", deviceConditions.ECCErrors.Uncorrected)
+				healthy.Status = metav1.ConditionFalse
+				healthy.Reason = eccCondition.Reason
+				healthy.Message = eccCondition.Message
+			}
+			deviceConditions.Conditions = append(deviceConditions.Conditions, xidCondition, eccCondition)
+		}
+		deviceConditions.Conditions = append([]schedulingv1alpha1.DeviceCondition{healthy}, deviceConditions.Conditions...)
+		conditions = append(conditions, deviceConditions)
+	}
+	return conditions
+}
+
+// mergeDeviceConditions merges the newly built device conditions with the ones in the existing Device.
+// It keeps the LastTransitionTime of the conditions whose status is not changed and refreshes the LastSeenTime
+// only if the conditions change or deviceLastSeenUpdateInterval elapses. The devices which disappear from the
+// node are marked as unhealthy if the other devices of the same type are still reported, otherwise the whole
+// type of devices may be missing due to a failed discovery and the old conditions are kept.
+func mergeDeviceConditions(newConditions, oldConditions []schedulingv1alpha1.DeviceConditions, now time.Time) []schedulingv1alpha1.DeviceConditions {
+	type deviceKey struct {
+		deviceType schedulingv1alpha1.DeviceType
+		minor      int32
+	}
+	oldConditionsMap := make(map[deviceKey]*schedulingv1alpha1.DeviceConditions, len(oldConditions))
+	for i := range oldConditions {
+		oldConditionsMap[deviceKey{oldConditions[i].Type, oldConditions[i].Minor}] = &oldConditions[i]
+	}
+	reportedTypes := map[schedulingv1alpha1.DeviceType]bool{}
+	merged := make([]schedulingv1alpha1.DeviceConditions, 0, len(newConditions))
+	for i := range newConditions {
+		deviceConditions := newConditions[i].DeepCopy()
+		reportedTypes[deviceConditions.Type] = true
+		key := deviceKey{deviceConditions.Type, deviceConditions.Minor}
+		old, ok := oldConditionsMap[key]
+		delete(oldConditionsMap, key)
+		if ok {
+			mergeDeviceConditionTransitions(deviceConditions.Conditions, old.Conditions)
+			lastSeenTime := deviceConditions.LastSeenTime
+			deviceConditions.LastSeenTime = old.LastSeenTime
+			if !apiequality.Semantic.DeepEqual(deviceConditions, old) ||
+				now.Sub(old.LastSeenTime.Time) >= deviceLastSeenUpdateInterval {
+				deviceConditions.LastSeenTime = lastSeenTime
+			}
+		}
+		merged = append(merged, *deviceConditions)
+	}
+
+	for _, old := range oldConditionsMap {
+		deviceConditions := old.DeepCopy()
+		if reportedTypes[deviceConditions.Type] {
+			lost := []schedulingv1alpha1.DeviceCondition{
+				{
+					Type:               schedulingv1alpha1.DeviceHealthy,
+					Status:             metav1.ConditionFalse,
+					Reason:             "DeviceNotFound",
+					Message:            "device is not found on the node",
+					LastTransitionTime: metav1.NewTime(now).Rfc3339Copy(),
+				},
+			}
+			for _, condition := range deviceConditions.Conditions {
+				if condition.Type != schedulingv1alpha1.DeviceHealthy {
+					lost = append(lost, condition)
+				}
+			}
+			mergeDeviceConditionTransitions(lost, old.Conditions)
+			deviceConditions.Conditions = lost
+		}
+		merged = append(merged, *deviceConditions)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Type != merged[j].Type {
+			return merged[i].Type < merged[j].Type
+		}
+		return merged[i].Minor < merged[j].Minor
+	})
+	return merged
+}
+
+// mergeDeviceConditionTransitions keeps the LastTransitionTime of the conditions whose status is not changed.
+func mergeDeviceConditionTransitions(conditions, oldConditions []schedulingv1alpha1.DeviceCondition) {
+	for i := range conditions {
+		old := util.GetDeviceCondition(oldConditions, conditions[i].Type)
+		if old != nil && old.Status == conditions[i].Status {
+			conditions[i].LastTransitionTime = old.LastTransitionTime
+		}
+	}
+}
+
 func (s *statesInformer) buildGPUDevice() []schedulingv1alpha1.DeviceInfo {
 	queryParam := generateQueryParam()
 	nodeResource := s.metricsCache.GetNodeResourceMetric(queryParam)
@@ -155,6 +332,15 @@ func (s *statesInformer) buildGPUDevice() []schedulingv1alpha1.DeviceInfo {
 		klog.V(5).Info("no gpu device found")
 		return nil
 	}
+	var topologies map[int32]*schedulingv1alpha1.DeviceTopology
+	if s.getGPUTopologyFunc != nil {
+		var err error
+		topologies, err = s.getGPUTopologyFunc()
+		if err != nil {
+			// report the devices without topology
+			klog.Warningf("failed to get gpu topology, err: %v", err)
+		}
+	}
 	var deviceInfos []schedulingv1alpha1.DeviceInfo
 	for i := range nodeResource.Metric.GPUs {
 		gpu := nodeResource.Metric.GPUs[i]
@@ -174,11 +360,197 @@ func (s *statesInformer) buildGPUDevice() []schedulingv1alpha1.DeviceInfo {
 				extension.GPUMemory:      gpu.MemoryTotal,
 				extension.GPUMemoryRatio: *resource.NewQuantity(100, resource.DecimalSI),
 			},
+			Topology: topologies[gpu.Minor],
+		})
+	}
+	return deviceInfos
+}
+
+// getGPUTopology gets the PCIe, NUMA and NVLink topology of the GPUs by NVML and sysfs.
+func (s *statesInformer) getGPUTopology() (map[int32]*schedulingv1alpha1.DeviceTopology, error) {
+	count, ret := nvml.DeviceGetCount()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("unable to get device count: %v", nvml.ErrorString(ret))
+	}
+
+	numaNodeToSocket := s.getNUMANodeToSocket()
+	devices := map[int32]nvml.Device{}
+	topologies := map[int32]*schedulingv1alpha1.DeviceTopology{}
+	busIDToMinor := map[string]int32{}
+	for deviceIndex := 0; deviceIndex < count; deviceIndex++ {
+		gpuDevice, ret := nvml.DeviceGetHandleByIndex(deviceIndex)
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("unable to get device at index %d: %v", deviceIndex, nvml.ErrorString(ret))
+		}
+		minor, ret := gpuDevice.GetMinorNumber()
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("unable to get device minor number: %v", nvml.ErrorString(ret))
+		}
+		pciInfo, ret := gpuDevice.GetPciInfo()
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("unable to get device pci info: %v", nvml.ErrorString(ret))
+		}
+		busID := system.NormalizePCIBusID(pciBusIDToString(pciInfo.BusId))
+		devices[int32(minor)] = gpuDevice
+		topologies[int32(minor)] = getPCIDeviceTopology(busID, numaNodeToSocket)
+		busIDToMinor[busID] = int32(minor)
+	}
+
+	for minor, gpuDevice := range devices {
+		peers := map[int32]struct{}{}
+		for link := 0; link < nvml.NVLINK_MAX_LINKS; link++ {
+			state, ret := gpuDevice.GetNvLinkState(link)
+			if ret != nvml.SUCCESS || state != nvml.FEATURE_ENABLED {
+				continue
+			}
+			remotePCIInfo, ret := gpuDevice.GetNvLinkRemotePciInfo(link)
+			if ret != nvml.SUCCESS {
+				continue
+			}
+			// the remote device may be a NVSwitch rather than a GPU
+			if peer, ok := busIDToMinor[system.NormalizePCIBusID(pciBusIDToString(remotePCIInfo.BusId))]; ok && peer != minor {
+				peers[peer] = struct{}{}
+			}
+		}
+		for peer := range peers {
+			topologies[minor].NVLinks = append(topologies[minor].NVLinks, peer)
+		}
+		sort.Slice(topologies[minor].NVLinks, func(i, j int) bool {
+			return topologies[minor].NVLinks[i] < topologies[minor].NVLinks[j]
 		})
 	}
+	return topologies, nil
+}
+
+// getPCIDevices discovers the RDMA devices and FPGAs from sysfs. The minor of a device is its index in the devices
+// of the same type ordered by the bus ID, and each device could be allocated by 100 percent.
+func (s *statesInformer) getPCIDevices() []schedulingv1alpha1.DeviceInfo {
+	numaNodeToSocket := s.getNUMANodeToSocket()
+	var deviceInfos []schedulingv1alpha1.DeviceInfo
+	rdmaBusIDs, err := system.GetRDMADeviceBusIDs()
+	if err != nil {
+		klog.Warningf("failed to get rdma devices, err: %v", err)
+	}
+	deviceInfos = append(deviceInfos, buildPCIDeviceInfos(schedulingv1alpha1.RDMA, extension.KoordRDMA, rdmaBusIDs, numaNodeToSocket)...)
+	fpgaBusIDs, err := system.GetFPGADeviceBusIDs()
+	if err != nil {
+		klog.Warningf("failed to get fpga devices, err: %v", err)
+	}
+	deviceInfos = append(deviceInfos, buildPCIDeviceInfos(schedulingv1alpha1.FPGA, extension.KoordFPGA, fpgaBusIDs, numaNodeToSocket)...)
+	return deviceInfos
+}
+
+func buildPCIDeviceInfos(deviceType schedulingv1alpha1.DeviceType, resourceName corev1.ResourceName,
+	busIDs []string, numaNodeToSocket map[int32]int32) []schedulingv1alpha1.DeviceInfo {
+	deviceInfos := make([]schedulingv1alpha1.DeviceInfo, 0, len(busIDs))
+	for i, busID := range busIDs {
+		minor := int32(i)
+		deviceInfo := schedulingv1alpha1.DeviceInfo{
+			UUID:   busID,
+			Minor:  &minor,
+			Type:   deviceType,
+			Health: true,
+			Resources: map[corev1.ResourceName]resource.Quantity{
+				resourceName: *resource.NewQuantity(100, resource.DecimalSI),
+			},
+			Topology: getPCIDeviceTopology(busID, numaNodeToSocket),
+		}
+		vfBusIDs, err := system.ListPCIVirtualFunctions(busID)
+		if err != nil {
+			klog.V(4).Infof("failed to list virtual functions of %s device %s, err: %v", deviceType, busID, err)
+		}
+		for j, vfBusID := range vfBusIDs {
+			deviceInfo.VFs = append(deviceInfo.VFs, schedulingv1alpha1.VirtualFunction{
+				Minor: int32(j),
+				BusID: vfBusID,
+			})
+		}
+		deviceInfos = append(deviceInfos, deviceInfo)
+	}
 	return deviceInfos
 }
 
+func (s *statesInformer) getNUMANodeToSocket() map[int32]int32 {
+	numaNodeToSocket := map[int32]int32{}
+	if nodeCPUInfo, err := s.metricsCache.GetNodeCPUInfo(&metriccache.QueryParam{}); err == nil && nodeCPUInfo != nil {
+		for _, processor := range nodeCPUInfo.ProcessorInfos {
+			numaNodeToSocket[processor.NodeID] = processor.SocketID
+		}
+	}
+	return numaNodeToSocket
+}
+
+// getPCIDeviceTopology gets the NUMA node and PCIe root port of the PCI device from sysfs.
+func getPCIDeviceTopology(busID string, numaNodeToSocket map[int32]int32) *schedulingv1alpha1.DeviceTopology {
+	topology := &schedulingv1alpha1.DeviceTopology{
+		SocketID: -1,
+		NodeID:   -1,
+		BusID:    busID,
+	}
+	if numaNode, err := system.GetPCIDeviceNUMANode(busID); err == nil {
+		topology.NodeID = numaNode
+		if socketID, ok := numaNodeToSocket[numaNode]; ok {
+			topology.SocketID = socketID
+		}
+	} else {
+		klog.V(4).Infof("failed to get NUMA node of PCI device %s, err: %v", busID, err)
+	}
+	if rootPort, err := system.GetPCIDeviceRootPort(busID); err == nil {
+		topology.PCIEID = rootPort
+	} else {
+		klog.V(4).Infof("failed to get PCIe root port of PCI device %s, err: %v", busID, err)
+	}
+	return topology
+}
+
+// getGPUECCErrors gets the volatile ECC error counts of the GPUs by NVML, the GPUs with ECC disabled are skipped.
+func (s *statesInformer) getGPUECCErrors() map[string]*schedulingv1alpha1.DeviceECCErrors {
+	count, ret := nvml.DeviceGetCount()
+	if ret != nvml.SUCCESS {
+		klog.V(4).Infof("unable to get device count: %v", nvml.ErrorString(ret))
+		return nil
+	}
+	eccErrors := map[string]*schedulingv1alpha1.DeviceECCErrors{}
+	for deviceIndex := 0; deviceIndex < count; deviceIndex++ {
+		gpuDevice, ret := nvml.DeviceGetHandleByIndex(deviceIndex)
+		if ret != nvml.SUCCESS {
+			klog.V(4).Infof("unable to get device at index %d: %v", deviceIndex, nvml.ErrorString(ret))
+			continue
+		}
+		uuid, ret := gpuDevice.GetUUID()
+		if ret != nvml.SUCCESS {
+			klog.V(4).Infof("failed to get device uuid at index %d, err: %v", deviceIndex, nvml.ErrorString(ret))
+			continue
+		}
+		corrected, ret := gpuDevice.GetTotalEccErrors(nvml.MEMORY_ERROR_TYPE_CORRECTED, nvml.VOLATILE_ECC)
+		if ret != nvml.SUCCESS {
+			klog.V(5).Infof("failed to get corrected ecc errors of device %s, err: %v", uuid, nvml.ErrorString(ret))
+			continue
+		}
+		uncorrected, ret := gpuDevice.GetTotalEccErrors(nvml.MEMORY_ERROR_TYPE_UNCORRECTED, nvml.VOLATILE_ECC)
+		if ret != nvml.SUCCESS {
+			klog.V(5).Infof("failed to get uncorrected ecc errors of device %s, err: %v", uuid, nvml.ErrorString(ret))
+			continue
+		}
+		eccErrors[uuid] = &schedulingv1alpha1.DeviceECCErrors{
+			Corrected:   int64(corrected),
+			Uncorrected: int64(uncorrected),
+		}
+	}
+	return eccErrors
+}
+
+func pciBusIDToString(busID [32]int8) string {
+	var b strings.Builder
+	for _, c := range busID {
+		if c == 0 {
+			break
+		}
+		b.WriteByte(byte(c))
+	}
+	return b.String()
+}
+
 func (s *statesInformer) initGPU() bool {
 	if ret := nvml.Init(); ret != nvml.SUCCESS {
 		if ret == nvml.ERROR_LIBRARY_NOT_FOUND {
@@ -272,20 +644,33 @@ func (s *statesInformer) gpuHealCheck(stopCh <-chan struct{}) {
 		}
 		devices = append(devices, uuid)
 	}
-	unhealthyChan := make(chan string)
+	unhealthyChan := make(chan gpuXIDEvent)
 	go checkHealth(stopCh, devices, unhealthyChan)
 	klog.Info("start to do gpu health check")
-	for d := range unhealthyChan {
+	for e := range unhealthyChan {
 		// FIXME: there is no way to recover from the Unhealthy state.
 		s.gpuMutex.Lock()
-		s.unhealthyGPU[d] = struct{}{}
+		s.unhealthyGPU[e.uuid] = struct{}{}
+		if e.xid > 0 {
+			xids := append(s.gpuXIDErrors[e.uuid], e.xid)
+			if len(xids) > maxRecentXIDErrors {
+				xids = xids[len(xids)-maxRecentXIDErrors:]
+			}
+			s.gpuXIDErrors[e.uuid] = xids
+		}
 		s.gpuMutex.Unlock()
-		klog.Infof("get a unhealthy gpu %s", d)
+		klog.Infof("get a unhealthy gpu %s, xid %d", e.uuid, e.xid)
 	}
 }
 
+type gpuXIDEvent struct {
+	uuid string
+	// xid is the critical XID error of the GPU, it is zero if the GPU does not support health checking
+	xid int64
+}
+
 // check status of gpus, and send unhealthy devices to the unhealthyDeviceChan channel
-func checkHealth(stopCh <-chan struct{}, devs []string, xids chan<- string) {
+func checkHealth(stopCh <-chan struct{}, devs []string, xids chan<- gpuXIDEvent) {
 	eventSet, ret := nvml.EventSetCreate()
 	if ret != nvml.SUCCESS {
 		klog.Errorf("failed to create event set, err: %v", nvml.ErrorString(ret))
@@ -302,7 +687,7 @@ func checkHealth(stopCh <-chan struct{}, devs []string, xids chan<- string) {
 		ret = nvml.DeviceRegisterEvents(device, nvml.EventTypeXidCriticalError, eventSet)
 		if ret == nvml.ERROR_NOT_SUPPORTED {
 			klog.Infof("Warning: %s is too old to support healthchecking: %v. Marking it unhealthy.", d, nvml.ErrorString(ret))
-			xids <- d
+			xids <- gpuXIDEvent{uuid: d}
 			continue
 		}
 
@@ -339,14 +724,14 @@ func checkHealth(stopCh <-chan struct{}, devs []string, xids chan<- string) {
 		if len(uuid) == 0 {
 			// All devices are unhealthy
 			for _, d := range devs {
-				xids <- d
+				xids <- gpuXIDEvent{uuid: d, xid: int64(e.EventData)}
 			}
 			continue
 		}
 
 		for _, d := range devs {
 			if d == uuid {
-				xids <- d
+				xids <- gpuXIDEvent{uuid: d, xid: int64(e.EventData)}
 			}
 		}
 	}
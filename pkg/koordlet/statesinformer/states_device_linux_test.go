@@ -18,7 +18,11 @@ package statesinformer
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -32,6 +36,9 @@ import (
 	schedulingfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
 	mock_metriccache "github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache/mockmetriccache"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
 func Test_reportGPUDevice(t *testing.T) {
@@ -134,3 +141,273 @@ func Test_reportGPUDevice(t *testing.T) {
 	assert.Equal(t, device.Labels[extension.GPUModel], "A100")
 	assert.Equal(t, device.Labels[extension.GPUDriver], "470")
 }
+
+func Test_reportGPUDeviceWithTopology(t *testing.T) {
+	testNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+	}
+	fakeClient := schedulingfake.NewSimpleClientset().SchedulingV1alpha1().Devices()
+	ctl := gomock.NewController(t)
+	mockMetricCache := mock_metriccache.NewMockMetricCache(ctl)
+	fakeResult := metriccache.NodeResourceQueryResult{
+		Metric: &metriccache.NodeResourceMetric{
+			GPUs: []metriccache.GPUMetric{
+				{
+					DeviceUUID:  "1",
+					Minor:       0,
+					MemoryTotal: *resource.NewQuantity(8000, resource.BinarySI),
+				},
+				{
+					DeviceUUID:  "2",
+					Minor:       1,
+					MemoryTotal: *resource.NewQuantity(8000, resource.BinarySI),
+				},
+			},
+		},
+	}
+	mockMetricCache.EXPECT().GetNodeResourceMetric(gomock.Any()).Return(fakeResult).AnyTimes()
+	r := &statesInformer{
+		deviceClient: fakeClient,
+		metricsCache: mockMetricCache,
+		states: &pluginState{
+			informerPlugins: map[pluginName]informerPlugin{
+				nodeInformerName: &nodeInformer{
+					node: testNode,
+				},
+			},
+		},
+		getGPUDriverAndModelFunc: func() (string, string) {
+			return "A100", "470"
+		},
+		getGPUTopologyFunc: func() (map[int32]*schedulingv1alpha1.DeviceTopology, error) {
+			return map[int32]*schedulingv1alpha1.DeviceTopology{
+				0: {SocketID: 0, NodeID: 0, PCIEID: "0000:00:01.0", BusID: "0000:3b:00.0", NVLinks: []int32{1}},
+				1: {SocketID: 0, NodeID: 0, PCIEID: "0000:00:01.0", BusID: "0000:3c:00.0", NVLinks: []int32{0}},
+			}, nil
+		},
+	}
+	r.reportDevice()
+
+	device, err := fakeClient.Get(context.TODO(), "test", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, device.Spec.Devices, 2)
+	assert.Equal(t, &schedulingv1alpha1.DeviceTopology{SocketID: 0, NodeID: 0, PCIEID: "0000:00:01.0", BusID: "0000:3b:00.0", NVLinks: []int32{1}}, device.Spec.Devices[0].Topology)
+	assert.Equal(t, &schedulingv1alpha1.DeviceTopology{SocketID: 0, NodeID: 0, PCIEID: "0000:00:01.0", BusID: "0000:3c:00.0", NVLinks: []int32{0}}, device.Spec.Devices[1].Topology)
+
+	r.getGPUTopologyFunc = func() (map[int32]*schedulingv1alpha1.DeviceTopology, error) {
+		return nil, fmt.Errorf("nvml not found")
+	}
+	r.reportDevice()
+	device, err = fakeClient.Get(context.TODO(), "test", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, device.Spec.Devices, 2)
+	assert.Nil(t, device.Spec.Devices[0].Topology)
+}
+
+func Test_reportDeviceConditions(t *testing.T) {
+	testNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+	}
+	fakeClient := schedulingfake.NewSimpleClientset().SchedulingV1alpha1().Devices()
+	ctl := gomock.NewController(t)
+	mockMetricCache := mock_metriccache.NewMockMetricCache(ctl)
+	fakeResult := metriccache.NodeResourceQueryResult{
+		Metric: &metriccache.NodeResourceMetric{
+			GPUs: []metriccache.GPUMetric{
+				{DeviceUUID: "1", Minor: 0, MemoryTotal: *resource.NewQuantity(8000, resource.BinarySI)},
+				{DeviceUUID: "2", Minor: 1, MemoryTotal: *resource.NewQuantity(8000, resource.BinarySI)},
+				{DeviceUUID: "3", Minor: 2, MemoryTotal: *resource.NewQuantity(8000, resource.BinarySI)},
+			},
+		},
+	}
+	mockMetricCache.EXPECT().GetNodeResourceMetric(gomock.Any()).Return(fakeResult).Times(1)
+	r := &statesInformer{
+		deviceClient: fakeClient,
+		metricsCache: mockMetricCache,
+		states: &pluginState{
+			informerPlugins: map[pluginName]informerPlugin{
+				nodeInformerName: &nodeInformer{
+					node: testNode,
+				},
+			},
+		},
+		unhealthyGPU: map[string]struct{}{"2": {}},
+		gpuXIDErrors: map[string][]int64{"2": {79}},
+		getGPUDriverAndModelFunc: func() (string, string) {
+			return "A100", "470"
+		},
+		getGPUECCErrorsFunc: func() map[string]*schedulingv1alpha1.DeviceECCErrors {
+			return map[string]*schedulingv1alpha1.DeviceECCErrors{
+				"1": {Corrected: 3},
+				"3": {Corrected: 1, Uncorrected: 2},
+			}
+		},
+	}
+	r.reportDevice()
+
+	device, err := fakeClient.Get(context.TODO(), "test", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, device.Status.Conditions, 3)
+	healthy := func(minor int32) *schedulingv1alpha1.DeviceCondition {
+		return util.GetDeviceCondition(util.GetDeviceConditions(device, schedulingv1alpha1.GPU, minor).Conditions, schedulingv1alpha1.DeviceHealthy)
+	}
+	assert.Equal(t, metav1.ConditionTrue, healthy(0).Status)
+	assert.Equal(t, &schedulingv1alpha1.DeviceECCErrors{Corrected: 3}, device.Status.Conditions[0].ECCErrors)
+	assert.Equal(t, metav1.ConditionFalse, healthy(1).Status)
+	assert.Equal(t, "CriticalXIDError", healthy(1).Reason)
+	assert.Equal(t, []int64{79}, device.Status.Conditions[1].XIDErrors)
+	assert.Equal(t, metav1.ConditionFalse, healthy(2).Status)
+	assert.Equal(t, "UncorrectableECCError", healthy(2).Reason)
+	assert.False(t, device.Spec.Devices[1].Health)
+	assert.True(t, device.Spec.Devices[2].Health)
+
+	// the GPU 2 falls off the bus
+	fakeResult.Metric.GPUs = fakeResult.Metric.GPUs[:2]
+	mockMetricCache.EXPECT().GetNodeResourceMetric(gomock.Any()).Return(fakeResult).Times(1)
+	r.reportDevice()
+	device, err = fakeClient.Get(context.TODO(), "test", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, device.Spec.Devices, 2)
+	assert.Len(t, device.Status.Conditions, 3)
+	assert.Equal(t, metav1.ConditionFalse, healthy(2).Status)
+	assert.Equal(t, "DeviceNotFound", healthy(2).Reason)
+	assert.Equal(t, []schedulingv1alpha1.DeviceConditionType{schedulingv1alpha1.DeviceHealthy, schedulingv1alpha1.DeviceXIDError, schedulingv1alpha1.DeviceECCError},
+		[]schedulingv1alpha1.DeviceConditionType{device.Status.Conditions[2].Conditions[0].Type, device.Status.Conditions[2].Conditions[1].Type, device.Status.Conditions[2].Conditions[2].Type})
+}
+
+func Test_mergeDeviceConditions(t *testing.T) {
+	now := time.Now()
+	lastSeen := metav1.NewTime(now.Add(-10 * time.Second)).Rfc3339Copy()
+	lastTransition := metav1.NewTime(now.Add(-time.Hour)).Rfc3339Copy()
+	current := metav1.NewTime(now).Rfc3339Copy()
+	oldConditions := []schedulingv1alpha1.DeviceConditions{
+		{
+			UUID:         "1",
+			Minor:        0,
+			Type:         schedulingv1alpha1.GPU,
+			LastSeenTime: lastSeen,
+			Conditions: []schedulingv1alpha1.DeviceCondition{
+				{Type: schedulingv1alpha1.DeviceHealthy, Status: metav1.ConditionTrue, Reason: "DeviceHealthy", LastTransitionTime: lastTransition},
+			},
+		},
+		{
+			UUID:         "2",
+			Minor:        1,
+			Type:         schedulingv1alpha1.GPU,
+			LastSeenTime: lastSeen,
+			Conditions: []schedulingv1alpha1.DeviceCondition{
+				{Type: schedulingv1alpha1.DeviceHealthy, Status: metav1.ConditionTrue, Reason: "DeviceHealthy", LastTransitionTime: lastTransition},
+			},
+		},
+	}
+	newConditions := []schedulingv1alpha1.DeviceConditions{
+		{
+			UUID:         "1",
+			Minor:        0,
+			Type:         schedulingv1alpha1.GPU,
+			LastSeenTime: current,
+			Conditions: []schedulingv1alpha1.DeviceCondition{
+				{Type: schedulingv1alpha1.DeviceHealthy, Status: metav1.ConditionTrue, Reason: "DeviceHealthy", LastTransitionTime: current},
+			},
+		},
+		{
+			UUID:         "2",
+			Minor:        1,
+			Type:         schedulingv1alpha1.GPU,
+			LastSeenTime: current,
+			Conditions: []schedulingv1alpha1.DeviceCondition{
+				{Type: schedulingv1alpha1.DeviceHealthy, Status: metav1.ConditionFalse, Reason: "CriticalXIDError", LastTransitionTime: current},
+			},
+		},
+		{
+			UUID:         "0000:1f:00.0",
+			Minor:        0,
+			Type:         schedulingv1alpha1.RDMA,
+			LastSeenTime: current,
+			Conditions: []schedulingv1alpha1.DeviceCondition{
+				{Type: schedulingv1alpha1.DeviceHealthy, Status: metav1.ConditionTrue, Reason: "DeviceHealthy", LastTransitionTime: current},
+			},
+		},
+	}
+	merged := mergeDeviceConditions(newConditions, oldConditions, now)
+	assert.Len(t, merged, 3)
+	// unchanged device keeps the last seen time within the update interval
+	assert.Equal(t, oldConditions[0], merged[0])
+	// changed device refreshes the last seen time and the transition time
+	assert.Equal(t, newConditions[1], merged[1])
+	assert.Equal(t, newConditions[2], merged[2])
+
+	// refresh the last seen time after the update interval
+	merged = mergeDeviceConditions(newConditions, oldConditions, now.Add(deviceLastSeenUpdateInterval))
+	assert.Equal(t, current, merged[0].LastSeenTime)
+	assert.Equal(t, lastTransition, merged[0].Conditions[0].LastTransitionTime)
+
+	// keep the old conditions if no device of the type is reported
+	merged = mergeDeviceConditions(newConditions[2:], oldConditions, now)
+	assert.Equal(t, append(oldConditions, newConditions[2]), merged)
+}
+
+func Test_getPCIDevices(t *testing.T) {
+	tempDir := t.TempDir()
+	oldSysRootDir := system.Conf.SysRootDir
+	system.Conf.SysRootDir = tempDir
+	defer func() {
+		system.Conf.SysRootDir = oldSysRootDir
+	}()
+	pciDevicesDir := filepath.Join(tempDir, system.SysPCIDevicesSubDir)
+	newPCIDevice := func(busID, class, numaNode string) string {
+		deviceDir := filepath.Join(pciDevicesDir, busID)
+		assert.NoError(t, os.MkdirAll(deviceDir, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(deviceDir, system.SysPCIClassName), []byte(class), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(deviceDir, system.SysPCINUMANodeName), []byte(numaNode), 0644))
+		return deviceDir
+	}
+	pfDir := newPCIDevice("0000:1f:00.0", "0x020000", "1")
+	vfDir := newPCIDevice("0000:1f:00.2", "0x020000", "1")
+	assert.NoError(t, os.Symlink("../0000:1f:00.0", filepath.Join(vfDir, system.SysPCIPhysFnName)))
+	assert.NoError(t, os.Symlink("../0000:1f:00.2", filepath.Join(pfDir, system.SysPCIVirtFnPrefix+"0")))
+	rdmaDir := filepath.Join(tempDir, system.SysInfinibandSubDir, "mlx5_0")
+	assert.NoError(t, os.MkdirAll(rdmaDir, 0755))
+	assert.NoError(t, os.Symlink("../../../bus/pci/devices/0000:1f:00.0", filepath.Join(rdmaDir, system.SysInfinibandDeviceName)))
+	newPCIDevice("0000:af:00.0", "0x120000", "-1")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	mockMetricCache := mock_metriccache.NewMockMetricCache(ctl)
+	mockMetricCache.EXPECT().GetNodeCPUInfo(gomock.Any()).Return(&metriccache.NodeCPUInfo{
+		ProcessorInfos: []koordletutil.ProcessorInfo{
+			{CPUID: 0, CoreID: 0, SocketID: 0, NodeID: 0},
+			{CPUID: 1, CoreID: 1, SocketID: 1, NodeID: 1},
+		},
+	}, nil).AnyTimes()
+	s := &statesInformer{metricsCache: mockMetricCache}
+
+	expected := []schedulingv1alpha1.DeviceInfo{
+		{
+			UUID:   "0000:1f:00.0",
+			Minor:  pointer.Int32(0),
+			Type:   schedulingv1alpha1.RDMA,
+			Health: true,
+			Resources: corev1.ResourceList{
+				extension.KoordRDMA: *resource.NewQuantity(100, resource.DecimalSI),
+			},
+			Topology: &schedulingv1alpha1.DeviceTopology{SocketID: 1, NodeID: 1, BusID: "0000:1f:00.0"},
+			VFs:      []schedulingv1alpha1.VirtualFunction{{Minor: 0, BusID: "0000:1f:00.2"}},
+		},
+		{
+			UUID:   "0000:af:00.0",
+			Minor:  pointer.Int32(0),
+			Type:   schedulingv1alpha1.FPGA,
+			Health: true,
+			Resources: corev1.ResourceList{
+				extension.KoordFPGA: *resource.NewQuantity(100, resource.DecimalSI),
+			},
+			Topology: &schedulingv1alpha1.DeviceTopology{SocketID: -1, NodeID: -1, BusID: "0000:af:00.0"},
+		},
+	}
+	assert.Equal(t, expected, s.getPCIDevices())
+}
@@ -19,6 +19,10 @@ limitations under the License.
 
 package statesinformer
 
+import (
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
 func (s *statesInformer) reportDevice() {
 	return
 }
@@ -34,3 +38,15 @@ func (s *statesInformer) gpuHealCheck(stopCh <-chan struct{}) {
 func (s *statesInformer) getGPUDriverAndModel() (string, string) {
 	return "", ""
 }
+
+func (s *statesInformer) getGPUTopology() (map[int32]*schedulingv1alpha1.DeviceTopology, error) {
+	return nil, nil
+}
+
+func (s *statesInformer) getPCIDevices() []schedulingv1alpha1.DeviceInfo {
+	return nil
+}
+
+func (s *statesInformer) getGPUECCErrors() map[string]*schedulingv1alpha1.DeviceECCErrors {
+	return nil
+}
@@ -25,12 +25,14 @@ import (
 	_ "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned/scheme"
 	"go.uber.org/atomic"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	schedv1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/typed/scheduling/v1alpha1"
@@ -54,6 +56,8 @@ type StatesInformer interface {
 
 	GetNodeTopo() *topov1alpha1.NodeResourceTopology
 
+	GetPodDisruptionBudgets(namespace string) []*policyv1.PodDisruptionBudget
+
 	RegisterCallbacks(objType RegisterType, name, description string, callbackFn UpdateCbFn)
 }
 
@@ -75,12 +79,22 @@ type pluginState struct {
 
 type GetGPUDriverAndModelFunc func() (string, string)
 
+// GetGPUTopologyFunc returns the topology of the GPUs on the node indexed by the minor.
+type GetGPUTopologyFunc func() (map[int32]*schedulingv1alpha1.DeviceTopology, error)
+
+// GetPCIDevicesFunc returns the RDMA devices and FPGAs on the node.
+type GetPCIDevicesFunc func() []schedulingv1alpha1.DeviceInfo
+
+// GetGPUECCErrorsFunc returns the volatile ECC error counts of the GPUs on the node indexed by the UUID.
+type GetGPUECCErrorsFunc func() map[string]*schedulingv1alpha1.DeviceECCErrors
+
 type statesInformer struct {
 	// TODO refactor device as plugin
 	config       *Config
 	metricsCache metriccache.MetricCache
 	deviceClient schedv1alpha1.DeviceInterface
 	unhealthyGPU map[string]struct{}
+	gpuXIDErrors map[string][]int64
 	gpuMutex     sync.RWMutex
 
 	option  *pluginOption
@@ -88,6 +102,9 @@ type statesInformer struct {
 	started *atomic.Bool
 
 	getGPUDriverAndModelFunc GetGPUDriverAndModelFunc
+	getGPUTopologyFunc       GetGPUTopologyFunc
+	getPCIDevicesFunc        GetPCIDevicesFunc
+	getGPUECCErrorsFunc      GetGPUECCErrorsFunc
 }
 
 type informerPlugin interface {
@@ -115,12 +132,16 @@ func NewStatesInformer(config *Config, kubeClient clientset.Interface, crdClient
 		metricsCache: metricsCache,
 		deviceClient: schedulingClient.Devices(),
 		unhealthyGPU: make(map[string]struct{}),
+		gpuXIDErrors: make(map[string][]int64),
 
 		option:  opt,
 		states:  stat,
 		started: atomic.NewBool(false),
 	}
 	s.getGPUDriverAndModelFunc = s.getGPUDriverAndModel
+	s.getGPUTopologyFunc = s.getGPUTopology
+	s.getPCIDevicesFunc = s.getPCIDevices
+	s.getGPUECCErrorsFunc = s.getGPUECCErrors
 	s.initInformerPlugins()
 	return s
 }
@@ -226,6 +247,19 @@ func (s *statesInformer) GetAllPods() []*PodMeta {
 	return podsInformer.GetAllPods()
 }
 
+func (s *statesInformer) GetPodDisruptionBudgets(namespace string) []*policyv1.PodDisruptionBudget {
+	pdbInformerIf, exist := s.states.informerPlugins[pdbInformerName]
+	if !exist {
+		// the pdb informer is disabled
+		return nil
+	}
+	pdbInformer, ok := pdbInformerIf.(*pdbInformer)
+	if !ok {
+		klog.Fatalf("pdb informer format error")
+	}
+	return pdbInformer.GetPodDisruptionBudgets(namespace)
+}
+
 func (s *statesInformer) RegisterCallbacks(rType RegisterType, name, description string, callbackFn UpdateCbFn) {
 	s.states.callbackRunner.RegisterCallbacks(rType, name, description, callbackFn)
 }
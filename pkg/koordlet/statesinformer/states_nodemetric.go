@@ -45,6 +45,7 @@ import (
 	clientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	clientsetv1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/typed/slo/v1alpha1"
 	listerv1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 )
@@ -67,6 +68,15 @@ const (
 var (
 	scheme = runtime.NewScheme()
 
+	// aggregationTypes are the aggregated usages reported in NodeMetric for each duration
+	aggregationTypes = map[slov1alpha1.AggregationType]metriccache.AggregationType{
+		slov1alpha1.P50:  metriccache.AggregationTypeP50,
+		slov1alpha1.P90:  metriccache.AggregationTypeP90,
+		slov1alpha1.P95:  metriccache.AggregationTypeP95,
+		slov1alpha1.P99:  metriccache.AggregationTypeP99,
+		slov1alpha1.EWMA: metriccache.AggregationTypeEWMA,
+	}
+
 	defaultNodeMetricSpec = slov1alpha1.NodeMetricSpec{
 		CollectPolicy: &slov1alpha1.NodeMetricCollectPolicy{
 			AggregateDurationSeconds: pointer.Int64(defaultAggregateDurationSeconds),
@@ -328,9 +338,18 @@ func (r *nodeMetricInformer) collectMetric() (*slov1alpha1.NodeMetricInfo, []*sl
 		Start:     &startTime,
 		End:       &endTime,
 	}
+	ioCollectorEnabled := features.DefaultKoordletFeatureGate.Enabled(features.IOCollector)
+	if ioCollectorEnabled {
+		nodeMetricInfo.NodeIOUsage = r.queryNodeIOMetric(podQueryParam)
+	}
 	for _, podMeta := range podsMeta {
 		podMetric := r.collectPodMetric(podMeta, podQueryParam)
 		if podMetric != nil {
+			podMetric.AggregatedPodUsages = r.collectPodAggregateMetric(string(podMeta.Pod.UID), endTime,
+				spec.CollectPolicy.PodAggregatePolicy)
+			if ioCollectorEnabled {
+				podMetric.PodIOUsage = r.queryPodIOMetric(string(podMeta.Pod.UID), podQueryParam)
+			}
 			r.fillExtensionMap(podMetric, podMeta.Pod)
 			podsMetricInfo = append(podsMetricInfo, podMetric)
 		}
@@ -382,19 +401,62 @@ func (r *nodeMetricInformer) collectNodeAggregateMetric(endTime time.Time, aggre
 	for _, d := range aggregatePolicy.Durations {
 		start := endTime.Add(-d.Duration)
 		aggregateUsage := slov1alpha1.AggregatedUsage{
-			Usage: map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap{
-				slov1alpha1.P50: r.queryNodeMetric(start, endTime, metriccache.AggregationTypeP50, true),
-				slov1alpha1.P90: r.queryNodeMetric(start, endTime, metriccache.AggregationTypeP90, true),
-				slov1alpha1.P95: r.queryNodeMetric(start, endTime, metriccache.AggregationTypeP95, true),
-				slov1alpha1.P99: r.queryNodeMetric(start, endTime, metriccache.AggregationTypeP99, true),
-			},
+			Usage:    map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap{},
 			Duration: d,
 		}
+		for aggregationType, metricAggregationType := range aggregationTypes {
+			aggregateUsage.Usage[aggregationType] = r.queryNodeMetric(start, endTime, metricAggregationType, true)
+		}
 		aggregateUsages = append(aggregateUsages, aggregateUsage)
 	}
 	return aggregateUsages
 }
 
+func (r *nodeMetricInformer) collectPodAggregateMetric(podUID string, endTime time.Time, aggregatePolicy *slov1alpha1.AggregatePolicy) []slov1alpha1.AggregatedUsage {
+	if aggregatePolicy == nil || len(aggregatePolicy.Durations) == 0 {
+		return nil
+	}
+	var aggregateUsages []slov1alpha1.AggregatedUsage
+	for _, d := range aggregatePolicy.Durations {
+		start := endTime.Add(-d.Duration)
+		aggregateUsage := slov1alpha1.AggregatedUsage{
+			Usage:    map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap{},
+			Duration: d,
+		}
+		for aggregationType, metricAggregationType := range aggregationTypes {
+			aggregateUsage.Usage[aggregationType] = r.queryPodMetric(podUID, start, endTime, metricAggregationType, true)
+		}
+		aggregateUsages = append(aggregateUsages, aggregateUsage)
+	}
+	return aggregateUsages
+}
+
+func (r *nodeMetricInformer) queryPodMetric(podUID string, start time.Time, end time.Time, aggregateType metriccache.AggregationType,
+	coldStartFilter bool) slov1alpha1.ResourceMap {
+	queryParam := &metriccache.QueryParam{
+		Aggregate: aggregateType,
+		Start:     &start,
+		End:       &end,
+	}
+	queryResult := r.metricCache.GetPodResourceMetric(&podUID, queryParam)
+	if queryResult.Error != nil {
+		klog.V(5).Infof("get pod %v resource metric failed, error %v", podUID, queryResult.Error)
+		return slov1alpha1.ResourceMap{}
+	}
+	if queryResult.Metric == nil {
+		klog.V(5).Infof("pod %v metric not exist", podUID)
+		return slov1alpha1.ResourceMap{}
+	}
+
+	if coldStartFilter && metricsInColdStart(start, end, &queryResult.QueryResult) {
+		klog.V(5).Infof("pod %v metrics is in cold start, no need to report, current result sample duration %v",
+			podUID, queryResult.AggregateInfo.TimeRangeDuration().String())
+		return slov1alpha1.ResourceMap{}
+	}
+
+	return *convertPodMetricToResourceMap(queryResult.Metric)
+}
+
 func (r *nodeMetricInformer) collectPodMetric(podMeta *PodMeta, queryParam *metriccache.QueryParam) *slov1alpha1.PodMetricInfo {
 	if podMeta == nil || podMeta.Pod == nil {
 		return nil
@@ -416,6 +478,32 @@ func (r *nodeMetricInformer) collectPodMetric(podMeta *PodMeta, queryParam *metr
 	}
 }
 
+func (r *nodeMetricInformer) queryNodeIOMetric(queryParam *metriccache.QueryParam) *slov1alpha1.IOUsage {
+	queryResult := r.metricCache.GetNodeIOMetric(queryParam)
+	if queryResult.Error != nil {
+		klog.V(4).Infof("get node io metric failed, error %v", queryResult.Error)
+		return nil
+	}
+	if queryResult.Metric == nil {
+		klog.V(4).Infof("node io metric not exist")
+		return nil
+	}
+	return convertIOMetricToIOUsage(&queryResult.Metric.Network, &queryResult.Metric.DiskIO)
+}
+
+func (r *nodeMetricInformer) queryPodIOMetric(podUID string, queryParam *metriccache.QueryParam) *slov1alpha1.IOUsage {
+	queryResult := r.metricCache.GetPodIOMetric(&podUID, queryParam)
+	if queryResult.Error != nil {
+		klog.V(5).Infof("get pod %v io metric failed, error %v", podUID, queryResult.Error)
+		return nil
+	}
+	if queryResult.Metric == nil {
+		klog.V(5).Infof("pod %v io metric not exist", podUID)
+		return nil
+	}
+	return convertIOMetricToIOUsage(&queryResult.Metric.Network, &queryResult.Metric.DiskIO)
+}
+
 const (
 	statusUpdateQPS   = 0.1
 	statusUpdateBurst = 2
@@ -504,3 +592,16 @@ func convertPodMetricToResourceMap(podMetric *metriccache.PodResourceMetric) *sl
 		Devices: deviceInfos,
 	}
 }
+
+func convertIOMetricToIOUsage(network *metriccache.NetworkMetric, diskIO *metriccache.DiskIOMetric) *slov1alpha1.IOUsage {
+	return &slov1alpha1.IOUsage{
+		NetworkReceiveBytes:    *resource.NewQuantity(int64(network.RxBytes), resource.BinarySI),
+		NetworkTransmitBytes:   *resource.NewQuantity(int64(network.TxBytes), resource.BinarySI),
+		NetworkReceivePackets:  *resource.NewMilliQuantity(int64(network.RxPackets*1000), resource.DecimalSI),
+		NetworkTransmitPackets: *resource.NewMilliQuantity(int64(network.TxPackets*1000), resource.DecimalSI),
+		DiskReadBytes:          *resource.NewQuantity(int64(diskIO.ReadBytes), resource.BinarySI),
+		DiskWriteBytes:         *resource.NewQuantity(int64(diskIO.WriteBytes), resource.BinarySI),
+		DiskReadIOPS:           *resource.NewMilliQuantity(int64(diskIO.ReadIOPS*1000), resource.DecimalSI),
+		DiskWriteIOPS:          *resource.NewMilliQuantity(int64(diskIO.WriteIOPS*1000), resource.DecimalSI),
+	}
+}
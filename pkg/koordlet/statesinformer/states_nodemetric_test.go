@@ -18,6 +18,7 @@ package statesinformer
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -498,11 +499,12 @@ func Test_nodeMetricInformer_collectNodeMetric(t *testing.T) {
 	end := time.Now()
 	start := end.Add(-defaultAggregateDurationSeconds * time.Second)
 	type fields struct {
-		nodeResultAVG metriccache.NodeResourceQueryResult
-		nodeResultP50 metriccache.NodeResourceQueryResult
-		nodeResultP90 metriccache.NodeResourceQueryResult
-		nodeResultP95 metriccache.NodeResourceQueryResult
-		nodeResultP99 metriccache.NodeResourceQueryResult
+		nodeResultAVG  metriccache.NodeResourceQueryResult
+		nodeResultP50  metriccache.NodeResourceQueryResult
+		nodeResultP90  metriccache.NodeResourceQueryResult
+		nodeResultP95  metriccache.NodeResourceQueryResult
+		nodeResultP99  metriccache.NodeResourceQueryResult
+		nodeResultEWMA metriccache.NodeResourceQueryResult
 	}
 	tests := []struct {
 		name   string
@@ -601,6 +603,24 @@ func Test_nodeMetricInformer_collectNodeMetric(t *testing.T) {
 						},
 					},
 				},
+				nodeResultEWMA: metriccache.NodeResourceQueryResult{
+					QueryResult: metriccache.QueryResult{
+						AggregateInfo: &metriccache.AggregateInfo{
+							MetricStart:  &start,
+							MetricEnd:    &end,
+							MetricsCount: 1,
+						},
+						Error: nil,
+					},
+					Metric: &metriccache.NodeResourceMetric{
+						CPUUsed: metriccache.CPUMetric{
+							CPUUsed: *resource.NewQuantity(6, resource.DecimalSI),
+						},
+						MemoryUsed: metriccache.MemoryMetric{
+							MemoryWithoutCache: *resource.NewQuantity(6, resource.BinarySI),
+						},
+					},
+				},
 			},
 		},
 	}
@@ -629,6 +649,11 @@ func Test_nodeMetricInformer_collectNodeMetric(t *testing.T) {
 				Start:     &start,
 				End:       &end,
 			}).Return(tt.fields.nodeResultP99)
+			c.EXPECT().GetNodeResourceMetric(&metriccache.QueryParam{
+				Aggregate: metriccache.AggregationTypeEWMA,
+				Start:     &start,
+				End:       &end,
+			}).Return(tt.fields.nodeResultEWMA)
 			r := &nodeMetricInformer{
 				metricCache: c,
 				nodeMetric: &slov1alpha1.NodeMetric{
@@ -650,10 +675,11 @@ func Test_nodeMetricInformer_collectNodeMetric(t *testing.T) {
 				AggregatedNodeUsages: []slov1alpha1.AggregatedUsage{
 					{
 						Usage: map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap{
-							slov1alpha1.P50: convertNodeMetricToResourceMap(tt.fields.nodeResultP50.Metric),
-							slov1alpha1.P90: convertNodeMetricToResourceMap(tt.fields.nodeResultP90.Metric),
-							slov1alpha1.P95: convertNodeMetricToResourceMap(tt.fields.nodeResultP95.Metric),
-							slov1alpha1.P99: convertNodeMetricToResourceMap(tt.fields.nodeResultP99.Metric),
+							slov1alpha1.P50:  convertNodeMetricToResourceMap(tt.fields.nodeResultP50.Metric),
+							slov1alpha1.P90:  convertNodeMetricToResourceMap(tt.fields.nodeResultP90.Metric),
+							slov1alpha1.P95:  convertNodeMetricToResourceMap(tt.fields.nodeResultP95.Metric),
+							slov1alpha1.P99:  convertNodeMetricToResourceMap(tt.fields.nodeResultP99.Metric),
+							slov1alpha1.EWMA: convertNodeMetricToResourceMap(tt.fields.nodeResultEWMA.Metric),
 						},
 						Duration: metav1.Duration{
 							Duration: end.Sub(start),
@@ -821,3 +847,162 @@ func Test_metricsInColdStart(t *testing.T) {
 		})
 	}
 }
+
+func Test_nodeMetricInformer_collectPodAggregateMetric(t *testing.T) {
+	end := time.Now()
+	start := end.Add(-5 * time.Minute)
+	podUID := "test-pod-uid"
+	newPodResult := func(cpu, memory int64, metricStart time.Time) metriccache.PodResourceQueryResult {
+		return metriccache.PodResourceQueryResult{
+			QueryResult: metriccache.QueryResult{
+				AggregateInfo: &metriccache.AggregateInfo{
+					MetricStart:  &metricStart,
+					MetricEnd:    &end,
+					MetricsCount: 10,
+				},
+			},
+			Metric: &metriccache.PodResourceMetric{
+				PodUID: podUID,
+				CPUUsed: metriccache.CPUMetric{
+					CPUUsed: *resource.NewQuantity(cpu, resource.DecimalSI),
+				},
+				MemoryUsed: metriccache.MemoryMetric{
+					MemoryWithoutCache: *resource.NewQuantity(memory, resource.BinarySI),
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name            string
+		aggregatePolicy *slov1alpha1.AggregatePolicy
+		metricStart     time.Time
+		want            func() []slov1alpha1.AggregatedUsage
+	}{
+		{
+			name:            "no aggregate policy",
+			aggregatePolicy: nil,
+			want: func() []slov1alpha1.AggregatedUsage {
+				return nil
+			},
+		},
+		{
+			name: "collect pod aggregated usages",
+			aggregatePolicy: &slov1alpha1.AggregatePolicy{
+				Durations: []metav1.Duration{{Duration: 5 * time.Minute}},
+			},
+			metricStart: start,
+			want: func() []slov1alpha1.AggregatedUsage {
+				return []slov1alpha1.AggregatedUsage{
+					{
+						Usage: map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap{
+							slov1alpha1.P50:  *convertPodMetricToResourceMap(newPodResult(1, 1, start).Metric),
+							slov1alpha1.P90:  *convertPodMetricToResourceMap(newPodResult(2, 2, start).Metric),
+							slov1alpha1.P95:  *convertPodMetricToResourceMap(newPodResult(3, 3, start).Metric),
+							slov1alpha1.P99:  *convertPodMetricToResourceMap(newPodResult(4, 4, start).Metric),
+							slov1alpha1.EWMA: *convertPodMetricToResourceMap(newPodResult(5, 5, start).Metric),
+						},
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+					},
+				}
+			},
+		},
+		{
+			name: "pod metrics in cold start",
+			aggregatePolicy: &slov1alpha1.AggregatePolicy{
+				Durations: []metav1.Duration{{Duration: 5 * time.Minute}},
+			},
+			metricStart: end.Add(-time.Minute),
+			want: func() []slov1alpha1.AggregatedUsage {
+				return []slov1alpha1.AggregatedUsage{
+					{
+						Usage: map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap{
+							slov1alpha1.P50:  {},
+							slov1alpha1.P90:  {},
+							slov1alpha1.P95:  {},
+							slov1alpha1.P99:  {},
+							slov1alpha1.EWMA: {},
+						},
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+					},
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := mockmetriccache.NewMockMetricCache(ctrl)
+			for i, aggregationType := range []metriccache.AggregationType{metriccache.AggregationTypeP50,
+				metriccache.AggregationTypeP90, metriccache.AggregationTypeP95, metriccache.AggregationTypeP99,
+				metriccache.AggregationTypeEWMA} {
+				c.EXPECT().GetPodResourceMetric(&podUID, &metriccache.QueryParam{
+					Aggregate: aggregationType,
+					Start:     &start,
+					End:       &end,
+				}).Return(newPodResult(int64(i+1), int64(i+1), tt.metricStart)).AnyTimes()
+			}
+			r := &nodeMetricInformer{
+				metricCache: c,
+			}
+			got := r.collectPodAggregateMetric(podUID, end, tt.aggregatePolicy)
+			assert.Equal(t, tt.want(), got)
+		})
+	}
+}
+
+func Test_nodeMetricInformer_queryIOMetric(t *testing.T) {
+	end := time.Now()
+	start := end.Add(-time.Minute)
+	podUID := "test-pod-uid"
+	queryParam := &metriccache.QueryParam{
+		Aggregate: metriccache.AggregationTypeAVG,
+		Start:     &start,
+		End:       &end,
+	}
+	network := metriccache.NetworkMetric{
+		RxBytes:   2048,
+		TxBytes:   1024,
+		RxPackets: 1.5,
+		TxPackets: 2,
+	}
+	diskIO := metriccache.DiskIOMetric{
+		ReadBytes:  4096,
+		WriteBytes: 8192,
+		ReadIOPS:   1,
+		WriteIOPS:  2.5,
+	}
+	wantUsage := &slov1alpha1.IOUsage{
+		NetworkReceiveBytes:    *resource.NewQuantity(2048, resource.BinarySI),
+		NetworkTransmitBytes:   *resource.NewQuantity(1024, resource.BinarySI),
+		NetworkReceivePackets:  *resource.NewMilliQuantity(1500, resource.DecimalSI),
+		NetworkTransmitPackets: *resource.NewMilliQuantity(2000, resource.DecimalSI),
+		DiskReadBytes:          *resource.NewQuantity(4096, resource.BinarySI),
+		DiskWriteBytes:         *resource.NewQuantity(8192, resource.BinarySI),
+		DiskReadIOPS:           *resource.NewMilliQuantity(1000, resource.DecimalSI),
+		DiskWriteIOPS:          *resource.NewMilliQuantity(2500, resource.DecimalSI),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := mockmetriccache.NewMockMetricCache(ctrl)
+	r := &nodeMetricInformer{
+		metricCache: c,
+	}
+
+	c.EXPECT().GetNodeIOMetric(queryParam).Return(metriccache.NodeIOQueryResult{
+		Metric: &metriccache.NodeIOMetric{Network: network, DiskIO: diskIO},
+	}).Times(1)
+	assert.Equal(t, wantUsage, r.queryNodeIOMetric(queryParam))
+	c.EXPECT().GetNodeIOMetric(queryParam).Return(metriccache.NodeIOQueryResult{
+		QueryResult: metriccache.QueryResult{Error: fmt.Errorf("expected error")},
+	}).Times(1)
+	assert.Nil(t, r.queryNodeIOMetric(queryParam))
+
+	c.EXPECT().GetPodIOMetric(&podUID, queryParam).Return(metriccache.PodIOQueryResult{
+		Metric: &metriccache.PodIOMetric{PodUID: podUID, Network: network, DiskIO: diskIO},
+	}).Times(1)
+	assert.Equal(t, wantUsage, r.queryPodIOMetric(podUID, queryParam))
+	c.EXPECT().GetPodIOMetric(&podUID, queryParam).Return(metriccache.PodIOQueryResult{}).Times(1)
+	assert.Nil(t, r.queryPodIOMetric(podUID, queryParam))
+}
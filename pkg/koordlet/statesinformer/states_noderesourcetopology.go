@@ -31,7 +31,9 @@ import (
 	topologyclientset "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned"
 	topologylister "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/listers/topology/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -39,6 +41,7 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
+	kubeletconfiginternal "k8s.io/kubernetes/pkg/kubelet/apis/config"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/state"
 
@@ -191,13 +194,21 @@ func (s *nodeTopoInformer) createNodeTopoIfNotExist() {
 	}
 }
 
-func (s *nodeTopoInformer) calcNodeTopo() (map[string]string, error) {
+// nodeTopologyStatus is the calculated topology of the node to report.
+type nodeTopologyStatus struct {
+	Annotations      map[string]string
+	Zones            v1alpha1.ZoneList
+	TopologyPolicies []string
+}
+
+func (s *nodeTopoInformer) calcNodeTopo() (*nodeTopologyStatus, error) {
 	nodeCPUInfo, cpuTopology, sharedPoolCPUs, err := s.calCPUTopology()
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate cpu topology, err: %v", err)
 	}
 
 	var cpuManagerPolicy extension.KubeletCPUManagerPolicy
+	var topologyPolicies []string
 	if s.config != nil && !s.config.DisableQueryKubeletConfig {
 		kubeletConfiguration, err := s.kubelet.GetKubeletConfiguration()
 		if err != nil {
@@ -223,6 +234,8 @@ func (s *nodeTopoInformer) calcNodeTopo() (map[string]string, error) {
 			//  ensure that Burstable Pods (e.g. Pods request 0C but are limited to 4C)
 			//  at least there are reservedCPUs available when nodes are allocated
 		}
+
+		topologyPolicies = []string{string(getTopologyPolicy(kubeletConfiguration.TopologyManagerPolicy, kubeletConfiguration.TopologyManagerScope))}
 	}
 
 	cpuManagerPolicyJSON, err := json.Marshal(cpuManagerPolicy)
@@ -273,7 +286,86 @@ func (s *nodeTopoInformer) calcNodeTopo() (map[string]string, error) {
 		annotations[extension.AnnotationNodeCPUAllocs] = string(podAllocsJSON)
 	}
 
-	return annotations, nil
+	zones, err := s.calTopologyZones(nodeCPUInfo)
+	if err != nil {
+		// the NUMA Node resources are optional, e.g. the sysfs NUMA info may be unavailable
+		klog.V(4).Infof("failed to calculate the topology zones, err: %v", err)
+	}
+
+	return &nodeTopologyStatus{
+		Annotations:      annotations,
+		Zones:            zones,
+		TopologyPolicies: topologyPolicies,
+	}, nil
+}
+
+// calTopologyZones returns the resources of each NUMA Node as the topology zones.
+func (s *nodeTopoInformer) calTopologyZones(nodeCPUInfo *metriccache.NodeCPUInfo) (v1alpha1.ZoneList, error) {
+	numaInfos, err := koordletutil.GetNodeNUMAInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	cpusInNUMANode := map[int]int64{}
+	for _, processor := range nodeCPUInfo.ProcessorInfos {
+		cpusInNUMANode[int(processor.NodeID)]++
+	}
+
+	var zones v1alpha1.ZoneList
+	for _, numaInfo := range numaInfos {
+		var resources v1alpha1.ResourceInfoList
+		resources = append(resources, newResourceInfo(corev1.ResourceCPU, *resource.NewQuantity(cpusInNUMANode[numaInfo.NUMANodeID], resource.DecimalSI)))
+
+		var hugePagesBytes uint64
+		for pageSizeKB, number := range numaInfo.HugePages {
+			pageSize := resource.NewQuantity(int64(pageSizeKB*1024), resource.BinarySI)
+			resources = append(resources, newResourceInfo(corev1.ResourceName(corev1.ResourceHugePagesPrefix+pageSize.String()),
+				*resource.NewQuantity(int64(pageSizeKB*1024*number), resource.BinarySI)))
+			hugePagesBytes += pageSizeKB * 1024 * number
+		}
+
+		var memoryBytes uint64
+		if numaInfo.MemInfo != nil && numaInfo.MemInfo.MemTotal*1024 > hugePagesBytes {
+			memoryBytes = numaInfo.MemInfo.MemTotal*1024 - hugePagesBytes
+		}
+		resources = append(resources, newResourceInfo(corev1.ResourceMemory, *resource.NewQuantity(int64(memoryBytes), resource.BinarySI)))
+		sort.Slice(resources, func(i, j int) bool {
+			return resources[i].Name < resources[j].Name
+		})
+
+		zones = append(zones, v1alpha1.Zone{
+			Name:      extension.GenNUMANodeZoneName(numaInfo.NUMANodeID),
+			Type:      extension.NUMANodeZoneType,
+			Resources: resources,
+		})
+	}
+	return zones, nil
+}
+
+func newResourceInfo(name corev1.ResourceName, quantity resource.Quantity) v1alpha1.ResourceInfo {
+	return v1alpha1.ResourceInfo{
+		Name:        string(name),
+		Capacity:    quantity,
+		Allocatable: quantity,
+		Available:   quantity,
+	}
+}
+
+// getTopologyPolicy converts the TopologyManager policy and scope of the kubelet into the reported topology policy.
+func getTopologyPolicy(topologyManagerPolicy, topologyManagerScope string) v1alpha1.TopologyManagerPolicy {
+	switch topologyManagerPolicy {
+	case kubeletconfiginternal.SingleNumaNodeTopologyManagerPolicy:
+		if topologyManagerScope == kubeletconfiginternal.PodTopologyManagerScope {
+			return v1alpha1.SingleNUMANodePodLevel
+		}
+		return v1alpha1.SingleNUMANodeContainerLevel
+	case kubeletconfiginternal.RestrictedTopologyManagerPolicy:
+		return v1alpha1.Restricted
+	case kubeletconfiginternal.BestEffortTopologyManagerPolicy:
+		return v1alpha1.BestEffort
+	default:
+		return v1alpha1.None
+	}
 }
 
 func (s *nodeTopoInformer) calGuaranteedCpu(usedCPUs map[int32]*extension.CPUInfo, stateJSON string) ([]extension.PodCPUAlloc, error) {
@@ -353,7 +445,7 @@ func (s *nodeTopoInformer) reportNodeTopology() {
 		klog.V(5).Infof("feature %v not enabled, node topo will not be reported", features.NodeTopologyReport)
 	}
 
-	nodeTopoAnnotations, err := s.calcNodeTopo()
+	nodeTopoStatus, err := s.calcNodeTopo()
 	if err != nil {
 		klog.Errorf("failed to calculate node topology, err: %v", err)
 		return
@@ -376,9 +468,15 @@ func (s *nodeTopoInformer) reportNodeTopology() {
 		if nodeResourceTopology.Annotations == nil {
 			nodeResourceTopology.Annotations = make(map[string]string)
 		}
-		for k, v := range nodeTopoAnnotations {
+		for k, v := range nodeTopoStatus.Annotations {
 			nodeResourceTopology.Annotations[k] = v
 		}
+		if len(nodeTopoStatus.Zones) > 0 {
+			nodeResourceTopology.Zones = nodeTopoStatus.Zones
+		}
+		if len(nodeTopoStatus.TopologyPolicies) > 0 {
+			nodeResourceTopology.TopologyPolicies = nodeTopoStatus.TopologyPolicies
+		}
 
 		if isSyncNeeded(s.nodeTopology, nodeResourceTopology, node.Name) {
 			// do UPDATE
@@ -403,7 +501,9 @@ func isSyncNeeded(oldNRT, newNRT *v1alpha1.NodeResourceTopology, nodename string
 	if oldNRT == nil || oldNRT.Annotations == nil || newNRT.Annotations == nil {
 		return true
 	}
-	if isEqualTopo(oldNRT.Annotations, newNRT.Annotations) {
+	if isEqualTopo(oldNRT.Annotations, newNRT.Annotations) &&
+		apiequality.Semantic.DeepEqual(oldNRT.Zones, newNRT.Zones) &&
+		apiequality.Semantic.DeepEqual(oldNRT.TopologyPolicies, newNRT.TopologyPolicies) {
 		// do nothing
 		klog.V(4).Infof("all good, no need to report nodetopo  %s", nodename)
 		return false
@@ -19,6 +19,9 @@ package statesinformer
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -27,7 +30,9 @@ import (
 	topologylister "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/listers/topology/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -41,6 +46,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
 	mock_metriccache "github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache/mockmetriccache"
 	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
 )
 
 var _ topologylister.NodeResourceTopologyLister = &fakeNodeResourceTopologyLister{}
@@ -351,6 +357,32 @@ func Test_reportNodeTopology(t *testing.T) {
 	}
 	mockMetricCache.EXPECT().GetNodeCPUInfo(gomock.Any()).Return(&mockNodeCPUInfo, nil).AnyTimes()
 
+	tempDir := t.TempDir()
+	oldSysRootDir := system.Conf.SysRootDir
+	system.Conf.SysRootDir = tempDir
+	defer func() {
+		system.Conf.SysRootDir = oldSysRootDir
+	}()
+	for i := 0; i < 2; i++ {
+		numaNodeDir := filepath.Join(tempDir, system.SysNUMANodeSubDir, fmt.Sprintf("node%d", i))
+		hugePagesDir := filepath.Join(numaNodeDir, system.SysHugePagesSubDir, "hugepages-1048576kB")
+		assert.NoError(t, os.MkdirAll(hugePagesDir, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(numaNodeDir, system.ProcMemInfoName), []byte(fmt.Sprintf("Node %d MemTotal:       16777216 kB\n", i)), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(hugePagesDir, system.SysHugePagesNumberName), []byte("2\n"), 0644))
+	}
+	var expectedZones topologyv1alpha1.ZoneList
+	for i := 0; i < 2; i++ {
+		expectedZones = append(expectedZones, topologyv1alpha1.Zone{
+			Name: extension.GenNUMANodeZoneName(i),
+			Type: extension.NUMANodeZoneType,
+			Resources: topologyv1alpha1.ResourceInfoList{
+				newResourceInfo(corev1.ResourceCPU, resource.MustParse("4")),
+				newResourceInfo(corev1.ResourceName("hugepages-1Gi"), resource.MustParse("2Gi")),
+				newResourceInfo(corev1.ResourceMemory, resource.MustParse("14Gi")),
+			},
+		})
+	}
+
 	expectedCPUSharedPool := `[{"socket":0,"node":0,"cpuset":"0-2"},{"socket":1,"node":1,"cpuset":"6-7"}]`
 	expectedCPUTopology := `{"detail":[{"id":0,"core":0,"socket":0,"node":0},{"id":1,"core":0,"socket":0,"node":0},{"id":2,"core":1,"socket":0,"node":0},{"id":3,"core":1,"socket":0,"node":0},{"id":4,"core":2,"socket":1,"node":1},{"id":5,"core":2,"socket":1,"node":1},{"id":6,"core":3,"socket":1,"node":1},{"id":7,"core":3,"socket":1,"node":1}]}`
 
@@ -362,6 +394,7 @@ func Test_reportNodeTopology(t *testing.T) {
 		expectedKubeletCPUManagerPolicy extension.KubeletCPUManagerPolicy
 		expectedCPUSharedPool           string
 		expectedCPUTopology             string
+		expectedTopologyPolicies        []string
 	}{
 		{
 			name:   "report topology",
@@ -372,14 +405,17 @@ func Test_reportNodeTopology(t *testing.T) {
 					KubeReserved: map[string]string{
 						"cpu": "2000m",
 					},
+					TopologyManagerPolicy: kubeletconfiginternal.SingleNumaNodeTopologyManagerPolicy,
+					TopologyManagerScope:  kubeletconfiginternal.PodTopologyManagerScope,
 				},
 			},
 			expectedKubeletCPUManagerPolicy: extension.KubeletCPUManagerPolicy{
 				Policy:       "static",
 				ReservedCPUs: "0-1",
 			},
-			expectedCPUSharedPool: expectedCPUSharedPool,
-			expectedCPUTopology:   expectedCPUTopology,
+			expectedCPUSharedPool:    expectedCPUSharedPool,
+			expectedCPUTopology:      expectedCPUTopology,
+			expectedTopologyPolicies: []string{string(topologyv1alpha1.SingleNUMANodePodLevel)},
 		},
 		{
 			name: "disable query topology",
@@ -417,8 +453,9 @@ func Test_reportNodeTopology(t *testing.T) {
 				Policy:       "static",
 				ReservedCPUs: "0-1",
 			},
-			expectedCPUSharedPool: expectedCPUSharedPool,
-			expectedCPUTopology:   expectedCPUTopology,
+			expectedCPUSharedPool:    expectedCPUSharedPool,
+			expectedCPUTopology:      expectedCPUTopology,
+			expectedTopologyPolicies: []string{string(topologyv1alpha1.None)},
 		},
 	}
 	for _, tt := range tests {
@@ -479,6 +516,8 @@ func Test_reportNodeTopology(t *testing.T) {
 			assert.Equal(t, tt.expectedKubeletCPUManagerPolicy, kubeletCPUManagerPolicy)
 			assert.Equal(t, tt.expectedCPUSharedPool, topology.Annotations[extension.AnnotationNodeCPUSharedPools])
 			assert.Equal(t, tt.expectedCPUTopology, topology.Annotations[extension.AnnotationNodeCPUTopology])
+			assert.True(t, apiequality.Semantic.DeepEqual(expectedZones, topology.Zones), topology.Zones)
+			assert.Equal(t, tt.expectedTopologyPolicies, topology.TopologyPolicies)
 		})
 	}
 }
@@ -584,3 +623,44 @@ func Test_isEqualTopo(t *testing.T) {
 		})
 	}
 }
+
+func Test_getTopologyPolicy(t *testing.T) {
+	tests := []struct {
+		name                  string
+		topologyManagerPolicy string
+		topologyManagerScope  string
+		want                  topologyv1alpha1.TopologyManagerPolicy
+	}{
+		{
+			name:                  "single-numa-node with pod scope",
+			topologyManagerPolicy: kubeletconfiginternal.SingleNumaNodeTopologyManagerPolicy,
+			topologyManagerScope:  kubeletconfiginternal.PodTopologyManagerScope,
+			want:                  topologyv1alpha1.SingleNUMANodePodLevel,
+		},
+		{
+			name:                  "single-numa-node with container scope",
+			topologyManagerPolicy: kubeletconfiginternal.SingleNumaNodeTopologyManagerPolicy,
+			topologyManagerScope:  kubeletconfiginternal.ContainerTopologyManagerScope,
+			want:                  topologyv1alpha1.SingleNUMANodeContainerLevel,
+		},
+		{
+			name:                  "restricted",
+			topologyManagerPolicy: kubeletconfiginternal.RestrictedTopologyManagerPolicy,
+			want:                  topologyv1alpha1.Restricted,
+		},
+		{
+			name:                  "best-effort",
+			topologyManagerPolicy: kubeletconfiginternal.BestEffortTopologyManagerPolicy,
+			want:                  topologyv1alpha1.BestEffort,
+		},
+		{
+			name: "default none",
+			want: topologyv1alpha1.None,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getTopologyPolicy(tt.topologyManagerPolicy, tt.topologyManagerScope))
+		})
+	}
+}
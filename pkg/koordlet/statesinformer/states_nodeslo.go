@@ -243,6 +243,7 @@ func mergeNoneResourceQOSIfDisabled(resourceQOS *slov1alpha1.ResourceQOSStrategy
 	mergeNoneCPUQOSIfDisabled(resourceQOS)
 	mergeNoneResctrlQOSIfDisabled(resourceQOS)
 	mergeNoneMemoryQOSIfDisabled(resourceQOS)
+	mergeNoneIOQOSIfDisabled(resourceQOS)
 	klog.V(5).Infof("get merged node ResourceQOS %v", util.DumpJSON(resourceQOS))
 }
 
@@ -279,6 +280,22 @@ func mergeNoneMemoryQOSIfDisabled(resourceQOS *slov1alpha1.ResourceQOSStrategy)
 	}
 }
 
+// mergeNoneIOQOSIfDisabled completes node's io qos config according to Enable options in IOQOS
+func mergeNoneIOQOSIfDisabled(resourceQOS *slov1alpha1.ResourceQOSStrategy) {
+	if resourceQOS.LSRClass != nil && resourceQOS.LSRClass.IOQOS != nil &&
+		resourceQOS.LSRClass.IOQOS.Enable != nil && !(*resourceQOS.LSRClass.IOQOS.Enable) {
+		resourceQOS.LSRClass.IOQOS.IOQOS = *util.NoneIOQOS()
+	}
+	if resourceQOS.LSClass != nil && resourceQOS.LSClass.IOQOS != nil &&
+		resourceQOS.LSClass.IOQOS.Enable != nil && !(*resourceQOS.LSClass.IOQOS.Enable) {
+		resourceQOS.LSClass.IOQOS.IOQOS = *util.NoneIOQOS()
+	}
+	if resourceQOS.BEClass != nil && resourceQOS.BEClass.IOQOS != nil &&
+		resourceQOS.BEClass.IOQOS.Enable != nil && !(*resourceQOS.BEClass.IOQOS.Enable) {
+		resourceQOS.BEClass.IOQOS.IOQOS = *util.NoneIOQOS()
+	}
+}
+
 func mergeNoneCPUQOSIfDisabled(resourceQOS *slov1alpha1.ResourceQOSStrategy) {
 	// if CPUQOS.Enabled=false, merge with NoneCPUQOS
 	if resourceQOS.LSRClass != nil && resourceQOS.LSRClass.CPUQOS != nil &&
@@ -48,6 +48,9 @@ func Test_mergeNodeSLOSpec(t *testing.T) {
 						CATRangeEndPercent: pointer.Int64Ptr(50),
 					},
 				},
+				IOQOS: &slov1alpha1.IOQOSCfg{
+					Enable: pointer.BoolPtr(true),
+				},
 			},
 		},
 		CPUBurstStrategy: &slov1alpha1.CPUBurstStrategy{
@@ -225,13 +228,16 @@ func Test_updateNodeSLOSpec(t *testing.T) {
 	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.LSRClass.CPUQOS.CPUQOS = *util.NoneCPUQOS()
 	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.LSRClass.MemoryQOS.MemoryQOS = *util.NoneMemoryQOS()
 	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.LSRClass.ResctrlQOS.ResctrlQOS = *util.NoneResctrlQOS()
+	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.LSRClass.IOQOS.IOQOS = *util.NoneIOQOS()
 
 	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.LSClass.CPUQOS.CPUQOS = *util.NoneCPUQOS()
 	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.LSClass.MemoryQOS.MemoryQOS = *util.NoneMemoryQOS()
 	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.LSClass.ResctrlQOS.ResctrlQOS = *util.NoneResctrlQOS()
+	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.LSClass.IOQOS.IOQOS = *util.NoneIOQOS()
 
 	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.BEClass.CPUQOS.CPUQOS = *util.NoneCPUQOS()
 	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.BEClass.MemoryQOS.MemoryQOS = *util.NoneMemoryQOS()
+	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.BEClass.IOQOS.IOQOS = *util.NoneIOQOS()
 	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.BEClass.ResctrlQOS.Enable = pointer.BoolPtr(true)
 	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.BEClass.ResctrlQOS.CATRangeStartPercent = pointer.Int64Ptr(0)
 	testingUpdatedNodeSLO.Spec.ResourceQOSStrategy.BEClass.ResctrlQOS.CATRangeEndPercent = pointer.Int64Ptr(20)
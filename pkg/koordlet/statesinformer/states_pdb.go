@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statesinformer
+
+import (
+	"time"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	policyinformers "k8s.io/client-go/informers/policy/v1"
+	policylisters "k8s.io/client-go/listers/policy/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+const (
+	pdbInformerName pluginName = "pdbInformer"
+)
+
+// pdbInformer caches the PodDisruptionBudgets of the cluster, which are used to rank the eviction victims.
+type pdbInformer struct {
+	pdbInformer cache.SharedIndexInformer
+	pdbLister   policylisters.PodDisruptionBudgetLister
+}
+
+func NewPDBInformer() *pdbInformer {
+	return &pdbInformer{}
+}
+
+func (s *pdbInformer) GetPodDisruptionBudgets(namespace string) []*policyv1.PodDisruptionBudget {
+	if s.pdbLister == nil {
+		return nil
+	}
+	pdbs, err := s.pdbLister.PodDisruptionBudgets(namespace).List(labels.Everything())
+	if err != nil {
+		klog.Warningf("failed to list PodDisruptionBudgets in namespace %s, err: %v", namespace, err)
+		return nil
+	}
+	return pdbs
+}
+
+func (s *pdbInformer) Setup(ctx *pluginOption, state *pluginState) {
+	s.pdbInformer = policyinformers.NewPodDisruptionBudgetInformer(ctx.KubeClient, "", time.Hour*12,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	s.pdbLister = policylisters.NewPodDisruptionBudgetLister(s.pdbInformer.GetIndexer())
+}
+
+func (s *pdbInformer) Start(stopCh <-chan struct{}) {
+	klog.V(2).Infof("starting pdb informer")
+	go s.pdbInformer.Run(stopCh)
+	klog.V(2).Infof("pdb informer started")
+}
+
+func (s *pdbInformer) HasSynced() bool {
+	if s.pdbInformer == nil {
+		return false
+	}
+	synced := s.pdbInformer.HasSynced()
+	klog.V(5).Infof("pdb informer has synced %v", synced)
+	return synced
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statesinformer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/koordinator-sh/koordinator/pkg/features"
+)
+
+func Test_pdbInformer_GetPodDisruptionBudgets(t *testing.T) {
+	s := NewPDBInformer()
+	assert.Nil(t, s.GetPodDisruptionBudgets("default"))
+
+	s.Setup(&pluginOption{KubeClient: fake.NewSimpleClientset()}, &pluginState{})
+	pdbs := []*policyv1.PodDisruptionBudget{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pdb-a", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pdb-b", Namespace: "other"}},
+	}
+	for _, pdb := range pdbs {
+		assert.NoError(t, s.pdbInformer.GetIndexer().Add(pdb))
+	}
+	assert.Equal(t, []*policyv1.PodDisruptionBudget{pdbs[0]}, s.GetPodDisruptionBudgets("default"))
+	assert.Empty(t, s.GetPodDisruptionBudgets("empty"))
+}
+
+func Test_statesInformer_PDBInformerFeatureGate(t *testing.T) {
+	s := &statesInformer{states: &pluginState{}}
+	s.initInformerPlugins()
+	_, exist := s.states.informerPlugins[pdbInformerName]
+	assert.False(t, exist)
+	assert.Nil(t, s.GetPodDisruptionBudgets("default"))
+
+	err := features.DefaultMutableKoordletFeatureGate.SetFromMap(map[string]bool{string(features.PDBHeadroomVictimPolicy): true})
+	assert.NoError(t, err)
+	defer features.DefaultMutableKoordletFeatureGate.SetFromMap(map[string]bool{string(features.PDBHeadroomVictimPolicy): false})
+	s.initInformerPlugins()
+	_, exist = s.states.informerPlugins[pdbInformerName]
+	assert.True(t, exist)
+}
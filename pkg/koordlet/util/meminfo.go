@@ -19,7 +19,9 @@ package util
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -95,9 +97,14 @@ func readMemInfo(path string) (*MemInfo, error) {
 		if len(fields) < 2 {
 			continue
 		}
+		// the lines of the NUMA meminfo are prefixed with the node, e.g. "Node 0 MemTotal:"
+		keyFields := strings.Fields(fields[0])
 		valFields := strings.Fields(fields[1])
+		if len(keyFields) <= 0 || len(valFields) <= 0 {
+			continue
+		}
 		val, _ := strconv.ParseUint(valFields[0], 10, 64)
-		statMap[fields[0]] = val
+		statMap[keyFields[len(keyFields)-1]] = val
 	}
 
 	elem := reflect.ValueOf(&info).Elem()
@@ -129,6 +136,83 @@ func GetMemInfoUsageKB() (int64, error) {
 	return usage, nil
 }
 
+// NUMAInfo is the memory info of a NUMA Node.
+type NUMAInfo struct {
+	NUMANodeID int      `json:"numa_node_id"`
+	MemInfo    *MemInfo `json:"mem_info"`
+	// HugePages is the number of the hugepages indexed by the page size (kB)
+	HugePages map[uint64]uint64 `json:"huge_pages,omitempty"`
+}
+
+// GetNodeNUMAInfo returns the memory info of the NUMA Nodes on the node, which is sorted by the NUMA Node id.
+func GetNodeNUMAInfo() ([]NUMAInfo, error) {
+	numaNodeRootDir := filepath.Join(system.Conf.SysRootDir, system.SysNUMANodeSubDir)
+	entries, err := os.ReadDir(numaNodeRootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var numaInfos []NUMAInfo
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), system.SysNUMANodePrefix) {
+			continue
+		}
+		numaNodeID, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), system.SysNUMANodePrefix))
+		if err != nil {
+			continue
+		}
+		numaNodeDir := filepath.Join(numaNodeRootDir, entry.Name())
+		memInfo, err := readMemInfo(filepath.Join(numaNodeDir, system.ProcMemInfoName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read meminfo of NUMA node %d, err: %v", numaNodeID, err)
+		}
+		hugePages, err := readNUMAHugePages(filepath.Join(numaNodeDir, system.SysHugePagesSubDir))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read hugepages of NUMA node %d, err: %v", numaNodeID, err)
+		}
+		numaInfos = append(numaInfos, NUMAInfo{
+			NUMANodeID: numaNodeID,
+			MemInfo:    memInfo,
+			HugePages:  hugePages,
+		})
+	}
+	sort.Slice(numaInfos, func(i, j int) bool {
+		return numaInfos[i].NUMANodeID < numaInfos[j].NUMANodeID
+	})
+	return numaInfos, nil
+}
+
+// readNUMAHugePages reads the number of the hugepages from the dir like "hugepages/hugepages-2048kB/nr_hugepages".
+func readNUMAHugePages(hugePagesDir string) (map[uint64]uint64, error) {
+	entries, err := os.ReadDir(hugePagesDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	hugePages := map[uint64]uint64{}
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), system.SysHugePagesPrefix) {
+			continue
+		}
+		pageSize, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(entry.Name(), system.SysHugePagesPrefix), "kB"), 10, 64)
+		if err != nil {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(hugePagesDir, entry.Name(), system.SysHugePagesNumberName))
+		if err != nil {
+			return nil, err
+		}
+		number, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse hugepages %s, err: %v", entry.Name(), err)
+		}
+		hugePages[pageSize] = number
+	}
+	return hugePages, nil
+}
+
 // DEPRECATED: use NewCgroupReader().ReadMemoryStat() instead.
 func readCgroupMemStat(memStatPath string) (int64, error) {
 	// memory.stat usage: total_inactive_anon + total_active_anon + total_unevictable
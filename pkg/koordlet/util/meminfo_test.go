@@ -17,6 +17,7 @@ limitations under the License.
 package util
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -245,3 +246,47 @@ func TestGetContainerMemStatUsageBytes(t *testing.T) {
 	_, err := GetContainerMemStatUsageBytes(tempDir, container)
 	assert.NotNil(t, err)
 }
+
+func Test_GetNodeNUMAInfo(t *testing.T) {
+	tempDir := t.TempDir()
+	oldSysRootDir := system.Conf.SysRootDir
+	system.Conf.SysRootDir = tempDir
+	defer func() {
+		system.Conf.SysRootDir = oldSysRootDir
+	}()
+
+	numaMemInfo := `Node %d MemTotal:       263432804 kB
+Node %d MemFree:        254391744 kB
+Node %d MemUsed:         9041060 kB
+Node %d Active:          2602884 kB
+Node %d Inactive:        3815256 kB
+Node %d Active(anon):     115104 kB
+Node %d HugePages_Total:     0
+`
+	for i := 0; i < 2; i++ {
+		numaNodeDir := filepath.Join(tempDir, system.SysNUMANodeSubDir, fmt.Sprintf("node%d", i))
+		assert.NoError(t, os.MkdirAll(numaNodeDir, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(numaNodeDir, system.ProcMemInfoName),
+			[]byte(fmt.Sprintf(numaMemInfo, i, i, i, i, i, i, i)), 0644))
+		hugePagesDir := filepath.Join(numaNodeDir, system.SysHugePagesSubDir, "hugepages-2048kB")
+		assert.NoError(t, os.MkdirAll(hugePagesDir, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(hugePagesDir, system.SysHugePagesNumberName), []byte(fmt.Sprintf("%d\n", i*512)), 0644))
+	}
+	// the non-NUMA entries should be ignored
+	assert.NoError(t, os.MkdirAll(filepath.Join(tempDir, system.SysNUMANodeSubDir, "power"), 0755))
+
+	got, err := GetNodeNUMAInfo()
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	for i, numaInfo := range got {
+		assert.Equal(t, i, numaInfo.NUMANodeID)
+		assert.Equal(t, uint64(263432804), numaInfo.MemInfo.MemTotal)
+		assert.Equal(t, uint64(254391744), numaInfo.MemInfo.MemFree)
+		assert.Equal(t, uint64(115104), numaInfo.MemInfo.ActiveAnon)
+		assert.Equal(t, map[uint64]uint64{2048: uint64(i * 512)}, numaInfo.HugePages)
+	}
+
+	system.Conf.SysRootDir = filepath.Join(tempDir, "not-exist")
+	_, err = GetNodeNUMAInfo()
+	assert.Error(t, err)
+}
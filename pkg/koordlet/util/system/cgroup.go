@@ -68,6 +68,14 @@ func (m *MemoryStatRaw) Usage() int64 {
 	return m.InactiveAnon + m.ActiveAnon + m.Unevictable
 }
 
+// BlkIOStatRaw is the accumulated block io of a cgroup, summed over all devices.
+type BlkIOStatRaw struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadIOs    uint64
+	WriteIOs   uint64
+}
+
 // CgroupFileWriteIfDifferent writes the cgroup file if current value is different from the given value.
 // TODO: moved into resourceexecutor package and marked as private.
 func CgroupFileWriteIfDifferent(cgroupTaskDir string, r Resource, value string) error {
@@ -412,3 +420,29 @@ func CalcCPUThrottledRatio(curPoint, prePoint *CPUStatRaw) float64 {
 	}
 	return throttledRatio
 }
+
+// ParseBlkioThrottleStat parses the read and write counters of a cgroups-v1 blkio.throttle stat file,
+// e.g. blkio.throttle.io_service_bytes_recursive, and sums them over all devices.
+func ParseBlkioThrottleStat(content string) (read uint64, write uint64, err error) {
+	// content: "8:0 Read 1024\n8:0 Write 2048\n8:0 Sync 0\n8:0 Async 3072\n8:0 Total 3072\nTotal 3072"
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		// skip the total line of all devices
+		if len(fields) != 3 {
+			continue
+		}
+		var v uint64
+		switch fields[1] {
+		case "Read":
+			v, err = strconv.ParseUint(fields[2], 10, 64)
+			read += v
+		case "Write":
+			v, err = strconv.ParseUint(fields[2], 10, 64)
+			write += v
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("parse blkio stat failed, line %s, err: %v", line, err)
+		}
+	}
+	return read, write, nil
+}
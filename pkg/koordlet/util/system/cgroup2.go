@@ -289,3 +289,87 @@ func ConvertCPUSharesToWeight(s string) (int64, error) {
 	}
 	return w, nil
 }
+
+// ConvertBlkioWeightToIOWeight converts the value of `blkio.weight` (cgroups-v1) into the value of `io.weight`
+// (cgroups-v2), where the default weight 500 of cgroups-v1 is mapped to the default weight 100 of cgroups-v2.
+func ConvertBlkioWeightToIOWeight(s string) (int64, error) {
+	isValid, msg := BlkioWeightValidator.Validate(s)
+	if !isValid {
+		return -1, fmt.Errorf("invalid blkio.weight value, err: %s", msg)
+	}
+	v, _ := strconv.ParseInt(s, 10, 64) // the valid value must be an integer
+	w := v * 100 / 500
+	if w < IOWeightMinValue {
+		w = IOWeightMinValue
+	} else if w > IOWeightMaxValue {
+		w = IOWeightMaxValue
+	}
+	return w, nil
+}
+
+var blkioThrottleIOMaxKeys = map[ResourceType]string{
+	BlkioTRBpsName:  "rbps",
+	BlkioTWBpsName:  "wbps",
+	BlkioTRIopsName: "riops",
+	BlkioTWIopsName: "wiops",
+}
+
+// ConvertBlkioThrottleToIOMax converts the value of a `blkio.throttle.*` file (cgroups-v1) into the value of
+// `io.max` (cgroups-v2). e.g. "253:0 1048576" of `blkio.throttle.read_bps_device` is converted into "253:0 rbps=1048576".
+// The zero value which removes the limit in cgroups-v1 is converted into "max".
+func ConvertBlkioThrottleToIOMax(resourceType ResourceType, s string) (string, error) {
+	key, ok := blkioThrottleIOMaxKeys[resourceType]
+	if !ok {
+		return "", fmt.Errorf("resource type %s cannot be converted into io.max", resourceType)
+	}
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return "", fmt.Errorf("invalid %s value %s", resourceType, s)
+	}
+	v, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || v < 0 {
+		return "", fmt.Errorf("invalid %s value %s, err: %v", resourceType, s, err)
+	}
+	value := fields[1]
+	if v == 0 {
+		value = CgroupMaxSymbolStr
+	}
+	return fmt.Sprintf("%s %s=%s", fields[0], key, value), nil
+}
+
+// ParseIOStatV2 parses the cgroups-v2 io.stat and sums the counters over all devices.
+func ParseIOStatV2(content string) (*BlkIOStatRaw, error) {
+	// content: "8:0 rbytes=1024 wbytes=2048 rios=1 wios=2 dbytes=0 dios=0\n..."
+	stat := &BlkIOStatRaw{}
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("parse io.stat failed, invalid field %s", field)
+			}
+			var value *uint64
+			switch kv[0] {
+			case "rbytes":
+				value = &stat.ReadBytes
+			case "wbytes":
+				value = &stat.WriteBytes
+			case "rios":
+				value = &stat.ReadIOs
+			case "wios":
+				value = &stat.WriteIOs
+			default:
+				continue
+			}
+			v, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parse io.stat failed, field %s, err: %v", field, err)
+			}
+			*value += v
+		}
+	}
+	return stat, nil
+}
@@ -106,11 +106,15 @@ const ( // subsystems
 )
 
 const (
-	CFSBasePeriodValue int64 = 100000
-	CFSQuotaMinValue   int64 = 1000 // min value except `-1`
-	CPUSharesMinValue  int64 = 2
-	CPUWeightMinValue  int64 = 1
-	CPUWeightMaxValue  int64 = 10000
+	CFSBasePeriodValue  int64 = 100000
+	CFSQuotaMinValue    int64 = 1000 // min value except `-1`
+	CPUSharesMinValue   int64 = 2
+	CPUWeightMinValue   int64 = 1
+	CPUWeightMaxValue   int64 = 10000
+	BlkioWeightMinValue int64 = 10
+	BlkioWeightMaxValue int64 = 1000
+	IOWeightMinValue    int64 = 1
+	IOWeightMaxValue    int64 = 10000
 
 	CPUStatName      = "cpu.stat"
 	CPUSharesName    = "cpu.shares"
@@ -126,6 +130,7 @@ const (
 
 	CPUSetCPUSName          = "cpuset.cpus"
 	CPUSetCPUSEffectiveName = "cpuset.cpus.effective"
+	CPUSetMemsName          = "cpuset.mems"
 
 	CPUAcctStatName           = "cpuacct.stat"
 	CPUAcctUsageName          = "cpuacct.usage"
@@ -152,6 +157,13 @@ const (
 	BlkioTRBpsName  = "blkio.throttle.read_bps_device"
 	BlkioTWIopsName = "blkio.throttle.write_iops_device"
 	BlkioTWBpsName  = "blkio.throttle.write_bps_device"
+	BlkioWeightName = "blkio.weight"
+	IOMaxName       = "io.max"
+	IOWeightName    = "io.weight"
+
+	BlkioIOServiceBytesRecursiveName = "blkio.throttle.io_service_bytes_recursive"
+	BlkioIOServicedRecursiveName     = "blkio.throttle.io_serviced_recursive"
+	IOStatName                       = "io.stat"
 )
 
 var (
@@ -161,6 +173,8 @@ var (
 	CPUBurstValidator                       = &RangeValidator{min: 0, max: 100 * 10 * 100000}
 	CPUBvtWarpNsValidator                   = &RangeValidator{min: -1, max: 2}
 	CPUWeightValidator                      = &RangeValidator{min: CPUWeightMinValue, max: CPUWeightMaxValue}
+	BlkioWeightValidator                    = &RangeValidator{min: BlkioWeightMinValue, max: BlkioWeightMaxValue}
+	IOWeightValidator                       = &RangeValidator{min: IOWeightMinValue, max: IOWeightMaxValue}
 	MemoryWmarkRatioValidator               = &RangeValidator{min: 0, max: 100}
 	MemoryPriorityValidator                 = &RangeValidator{min: 0, max: 12}
 	MemoryOomGroupValidator                 = &RangeValidator{min: 0, max: 1}
@@ -184,7 +198,8 @@ var (
 	CPUTasks     = DefaultFactory.New(CPUTasksName, CgroupCPUDir)
 	CPUProcs     = DefaultFactory.New(CPUProcsName, CgroupCPUDir)
 
-	CPUSet = DefaultFactory.New(CPUSetCPUSName, CgroupCPUSetDir).WithValidator(CPUSetCPUSValidator)
+	CPUSet     = DefaultFactory.New(CPUSetCPUSName, CgroupCPUSetDir).WithValidator(CPUSetCPUSValidator)
+	CPUSetMems = DefaultFactory.New(CPUSetMemsName, CgroupCPUSetDir).WithValidator(CPUSetCPUSValidator)
 
 	CPUAcctStat           = DefaultFactory.New(CPUAcctStatName, CgroupCPUAcctDir)
 	CPUAcctUsage          = DefaultFactory.New(CPUAcctUsageName, CgroupCPUAcctDir)
@@ -209,6 +224,10 @@ var (
 	BlkioReadBps   = DefaultFactory.New(BlkioTRBpsName, CgroupBlkioDir)
 	BlkioWriteIops = DefaultFactory.New(BlkioTWIopsName, CgroupBlkioDir)
 	BlkioWriteBps  = DefaultFactory.New(BlkioTWBpsName, CgroupBlkioDir)
+	BlkioWeight    = DefaultFactory.New(BlkioWeightName, CgroupBlkioDir).WithValidator(BlkioWeightValidator).WithCheckSupported(SupportedIfFileExists)
+
+	BlkioIOServiceBytesRecursive = DefaultFactory.New(BlkioIOServiceBytesRecursiveName, CgroupBlkioDir)
+	BlkioIOServicedRecursive     = DefaultFactory.New(BlkioIOServicedRecursiveName, CgroupBlkioDir)
 
 	knownCgroupResources = []Resource{
 		CPUStat,
@@ -219,6 +238,7 @@ var (
 		CPUTasks,
 		CPUBVTWarpNs,
 		CPUSet,
+		CPUSetMems,
 		CPUAcctStat,
 		CPUAcctUsage,
 		CPUAcctCPUPressure,
@@ -240,6 +260,9 @@ var (
 		BlkioReadBps,
 		BlkioWriteIops,
 		BlkioWriteBps,
+		BlkioWeight,
+		BlkioIOServiceBytesRecursive,
+		BlkioIOServicedRecursive,
 	}
 
 	CPUCFSQuotaV2  = DefaultFactory.NewV2(CPUCFSQuotaName, CPUMaxName)
@@ -255,6 +278,7 @@ var (
 
 	CPUSetV2                 = DefaultFactory.NewV2(CPUSetCPUSName, CPUSetCPUSName).WithValidator(CPUSetCPUSValidator)
 	CPUSetEffectiveV2        = DefaultFactory.NewV2(CPUSetCPUSEffectiveName, CPUSetCPUSEffectiveName) // TODO: unify the R/W
+	CPUSetMemsV2             = DefaultFactory.NewV2(CPUSetMemsName, CPUSetMemsName).WithValidator(CPUSetCPUSValidator)
 	CPUTasksV2               = DefaultFactory.NewV2(CPUTasksName, CPUThreadsName)
 	CPUProcsV2               = DefaultFactory.NewV2(CPUProcsName, CPUProcsName)
 	MemoryLimitV2            = DefaultFactory.NewV2(MemoryLimitName, MemoryMaxName)
@@ -270,6 +294,14 @@ var (
 	MemoryUsePriorityOomV2   = DefaultFactory.NewV2(MemoryUsePriorityOomName, MemoryUsePriorityOomName).WithValidator(MemoryUsePriorityOomValidator).WithCheckSupported(SupportedIfFileExists)
 	MemoryOomGroupV2         = DefaultFactory.NewV2(MemoryOomGroupName, MemoryOomGroupName).WithValidator(MemoryOomGroupValidator).WithCheckSupported(SupportedIfFileExists)
 
+	// blkio throttle files of cgroups-v1 are all mapped into `io.max`, whose values should be converted before writing
+	BlkioReadIopsV2  = DefaultFactory.NewV2(BlkioTRIopsName, IOMaxName).WithCheckSupported(SupportedIfFileExists)
+	BlkioReadBpsV2   = DefaultFactory.NewV2(BlkioTRBpsName, IOMaxName).WithCheckSupported(SupportedIfFileExists)
+	BlkioWriteIopsV2 = DefaultFactory.NewV2(BlkioTWIopsName, IOMaxName).WithCheckSupported(SupportedIfFileExists)
+	BlkioWriteBpsV2  = DefaultFactory.NewV2(BlkioTWBpsName, IOMaxName).WithCheckSupported(SupportedIfFileExists)
+	BlkioWeightV2    = DefaultFactory.NewV2(BlkioWeightName, IOWeightName).WithValidator(IOWeightValidator).WithCheckSupported(SupportedIfFileExists)
+	IOStatV2         = DefaultFactory.NewV2(IOStatName, IOStatName)
+
 	knownCgroupV2Resources = []Resource{
 		CPUCFSQuotaV2,
 		CPUCFSPeriodV2,
@@ -282,6 +314,7 @@ var (
 		CPUAcctIOPressureV2,
 		CPUSetV2,
 		CPUSetEffectiveV2,
+		CPUSetMemsV2,
 		CPUTasksV2,
 		CPUProcsV2,
 		MemoryLimitV2,
@@ -296,6 +329,12 @@ var (
 		MemoryPriorityV2,
 		MemoryUsePriorityOomV2,
 		MemoryOomGroupV2,
+		BlkioReadIopsV2,
+		BlkioReadBpsV2,
+		BlkioWriteIopsV2,
+		BlkioWriteBpsV2,
+		BlkioWeightV2,
+		IOStatV2,
 	}
 )
 
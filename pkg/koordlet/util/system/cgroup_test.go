@@ -307,3 +307,49 @@ func TestCgroupPathExist(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertBlkioWeightToIOWeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    int64
+		wantErr bool
+	}{
+		{name: "default weight", arg: "500", want: 100},
+		{name: "max weight", arg: "1000", want: 200},
+		{name: "min weight", arg: "10", want: 2},
+		{name: "out of range", arg: "1", want: -1, wantErr: true},
+		{name: "not an integer", arg: "abc", want: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := ConvertBlkioWeightToIOWeight(tt.arg)
+			assert.Equal(t, tt.wantErr, gotErr != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConvertBlkioThrottleToIOMax(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType ResourceType
+		arg          string
+		want         string
+		wantErr      bool
+	}{
+		{name: "read bps", resourceType: BlkioTRBpsName, arg: "253:0 1048576", want: "253:0 rbps=1048576"},
+		{name: "write iops", resourceType: BlkioTWIopsName, arg: "253:0 100", want: "253:0 wiops=100"},
+		{name: "unlimited", resourceType: BlkioTWBpsName, arg: "253:16 0", want: "253:16 wbps=max"},
+		{name: "unknown resource", resourceType: BlkioWeightName, arg: "253:0 100", wantErr: true},
+		{name: "invalid format", resourceType: BlkioTRIopsName, arg: "100", wantErr: true},
+		{name: "negative value", resourceType: BlkioTRIopsName, arg: "253:0 -1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := ConvertBlkioThrottleToIOMax(tt.resourceType, tt.arg)
+			assert.Equal(t, tt.wantErr, gotErr != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
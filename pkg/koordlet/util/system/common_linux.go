@@ -36,6 +36,7 @@ import (
 	"unicode"
 
 	"github.com/cakturk/go-netstat/netstat"
+	"golang.org/x/sys/unix"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 )
@@ -222,3 +223,24 @@ func WorkingDirOf(pid int) (string, error) {
 		return strings.TrimSpace(tokens[1]), nil
 	}
 }
+
+var GetDeviceNumber = getDeviceNumberFn
+
+// getDeviceNumberFn returns the device type ("c" or "b"), major and minor number of the device file.
+func getDeviceNumberFn(devicePath string) (string, int64, int64, error) {
+	var stat unix.Stat_t
+	if err := unix.Stat(devicePath, &stat); err != nil {
+		return "", 0, 0, err
+	}
+	var devType string
+	switch stat.Mode & unix.S_IFMT {
+	case unix.S_IFCHR:
+		devType = "c"
+	case unix.S_IFBLK:
+		devType = "b"
+	default:
+		return "", 0, 0, fmt.Errorf("%s is not a device file", devicePath)
+	}
+	dev := uint64(stat.Rdev) //nolint:unconvert
+	return devType, int64(unix.Major(dev)), int64(unix.Minor(dev)), nil
+}
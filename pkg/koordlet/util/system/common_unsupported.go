@@ -42,3 +42,9 @@ func execCmdOnHostFn(cmds []string) ([]byte, int, error) {
 func WorkingDirOf(pid int) (string, error) {
 	return "", fmt.Errorf("only support linux")
 }
+
+var GetDeviceNumber = getDeviceNumberFn
+
+func getDeviceNumberFn(devicePath string) (string, int64, int64, error) {
+	return "", 0, 0, fmt.Errorf("only support linux")
+}
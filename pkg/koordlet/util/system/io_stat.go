@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	ProcNetDevName    = "net/dev"
+	ProcDiskStatsName = "diskstats"
+
+	// DiskSectorSize is the sector size used by /proc/diskstats, which is always 512 bytes regardless of the device.
+	DiskSectorSize = 512
+
+	loopbackInterface = "lo"
+)
+
+var blockDeviceNumberRegex = regexp.MustCompile(`^\d+:\d+$`)
+
+// NetDevStatRaw is the accumulated network statistics of all interfaces except the loopback.
+type NetDevStatRaw struct {
+	RxBytes   uint64
+	RxPackets uint64
+	TxBytes   uint64
+	TxPackets uint64
+}
+
+// GetNetDevStatFilePath returns the net/dev file path of the process; the host netns is used when pid is empty.
+func GetNetDevStatFilePath(pid string) string {
+	if len(pid) == 0 {
+		return GetProcFilePath(ProcNetDevName)
+	}
+	return filepath.Join(Conf.ProcRootDir, pid, ProcNetDevName)
+}
+
+// GetNetDevStat reads the network statistics in the netns of the process.
+func GetNetDevStat(pid string) (*NetDevStatRaw, error) {
+	content, err := os.ReadFile(GetNetDevStatFilePath(pid))
+	if err != nil {
+		return nil, err
+	}
+	return ParseNetDevStat(string(content))
+}
+
+// ParseNetDevStat parses the content of /proc/net/dev.
+// e.g.
+// Inter-|   Receive                                                |  Transmit
+// face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+// lo: 1000 10 0 0 0 0 0 0 1000 10 0 0 0 0 0 0
+// eth0: 2000 20 0 0 0 0 0 0 3000 30 0 0 0 0 0 0
+func ParseNetDevStat(content string) (*NetDevStatRaw, error) {
+	stat := &NetDevStatRaw{}
+	for _, line := range strings.Split(content, "\n") {
+		idx := strings.Index(line, ":")
+		if idx < 0 {
+			continue
+		}
+		if strings.TrimSpace(line[:idx]) == loopbackInterface {
+			continue
+		}
+		fields := strings.Fields(line[idx+1:])
+		if len(fields) < 10 {
+			continue // header lines
+		}
+		values := make([]uint64, 4)
+		for i, pos := range []int{0, 1, 8, 9} {
+			v, err := strconv.ParseUint(fields[pos], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse net dev line %s, err: %v", line, err)
+			}
+			values[i] = v
+		}
+		stat.RxBytes += values[0]
+		stat.RxPackets += values[1]
+		stat.TxBytes += values[2]
+		stat.TxPackets += values[3]
+	}
+	return stat, nil
+}
+
+// GetDiskStats reads the accumulated IO statistics of the physical block devices on the node.
+func GetDiskStats() (*BlkIOStatRaw, error) {
+	content, err := os.ReadFile(GetProcFilePath(ProcDiskStatsName))
+	if err != nil {
+		return nil, err
+	}
+	return ParseDiskStats(string(content), isPhysicalBlockDevice)
+}
+
+// ParseDiskStats parses the content of /proc/diskstats and sums up the devices accepted by the filter.
+// e.g.
+// 253       0 vda 1000 0 2000 0 3000 0 4000 0 0 0 0
+func ParseDiskStats(content string, filter func(device string) bool) (*BlkIOStatRaw, error) {
+	stat := &BlkIOStatRaw{}
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 10 {
+			continue
+		}
+		if filter != nil && !filter(fields[2]) {
+			continue
+		}
+		// reads completed, sectors read, writes completed, sectors written
+		values := make([]uint64, 4)
+		for i, pos := range []int{3, 5, 7, 9} {
+			v, err := strconv.ParseUint(fields[pos], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse disk stats line %s, err: %v", line, err)
+			}
+			values[i] = v
+		}
+		stat.ReadIOs += values[0]
+		stat.ReadBytes += values[1] * DiskSectorSize
+		stat.WriteIOs += values[2]
+		stat.WriteBytes += values[3] * DiskSectorSize
+	}
+	return stat, nil
+}
+
+// isPhysicalBlockDevice checks if the block device is a whole physical disk. Partitions and virtual devices like
+// loop, ram and device-mapper have no `device` link under /sys/block, so they are not counted twice.
+func isPhysicalBlockDevice(device string) bool {
+	return FileExists(filepath.Join(Conf.SysRootDir, "block", device, "device"))
+}
+
+// GetBlockDeviceNumber returns the device number in the format of "major:minor" of the block device. The device can
+// be a device name under /sys/block like "vda", or a device number which is returned directly.
+func GetBlockDeviceNumber(device string) (string, error) {
+	if blockDeviceNumberRegex.MatchString(device) {
+		return device, nil
+	}
+	if len(device) == 0 || strings.Contains(device, "/") {
+		return "", fmt.Errorf("invalid block device name %q", device)
+	}
+	content, err := os.ReadFile(filepath.Join(Conf.SysRootDir, "block", device, "dev"))
+	if err != nil {
+		return "", err
+	}
+	devNumber := strings.TrimSpace(string(content))
+	if !blockDeviceNumberRegex.MatchString(devNumber) {
+		return "", fmt.Errorf("invalid device number %q of block device %s", devNumber, device)
+	}
+	return devNumber, nil
+}
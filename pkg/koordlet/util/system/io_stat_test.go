@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testNetDevContent = `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo: 1000      10    0    0    0     0          0         0     1000      10    0    0    0     0       0          0
+  eth0: 2000      20    0    0    0     0          0         0     3000      30    0    0    0     0       0          0
+  eth1: 500        5    0    0    0     0          0         0      700       7    0    0    0     0       0          0
+`
+
+const testDiskStatsContent = `   7       0 loop0 100 0 200 0 0 0 0 0 0 0 0 0 0 0 0
+ 253       0 vda 1000 10 2000 100 3000 30 4000 300 0 400 400 0 0 0 0
+ 253       1 vda1 900 10 1800 100 2900 30 3900 300 0 400 400 0 0 0 0
+ 253      16 vdb 10 0 20 0 30 0 40 0 0 0 0 0 0 0 0
+`
+
+func TestGetNetDevStat(t *testing.T) {
+	helper := NewFileTestUtil(t)
+	defer helper.Cleanup()
+
+	_, err := GetNetDevStat("")
+	assert.Error(t, err)
+
+	helper.WriteProcSubFileContents(ProcNetDevName, testNetDevContent)
+	helper.WriteProcSubFileContents(filepath.Join("1000", ProcNetDevName), testNetDevContent)
+	want := &NetDevStatRaw{
+		RxBytes:   2500,
+		RxPackets: 25,
+		TxBytes:   3700,
+		TxPackets: 37,
+	}
+	got, err := GetNetDevStat("")
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+	got, err = GetNetDevStat("1000")
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = ParseNetDevStat("eth0: 1 2 3 4 5 6 7 8 x 10")
+	assert.Error(t, err)
+}
+
+func TestGetDiskStats(t *testing.T) {
+	helper := NewFileTestUtil(t)
+	defer helper.Cleanup()
+	oldSysRootDir := Conf.SysRootDir
+	Conf.SysRootDir = filepath.Join(helper.TempDir, "sys")
+	defer func() {
+		Conf.SysRootDir = oldSysRootDir
+	}()
+
+	_, err := GetDiskStats()
+	assert.Error(t, err)
+
+	helper.WriteProcSubFileContents(ProcDiskStatsName, testDiskStatsContent)
+	for _, device := range []string{"vda", "vdb"} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(Conf.SysRootDir, "block", device, "device"), 0755))
+	}
+	got, err := GetDiskStats()
+	assert.NoError(t, err)
+	assert.Equal(t, &BlkIOStatRaw{
+		ReadBytes:  2020 * DiskSectorSize,
+		WriteBytes: 4040 * DiskSectorSize,
+		ReadIOs:    1010,
+		WriteIOs:   3030,
+	}, got)
+
+	got, err = ParseDiskStats(testDiskStatsContent, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2010), got.ReadIOs)
+}
+
+func TestGetBlockDeviceNumber(t *testing.T) {
+	helper := NewFileTestUtil(t)
+	defer helper.Cleanup()
+	oldSysRootDir := Conf.SysRootDir
+	Conf.SysRootDir = filepath.Join(helper.TempDir, "sys")
+	defer func() {
+		Conf.SysRootDir = oldSysRootDir
+	}()
+	assert.NoError(t, os.MkdirAll(filepath.Join(Conf.SysRootDir, "block", "vda"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(Conf.SysRootDir, "block", "vda", "dev"), []byte("253:0\n"), 0644))
+
+	got, err := GetBlockDeviceNumber("vda")
+	assert.NoError(t, err)
+	assert.Equal(t, "253:0", got)
+	got, err = GetBlockDeviceNumber("8:16")
+	assert.NoError(t, err)
+	assert.Equal(t, "8:16", got)
+	_, err = GetBlockDeviceNumber("vdb")
+	assert.Error(t, err)
+	_, err = GetBlockDeviceNumber("../vda")
+	assert.Error(t, err)
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	SysPCIDevicesSubDir      = "bus/pci/devices"
+	SysPCINUMANodeName       = "numa_node"
+	SysPCIClassName          = "class"
+	SysPCIPhysFnName         = "physfn"
+	SysPCIVirtFnPrefix       = "virtfn"
+	SysPCIInfinibandVerbsDir = "infiniband_verbs"
+	SysInfinibandSubDir      = "class/infiniband"
+	SysInfinibandDeviceName  = "device"
+	SysUEventName            = "uevent"
+	SysUEventDevNamePrefix   = "DEVNAME="
+
+	// PCIClassProcessingAccelerator is the PCI class code of the processing accelerators, e.g. FPGA.
+	PCIClassProcessingAccelerator = "0x1200"
+	// InfinibandDevDir is the directory of the RDMA device nodes, e.g. /dev/infiniband/uverbs0.
+	InfinibandDevDir = "/dev/infiniband"
+	// DevDir is the directory of the device nodes named by the DEVNAME of the uevents.
+	DevDir = "/dev"
+	// InfinibandRDMACMDevice is the device node of the RDMA communication manager shared by all RDMA devices.
+	InfinibandRDMACMDevice = "/dev/infiniband/rdma_cm"
+)
+
+// NormalizePCIBusID converts the PCI bus ID reported by NVML (e.g. "00000000:3B:00.0") into
+// the format used by sysfs (e.g. "0000:3b:00.0").
+func NormalizePCIBusID(busID string) string {
+	busID = strings.ToLower(strings.TrimSpace(strings.TrimRight(busID, "\x00")))
+	parts := strings.SplitN(busID, ":", 2)
+	if len(parts) == 2 && len(parts[0]) > 4 {
+		parts[0] = parts[0][len(parts[0])-4:]
+	}
+	return strings.Join(parts, ":")
+}
+
+func getPCIDevicePath(busID string) string {
+	return filepath.Join(Conf.SysRootDir, SysPCIDevicesSubDir, busID)
+}
+
+// GetPCIDeviceNUMANode returns the NUMA Node of the PCI device, or -1 if the device has no NUMA affinity.
+func GetPCIDeviceNUMANode(busID string) (int32, error) {
+	content, err := os.ReadFile(filepath.Join(getPCIDevicePath(busID), SysPCINUMANodeName))
+	if err != nil {
+		return -1, err
+	}
+	numaNode, err := strconv.ParseInt(strings.TrimSpace(string(content)), 10, 32)
+	if err != nil {
+		return -1, fmt.Errorf("failed to parse NUMA node of PCI device %s, err: %v", busID, err)
+	}
+	return int32(numaNode), nil
+}
+
+// GetPCIDeviceRootPort returns the PCIe root port to which the PCI device is attached. The devices under the same
+// root port are connected by the same PCIe switch. e.g.
+// /sys/bus/pci/devices/0000:3d:00.0 -> ../../../devices/pci0000:3a/0000:3a:00.0/0000:3b:00.0/0000:3c:08.0/0000:3d:00.0
+// the root port is 0000:3a:00.0.
+func GetPCIDeviceRootPort(busID string) (string, error) {
+	link, err := os.Readlink(getPCIDevicePath(busID))
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(filepath.ToSlash(link), "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, "pci") && i+1 < len(parts) {
+			return parts[i+1], nil
+		}
+	}
+	return "", fmt.Errorf("failed to find the root port of PCI device %s in %s", busID, link)
+}
+
+// IsPCIVirtualFunction returns whether the PCI device is a SR-IOV virtual function.
+func IsPCIVirtualFunction(busID string) bool {
+	_, err := os.Lstat(filepath.Join(getPCIDevicePath(busID), SysPCIPhysFnName))
+	return err == nil
+}
+
+// ListPCIVirtualFunctions returns the bus IDs of the SR-IOV virtual functions of the PCI device ordered by the VF index.
+func ListPCIVirtualFunctions(busID string) ([]string, error) {
+	entries, err := os.ReadDir(getPCIDevicePath(busID))
+	if err != nil {
+		return nil, err
+	}
+	vfs := map[int]string{}
+	var indexes []int
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), SysPCIVirtFnPrefix) {
+			continue
+		}
+		index, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), SysPCIVirtFnPrefix))
+		if err != nil {
+			continue
+		}
+		link, err := os.Readlink(filepath.Join(getPCIDevicePath(busID), entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		vfs[index] = filepath.Base(link)
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	busIDs := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		busIDs = append(busIDs, vfs[index])
+	}
+	return busIDs, nil
+}
+
+// GetRDMADeviceBusIDs returns the sorted bus IDs of the RDMA devices on the node, the virtual functions are excluded.
+func GetRDMADeviceBusIDs() ([]string, error) {
+	infinibandDir := filepath.Join(Conf.SysRootDir, SysInfinibandSubDir)
+	entries, err := os.ReadDir(infinibandDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var busIDs []string
+	for _, entry := range entries {
+		link, err := os.Readlink(filepath.Join(infinibandDir, entry.Name(), SysInfinibandDeviceName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the PCI device of RDMA device %s, err: %v", entry.Name(), err)
+		}
+		busID := filepath.Base(link)
+		if IsPCIVirtualFunction(busID) {
+			continue
+		}
+		busIDs = append(busIDs, busID)
+	}
+	sort.Strings(busIDs)
+	return busIDs, nil
+}
+
+// GetFPGADeviceBusIDs returns the sorted bus IDs of the FPGA devices on the node, the virtual functions are excluded.
+// The FPGAs are recognized by the PCI class of the processing accelerators.
+func GetFPGADeviceBusIDs() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(Conf.SysRootDir, SysPCIDevicesSubDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var busIDs []string
+	for _, entry := range entries {
+		busID := entry.Name()
+		content, err := os.ReadFile(filepath.Join(getPCIDevicePath(busID), SysPCIClassName))
+		if err != nil {
+			continue
+		}
+		if !strings.HasPrefix(strings.TrimSpace(string(content)), PCIClassProcessingAccelerator) || IsPCIVirtualFunction(busID) {
+			continue
+		}
+		busIDs = append(busIDs, busID)
+	}
+	sort.Strings(busIDs)
+	return busIDs, nil
+}
+
+// GetRDMAUVerbsDevices returns the user verbs device nodes of the RDMA device, e.g. /dev/infiniband/uverbs0.
+func GetRDMAUVerbsDevices(busID string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(getPCIDevicePath(busID), SysPCIInfinibandVerbsDir))
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		devices = append(devices, filepath.Join(InfinibandDevDir, entry.Name()))
+	}
+	sort.Strings(devices)
+	return devices, nil
+}
+
+// GetFPGADeviceNodes returns the device nodes created by the driver of the FPGA device, e.g. /dev/dfl-fme.0 and
+// /dev/dfl-port.0 of the DFL driver, or /dev/dri/renderD128 of the XRT driver. The device nodes are found by the
+// DEVNAME of the uevents under the sysfs directory of the PCI device.
+func GetFPGADeviceNodes(busID string) ([]string, error) {
+	// the directory under bus/pci/devices is a symlink, resolve it so that the walk descends into it
+	deviceDir, err := filepath.EvalSymlinks(getPCIDevicePath(busID))
+	if err != nil {
+		return nil, err
+	}
+	var devices []string
+	err = filepath.WalkDir(deviceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != SysUEventName {
+			return nil
+		}
+		devName, err := readUEventDevName(path)
+		if err != nil {
+			return err
+		}
+		if devName != "" {
+			devices = append(devices, filepath.Join(DevDir, devName))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(devices)
+	return devices, nil
+}
+
+func readUEventDevName(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); strings.HasPrefix(line, SysUEventDevNamePrefix) {
+			return strings.TrimPrefix(line, SysUEventDevNamePrefix), nil
+		}
+	}
+	return "", scanner.Err()
+}
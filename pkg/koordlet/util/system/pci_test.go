@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePCIBusID(t *testing.T) {
+	assert.Equal(t, "0000:3b:00.0", NormalizePCIBusID("00000000:3B:00.0"))
+	assert.Equal(t, "0000:3b:00.0", NormalizePCIBusID("0000:3b:00.0"))
+	assert.Equal(t, "0000:3b:00.0", NormalizePCIBusID("00000000:3B:00.0\x00\x00"))
+}
+
+func TestGetPCIDeviceTopology(t *testing.T) {
+	tempDir := t.TempDir()
+	oldSysRootDir := Conf.SysRootDir
+	Conf.SysRootDir = tempDir
+	defer func() {
+		Conf.SysRootDir = oldSysRootDir
+	}()
+
+	busID := "0000:3d:00.0"
+	deviceDir := filepath.Join(tempDir, "devices", "pci0000:3a", "0000:3a:00.0", "0000:3b:00.0", "0000:3c:08.0", busID)
+	assert.NoError(t, os.MkdirAll(deviceDir, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(deviceDir, SysPCINUMANodeName), []byte("1\n"), 0644))
+	pciDevicesDir := filepath.Join(tempDir, SysPCIDevicesSubDir)
+	assert.NoError(t, os.MkdirAll(pciDevicesDir, 0755))
+	assert.NoError(t, os.Symlink("../../../devices/pci0000:3a/0000:3a:00.0/0000:3b:00.0/0000:3c:08.0/"+busID, filepath.Join(pciDevicesDir, busID)))
+
+	numaNode, err := GetPCIDeviceNUMANode(busID)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), numaNode)
+	rootPort, err := GetPCIDeviceRootPort(busID)
+	assert.NoError(t, err)
+	assert.Equal(t, "0000:3a:00.0", rootPort)
+
+	_, err = GetPCIDeviceNUMANode("0000:00:00.0")
+	assert.Error(t, err)
+	_, err = GetPCIDeviceRootPort("0000:00:00.0")
+	assert.Error(t, err)
+}
+
+func TestGetRDMAAndFPGADevices(t *testing.T) {
+	tempDir := t.TempDir()
+	oldSysRootDir := Conf.SysRootDir
+	Conf.SysRootDir = tempDir
+	defer func() {
+		Conf.SysRootDir = oldSysRootDir
+	}()
+
+	pciDevicesDir := filepath.Join(tempDir, SysPCIDevicesSubDir)
+	newPCIDevice := func(busID, class string) string {
+		deviceDir := filepath.Join(pciDevicesDir, busID)
+		assert.NoError(t, os.MkdirAll(deviceDir, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(deviceDir, SysPCIClassName), []byte(class+"\n"), 0644))
+		return deviceDir
+	}
+	newRDMADevice := func(name, busID string) {
+		rdmaDir := filepath.Join(tempDir, SysInfinibandSubDir, name)
+		assert.NoError(t, os.MkdirAll(rdmaDir, 0755))
+		assert.NoError(t, os.Symlink("../../../bus/pci/devices/"+busID, filepath.Join(rdmaDir, SysInfinibandDeviceName)))
+	}
+	// a RDMA NIC with two virtual functions
+	pfDir := newPCIDevice("0000:1f:00.0", "0x020000")
+	assert.NoError(t, os.MkdirAll(filepath.Join(pfDir, SysPCIInfinibandVerbsDir, "uverbs0"), 0755))
+	for i, vf := range []string{"0000:1f:00.2", "0000:1f:00.3"} {
+		vfDir := newPCIDevice(vf, "0x020000")
+		assert.NoError(t, os.Symlink("../0000:1f:00.0", filepath.Join(vfDir, SysPCIPhysFnName)))
+		assert.NoError(t, os.Symlink("../"+vf, filepath.Join(pfDir, SysPCIVirtFnPrefix+strconv.Itoa(i))))
+		newRDMADevice("mlx5_"+strconv.Itoa(i+2), vf)
+	}
+	newRDMADevice("mlx5_0", "0000:1f:00.0")
+	// a FPGA and a normal NIC
+	fpgaDir := newPCIDevice("0000:af:00.0", "0x120000")
+	assert.NoError(t, os.WriteFile(filepath.Join(fpgaDir, SysUEventName), []byte("DRIVER=dfl-pci\nPCI_SLOT_NAME=0000:af:00.0\n"), 0644))
+	for _, name := range []string{"dfl-fme.0", "dfl-port.0"} {
+		regionDir := filepath.Join(fpgaDir, "fpga_region", "region0", name)
+		assert.NoError(t, os.MkdirAll(regionDir, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(regionDir, SysUEventName), []byte("MAJOR=509\nMINOR=0\nDEVNAME="+name+"\n"), 0644))
+	}
+	newPCIDevice("0000:3b:00.0", "0x020000")
+
+	rdmaDevices, err := GetRDMADeviceBusIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0000:1f:00.0"}, rdmaDevices)
+	vfs, err := ListPCIVirtualFunctions("0000:1f:00.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0000:1f:00.2", "0000:1f:00.3"}, vfs)
+	assert.True(t, IsPCIVirtualFunction("0000:1f:00.2"))
+	assert.False(t, IsPCIVirtualFunction("0000:1f:00.0"))
+	uverbs, err := GetRDMAUVerbsDevices("0000:1f:00.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/dev/infiniband/uverbs0"}, uverbs)
+
+	fpgaDevices, err := GetFPGADeviceBusIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0000:af:00.0"}, fpgaDevices)
+	fpgaNodes, err := GetFPGADeviceNodes("0000:af:00.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/dev/dfl-fme.0", "/dev/dfl-port.0"}, fpgaNodes)
+	fpgaNodes, err = GetFPGADeviceNodes("0000:3b:00.0")
+	assert.NoError(t, err)
+	assert.Empty(t, fpgaNodes)
+}
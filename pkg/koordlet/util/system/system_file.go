@@ -35,6 +35,12 @@ const (
 	ProcMemInfoName = "meminfo"
 	SysctlSubDir    = "sys"
 
+	SysNUMANodeSubDir      = "devices/system/node"
+	SysNUMANodePrefix      = "node"
+	SysHugePagesSubDir     = "hugepages"
+	SysHugePagesPrefix     = "hugepages-"
+	SysHugePagesNumberName = "nr_hugepages"
+
 	KernelSchedGroupIdentityEnable = "kernel/sched_group_identity_enabled"
 )
 
@@ -19,6 +19,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 type FailurePolicyType string
@@ -57,6 +58,11 @@ const (
 	PostStartContainer          RuntimeHookType = "PostStartContainer"
 	PreUpdateContainerResources RuntimeHookType = "PreUpdateContainerResources"
 	PostStopContainer           RuntimeHookType = "PostStopContainer"
+	PrePullImage                RuntimeHookType = "PrePullImage"
+	PreExecSync                 RuntimeHookType = "PreExecSync"
+	PostContainerStats          RuntimeHookType = "PostContainerStats"
+	PostListContainerStats      RuntimeHookType = "PostListContainerStats"
+	PreUpdateRuntimeConfig      RuntimeHookType = "PreUpdateRuntimeConfig"
 	NoneRuntimeHookType         RuntimeHookType = "NoneRuntimeHookType"
 )
 
@@ -64,6 +70,18 @@ type RuntimeHookConfig struct {
 	RemoteEndpoint string            `json:"remote-endpoint,omitempty"`
 	FailurePolicy  FailurePolicyType `json:"failure-policy,omitempty"`
 	RuntimeHooks   []RuntimeHookType `json:"runtime-hooks,omitempty"`
+	// Priority decides the order of the hook servers registered for the same hook, the hook server with higher
+	// priority would be called first, and its response would be merged into the request sent to the next one.
+	Priority int32 `json:"priority,omitempty"`
+	// TimeoutMilliseconds is the timeout of calling the hook server. Default: 0 (only limited by the CRI request).
+	TimeoutMilliseconds int64 `json:"timeout-milliseconds,omitempty"`
+}
+
+func (c *RuntimeHookConfig) GetTimeout() time.Duration {
+	if c == nil || c.TimeoutMilliseconds <= 0 {
+		return 0
+	}
+	return time.Duration(c.TimeoutMilliseconds) * time.Millisecond
 }
 
 type RuntimeRequestPath string
@@ -75,6 +93,11 @@ const (
 	StartContainer           RuntimeRequestPath = "StartContainer"
 	UpdateContainerResources RuntimeRequestPath = "UpdateContainerResources"
 	StopContainer            RuntimeRequestPath = "StopContainer"
+	PullImage                RuntimeRequestPath = "PullImage"
+	ExecSync                 RuntimeRequestPath = "ExecSync"
+	ContainerStats           RuntimeRequestPath = "ContainerStats"
+	ListContainerStats       RuntimeRequestPath = "ListContainerStats"
+	UpdateRuntimeConfig      RuntimeRequestPath = "UpdateRuntimeConfig"
 	NoneRuntimeHookPath      RuntimeRequestPath = "NoneRuntimeHookPath"
 )
 
@@ -108,6 +131,26 @@ func (ht RuntimeHookType) OccursOn(path RuntimeRequestPath) bool {
 		if path == StopContainer {
 			return true
 		}
+	case PrePullImage:
+		if path == PullImage {
+			return true
+		}
+	case PreExecSync:
+		if path == ExecSync {
+			return true
+		}
+	case PostContainerStats:
+		if path == ContainerStats {
+			return true
+		}
+	case PostListContainerStats:
+		if path == ListContainerStats {
+			return true
+		}
+	case PreUpdateRuntimeConfig:
+		if path == UpdateRuntimeConfig {
+			return true
+		}
 	}
 	return false
 }
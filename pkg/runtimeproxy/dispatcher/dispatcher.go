@@ -19,14 +19,17 @@ package dispatcher
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"k8s.io/klog/v2"
 
 	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/client"
 	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/config"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/resexecutor/cri"
 )
 
 // RuntimeHookDispatcher dispatches hook request to RuntimeHookServer(e.g. koordlet)
@@ -61,36 +64,115 @@ func (rd *RuntimeHookDispatcher) dispatchInternal(ctx context.Context, hookType
 		return client.PostStartContainerHook(ctx, request.(*v1alpha1.ContainerResourceHookRequest))
 	case config.PostStopContainer:
 		return client.PostStopContainerHook(ctx, request.(*v1alpha1.ContainerResourceHookRequest))
+	case config.PrePullImage:
+		return client.PrePullImageHook(ctx, request.(*v1alpha1.ImageHookRequest))
+	case config.PreExecSync:
+		return client.PreExecSyncHook(ctx, request.(*v1alpha1.ExecSyncHookRequest))
+	case config.PostContainerStats:
+		return client.PostContainerStatsHook(ctx, request.(*v1alpha1.ContainerStatsHookRequest))
+	case config.PostListContainerStats:
+		return client.PostListContainerStatsHook(ctx, request.(*v1alpha1.ContainerStatsHookRequest))
+	case config.PreUpdateRuntimeConfig:
+		return client.PreUpdateRuntimeConfigHook(ctx, request.(*v1alpha1.RuntimeConfigHookRequest))
 	}
 	return nil, status.Errorf(codes.Unimplemented, fmt.Sprintf("method %v not implemented", string(hookType)))
 }
 
+// Dispatch calls the hook servers registered for the hook of the runtime request path and stage in the order of
+// priority. The response of each hook server is merged into the request sent to the next one, and the merged
+// response is returned. A hook server failed with PolicyFail stops the chain and returns the error, while the ones
+// with other policies are skipped.
 func (rd *RuntimeHookDispatcher) Dispatch(ctx context.Context, runtimeRequestPath config.RuntimeRequestPath,
 	stage config.RuntimeHookStage, request interface{}) (interface{}, error, config.FailurePolicyType) {
-	hookServers := rd.hookManager.GetAllHook()
+	hookServers := rd.getHookServers(runtimeRequestPath, stage)
+	if len(hookServers) == 0 {
+		return nil, nil, config.PolicyNone
+	}
+	message, ok := request.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("hook request type %T not supported", request), config.PolicyNone
+	}
+	// the request is cloned since it would be modified by the responses of the hook servers
+	hookRequest := proto.Clone(message)
+
+	var (
+		lastErr    error
+		lastPolicy config.FailurePolicyType = config.PolicyNone
+		responded  bool
+	)
 	for _, hookServer := range hookServers {
-		for _, hookType := range hookServer.RuntimeHooks {
-			if !hookType.OccursOn(runtimeRequestPath) {
-				continue
-			}
-			if hookType.HookStage() != stage {
-				continue
-			}
-			client, err := rd.cm.RuntimeHookServerClient(client.HookServerPath{
-				Path: hookServer.RemoteEndpoint,
-			})
-			if err != nil {
-				klog.Errorf("fail to get client %v", err)
-				continue
+		client, err := rd.cm.RuntimeHookServerClient(client.HookServerPath{
+			Path: hookServer.RemoteEndpoint,
+		})
+		lastPolicy = hookServer.FailurePolicy
+		if err != nil {
+			if hookServer.FailurePolicy == config.PolicyFail {
+				return nil, err, hookServer.FailurePolicy
 			}
-			// currently, only one hook be called during one runtime
-			// TODO: multi hook server to merge response
-			rsp, err := rd.dispatchInternal(ctx, hookType, client, request)
-			if err != nil {
+			klog.Errorf("fail to get client of hook server %v, ignore it, err: %v", hookServer.RemoteEndpoint, err)
+			lastErr = err
+			continue
+		}
+		rsp, err := rd.callHookServer(ctx, hookServer, client, hookRequest)
+		if err != nil {
+			if hookServer.FailurePolicy == config.PolicyFail {
 				return nil, err, hookServer.FailurePolicy
 			}
-			return rsp, err, hookServer.FailurePolicy
+			klog.Warningf("fail to call hook server %v for %v, ignore it, err: %v",
+				hookServer.RemoteEndpoint, runtimeRequestPath, err)
+			lastErr = err
+			continue
+		}
+		if err = cri.MergeHookResponse(hookRequest, rsp); err != nil {
+			klog.Errorf("fail to merge response of hook server %v, err: %v", hookServer.RemoteEndpoint, err)
+			continue
 		}
+		responded = true
+	}
+	if !responded {
+		return nil, lastErr, lastPolicy
+	}
+	response, err := cri.GenerateHookResponse(hookRequest)
+	return response, err, lastPolicy
+}
+
+// hookServerEntry is a hook server to call and the hook it registered for the runtime request path and stage.
+type hookServerEntry struct {
+	*config.RuntimeHookConfig
+	hookType config.RuntimeHookType
+}
+
+// getHookServers returns the hook servers registered for the runtime request path and stage, which are sorted by
+// priority in descending order and then by remote endpoint.
+func (rd *RuntimeHookDispatcher) getHookServers(runtimeRequestPath config.RuntimeRequestPath,
+	stage config.RuntimeHookStage) []hookServerEntry {
+	var hookServers []hookServerEntry
+	for _, hookServer := range rd.hookManager.GetAllHook() {
+		if hookServer == nil {
+			continue
+		}
+		for _, hookType := range hookServer.RuntimeHooks {
+			if hookType.OccursOn(runtimeRequestPath) && hookType.HookStage() == stage {
+				hookServers = append(hookServers, hookServerEntry{RuntimeHookConfig: hookServer, hookType: hookType})
+				break
+			}
+		}
+	}
+	sort.SliceStable(hookServers, func(i, j int) bool {
+		if hookServers[i].Priority != hookServers[j].Priority {
+			return hookServers[i].Priority > hookServers[j].Priority
+		}
+		return hookServers[i].RemoteEndpoint < hookServers[j].RemoteEndpoint
+	})
+	return hookServers
+}
+
+func (rd *RuntimeHookDispatcher) callHookServer(ctx context.Context, hookServer hookServerEntry,
+	client *client.RuntimeHookClient, request interface{}) (interface{}, error) {
+	if timeout := hookServer.GetTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
-	return nil, nil, config.PolicyNone
+	return rd.dispatchInternal(ctx, hookServer.hookType, client, request)
 }
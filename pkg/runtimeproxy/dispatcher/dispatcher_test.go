@@ -36,6 +36,7 @@ func TestRuntimeHookDispatcher_Dispatch(t *testing.T) {
 	tests := []struct {
 		name               string
 		requestPath        config.RuntimeRequestPath
+		stage              config.RuntimeHookStage
 		allHooks           []*config.RuntimeHookConfig
 		request            interface{}
 		hookSeverReturnErr error
@@ -99,6 +100,89 @@ func TestRuntimeHookDispatcher_Dispatch(t *testing.T) {
 			expectedOperation: config.PolicyNone,
 			expectReturnErr:   false,
 		},
+		{
+			name:               "image hook hit, and hook server rejects the image",
+			requestPath:        config.PullImage,
+			request:            &v1alpha1.ImageHookRequest{},
+			hookSeverReturnErr: fmt.Errorf("image not allowed"),
+			allHooks: []*config.RuntimeHookConfig{
+				{
+					RemoteEndpoint: "endpoint0",
+					FailurePolicy:  config.PolicyFail,
+					RuntimeHooks: []config.RuntimeHookType{
+						config.PrePullImage,
+					},
+				},
+			},
+			expectedOperation: config.PolicyFail,
+			expectReturnErr:   true,
+		},
+		{
+			name:        "exec sync hook hit, and hook server access ok",
+			requestPath: config.ExecSync,
+			request:     &v1alpha1.ExecSyncHookRequest{},
+			allHooks: []*config.RuntimeHookConfig{
+				{
+					RemoteEndpoint: "endpoint0",
+					FailurePolicy:  config.PolicyIgnore,
+					RuntimeHooks: []config.RuntimeHookType{
+						config.PreExecSync,
+					},
+				},
+			},
+			expectedOperation: config.PolicyIgnore,
+			expectReturnErr:   false,
+		},
+		{
+			name:        "list container stats post hook hit",
+			requestPath: config.ListContainerStats,
+			stage:       config.PostHook,
+			request:     &v1alpha1.ContainerStatsHookRequest{},
+			allHooks: []*config.RuntimeHookConfig{
+				{
+					RemoteEndpoint: "endpoint0",
+					FailurePolicy:  config.PolicyIgnore,
+					RuntimeHooks: []config.RuntimeHookType{
+						config.PostContainerStats,
+						config.PostListContainerStats,
+					},
+				},
+			},
+			expectedOperation: config.PolicyIgnore,
+			expectReturnErr:   false,
+		},
+		{
+			name:        "post hook not hit during pre hook stage",
+			requestPath: config.ContainerStats,
+			request:     &v1alpha1.ContainerStatsHookRequest{},
+			allHooks: []*config.RuntimeHookConfig{
+				{
+					RemoteEndpoint: "endpoint0",
+					FailurePolicy:  config.PolicyIgnore,
+					RuntimeHooks: []config.RuntimeHookType{
+						config.PostContainerStats,
+					},
+				},
+			},
+			expectedOperation: config.PolicyNone,
+			expectReturnErr:   false,
+		},
+		{
+			name:        "update runtime config hook hit",
+			requestPath: config.UpdateRuntimeConfig,
+			request:     &v1alpha1.RuntimeConfigHookRequest{},
+			allHooks: []*config.RuntimeHookConfig{
+				{
+					RemoteEndpoint: "endpoint0",
+					FailurePolicy:  config.PolicyFail,
+					RuntimeHooks: []config.RuntimeHookType{
+						config.PreUpdateRuntimeConfig,
+					},
+				},
+			},
+			expectedOperation: config.PolicyFail,
+			expectReturnErr:   false,
+		},
 	}
 	for _, tt := range tests {
 		ctl := gomock.NewController(t)
@@ -108,6 +192,14 @@ func TestRuntimeHookDispatcher_Dispatch(t *testing.T) {
 		runtimeProxyClient := mock.NewMockRuntimeHookServiceClient(ctl)
 		runtimeProxyClient.EXPECT().PreRunPodSandboxHook(gomock.Any(), gomock.Any()).Return(&v1alpha1.PodSandboxHookResponse{},
 			tt.hookSeverReturnErr).AnyTimes()
+		runtimeProxyClient.EXPECT().PrePullImageHook(gomock.Any(), gomock.Any()).Return(&v1alpha1.ImageHookResponse{},
+			tt.hookSeverReturnErr).AnyTimes()
+		runtimeProxyClient.EXPECT().PreExecSyncHook(gomock.Any(), gomock.Any()).Return(&v1alpha1.ExecSyncHookResponse{},
+			tt.hookSeverReturnErr).AnyTimes()
+		runtimeProxyClient.EXPECT().PostListContainerStatsHook(gomock.Any(), gomock.Any()).Return(&v1alpha1.ContainerStatsHookResponse{},
+			tt.hookSeverReturnErr).AnyTimes()
+		runtimeProxyClient.EXPECT().PreUpdateRuntimeConfigHook(gomock.Any(), gomock.Any()).Return(&v1alpha1.RuntimeConfigHookResponse{},
+			tt.hookSeverReturnErr).AnyTimes()
 
 		clientManager := mock_hookclient.NewMockHookServerClientManagerInterface(ctl)
 
@@ -121,7 +213,11 @@ func TestRuntimeHookDispatcher_Dispatch(t *testing.T) {
 			hookManager: configManager,
 			cm:          clientManager,
 		}
-		rsp, err, operation := runtimeHookDispatcher.Dispatch(context.TODO(), tt.requestPath, config.PreHook, tt.request)
+		stage := tt.stage
+		if stage == "" {
+			stage = config.PreHook
+		}
+		rsp, err, operation := runtimeHookDispatcher.Dispatch(context.TODO(), tt.requestPath, stage, tt.request)
 		assert.Equal(t, operation, tt.expectedOperation, tt.name)
 		assert.Equal(t, err != nil, tt.expectReturnErr)
 		//if err != nil, the rsp need to be nil
@@ -130,3 +226,209 @@ func TestRuntimeHookDispatcher_Dispatch(t *testing.T) {
 		}
 	}
 }
+
+func TestRuntimeHookDispatcher_DispatchChain(t *testing.T) {
+	tests := []struct {
+		name                  string
+		allHooks              []*config.RuntimeHookConfig
+		lowPriorityErr        error
+		highPriorityErr       error
+		highPriorityClientErr error
+		expectedResponse      *v1alpha1.PodSandboxHookResponse
+		expectedOperation     config.FailurePolicyType
+		expectReturnErr       bool
+	}{
+		{
+			name: "responses merged in the order of priority",
+			allHooks: []*config.RuntimeHookConfig{
+				{
+					RemoteEndpoint: "low",
+					FailurePolicy:  config.PolicyIgnore,
+					RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+				},
+				{
+					RemoteEndpoint: "high",
+					FailurePolicy:  config.PolicyFail,
+					Priority:       10,
+					RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+				},
+			},
+			expectedResponse: &v1alpha1.PodSandboxHookResponse{
+				Labels:       map[string]string{"origin": "true", "high": "true", "low": "true"},
+				Annotations:  map[string]string{},
+				CgroupParent: "/kubepods/low",
+			},
+			expectedOperation: config.PolicyIgnore,
+		},
+		{
+			name: "failed hook server with ignore policy skipped",
+			allHooks: []*config.RuntimeHookConfig{
+				{
+					RemoteEndpoint: "low",
+					FailurePolicy:  config.PolicyIgnore,
+					RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+				},
+				{
+					RemoteEndpoint: "high",
+					FailurePolicy:  config.PolicyIgnore,
+					Priority:       10,
+					RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+				},
+			},
+			highPriorityErr: fmt.Errorf("high priority hook server failed"),
+			expectedResponse: &v1alpha1.PodSandboxHookResponse{
+				Labels:       map[string]string{"origin": "true", "low": "true"},
+				Annotations:  map[string]string{},
+				CgroupParent: "/kubepods/low",
+			},
+			expectedOperation: config.PolicyIgnore,
+		},
+		{
+			name: "failed hook server with fail policy stops the chain",
+			allHooks: []*config.RuntimeHookConfig{
+				{
+					RemoteEndpoint: "low",
+					FailurePolicy:  config.PolicyIgnore,
+					RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+				},
+				{
+					RemoteEndpoint: "high",
+					FailurePolicy:  config.PolicyFail,
+					Priority:       10,
+					RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+				},
+			},
+			highPriorityErr:   fmt.Errorf("high priority hook server failed"),
+			expectedOperation: config.PolicyFail,
+			expectReturnErr:   true,
+		},
+		{
+			name: "hook server with fail policy failed to get client stops the chain",
+			allHooks: []*config.RuntimeHookConfig{
+				{
+					RemoteEndpoint: "low",
+					FailurePolicy:  config.PolicyIgnore,
+					RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+				},
+				{
+					RemoteEndpoint: "high",
+					FailurePolicy:  config.PolicyFail,
+					Priority:       10,
+					RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+				},
+			},
+			highPriorityClientErr: fmt.Errorf("high priority hook server unreachable"),
+			expectedOperation:     config.PolicyFail,
+			expectReturnErr:       true,
+		},
+		{
+			name: "hook server with ignore policy failed to get client skipped",
+			allHooks: []*config.RuntimeHookConfig{
+				{
+					RemoteEndpoint: "low",
+					FailurePolicy:  config.PolicyIgnore,
+					RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+				},
+				{
+					RemoteEndpoint: "high",
+					FailurePolicy:  config.PolicyIgnore,
+					Priority:       10,
+					RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+				},
+			},
+			highPriorityClientErr: fmt.Errorf("high priority hook server unreachable"),
+			expectedResponse: &v1alpha1.PodSandboxHookResponse{
+				Labels:       map[string]string{"origin": "true", "low": "true"},
+				Annotations:  map[string]string{},
+				CgroupParent: "/kubepods/low",
+			},
+			expectedOperation: config.PolicyIgnore,
+		},
+		{
+			name: "all hook servers failed with ignore policy",
+			allHooks: []*config.RuntimeHookConfig{
+				{
+					RemoteEndpoint: "low",
+					FailurePolicy:  config.PolicyIgnore,
+					RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+				},
+				{
+					RemoteEndpoint: "high",
+					FailurePolicy:  config.PolicyIgnore,
+					Priority:       10,
+					RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+				},
+			},
+			lowPriorityErr:    fmt.Errorf("low priority hook server failed"),
+			highPriorityErr:   fmt.Errorf("high priority hook server failed"),
+			expectedOperation: config.PolicyIgnore,
+			expectReturnErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			configManager := mock_config.NewMockManagerInterface(ctl)
+			configManager.EXPECT().GetAllHook().Return(tt.allHooks).AnyTimes()
+
+			// the high priority hook server sees the original request
+			highClient := mock.NewMockRuntimeHookServiceClient(ctl)
+			highClient.EXPECT().PreRunPodSandboxHook(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, req *v1alpha1.PodSandboxHookRequest, opts ...interface{}) (*v1alpha1.PodSandboxHookResponse, error) {
+					assert.Equal(t, map[string]string{"origin": "true"}, req.GetLabels())
+					if tt.highPriorityErr != nil {
+						return nil, tt.highPriorityErr
+					}
+					return &v1alpha1.PodSandboxHookResponse{
+						Labels:       map[string]string{"high": "true"},
+						CgroupParent: "/kubepods/high",
+					}, nil
+				}).AnyTimes()
+			// the low priority hook server sees the request merged with the response of the high priority one
+			lowClient := mock.NewMockRuntimeHookServiceClient(ctl)
+			lowClient.EXPECT().PreRunPodSandboxHook(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, req *v1alpha1.PodSandboxHookRequest, opts ...interface{}) (*v1alpha1.PodSandboxHookResponse, error) {
+					if tt.highPriorityErr == nil && tt.highPriorityClientErr == nil {
+						assert.Equal(t, "/kubepods/high", req.GetCgroupParent())
+					}
+					if tt.lowPriorityErr != nil {
+						return nil, tt.lowPriorityErr
+					}
+					return &v1alpha1.PodSandboxHookResponse{
+						Labels:       map[string]string{"low": "true"},
+						CgroupParent: "/kubepods/low",
+					}, nil
+				}).AnyTimes()
+
+			clientManager := mock_hookclient.NewMockHookServerClientManagerInterface(ctl)
+			var highHookClient *client.RuntimeHookClient
+			if tt.highPriorityClientErr == nil {
+				highHookClient = &client.RuntimeHookClient{RuntimeHookServiceClient: highClient}
+			}
+			clientManager.EXPECT().RuntimeHookServerClient(client.HookServerPath{Path: "high"}).Return(
+				highHookClient, tt.highPriorityClientErr).AnyTimes()
+			clientManager.EXPECT().RuntimeHookServerClient(client.HookServerPath{Path: "low"}).Return(
+				&client.RuntimeHookClient{RuntimeHookServiceClient: lowClient}, nil).AnyTimes()
+
+			runtimeHookDispatcher := &RuntimeHookDispatcher{
+				hookManager: configManager,
+				cm:          clientManager,
+			}
+			request := &v1alpha1.PodSandboxHookRequest{
+				Labels:       map[string]string{"origin": "true"},
+				CgroupParent: "/kubepods",
+			}
+			rsp, err, operation := runtimeHookDispatcher.Dispatch(context.TODO(), config.RunPodSandbox, config.PreHook, request)
+			assert.Equal(t, tt.expectedOperation, operation)
+			assert.Equal(t, tt.expectReturnErr, err != nil, err)
+			if tt.expectReturnErr {
+				assert.Nil(t, rsp)
+			} else {
+				assert.Equal(t, tt.expectedResponse, rsp)
+			}
+			// the original request should not be modified
+			assert.Equal(t, map[string]string{"origin": "true"}, request.GetLabels())
+		})
+	}
+}
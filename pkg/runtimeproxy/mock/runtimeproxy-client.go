@@ -52,6 +52,46 @@ func (m *MockRuntimeHookServiceClient) EXPECT() *MockRuntimeHookServiceClientMoc
 	return m.recorder
 }
 
+// PostContainerStatsHook mocks base method.
+func (m *MockRuntimeHookServiceClient) PostContainerStatsHook(ctx context.Context, in *v1alpha1.ContainerStatsHookRequest, opts ...grpc.CallOption) (*v1alpha1.ContainerStatsHookResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PostContainerStatsHook", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.ContainerStatsHookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostContainerStatsHook indicates an expected call of PostContainerStatsHook.
+func (mr *MockRuntimeHookServiceClientMockRecorder) PostContainerStatsHook(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostContainerStatsHook", reflect.TypeOf((*MockRuntimeHookServiceClient)(nil).PostContainerStatsHook), varargs...)
+}
+
+// PostListContainerStatsHook mocks base method.
+func (m *MockRuntimeHookServiceClient) PostListContainerStatsHook(ctx context.Context, in *v1alpha1.ContainerStatsHookRequest, opts ...grpc.CallOption) (*v1alpha1.ContainerStatsHookResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PostListContainerStatsHook", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.ContainerStatsHookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostListContainerStatsHook indicates an expected call of PostListContainerStatsHook.
+func (mr *MockRuntimeHookServiceClientMockRecorder) PostListContainerStatsHook(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostListContainerStatsHook", reflect.TypeOf((*MockRuntimeHookServiceClient)(nil).PostListContainerStatsHook), varargs...)
+}
+
 // PostStartContainerHook mocks base method.
 func (m *MockRuntimeHookServiceClient) PostStartContainerHook(ctx context.Context, in *v1alpha1.ContainerResourceHookRequest, opts ...grpc.CallOption) (*v1alpha1.ContainerResourceHookResponse, error) {
 	m.ctrl.T.Helper()
@@ -132,6 +172,46 @@ func (mr *MockRuntimeHookServiceClientMockRecorder) PreCreateContainerHook(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreCreateContainerHook", reflect.TypeOf((*MockRuntimeHookServiceClient)(nil).PreCreateContainerHook), varargs...)
 }
 
+// PreExecSyncHook mocks base method.
+func (m *MockRuntimeHookServiceClient) PreExecSyncHook(ctx context.Context, in *v1alpha1.ExecSyncHookRequest, opts ...grpc.CallOption) (*v1alpha1.ExecSyncHookResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PreExecSyncHook", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.ExecSyncHookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreExecSyncHook indicates an expected call of PreExecSyncHook.
+func (mr *MockRuntimeHookServiceClientMockRecorder) PreExecSyncHook(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreExecSyncHook", reflect.TypeOf((*MockRuntimeHookServiceClient)(nil).PreExecSyncHook), varargs...)
+}
+
+// PrePullImageHook mocks base method.
+func (m *MockRuntimeHookServiceClient) PrePullImageHook(ctx context.Context, in *v1alpha1.ImageHookRequest, opts ...grpc.CallOption) (*v1alpha1.ImageHookResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PrePullImageHook", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.ImageHookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrePullImageHook indicates an expected call of PrePullImageHook.
+func (mr *MockRuntimeHookServiceClientMockRecorder) PrePullImageHook(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrePullImageHook", reflect.TypeOf((*MockRuntimeHookServiceClient)(nil).PrePullImageHook), varargs...)
+}
+
 // PreRunPodSandboxHook mocks base method.
 func (m *MockRuntimeHookServiceClient) PreRunPodSandboxHook(ctx context.Context, in *v1alpha1.PodSandboxHookRequest, opts ...grpc.CallOption) (*v1alpha1.PodSandboxHookResponse, error) {
 	m.ctrl.T.Helper()
@@ -192,6 +272,26 @@ func (mr *MockRuntimeHookServiceClientMockRecorder) PreUpdateContainerResourcesH
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreUpdateContainerResourcesHook", reflect.TypeOf((*MockRuntimeHookServiceClient)(nil).PreUpdateContainerResourcesHook), varargs...)
 }
 
+// PreUpdateRuntimeConfigHook mocks base method.
+func (m *MockRuntimeHookServiceClient) PreUpdateRuntimeConfigHook(ctx context.Context, in *v1alpha1.RuntimeConfigHookRequest, opts ...grpc.CallOption) (*v1alpha1.RuntimeConfigHookResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PreUpdateRuntimeConfigHook", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.RuntimeConfigHookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreUpdateRuntimeConfigHook indicates an expected call of PreUpdateRuntimeConfigHook.
+func (mr *MockRuntimeHookServiceClientMockRecorder) PreUpdateRuntimeConfigHook(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreUpdateRuntimeConfigHook", reflect.TypeOf((*MockRuntimeHookServiceClient)(nil).PreUpdateRuntimeConfigHook), varargs...)
+}
+
 // MockRuntimeHookServiceServer is a mock of RuntimeHookServiceServer interface.
 type MockRuntimeHookServiceServer struct {
 	ctrl     *gomock.Controller
@@ -215,6 +315,36 @@ func (m *MockRuntimeHookServiceServer) EXPECT() *MockRuntimeHookServiceServerMoc
 	return m.recorder
 }
 
+// PostContainerStatsHook mocks base method.
+func (m *MockRuntimeHookServiceServer) PostContainerStatsHook(arg0 context.Context, arg1 *v1alpha1.ContainerStatsHookRequest) (*v1alpha1.ContainerStatsHookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostContainerStatsHook", arg0, arg1)
+	ret0, _ := ret[0].(*v1alpha1.ContainerStatsHookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostContainerStatsHook indicates an expected call of PostContainerStatsHook.
+func (mr *MockRuntimeHookServiceServerMockRecorder) PostContainerStatsHook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostContainerStatsHook", reflect.TypeOf((*MockRuntimeHookServiceServer)(nil).PostContainerStatsHook), arg0, arg1)
+}
+
+// PostListContainerStatsHook mocks base method.
+func (m *MockRuntimeHookServiceServer) PostListContainerStatsHook(arg0 context.Context, arg1 *v1alpha1.ContainerStatsHookRequest) (*v1alpha1.ContainerStatsHookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostListContainerStatsHook", arg0, arg1)
+	ret0, _ := ret[0].(*v1alpha1.ContainerStatsHookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostListContainerStatsHook indicates an expected call of PostListContainerStatsHook.
+func (mr *MockRuntimeHookServiceServerMockRecorder) PostListContainerStatsHook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostListContainerStatsHook", reflect.TypeOf((*MockRuntimeHookServiceServer)(nil).PostListContainerStatsHook), arg0, arg1)
+}
+
 // PostStartContainerHook mocks base method.
 func (m *MockRuntimeHookServiceServer) PostStartContainerHook(arg0 context.Context, arg1 *v1alpha1.ContainerResourceHookRequest) (*v1alpha1.ContainerResourceHookResponse, error) {
 	m.ctrl.T.Helper()
@@ -275,6 +405,36 @@ func (mr *MockRuntimeHookServiceServerMockRecorder) PreCreateContainerHook(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreCreateContainerHook", reflect.TypeOf((*MockRuntimeHookServiceServer)(nil).PreCreateContainerHook), arg0, arg1)
 }
 
+// PreExecSyncHook mocks base method.
+func (m *MockRuntimeHookServiceServer) PreExecSyncHook(arg0 context.Context, arg1 *v1alpha1.ExecSyncHookRequest) (*v1alpha1.ExecSyncHookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreExecSyncHook", arg0, arg1)
+	ret0, _ := ret[0].(*v1alpha1.ExecSyncHookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreExecSyncHook indicates an expected call of PreExecSyncHook.
+func (mr *MockRuntimeHookServiceServerMockRecorder) PreExecSyncHook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreExecSyncHook", reflect.TypeOf((*MockRuntimeHookServiceServer)(nil).PreExecSyncHook), arg0, arg1)
+}
+
+// PrePullImageHook mocks base method.
+func (m *MockRuntimeHookServiceServer) PrePullImageHook(arg0 context.Context, arg1 *v1alpha1.ImageHookRequest) (*v1alpha1.ImageHookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrePullImageHook", arg0, arg1)
+	ret0, _ := ret[0].(*v1alpha1.ImageHookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrePullImageHook indicates an expected call of PrePullImageHook.
+func (mr *MockRuntimeHookServiceServerMockRecorder) PrePullImageHook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrePullImageHook", reflect.TypeOf((*MockRuntimeHookServiceServer)(nil).PrePullImageHook), arg0, arg1)
+}
+
 // PreRunPodSandboxHook mocks base method.
 func (m *MockRuntimeHookServiceServer) PreRunPodSandboxHook(arg0 context.Context, arg1 *v1alpha1.PodSandboxHookRequest) (*v1alpha1.PodSandboxHookResponse, error) {
 	m.ctrl.T.Helper()
@@ -320,6 +480,21 @@ func (mr *MockRuntimeHookServiceServerMockRecorder) PreUpdateContainerResourcesH
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreUpdateContainerResourcesHook", reflect.TypeOf((*MockRuntimeHookServiceServer)(nil).PreUpdateContainerResourcesHook), arg0, arg1)
 }
 
+// PreUpdateRuntimeConfigHook mocks base method.
+func (m *MockRuntimeHookServiceServer) PreUpdateRuntimeConfigHook(arg0 context.Context, arg1 *v1alpha1.RuntimeConfigHookRequest) (*v1alpha1.RuntimeConfigHookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreUpdateRuntimeConfigHook", arg0, arg1)
+	ret0, _ := ret[0].(*v1alpha1.RuntimeConfigHookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreUpdateRuntimeConfigHook indicates an expected call of PreUpdateRuntimeConfigHook.
+func (mr *MockRuntimeHookServiceServerMockRecorder) PreUpdateRuntimeConfigHook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreUpdateRuntimeConfigHook", reflect.TypeOf((*MockRuntimeHookServiceServer)(nil).PreUpdateRuntimeConfigHook), arg0, arg1)
+}
+
 // mustEmbedUnimplementedRuntimeHookServiceServer mocks base method.
 func (m *MockRuntimeHookServiceServer) mustEmbedUnimplementedRuntimeHookServiceServer() {
 	m.ctrl.T.Helper()
@@ -89,6 +89,7 @@ func (c *ContainerResourceExecutor) ParseRequest(req interface{}) (utils.CallHoo
 				ContainerResources:   transferToKoordResources(request.GetConfig().GetLinux().GetResources()),
 				PodCgroupParent:      request.GetSandboxConfig().GetLinux().GetCgroupParent(),
 				ContainerEnvs:        transferToKoordContainerEnvs(request.GetConfig().GetEnvs()),
+				ContainerDevices:     transferToKoordContainerDevices(request.GetConfig().GetDevices()),
 			},
 		}
 		klog.Infof("success parse container info %v during container create", c)
@@ -179,6 +180,9 @@ func (c *ContainerResourceExecutor) UpdateRequest(rsp interface{}, req interface
 	if response.GetContainerEnvs() != nil {
 		c.ContainerEnvs = response.GetContainerEnvs()
 	}
+	if response.GetContainerDevices() != nil {
+		c.ContainerDevices = response.GetContainerDevices()
+	}
 
 	// update CRI request
 	switch request := req.(type) {
@@ -193,6 +197,7 @@ func (c *ContainerResourceExecutor) UpdateRequest(rsp interface{}, req interface
 			request.SandboxConfig.Linux.CgroupParent = c.PodCgroupParent
 		}
 		request.Config.Envs = transferToCRIContainerEnvs(c.ContainerEnvs)
+		request.Config.Devices = transferToCRIContainerDevices(c.ContainerDevices)
 	case *runtimeapi.UpdateContainerResourcesRequest:
 		if c.ContainerAnnotations != nil {
 			request.Annotations = c.ContainerAnnotations
@@ -203,3 +208,7 @@ func (c *ContainerResourceExecutor) UpdateRequest(rsp interface{}, req interface
 	}
 	return nil
 }
+
+func (c *ContainerResourceExecutor) UpdateResponse(hookRsp interface{}, rsp interface{}) error {
+	return nil
+}
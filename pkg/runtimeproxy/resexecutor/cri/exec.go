@@ -0,0 +1,120 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"fmt"
+	"reflect"
+
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	"github.com/koordinator-sh/koordinator/cmd/koord-runtime-proxy/options"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/store"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/utils"
+)
+
+type ExecSyncExecutor struct {
+	store.ContainerInfo
+	cmd     []string
+	timeout int64
+}
+
+func NewExecSyncExecutor() *ExecSyncExecutor {
+	return &ExecSyncExecutor{}
+}
+
+func (e *ExecSyncExecutor) String() string {
+	return fmt.Sprintf("pod(%v/%v)container(%v)cmd(%v)",
+		e.GetPodMeta().GetName(), e.GetPodMeta().GetUid(),
+		e.GetContainerMeta().GetName(), e.cmd)
+}
+
+func (e *ExecSyncExecutor) GetMetaInfo() string {
+	return fmt.Sprintf("pod(%v/%v)container(%v)",
+		e.GetPodMeta().GetName(), e.GetPodMeta().GetUid(),
+		e.GetContainerMeta().GetName())
+}
+
+func (e *ExecSyncExecutor) GenerateHookRequest() interface{} {
+	return &v1alpha1.ExecSyncHookRequest{
+		PodMeta:              e.GetPodMeta(),
+		ContainerMeta:        e.GetContainerMeta(),
+		ContainerAnnotations: e.GetContainerAnnotations(),
+		PodAnnotations:       e.GetPodAnnotations(),
+		PodLabels:            e.GetPodLabels(),
+		Cmd:                  e.cmd,
+		Timeout:              e.timeout,
+	}
+}
+
+// ParseRequest loads the container info from local store, the hook plugins would not be called for the containers
+// unknown to the runtime proxy.
+func (e *ExecSyncExecutor) ParseRequest(req interface{}) (utils.CallHookPluginOperation, error) {
+	request, ok := req.(*runtimeapi.ExecSyncRequest)
+	if !ok {
+		return utils.Unknown, fmt.Errorf("request type not compatible. Should be ExecSyncRequest, but got %s", reflect.TypeOf(req).String())
+	}
+	containerCheckPoint := store.GetContainerInfo(request.GetContainerId())
+	if containerCheckPoint == nil {
+		// the containers created before the runtime proxy or not managed by it, e.g. the sandbox containers
+		klog.V(5).Infof("skip calling hook plugins for the untracked container(%v) during ExecSync", request.GetContainerId())
+		return utils.ShouldNotCallHookPluginAlways, nil
+	}
+	e.ContainerInfo = *containerCheckPoint
+	e.cmd = request.GetCmd()
+	e.timeout = request.GetTimeout()
+	if exist := IsKeyValExistInLabels(e.GetPodLabels(), options.RuntimeHookServerKey, options.RuntimeHookServerVal); exist {
+		return utils.ShouldNotCallHookPluginAlways, nil
+	}
+	return utils.ShouldCallHookPlugin, nil
+}
+
+func (e *ExecSyncExecutor) ResourceCheckPoint(rsp interface{}) error {
+	return nil
+}
+
+func (e *ExecSyncExecutor) DeleteCheckpointIfNeed(req interface{}) error {
+	return nil
+}
+
+// UpdateRequest will update ExecSyncExecutor from hook response and then update CRI request.
+func (e *ExecSyncExecutor) UpdateRequest(rsp interface{}, req interface{}) error {
+	response, ok := rsp.(*v1alpha1.ExecSyncHookResponse)
+	if !ok {
+		return fmt.Errorf("response type not compatible. Should be ExecSyncHookResponse, but got %s", reflect.TypeOf(rsp).String())
+	}
+	// update ExecSyncExecutor
+	if len(response.GetCmd()) > 0 {
+		e.cmd = response.GetCmd()
+	}
+	if response.GetTimeout() > 0 {
+		e.timeout = response.GetTimeout()
+	}
+
+	// update CRI request
+	if request, ok := req.(*runtimeapi.ExecSyncRequest); ok {
+		request.Cmd = e.cmd
+		request.Timeout = e.timeout
+	}
+	return nil
+}
+
+func (e *ExecSyncExecutor) UpdateResponse(hookRsp interface{}, rsp interface{}) error {
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/store"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/utils"
+)
+
+func TestExecSyncExecutor(t *testing.T) {
+	containerInfo := &store.ContainerInfo{
+		ContainerResourceHookRequest: &v1alpha1.ContainerResourceHookRequest{
+			PodMeta: &v1alpha1.PodSandboxMetadata{
+				Name:      "test-pod",
+				Namespace: "default",
+				Uid:       "uid-1",
+			},
+			ContainerMeta: &v1alpha1.ContainerMetadata{
+				Name: "test-container",
+				Id:   "exec-container-1",
+			},
+			PodLabels: map[string]string{"app": "nginx"},
+		},
+	}
+	_ = store.WriteContainerInfo("exec-container-1", containerInfo)
+	defer store.DeleteContainerInfo("exec-container-1")
+
+	tests := []struct {
+		name          string
+		request       *runtimeapi.ExecSyncRequest
+		response      *v1alpha1.ExecSyncHookResponse
+		wantOperation utils.CallHookPluginOperation
+		wantErr       bool
+		wantRequest   *runtimeapi.ExecSyncRequest
+	}{
+		{
+			name: "container not found in store",
+			request: &runtimeapi.ExecSyncRequest{
+				ContainerId: "unknown",
+				Cmd:         []string{"ls"},
+			},
+			wantOperation: utils.ShouldNotCallHookPluginAlways,
+		},
+		{
+			name: "rewrite the command",
+			request: &runtimeapi.ExecSyncRequest{
+				ContainerId: "exec-container-1",
+				Cmd:         []string{"ls"},
+				Timeout:     10,
+			},
+			response: &v1alpha1.ExecSyncHookResponse{
+				Cmd: []string{"ls", "-l"},
+			},
+			wantOperation: utils.ShouldCallHookPlugin,
+			wantRequest: &runtimeapi.ExecSyncRequest{
+				ContainerId: "exec-container-1",
+				Cmd:         []string{"ls", "-l"},
+				Timeout:     10,
+			},
+		},
+		{
+			name: "empty response keeps the command",
+			request: &runtimeapi.ExecSyncRequest{
+				ContainerId: "exec-container-1",
+				Cmd:         []string{"ls"},
+			},
+			response:      &v1alpha1.ExecSyncHookResponse{},
+			wantOperation: utils.ShouldCallHookPlugin,
+			wantRequest: &runtimeapi.ExecSyncRequest{
+				ContainerId: "exec-container-1",
+				Cmd:         []string{"ls"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewExecSyncExecutor()
+			operation, err := e.ParseRequest(tt.request)
+			assert.Equal(t, tt.wantOperation, operation)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			if tt.wantErr || operation != utils.ShouldCallHookPlugin {
+				return
+			}
+			hookRequest := e.GenerateHookRequest().(*v1alpha1.ExecSyncHookRequest)
+			assert.Equal(t, containerInfo.GetPodMeta(), hookRequest.GetPodMeta())
+			assert.Equal(t, tt.request.GetCmd(), hookRequest.GetCmd())
+
+			assert.NoError(t, e.UpdateRequest(tt.response, tt.request))
+			assert.Equal(t, tt.wantRequest, tt.request)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/utils"
+)
+
+// MergeHookResponse merges the response of a hook server into the hook request, so the next hook server in the chain
+// would receive the request updated by the previous ones. The fields are merged in the same way as UpdateRequest.
+func MergeHookResponse(hookRequest, hookResponse interface{}) error {
+	switch request := hookRequest.(type) {
+	case *v1alpha1.PodSandboxHookRequest:
+		response, ok := hookResponse.(*v1alpha1.PodSandboxHookResponse)
+		if !ok {
+			break
+		}
+		request.Labels = utils.MergeMap(request.Labels, response.GetLabels())
+		request.Annotations = utils.MergeMap(request.Annotations, response.GetAnnotations())
+		if response.GetCgroupParent() != "" {
+			request.CgroupParent = response.GetCgroupParent()
+		}
+		request.Resources = mergeResource(request.Resources, response.GetResources())
+		return nil
+	case *v1alpha1.ContainerResourceHookRequest:
+		response, ok := hookResponse.(*v1alpha1.ContainerResourceHookResponse)
+		if !ok {
+			break
+		}
+		request.ContainerAnnotations = utils.MergeMap(request.ContainerAnnotations, response.GetContainerAnnotations())
+		request.ContainerResources = mergeResource(request.ContainerResources, response.GetContainerResources())
+		if response.GetPodCgroupParent() != "" {
+			request.PodCgroupParent = response.GetPodCgroupParent()
+		}
+		if response.GetContainerEnvs() != nil {
+			request.ContainerEnvs = response.GetContainerEnvs()
+		}
+		if response.GetContainerDevices() != nil {
+			request.ContainerDevices = response.GetContainerDevices()
+		}
+		return nil
+	case *v1alpha1.ImageHookRequest:
+		response, ok := hookResponse.(*v1alpha1.ImageHookResponse)
+		if !ok {
+			break
+		}
+		if response.GetImage() != "" {
+			request.Image = response.GetImage()
+		}
+		request.ImageAnnotations = utils.MergeMap(request.ImageAnnotations, response.GetImageAnnotations())
+		return nil
+	case *v1alpha1.ExecSyncHookRequest:
+		response, ok := hookResponse.(*v1alpha1.ExecSyncHookResponse)
+		if !ok {
+			break
+		}
+		if len(response.GetCmd()) > 0 {
+			request.Cmd = response.GetCmd()
+		}
+		if response.GetTimeout() > 0 {
+			request.Timeout = response.GetTimeout()
+		}
+		return nil
+	case *v1alpha1.ContainerStatsHookRequest:
+		response, ok := hookResponse.(*v1alpha1.ContainerStatsHookResponse)
+		if !ok {
+			break
+		}
+		responseStats := make(map[string]*v1alpha1.ContainerStatsInfo, len(response.GetStats()))
+		for _, stats := range response.GetStats() {
+			if id := stats.GetContainerMeta().GetId(); id != "" {
+				responseStats[id] = stats
+			}
+		}
+		for _, stats := range request.GetStats() {
+			if hookStats, exist := responseStats[stats.GetContainerMeta().GetId()]; exist {
+				mergeContainerStatsInfo(stats, hookStats)
+			}
+		}
+		return nil
+	case *v1alpha1.RuntimeConfigHookRequest:
+		response, ok := hookResponse.(*v1alpha1.RuntimeConfigHookResponse)
+		if !ok {
+			break
+		}
+		if response.GetPodCidr() != "" {
+			request.PodCidr = response.GetPodCidr()
+		}
+		return nil
+	}
+	return fmt.Errorf("response type %s not compatible with request type %s",
+		reflect.TypeOf(hookResponse).String(), reflect.TypeOf(hookRequest).String())
+}
+
+// GenerateHookResponse generates the hook response from the hook request which has merged the responses of all hook
+// servers in the chain.
+func GenerateHookResponse(hookRequest interface{}) (interface{}, error) {
+	switch request := hookRequest.(type) {
+	case *v1alpha1.PodSandboxHookRequest:
+		return &v1alpha1.PodSandboxHookResponse{
+			Labels:       request.GetLabels(),
+			Annotations:  request.GetAnnotations(),
+			CgroupParent: request.GetCgroupParent(),
+			Resources:    request.GetResources(),
+		}, nil
+	case *v1alpha1.ContainerResourceHookRequest:
+		return &v1alpha1.ContainerResourceHookResponse{
+			ContainerAnnotations: request.GetContainerAnnotations(),
+			ContainerResources:   request.GetContainerResources(),
+			PodCgroupParent:      request.GetPodCgroupParent(),
+			ContainerEnvs:        request.GetContainerEnvs(),
+			ContainerDevices:     request.GetContainerDevices(),
+		}, nil
+	case *v1alpha1.ImageHookRequest:
+		return &v1alpha1.ImageHookResponse{
+			Image:            request.GetImage(),
+			ImageAnnotations: request.GetImageAnnotations(),
+		}, nil
+	case *v1alpha1.ExecSyncHookRequest:
+		return &v1alpha1.ExecSyncHookResponse{
+			Cmd:     request.GetCmd(),
+			Timeout: request.GetTimeout(),
+		}, nil
+	case *v1alpha1.ContainerStatsHookRequest:
+		return &v1alpha1.ContainerStatsHookResponse{
+			Stats: request.GetStats(),
+		}, nil
+	case *v1alpha1.RuntimeConfigHookRequest:
+		return &v1alpha1.RuntimeConfigHookResponse{
+			PodCidr: request.GetPodCidr(),
+		}, nil
+	}
+	return nil, fmt.Errorf("hook request type %s not supported", reflect.TypeOf(hookRequest).String())
+}
+
+func mergeResource(a, b *v1alpha1.LinuxContainerResources) *v1alpha1.LinuxContainerResources {
+	if a == nil {
+		return b
+	}
+	return updateResource(a, b)
+}
+
+func mergeContainerStatsInfo(a, b *v1alpha1.ContainerStatsInfo) {
+	a.ContainerAnnotations = utils.MergeMap(a.ContainerAnnotations, b.GetContainerAnnotations())
+	if b.GetTimestamp() > 0 {
+		a.Timestamp = b.GetTimestamp()
+	}
+	if b.GetCpuUsageCoreNanoSeconds() > 0 {
+		a.CpuUsageCoreNanoSeconds = b.GetCpuUsageCoreNanoSeconds()
+	}
+	if b.GetMemoryWorkingSetBytes() > 0 {
+		a.MemoryWorkingSetBytes = b.GetMemoryWorkingSetBytes()
+	}
+}
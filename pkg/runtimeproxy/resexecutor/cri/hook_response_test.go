@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+)
+
+func TestMergeHookResponse(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      interface{}
+		responses    []interface{}
+		wantErr      bool
+		wantResponse interface{}
+	}{
+		{
+			name: "merge container responses",
+			request: &v1alpha1.ContainerResourceHookRequest{
+				ContainerAnnotations: map[string]string{"origin": "true"},
+				ContainerResources: &v1alpha1.LinuxContainerResources{
+					CpuShares:  1024,
+					CpusetCpus: "0-3",
+				},
+				PodCgroupParent: "/kubepods",
+			},
+			responses: []interface{}{
+				&v1alpha1.ContainerResourceHookResponse{
+					ContainerAnnotations: map[string]string{"first": "true"},
+					ContainerResources: &v1alpha1.LinuxContainerResources{
+						CpuShares:  2,
+						CpusetCpus: "0-1",
+					},
+				},
+				&v1alpha1.ContainerResourceHookResponse{
+					ContainerAnnotations: map[string]string{"second": "true"},
+					ContainerResources: &v1alpha1.LinuxContainerResources{
+						CpusetCpus: "2-3",
+					},
+					PodCgroupParent: "/kubepods/besteffort",
+					ContainerEnvs:   map[string]string{"env": "second"},
+					ContainerDevices: []*v1alpha1.LinuxDevice{
+						{ContainerPath: "/dev/infiniband/uverbs0", HostPath: "/dev/infiniband/uverbs0", Permissions: "rwm"},
+					},
+				},
+			},
+			wantResponse: &v1alpha1.ContainerResourceHookResponse{
+				ContainerAnnotations: map[string]string{"origin": "true", "first": "true", "second": "true"},
+				ContainerResources: &v1alpha1.LinuxContainerResources{
+					CpuShares:  2,
+					CpusetCpus: "2-3",
+					Unified:    map[string]string{},
+				},
+				PodCgroupParent: "/kubepods/besteffort",
+				ContainerEnvs:   map[string]string{"env": "second"},
+				ContainerDevices: []*v1alpha1.LinuxDevice{
+					{ContainerPath: "/dev/infiniband/uverbs0", HostPath: "/dev/infiniband/uverbs0", Permissions: "rwm"},
+				},
+			},
+		},
+		{
+			name: "merge image responses",
+			request: &v1alpha1.ImageHookRequest{
+				Image: "nginx:latest",
+			},
+			responses: []interface{}{
+				&v1alpha1.ImageHookResponse{Image: "mirror.local/nginx:latest"},
+				&v1alpha1.ImageHookResponse{ImageAnnotations: map[string]string{"checked": "true"}},
+			},
+			wantResponse: &v1alpha1.ImageHookResponse{
+				Image:            "mirror.local/nginx:latest",
+				ImageAnnotations: map[string]string{"checked": "true"},
+			},
+		},
+		{
+			name: "merge container stats responses by container id",
+			request: &v1alpha1.ContainerStatsHookRequest{
+				Stats: []*v1alpha1.ContainerStatsInfo{
+					{
+						ContainerMeta:           &v1alpha1.ContainerMetadata{Id: "container-1"},
+						CpuUsageCoreNanoSeconds: 100,
+					},
+					{
+						ContainerMeta:           &v1alpha1.ContainerMetadata{Id: "container-2"},
+						CpuUsageCoreNanoSeconds: 200,
+					},
+				},
+			},
+			responses: []interface{}{
+				&v1alpha1.ContainerStatsHookResponse{
+					Stats: []*v1alpha1.ContainerStatsInfo{
+						{
+							ContainerMeta:         &v1alpha1.ContainerMetadata{Id: "container-2"},
+							MemoryWorkingSetBytes: 1024,
+						},
+					},
+				},
+			},
+			wantResponse: &v1alpha1.ContainerStatsHookResponse{
+				Stats: []*v1alpha1.ContainerStatsInfo{
+					{
+						ContainerMeta:           &v1alpha1.ContainerMetadata{Id: "container-1"},
+						CpuUsageCoreNanoSeconds: 100,
+					},
+					{
+						ContainerMeta:           &v1alpha1.ContainerMetadata{Id: "container-2"},
+						CpuUsageCoreNanoSeconds: 200,
+						MemoryWorkingSetBytes:   1024,
+						ContainerAnnotations:    map[string]string{},
+					},
+				},
+			},
+		},
+		{
+			name:      "response type not compatible",
+			request:   &v1alpha1.RuntimeConfigHookRequest{PodCidr: "10.0.0.0/16"},
+			responses: []interface{}{&v1alpha1.ImageHookResponse{}},
+			wantErr:   true,
+			wantResponse: &v1alpha1.RuntimeConfigHookResponse{
+				PodCidr: "10.0.0.0/16",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			for _, response := range tt.responses {
+				if mergeErr := MergeHookResponse(tt.request, response); mergeErr != nil {
+					err = mergeErr
+				}
+			}
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			gotResponse, err := GenerateHookResponse(tt.request)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantResponse, gotResponse)
+		})
+	}
+}
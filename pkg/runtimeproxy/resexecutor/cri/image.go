@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"fmt"
+	"reflect"
+
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	"github.com/koordinator-sh/koordinator/cmd/koord-runtime-proxy/options"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/utils"
+)
+
+type ImageExecutor struct {
+	*v1alpha1.ImageHookRequest
+}
+
+func NewImageExecutor() *ImageExecutor {
+	return &ImageExecutor{}
+}
+
+func (i *ImageExecutor) String() string {
+	return fmt.Sprintf("pod(%v/%v)image(%v)",
+		i.GetPodMeta().GetName(), i.GetPodMeta().GetUid(), i.GetImage())
+}
+
+func (i *ImageExecutor) GetMetaInfo() string {
+	return fmt.Sprintf("pod(%v/%v)image(%v)",
+		i.GetPodMeta().GetName(), i.GetPodMeta().GetUid(), i.GetImage())
+}
+
+func (i *ImageExecutor) GenerateHookRequest() interface{} {
+	return i.ImageHookRequest
+}
+
+func (i *ImageExecutor) ParseRequest(req interface{}) (utils.CallHookPluginOperation, error) {
+	request, ok := req.(*runtimeapi.PullImageRequest)
+	if !ok {
+		return utils.Unknown, fmt.Errorf("request type not compatible. Should be PullImageRequest, but got %s", reflect.TypeOf(req).String())
+	}
+	i.ImageHookRequest = &v1alpha1.ImageHookRequest{
+		Image:            request.GetImage().GetImage(),
+		ImageAnnotations: request.GetImage().GetAnnotations(),
+		PodLabels:        request.GetSandboxConfig().GetLabels(),
+		PodAnnotations:   request.GetSandboxConfig().GetAnnotations(),
+	}
+	if podMeta := request.GetSandboxConfig().GetMetadata(); podMeta != nil {
+		i.PodMeta = &v1alpha1.PodSandboxMetadata{
+			Name:      podMeta.GetName(),
+			Namespace: podMeta.GetNamespace(),
+			Uid:       podMeta.GetUid(),
+			Attempt:   podMeta.GetAttempt(),
+		}
+	}
+	klog.V(5).Infof("success parse image info %v during image pull", i)
+	if exist := IsKeyValExistInLabels(i.GetPodLabels(), options.RuntimeHookServerKey, options.RuntimeHookServerVal); exist {
+		return utils.ShouldNotCallHookPluginAlways, nil
+	}
+	return utils.ShouldCallHookPlugin, nil
+}
+
+func (i *ImageExecutor) ResourceCheckPoint(rsp interface{}) error {
+	return nil
+}
+
+func (i *ImageExecutor) DeleteCheckpointIfNeed(req interface{}) error {
+	return nil
+}
+
+// UpdateRequest will update ImageExecutor from hook response and then update CRI request.
+func (i *ImageExecutor) UpdateRequest(rsp interface{}, req interface{}) error {
+	response, ok := rsp.(*v1alpha1.ImageHookResponse)
+	if !ok {
+		return fmt.Errorf("response type not compatible. Should be ImageHookResponse, but got %s", reflect.TypeOf(rsp).String())
+	}
+	// update ImageExecutor
+	if response.GetImage() != "" {
+		i.Image = response.GetImage()
+	}
+	i.ImageAnnotations = utils.MergeMap(i.ImageAnnotations, response.GetImageAnnotations())
+
+	// update CRI request
+	request, ok := req.(*runtimeapi.PullImageRequest)
+	if !ok {
+		return nil
+	}
+	if request.Image == nil {
+		request.Image = &runtimeapi.ImageSpec{}
+	}
+	request.Image.Image = i.Image
+	if len(i.ImageAnnotations) > 0 {
+		request.Image.Annotations = i.ImageAnnotations
+	}
+	return nil
+}
+
+func (i *ImageExecutor) UpdateResponse(hookRsp interface{}, rsp interface{}) error {
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	"github.com/koordinator-sh/koordinator/cmd/koord-runtime-proxy/options"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/utils"
+)
+
+func TestImageExecutor_ParseRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       interface{}
+		wantOperation utils.CallHookPluginOperation
+		wantErr       bool
+		wantRequest   *v1alpha1.ImageHookRequest
+	}{
+		{
+			name: "pull image for pod",
+			request: &runtimeapi.PullImageRequest{
+				Image: &runtimeapi.ImageSpec{
+					Image:       "nginx:latest",
+					Annotations: map[string]string{"a": "b"},
+				},
+				SandboxConfig: &runtimeapi.PodSandboxConfig{
+					Metadata: &runtimeapi.PodSandboxMetadata{
+						Name:      "test-pod",
+						Namespace: "default",
+						Uid:       "uid-1",
+					},
+					Labels: map[string]string{"app": "nginx"},
+				},
+			},
+			wantOperation: utils.ShouldCallHookPlugin,
+			wantRequest: &v1alpha1.ImageHookRequest{
+				Image:            "nginx:latest",
+				ImageAnnotations: map[string]string{"a": "b"},
+				PodMeta: &v1alpha1.PodSandboxMetadata{
+					Name:      "test-pod",
+					Namespace: "default",
+					Uid:       "uid-1",
+				},
+				PodLabels: map[string]string{"app": "nginx"},
+			},
+		},
+		{
+			name: "pull image for hook server",
+			request: &runtimeapi.PullImageRequest{
+				Image: &runtimeapi.ImageSpec{Image: "koordlet:latest"},
+				SandboxConfig: &runtimeapi.PodSandboxConfig{
+					Labels: map[string]string{options.RuntimeHookServerKey: options.RuntimeHookServerVal},
+				},
+			},
+			wantOperation: utils.ShouldNotCallHookPluginAlways,
+			wantRequest: &v1alpha1.ImageHookRequest{
+				Image:     "koordlet:latest",
+				PodLabels: map[string]string{options.RuntimeHookServerKey: options.RuntimeHookServerVal},
+			},
+		},
+		{
+			name:          "request type not compatible",
+			request:       &runtimeapi.ImageStatusRequest{},
+			wantOperation: utils.Unknown,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := NewImageExecutor()
+			operation, err := i.ParseRequest(tt.request)
+			assert.Equal(t, tt.wantOperation, operation)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			if !tt.wantErr {
+				assert.Equal(t, tt.wantRequest, i.GenerateHookRequest())
+			}
+		})
+	}
+}
+
+func TestImageExecutor_UpdateRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *runtimeapi.PullImageRequest
+		response    interface{}
+		wantErr     bool
+		wantRequest *runtimeapi.PullImageRequest
+	}{
+		{
+			name: "redirect image and inject annotations",
+			request: &runtimeapi.PullImageRequest{
+				Image: &runtimeapi.ImageSpec{Image: "nginx:latest"},
+			},
+			response: &v1alpha1.ImageHookResponse{
+				Image:            "mirror.local/nginx:latest",
+				ImageAnnotations: map[string]string{"mirror": "true"},
+			},
+			wantRequest: &runtimeapi.PullImageRequest{
+				Image: &runtimeapi.ImageSpec{
+					Image:       "mirror.local/nginx:latest",
+					Annotations: map[string]string{"mirror": "true"},
+				},
+			},
+		},
+		{
+			name: "empty response keeps the image",
+			request: &runtimeapi.PullImageRequest{
+				Image: &runtimeapi.ImageSpec{Image: "nginx:latest"},
+			},
+			response: &v1alpha1.ImageHookResponse{},
+			wantRequest: &runtimeapi.PullImageRequest{
+				Image: &runtimeapi.ImageSpec{Image: "nginx:latest"},
+			},
+		},
+		{
+			name: "response type not compatible",
+			request: &runtimeapi.PullImageRequest{
+				Image: &runtimeapi.ImageSpec{Image: "nginx:latest"},
+			},
+			response: &v1alpha1.PodSandboxHookResponse{},
+			wantErr:  true,
+			wantRequest: &runtimeapi.PullImageRequest{
+				Image: &runtimeapi.ImageSpec{Image: "nginx:latest"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := NewImageExecutor()
+			_, err := i.ParseRequest(tt.request)
+			assert.NoError(t, err)
+			err = i.UpdateRequest(tt.response, tt.request)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantRequest, tt.request)
+		})
+	}
+}
@@ -162,3 +162,7 @@ func (p *PodResourceExecutor) UpdateRequest(rsp interface{}, req interface{}) er
 	}
 	return nil
 }
+
+func (p *PodResourceExecutor) UpdateResponse(hookRsp interface{}, rsp interface{}) error {
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"fmt"
+	"reflect"
+
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/utils"
+)
+
+type RuntimeConfigExecutor struct {
+	*v1alpha1.RuntimeConfigHookRequest
+}
+
+func NewRuntimeConfigExecutor() *RuntimeConfigExecutor {
+	return &RuntimeConfigExecutor{}
+}
+
+func (r *RuntimeConfigExecutor) GetMetaInfo() string {
+	return fmt.Sprintf("runtimeConfig(podCIDR:%v)", r.GetPodCidr())
+}
+
+func (r *RuntimeConfigExecutor) GenerateHookRequest() interface{} {
+	return r.RuntimeConfigHookRequest
+}
+
+func (r *RuntimeConfigExecutor) ParseRequest(req interface{}) (utils.CallHookPluginOperation, error) {
+	request, ok := req.(*runtimeapi.UpdateRuntimeConfigRequest)
+	if !ok {
+		return utils.Unknown, fmt.Errorf("request type not compatible. Should be UpdateRuntimeConfigRequest, but got %s", reflect.TypeOf(req).String())
+	}
+	r.RuntimeConfigHookRequest = &v1alpha1.RuntimeConfigHookRequest{
+		PodCidr: request.GetRuntimeConfig().GetNetworkConfig().GetPodCidr(),
+	}
+	return utils.ShouldCallHookPlugin, nil
+}
+
+func (r *RuntimeConfigExecutor) ResourceCheckPoint(rsp interface{}) error {
+	return nil
+}
+
+func (r *RuntimeConfigExecutor) DeleteCheckpointIfNeed(req interface{}) error {
+	return nil
+}
+
+// UpdateRequest will update RuntimeConfigExecutor from hook response and then update CRI request.
+func (r *RuntimeConfigExecutor) UpdateRequest(rsp interface{}, req interface{}) error {
+	response, ok := rsp.(*v1alpha1.RuntimeConfigHookResponse)
+	if !ok {
+		return fmt.Errorf("response type not compatible. Should be RuntimeConfigHookResponse, but got %s", reflect.TypeOf(rsp).String())
+	}
+	if response.GetPodCidr() == "" {
+		return nil
+	}
+	r.PodCidr = response.GetPodCidr()
+
+	if request, ok := req.(*runtimeapi.UpdateRuntimeConfigRequest); ok {
+		if request.RuntimeConfig == nil {
+			request.RuntimeConfig = &runtimeapi.RuntimeConfig{}
+		}
+		if request.RuntimeConfig.NetworkConfig == nil {
+			request.RuntimeConfig.NetworkConfig = &runtimeapi.NetworkConfig{}
+		}
+		request.RuntimeConfig.NetworkConfig.PodCidr = r.PodCidr
+	}
+	return nil
+}
+
+func (r *RuntimeConfigExecutor) UpdateResponse(hookRsp interface{}, rsp interface{}) error {
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"fmt"
+	"reflect"
+
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	"github.com/koordinator-sh/koordinator/cmd/koord-runtime-proxy/options"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/store"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/utils"
+)
+
+// ContainerStatsExecutor collects the container stats returned from backend runtime engine for the post hooks, and
+// merges the stats enriched by hook server into the CRI response.
+type ContainerStatsExecutor struct {
+	stats []*v1alpha1.ContainerStatsInfo
+}
+
+func NewContainerStatsExecutor() *ContainerStatsExecutor {
+	return &ContainerStatsExecutor{}
+}
+
+func (c *ContainerStatsExecutor) GetMetaInfo() string {
+	return fmt.Sprintf("containerStats(%v)", len(c.stats))
+}
+
+func (c *ContainerStatsExecutor) GenerateHookRequest() interface{} {
+	return &v1alpha1.ContainerStatsHookRequest{
+		Stats: c.stats,
+	}
+}
+
+func (c *ContainerStatsExecutor) ParseRequest(req interface{}) (utils.CallHookPluginOperation, error) {
+	switch req.(type) {
+	case *runtimeapi.ContainerStatsRequest, *runtimeapi.ListContainerStatsRequest:
+		return utils.ShouldCallHookPlugin, nil
+	}
+	return utils.Unknown, fmt.Errorf("request type not compatible. Should be ContainerStatsRequest or ListContainerStatsRequest, but got %s", reflect.TypeOf(req).String())
+}
+
+// ResourceCheckPoint keeps the container stats from backend response, which would be sent to hook server during
+// post hook stage. Nothing is written to local store.
+func (c *ContainerStatsExecutor) ResourceCheckPoint(rsp interface{}) error {
+	switch response := rsp.(type) {
+	case *runtimeapi.ContainerStatsResponse:
+		c.stats = transferToKoordContainerStats([]*runtimeapi.ContainerStats{response.GetStats()})
+	case *runtimeapi.ListContainerStatsResponse:
+		c.stats = transferToKoordContainerStats(response.GetStats())
+	}
+	return nil
+}
+
+func (c *ContainerStatsExecutor) DeleteCheckpointIfNeed(req interface{}) error {
+	return nil
+}
+
+func (c *ContainerStatsExecutor) UpdateRequest(rsp interface{}, req interface{}) error {
+	return nil
+}
+
+// UpdateResponse merges the container stats from hook response into CRI response by container id.
+func (c *ContainerStatsExecutor) UpdateResponse(hookRsp interface{}, rsp interface{}) error {
+	hookResponse, ok := hookRsp.(*v1alpha1.ContainerStatsHookResponse)
+	if !ok {
+		return fmt.Errorf("response type not compatible. Should be ContainerStatsHookResponse, but got %s", reflect.TypeOf(hookRsp).String())
+	}
+	hookStats := make(map[string]*v1alpha1.ContainerStatsInfo, len(hookResponse.GetStats()))
+	for _, stats := range hookResponse.GetStats() {
+		if id := stats.GetContainerMeta().GetId(); id != "" {
+			hookStats[id] = stats
+		}
+	}
+
+	var criStats []*runtimeapi.ContainerStats
+	switch response := rsp.(type) {
+	case *runtimeapi.ContainerStatsResponse:
+		criStats = []*runtimeapi.ContainerStats{response.GetStats()}
+	case *runtimeapi.ListContainerStatsResponse:
+		criStats = response.GetStats()
+	}
+	for _, stats := range criStats {
+		if stats == nil || stats.Attributes == nil {
+			continue
+		}
+		if hookStat, exist := hookStats[stats.Attributes.Id]; exist {
+			updateContainerStats(stats, hookStat)
+		}
+	}
+	return nil
+}
+
+func transferToKoordContainerStats(criStats []*runtimeapi.ContainerStats) []*v1alpha1.ContainerStatsInfo {
+	var stats []*v1alpha1.ContainerStatsInfo
+	for _, s := range criStats {
+		containerID := s.GetAttributes().GetId()
+		if containerID == "" {
+			continue
+		}
+		info := &v1alpha1.ContainerStatsInfo{
+			ContainerMeta: &v1alpha1.ContainerMetadata{
+				Name:    s.GetAttributes().GetMetadata().GetName(),
+				Attempt: s.GetAttributes().GetMetadata().GetAttempt(),
+				Id:      containerID,
+			},
+			ContainerAnnotations:    s.GetAttributes().GetAnnotations(),
+			ContainerLabels:         s.GetAttributes().GetLabels(),
+			Timestamp:               s.GetCpu().GetTimestamp(),
+			CpuUsageCoreNanoSeconds: s.GetCpu().GetUsageCoreNanoSeconds().GetValue(),
+			MemoryWorkingSetBytes:   s.GetMemory().GetWorkingSetBytes().GetValue(),
+		}
+		if containerInfo := store.GetContainerInfo(containerID); containerInfo != nil {
+			// the stats of hook server itself would not be sent to the hook server
+			if IsKeyValExistInLabels(containerInfo.GetPodLabels(), options.RuntimeHookServerKey, options.RuntimeHookServerVal) {
+				continue
+			}
+			info.PodMeta = containerInfo.GetPodMeta()
+		}
+		stats = append(stats, info)
+	}
+	return stats
+}
+
+func updateContainerStats(stats *runtimeapi.ContainerStats, hookStats *v1alpha1.ContainerStatsInfo) {
+	if len(hookStats.GetContainerAnnotations()) > 0 {
+		stats.Attributes.Annotations = utils.MergeMap(stats.Attributes.Annotations, hookStats.GetContainerAnnotations())
+	}
+	if hookStats.GetCpuUsageCoreNanoSeconds() > 0 {
+		if stats.Cpu == nil {
+			stats.Cpu = &runtimeapi.CpuUsage{Timestamp: hookStats.GetTimestamp()}
+		}
+		stats.Cpu.UsageCoreNanoSeconds = &runtimeapi.UInt64Value{Value: hookStats.GetCpuUsageCoreNanoSeconds()}
+	}
+	if hookStats.GetMemoryWorkingSetBytes() > 0 {
+		if stats.Memory == nil {
+			stats.Memory = &runtimeapi.MemoryUsage{Timestamp: hookStats.GetTimestamp()}
+		}
+		stats.Memory.WorkingSetBytes = &runtimeapi.UInt64Value{Value: hookStats.GetMemoryWorkingSetBytes()}
+	}
+}
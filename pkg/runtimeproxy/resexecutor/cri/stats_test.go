@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	"github.com/koordinator-sh/koordinator/cmd/koord-runtime-proxy/options"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/store"
+)
+
+func TestContainerStatsExecutor(t *testing.T) {
+	podMeta := &v1alpha1.PodSandboxMetadata{
+		Name:      "test-pod",
+		Namespace: "default",
+		Uid:       "uid-1",
+	}
+	_ = store.WriteContainerInfo("stats-container-1", &store.ContainerInfo{
+		ContainerResourceHookRequest: &v1alpha1.ContainerResourceHookRequest{
+			PodMeta: podMeta,
+		},
+	})
+	_ = store.WriteContainerInfo("stats-hook-server", &store.ContainerInfo{
+		ContainerResourceHookRequest: &v1alpha1.ContainerResourceHookRequest{
+			PodLabels: map[string]string{options.RuntimeHookServerKey: options.RuntimeHookServerVal},
+		},
+	})
+	defer store.DeleteContainerInfo("stats-container-1")
+	defer store.DeleteContainerInfo("stats-hook-server")
+
+	response := &runtimeapi.ListContainerStatsResponse{
+		Stats: []*runtimeapi.ContainerStats{
+			{
+				Attributes: &runtimeapi.ContainerAttributes{
+					Id:       "stats-container-1",
+					Metadata: &runtimeapi.ContainerMetadata{Name: "container-1"},
+				},
+				Cpu: &runtimeapi.CpuUsage{
+					Timestamp:            100,
+					UsageCoreNanoSeconds: &runtimeapi.UInt64Value{Value: 1000},
+				},
+			},
+			{
+				Attributes: &runtimeapi.ContainerAttributes{
+					Id: "stats-container-2",
+				},
+			},
+			{
+				Attributes: &runtimeapi.ContainerAttributes{
+					Id: "stats-hook-server",
+				},
+			},
+		},
+	}
+
+	c := NewContainerStatsExecutor()
+	_, err := c.ParseRequest(&runtimeapi.ListContainerStatsRequest{})
+	assert.NoError(t, err)
+	assert.NoError(t, c.ResourceCheckPoint(response))
+	wantHookRequest := &v1alpha1.ContainerStatsHookRequest{
+		Stats: []*v1alpha1.ContainerStatsInfo{
+			{
+				PodMeta:                 podMeta,
+				ContainerMeta:           &v1alpha1.ContainerMetadata{Name: "container-1", Id: "stats-container-1"},
+				Timestamp:               100,
+				CpuUsageCoreNanoSeconds: 1000,
+			},
+			{
+				ContainerMeta: &v1alpha1.ContainerMetadata{Id: "stats-container-2"},
+			},
+		},
+	}
+	assert.Equal(t, wantHookRequest, c.GenerateHookRequest())
+
+	hookResponse := &v1alpha1.ContainerStatsHookResponse{
+		Stats: []*v1alpha1.ContainerStatsInfo{
+			{
+				ContainerMeta:         &v1alpha1.ContainerMetadata{Id: "stats-container-1"},
+				ContainerAnnotations:  map[string]string{"enriched": "true"},
+				Timestamp:             200,
+				MemoryWorkingSetBytes: 4096,
+			},
+			{
+				ContainerMeta:           &v1alpha1.ContainerMetadata{Id: "stats-container-2"},
+				CpuUsageCoreNanoSeconds: 2000,
+			},
+		},
+	}
+	assert.NoError(t, c.UpdateResponse(hookResponse, response))
+	assert.Equal(t, map[string]string{"enriched": "true"}, response.Stats[0].Attributes.Annotations)
+	assert.Equal(t, uint64(1000), response.Stats[0].Cpu.UsageCoreNanoSeconds.Value)
+	assert.Equal(t, &runtimeapi.MemoryUsage{
+		Timestamp:       200,
+		WorkingSetBytes: &runtimeapi.UInt64Value{Value: 4096},
+	}, response.Stats[0].Memory)
+	assert.Equal(t, uint64(2000), response.Stats[1].Cpu.UsageCoreNanoSeconds.Value)
+	assert.Nil(t, response.Stats[2].Cpu)
+
+	assert.Error(t, c.UpdateResponse(&v1alpha1.ImageHookResponse{}, response))
+}
@@ -17,12 +17,21 @@ limitations under the License.
 package cri
 
 import (
+	"strconv"
+
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 
 	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/utils"
 )
 
+// the cgroup v2 keys of unified resources to carry the memory qos, since the CRI resources have no such fields
+const (
+	unifiedMemoryMin  = "memory.min"
+	unifiedMemoryLow  = "memory.low"
+	unifiedMemoryHigh = "memory.high"
+)
+
 func transferToKoordResources(r *runtimeapi.LinuxContainerResources) *v1alpha1.LinuxContainerResources {
 	linuxResources := &v1alpha1.LinuxContainerResources{
 		CpuPeriod:              r.GetCpuPeriod(),
@@ -34,6 +43,9 @@ func transferToKoordResources(r *runtimeapi.LinuxContainerResources) *v1alpha1.L
 		CpusetMems:             r.GetCpusetMems(),
 		Unified:                r.GetUnified(),
 		MemorySwapLimitInBytes: r.GetMemorySwapLimitInBytes(),
+		MemoryMinInBytes:       parseUnifiedInt64(r.GetUnified(), unifiedMemoryMin),
+		MemoryLowInBytes:       parseUnifiedInt64(r.GetUnified(), unifiedMemoryLow),
+		MemoryHighInBytes:      parseUnifiedInt64(r.GetUnified(), unifiedMemoryHigh),
 	}
 
 	for _, item := range r.GetHugepageLimits() {
@@ -54,7 +66,7 @@ func transferToCRIResources(r *v1alpha1.LinuxContainerResources) *runtimeapi.Lin
 		OomScoreAdj:            r.GetOomScoreAdj(),
 		CpusetCpus:             r.GetCpusetCpus(),
 		CpusetMems:             r.GetCpusetMems(),
-		Unified:                r.GetUnified(),
+		Unified:                transferToCRIUnified(r),
 		MemorySwapLimitInBytes: r.GetMemorySwapLimitInBytes(),
 	}
 
@@ -67,6 +79,38 @@ func transferToCRIResources(r *v1alpha1.LinuxContainerResources) *runtimeapi.Lin
 	return linuxResources
 }
 
+// transferToCRIUnified returns the unified resources merged with the memory qos.
+func transferToCRIUnified(r *v1alpha1.LinuxContainerResources) map[string]string {
+	memoryQOS := map[string]string{}
+	for key, value := range map[string]int64{
+		unifiedMemoryMin:  r.GetMemoryMinInBytes(),
+		unifiedMemoryLow:  r.GetMemoryLowInBytes(),
+		unifiedMemoryHigh: r.GetMemoryHighInBytes(),
+	} {
+		if value > 0 {
+			memoryQOS[key] = strconv.FormatInt(value, 10)
+		}
+	}
+	if len(memoryQOS) <= 0 {
+		return r.GetUnified()
+	}
+	// copy the unified resources to avoid modifying the origin
+	unified := utils.MergeMap(nil, r.GetUnified())
+	return utils.MergeMap(unified, memoryQOS)
+}
+
+func parseUnifiedInt64(unified map[string]string, key string) int64 {
+	value, ok := unified[key]
+	if !ok {
+		return 0
+	}
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
 func updateResource(a, b *v1alpha1.LinuxContainerResources) *v1alpha1.LinuxContainerResources {
 	if a == nil || b == nil {
 		return a
@@ -94,6 +138,18 @@ func updateResource(a, b *v1alpha1.LinuxContainerResources) *v1alpha1.LinuxConta
 	if b.MemorySwapLimitInBytes > 0 {
 		a.MemorySwapLimitInBytes = b.MemorySwapLimitInBytes
 	}
+	if len(b.HugepageLimits) > 0 {
+		a.HugepageLimits = b.HugepageLimits
+	}
+	if b.MemoryMinInBytes > 0 {
+		a.MemoryMinInBytes = b.MemoryMinInBytes
+	}
+	if b.MemoryLowInBytes > 0 {
+		a.MemoryLowInBytes = b.MemoryLowInBytes
+	}
+	if b.MemoryHighInBytes > 0 {
+		a.MemoryHighInBytes = b.MemoryHighInBytes
+	}
 	return a
 }
 
@@ -128,6 +184,18 @@ func updateResourceByUpdateContainerResourceRequest(a, b *v1alpha1.LinuxContaine
 	if b.MemorySwapLimitInBytes > 0 {
 		a.MemorySwapLimitInBytes = b.MemorySwapLimitInBytes
 	}
+	if len(b.HugepageLimits) > 0 {
+		a.HugepageLimits = b.HugepageLimits
+	}
+	if b.MemoryMinInBytes > 0 {
+		a.MemoryMinInBytes = b.MemoryMinInBytes
+	}
+	if b.MemoryLowInBytes > 0 {
+		a.MemoryLowInBytes = b.MemoryLowInBytes
+	}
+	if b.MemoryHighInBytes > 0 {
+		a.MemoryHighInBytes = b.MemoryHighInBytes
+	}
 	return a
 }
 
@@ -156,6 +224,36 @@ func transferToCRIContainerEnvs(envs map[string]string) []*runtimeapi.KeyValue {
 	return res
 }
 
+func transferToKoordContainerDevices(devices []*runtimeapi.Device) []*v1alpha1.LinuxDevice {
+	if devices == nil {
+		return nil
+	}
+	res := make([]*v1alpha1.LinuxDevice, 0, len(devices))
+	for _, device := range devices {
+		res = append(res, &v1alpha1.LinuxDevice{
+			ContainerPath: device.GetContainerPath(),
+			HostPath:      device.GetHostPath(),
+			Permissions:   device.GetPermissions(),
+		})
+	}
+	return res
+}
+
+func transferToCRIContainerDevices(devices []*v1alpha1.LinuxDevice) []*runtimeapi.Device {
+	if devices == nil {
+		return nil
+	}
+	res := make([]*runtimeapi.Device, 0, len(devices))
+	for _, device := range devices {
+		res = append(res, &runtimeapi.Device{
+			ContainerPath: device.GetContainerPath(),
+			HostPath:      device.GetHostPath(),
+			Permissions:   device.GetPermissions(),
+		})
+	}
+	return res
+}
+
 func IsKeyValExistInLabels(labels map[string]string, key, val string) bool {
 	if labels == nil {
 		return false
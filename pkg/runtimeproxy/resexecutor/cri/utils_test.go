@@ -91,6 +91,32 @@ func Test_updateResource(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "update memory qos and hugepage limits",
+			args: args{
+				a: &v1alpha1.LinuxContainerResources{
+					MemoryLimitInBytes: 300,
+					MemoryLowInBytes:   100,
+				},
+				b: &v1alpha1.LinuxContainerResources{
+					MemoryMinInBytes:  50,
+					MemoryHighInBytes: 250,
+					HugepageLimits: []*v1alpha1.HugepageLimit{
+						{PageSize: "2MB", Limit: 1 << 30},
+					},
+				},
+			},
+			want: &v1alpha1.LinuxContainerResources{
+				MemoryLimitInBytes: 300,
+				MemoryMinInBytes:   50,
+				MemoryLowInBytes:   100,
+				MemoryHighInBytes:  250,
+				HugepageLimits: []*v1alpha1.HugepageLimit{
+					{PageSize: "2MB", Limit: 1 << 30},
+				},
+				Unified: map[string]string{},
+			},
+		},
 	}
 	for _, tt := range tests {
 		gotResources := updateResource(tt.args.a, tt.args.b)
@@ -128,6 +154,32 @@ func Test_transferToKoordResources(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "parse memory qos from unified",
+			args: args{
+				r: &runtimeapi.LinuxContainerResources{
+					CpusetMems: "0-1",
+					Unified: map[string]string{
+						"memory.min":  "1048576",
+						"memory.high": "invalid",
+					},
+					HugepageLimits: []*runtimeapi.HugepageLimit{
+						{PageSize: "2MB", Limit: 1 << 30},
+					},
+				},
+			},
+			want: &v1alpha1.LinuxContainerResources{
+				CpusetMems: "0-1",
+				Unified: map[string]string{
+					"memory.min":  "1048576",
+					"memory.high": "invalid",
+				},
+				MemoryMinInBytes: 1048576,
+				HugepageLimits: []*v1alpha1.HugepageLimit{
+					{PageSize: "2MB", Limit: 1 << 30},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		gotResources := transferToKoordResources(tt.args.r)
@@ -165,6 +217,35 @@ func Test_transferToCRIResources(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "carry memory qos by unified",
+			args: args{
+				r: &v1alpha1.LinuxContainerResources{
+					CpusetMems: "0-1",
+					Unified: map[string]string{
+						"resourceA": "resource A",
+					},
+					MemoryMinInBytes:  1048576,
+					MemoryLowInBytes:  2097152,
+					MemoryHighInBytes: 4194304,
+					HugepageLimits: []*v1alpha1.HugepageLimit{
+						{PageSize: "2MB", Limit: 1 << 30},
+					},
+				},
+			},
+			want: &runtimeapi.LinuxContainerResources{
+				CpusetMems: "0-1",
+				Unified: map[string]string{
+					"resourceA":   "resource A",
+					"memory.min":  "1048576",
+					"memory.low":  "2097152",
+					"memory.high": "4194304",
+				},
+				HugepageLimits: []*runtimeapi.HugepageLimit{
+					{PageSize: "2MB", Limit: 1 << 30},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		gotResources := transferToCRIResources(tt.args.r)
@@ -354,3 +435,19 @@ func Test_transferToCRIContainerEnvs(t *testing.T) {
 		assert.Equalf(t, realContainerdEnvs, tt.expectedContainerdEnvs, tt.name)
 	}
 }
+
+func Test_transferContainerDevices(t *testing.T) {
+	assert.Nil(t, transferToKoordContainerDevices(nil))
+	assert.Nil(t, transferToCRIContainerDevices(nil))
+
+	criDevices := []*runtimeapi.Device{
+		{ContainerPath: "/dev/infiniband/uverbs0", HostPath: "/dev/infiniband/uverbs0", Permissions: "rwm"},
+		{ContainerPath: "/dev/infiniband/rdma_cm", HostPath: "/dev/infiniband/rdma_cm", Permissions: "rw"},
+	}
+	koordDevices := []*v1alpha1.LinuxDevice{
+		{ContainerPath: "/dev/infiniband/uverbs0", HostPath: "/dev/infiniband/uverbs0", Permissions: "rwm"},
+		{ContainerPath: "/dev/infiniband/rdma_cm", HostPath: "/dev/infiniband/rdma_cm", Permissions: "rw"},
+	}
+	assert.Equal(t, koordDevices, transferToKoordContainerDevices(criDevices))
+	assert.Equal(t, criDevices, transferToCRIContainerDevices(koordDevices))
+}
@@ -34,6 +34,9 @@ type RuntimeResourceExecutor interface {
 	ResourceCheckPoint(response interface{}) error
 	DeleteCheckpointIfNeed(request interface{}) error
 	UpdateRequest(response interface{}, request interface{}) error
+	// UpdateResponse would update the CRI response with the response of post hook plugins, e.g. to merge the
+	// container stats enriched by hook server.
+	UpdateResponse(hookResponse interface{}, response interface{}) error
 }
 
 type RuntimeResourceType string
@@ -41,6 +44,10 @@ type RuntimeResourceType string
 const (
 	RuntimePodResource       RuntimeResourceType = "RuntimePodResource"
 	RuntimeContainerResource RuntimeResourceType = "RuntimeContainerResource"
+	RuntimeImageResource     RuntimeResourceType = "RuntimeImageResource"
+	RuntimeExecSyncResource  RuntimeResourceType = "RuntimeExecSyncResource"
+	RuntimeStatsResource     RuntimeResourceType = "RuntimeStatsResource"
+	RuntimeConfigResource    RuntimeResourceType = "RuntimeConfigResource"
 	RuntimeNoopResource      RuntimeResourceType = "RuntimeNoopResource"
 )
 
@@ -50,12 +57,20 @@ func NewRuntimeResourceExecutor(runtimeResourceType RuntimeResourceType) Runtime
 		return cri.NewPodResourceExecutor()
 	case RuntimeContainerResource:
 		return cri.NewContainerResourceExecutor()
+	case RuntimeImageResource:
+		return cri.NewImageExecutor()
+	case RuntimeExecSyncResource:
+		return cri.NewExecSyncExecutor()
+	case RuntimeStatsResource:
+		return cri.NewContainerStatsExecutor()
+	case RuntimeConfigResource:
+		return cri.NewRuntimeConfigExecutor()
 	}
 	return &NoopResourceExecutor{}
 }
 
 // NoopResourceExecutor means no-operation for cri request,
-// where no hook exists like ListContainerStats/ExecSync etc.
+// where no hook exists like ListContainers/Attach etc.
 type NoopResourceExecutor struct {
 }
 
@@ -85,3 +100,7 @@ func (n *NoopResourceExecutor) DeleteCheckpointIfNeed(request interface{}) error
 func (n *NoopResourceExecutor) UpdateRequest(response interface{}, request interface{}) error {
 	return nil
 }
+
+func (n *NoopResourceExecutor) UpdateResponse(hookResponse interface{}, response interface{}) error {
+	return nil
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/dispatcher"
 	resource_executor "github.com/koordinator-sh/koordinator/pkg/runtimeproxy/resexecutor"
 	cri_resource_executor "github.com/koordinator-sh/koordinator/pkg/runtimeproxy/resexecutor/cri"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/store"
 	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/utils"
 )
 
@@ -59,9 +60,11 @@ func (c *RuntimeManagerCriServer) Run() error {
 	if err := c.initBackendServer(options.RemoteRuntimeServiceEndpoint, options.RemoteImageServiceEndpoint); err != nil {
 		return err
 	}
-	c.failOver()
-
-	klog.Infof("do failOver done")
+	if err := c.failOver(); err != nil {
+		klog.Errorf("failed to failOver from backend runtime, err: %v", err)
+	} else {
+		klog.Infof("do failOver done")
+	}
 
 	listener, err := net.Listen("unix", options.RuntimeProxyEndpoint)
 	if err != nil {
@@ -90,6 +93,16 @@ func (c *RuntimeManagerCriServer) getRuntimeHookInfo(serviceType RuntimeServiceT
 		return config.StopContainer, resource_executor.RuntimeContainerResource
 	case UpdateContainerResources:
 		return config.UpdateContainerResources, resource_executor.RuntimeContainerResource
+	case ExecSync:
+		return config.ExecSync, resource_executor.RuntimeExecSyncResource
+	case ContainerStats:
+		return config.ContainerStats, resource_executor.RuntimeStatsResource
+	case ListContainerStats:
+		return config.ListContainerStats, resource_executor.RuntimeStatsResource
+	case UpdateRuntimeConfig:
+		return config.UpdateRuntimeConfig, resource_executor.RuntimeConfigResource
+	case PullImage:
+		return config.PullImage, resource_executor.RuntimeImageResource
 	}
 	return config.NoneRuntimeHookPath, resource_executor.RuntimeNoopResource
 }
@@ -123,23 +136,42 @@ func (c *RuntimeManagerCriServer) interceptRuntimeRequest(serviceType RuntimeSer
 	// call the backend runtime engine
 	res, err := handler(ctx, request)
 	if err == nil {
-		klog.Infof("%v call containerd %v success", resourceExecutor.GetMetaInfo(), string(runtimeHookPath))
+		klog.V(5).Infof("%v call containerd %v success", resourceExecutor.GetMetaInfo(), string(runtimeHookPath))
 		// store checkpoint info basing request only when response success
 		if err := resourceExecutor.ResourceCheckPoint(res); err != nil {
 			klog.Errorf("fail to checkpoint %v %v", resourceExecutor.GetMetaInfo(), err)
 		}
+	} else if isFrequentRuntimeService(serviceType) {
+		// the errors are returned to the kubelet, e.g. the failed probes by ExecSync, so don't flood the log
+		klog.V(4).Infof("%v call containerd %v fail %v", resourceExecutor.GetMetaInfo(), string(runtimeHookPath), err)
 	} else {
 		klog.Errorf("%v call containerd %v fail %v", resourceExecutor.GetMetaInfo(), string(runtimeHookPath), err)
 	}
 	switch callHookOperation {
 	case utils.ShouldCallHookPlugin:
 		// post call hook server
-		// TODO the response
-		c.hookDispatcher.Dispatch(ctx, runtimeHookPath, config.PostHook, resourceExecutor.GenerateHookRequest())
+		response, hookErr, _ := c.hookDispatcher.Dispatch(ctx, runtimeHookPath, config.PostHook, resourceExecutor.GenerateHookRequest())
+		if hookErr != nil {
+			klog.Errorf("fail to call post hook server %v", hookErr)
+		} else if response != nil && err == nil {
+			if updateErr := resourceExecutor.UpdateResponse(response, res); updateErr != nil {
+				klog.Errorf("failed to update cri response %v", updateErr)
+			}
+		}
 	}
 	return res, err
 }
 
+// isFrequentRuntimeService returns whether the service is called periodically by the kubelet,
+// e.g. the probes and the stats collection, whose failures are too noisy to be logged as errors.
+func isFrequentRuntimeService(serviceType RuntimeServiceType) bool {
+	switch serviceType {
+	case ExecSync, ContainerStats, ListContainerStats:
+		return true
+	}
+	return false
+}
+
 func dialer(ctx context.Context, addr string) (net.Conn, error) {
 	return (&net.Dialer{}).DialContext(ctx, "unix", addr)
 }
@@ -168,12 +200,20 @@ func (c *RuntimeManagerCriServer) initBackendServer(runtimeSockPath, imageSockPa
 	return nil
 }
 
+// failOver rebuilds the pod and container infos missing in store from the backend runtime, e.g. the ones created
+// before runtime-proxy started, and removes the stale ones whose pod or container has gone. The infos loaded from
+// checkpoint are kept since they are more complete than the ones rebuilt.
 func (c *RuntimeManagerCriServer) failOver() error {
 	podResponse, podErr := c.backendRuntimeServiceClient.ListPodSandbox(context.TODO(), &runtimeapi.ListPodSandboxRequest{})
 	if podErr != nil {
 		return podErr
 	}
+	activePods := make(map[string]struct{}, len(podResponse.Items))
 	for _, pod := range podResponse.Items {
+		activePods[pod.GetId()] = struct{}{}
+		if store.GetPodSandboxInfo(pod.GetId()) != nil {
+			continue
+		}
 		podResourceExecutor := cri_resource_executor.NewPodResourceExecutor()
 		podResourceExecutor.ParsePod(pod)
 		podResourceExecutor.ResourceCheckPoint(&runtimeapi.RunPodSandboxResponse{
@@ -185,7 +225,12 @@ func (c *RuntimeManagerCriServer) failOver() error {
 	if containerErr != nil {
 		return containerErr
 	}
+	activeContainers := make(map[string]struct{}, len(containerResponse.Containers))
 	for _, container := range containerResponse.Containers {
+		activeContainers[container.GetId()] = struct{}{}
+		if store.GetContainerInfo(container.GetId()) != nil {
+			continue
+		}
 		containerExecutor := cri_resource_executor.NewContainerResourceExecutor()
 		if err := containerExecutor.ParseContainer(container); err != nil {
 			klog.Errorf("failed to parse container %s, err: %v", container.Id, err)
@@ -196,5 +241,17 @@ func (c *RuntimeManagerCriServer) failOver() error {
 		})
 	}
 
+	for _, podID := range store.ListPodSandboxIDs() {
+		if _, ok := activePods[podID]; !ok {
+			klog.Infof("remove stale pod %v from store", podID)
+			store.DeletePodSandboxInfo(podID)
+		}
+	}
+	for _, containerID := range store.ListContainerIDs() {
+		if _, ok := activeContainers[containerID]; !ok {
+			klog.Infof("remove stale container %v from store", containerID)
+			store.DeleteContainerInfo(containerID)
+		}
+	}
 	return nil
 }
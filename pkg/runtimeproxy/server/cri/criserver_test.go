@@ -0,0 +1,169 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/store"
+)
+
+// fakeRuntimeServiceClient only serves the pods and containers listed by failOver.
+type fakeRuntimeServiceClient struct {
+	runtimeapi.RuntimeServiceClient
+	pods       []*runtimeapi.PodSandbox
+	containers []*runtimeapi.Container
+}
+
+func (f *fakeRuntimeServiceClient) ListPodSandbox(ctx context.Context, in *runtimeapi.ListPodSandboxRequest, opts ...grpc.CallOption) (*runtimeapi.ListPodSandboxResponse, error) {
+	return &runtimeapi.ListPodSandboxResponse{Items: f.pods}, nil
+}
+
+func (f *fakeRuntimeServiceClient) ListContainers(ctx context.Context, in *runtimeapi.ListContainersRequest, opts ...grpc.CallOption) (*runtimeapi.ListContainersResponse, error) {
+	return &runtimeapi.ListContainersResponse{Containers: f.containers}, nil
+}
+
+func writeCheckpointFile(t *testing.T, dir, subDir, id string, obj interface{}) {
+	data, err := json.Marshal(obj)
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, subDir), 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, subDir, id+".json"), data, 0600))
+}
+
+func TestFailOver(t *testing.T) {
+	dir := t.TempDir()
+	t.Cleanup(func() {
+		for _, id := range store.ListPodSandboxIDs() {
+			store.DeletePodSandboxInfo(id)
+		}
+		for _, id := range store.ListContainerIDs() {
+			store.DeleteContainerInfo(id)
+		}
+	})
+
+	// the infos checkpointed before restart, pod2 and container2 have gone during the restart
+	checkpointedPod := &store.PodSandboxInfo{
+		PodSandboxHookRequest: &v1alpha1.PodSandboxHookRequest{
+			PodMeta: &v1alpha1.PodSandboxMetadata{
+				Name:      "pod1",
+				Namespace: "default",
+				Uid:       "uid1",
+			},
+			CgroupParent: "kubepods/poduid1",
+		},
+	}
+	checkpointedContainer := &store.ContainerInfo{
+		ContainerResourceHookRequest: &v1alpha1.ContainerResourceHookRequest{
+			PodMeta: checkpointedPod.PodMeta,
+			ContainerMeta: &v1alpha1.ContainerMetadata{
+				Name: "container1",
+				Id:   "container1",
+			},
+			ContainerResources: &v1alpha1.LinuxContainerResources{
+				CpuShares:  1024,
+				CpusetCpus: "0-3",
+			},
+			PodCgroupParent: "kubepods/poduid1",
+			ContainerEnvs:   map[string]string{"key": "value"},
+		},
+	}
+	writeCheckpointFile(t, dir, "pods", "pod1", checkpointedPod)
+	writeCheckpointFile(t, dir, "pods", "pod2", checkpointedPod)
+	writeCheckpointFile(t, dir, "containers", "container1", checkpointedContainer)
+	writeCheckpointFile(t, dir, "containers", "container2", checkpointedContainer)
+	assert.NoError(t, store.InitCheckpoint(dir))
+	assert.ElementsMatch(t, []string{"pod1", "pod2"}, store.ListPodSandboxIDs())
+	assert.ElementsMatch(t, []string{"container1", "container2"}, store.ListContainerIDs())
+
+	c := NewRuntimeManagerCriServer()
+	c.backendRuntimeServiceClient = &fakeRuntimeServiceClient{
+		pods: []*runtimeapi.PodSandbox{
+			{
+				Id:       "pod1",
+				Metadata: &runtimeapi.PodSandboxMetadata{Name: "pod1", Namespace: "default", Uid: "uid1"},
+			},
+			{
+				Id:       "pod3",
+				Metadata: &runtimeapi.PodSandboxMetadata{Name: "pod3", Namespace: "default", Uid: "uid3"},
+				Labels:   map[string]string{"app": "test"},
+			},
+		},
+		containers: []*runtimeapi.Container{
+			{
+				Id:           "container1",
+				PodSandboxId: "pod1",
+				Metadata:     &runtimeapi.ContainerMetadata{Name: "container1"},
+			},
+			{
+				Id:           "container3",
+				PodSandboxId: "pod3",
+				Metadata:     &runtimeapi.ContainerMetadata{Name: "container3"},
+			},
+			{
+				// the pod is not found, so the container is skipped
+				Id:           "container4",
+				PodSandboxId: "pod4",
+				Metadata:     &runtimeapi.ContainerMetadata{Name: "container4"},
+			},
+		},
+	}
+	assert.NoError(t, c.failOver())
+
+	// the infos in store are kept rather than rebuilt from the backend
+	gotPod := store.GetPodSandboxInfo("pod1")
+	assert.Equal(t, "kubepods/poduid1", gotPod.GetCgroupParent())
+	gotContainer := store.GetContainerInfo("container1")
+	assert.Equal(t, "0-3", gotContainer.GetContainerResources().GetCpusetCpus())
+	assert.Equal(t, map[string]string{"key": "value"}, gotContainer.GetContainerEnvs())
+
+	// the infos missing in store are rebuilt from the backend
+	gotPod = store.GetPodSandboxInfo("pod3")
+	assert.Equal(t, "uid3", gotPod.GetPodMeta().GetUid())
+	assert.Equal(t, map[string]string{"app": "test"}, gotPod.GetLabels())
+	gotContainer = store.GetContainerInfo("container3")
+	assert.Equal(t, "container3", gotContainer.GetContainerMeta().GetId())
+	assert.Equal(t, "uid3", gotContainer.GetPodMeta().GetUid())
+	assert.Nil(t, store.GetContainerInfo("container4"))
+
+	// the stale infos are removed from both the store and the checkpoint
+	assert.ElementsMatch(t, []string{"pod1", "pod3"}, store.ListPodSandboxIDs())
+	assert.ElementsMatch(t, []string{"container1", "container3"}, store.ListContainerIDs())
+	_, err := os.Stat(filepath.Join(dir, "pods", "pod2.json"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "containers", "container2.json"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "pods", "pod3.json"))
+	assert.NoError(t, err)
+}
+
+func TestIsFrequentRuntimeService(t *testing.T) {
+	for _, serviceType := range []RuntimeServiceType{ExecSync, ContainerStats, ListContainerStats} {
+		assert.True(t, isFrequentRuntimeService(serviceType))
+	}
+	for _, serviceType := range []RuntimeServiceType{RunPodSandbox, CreateContainer, StartContainer, UpdateContainerResources, PullImage} {
+		assert.False(t, isFrequentRuntimeService(serviceType))
+	}
+}
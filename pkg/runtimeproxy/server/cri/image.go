@@ -23,7 +23,14 @@ import (
 )
 
 func (c *RuntimeManagerCriServer) PullImage(ctx context.Context, req *runtimeapi.PullImageRequest) (*runtimeapi.PullImageResponse, error) {
-	return c.backendImageServiceClient.PullImage(ctx, req)
+	rsp, err := c.interceptRuntimeRequest(PullImage, ctx, req,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return c.backendImageServiceClient.PullImage(ctx, req.(*runtimeapi.PullImageRequest))
+		})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.(*runtimeapi.PullImageResponse), err
 }
 
 func (c *RuntimeManagerCriServer) ImageStatus(ctx context.Context, req *runtimeapi.ImageStatusRequest) (*runtimeapi.ImageStatusResponse, error) {
@@ -125,10 +125,24 @@ func (c *RuntimeManagerCriServer) UpdateContainerResources(ctx context.Context,
 }
 
 func (c *RuntimeManagerCriServer) ContainerStats(ctx context.Context, req *runtimeapi.ContainerStatsRequest) (*runtimeapi.ContainerStatsResponse, error) {
-	return c.backendRuntimeServiceClient.ContainerStats(ctx, req)
+	rsp, err := c.interceptRuntimeRequest(ContainerStats, ctx, req,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return c.backendRuntimeServiceClient.ContainerStats(ctx, req.(*runtimeapi.ContainerStatsRequest))
+		})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.(*runtimeapi.ContainerStatsResponse), err
 }
 func (c *RuntimeManagerCriServer) ListContainerStats(ctx context.Context, req *runtimeapi.ListContainerStatsRequest) (*runtimeapi.ListContainerStatsResponse, error) {
-	return c.backendRuntimeServiceClient.ListContainerStats(ctx, req)
+	rsp, err := c.interceptRuntimeRequest(ListContainerStats, ctx, req,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return c.backendRuntimeServiceClient.ListContainerStats(ctx, req.(*runtimeapi.ListContainerStatsRequest))
+		})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.(*runtimeapi.ListContainerStatsResponse), err
 }
 
 func (c *RuntimeManagerCriServer) Status(ctx context.Context, req *runtimeapi.StatusRequest) (*runtimeapi.StatusResponse, error) {
@@ -139,7 +153,14 @@ func (c *RuntimeManagerCriServer) ReopenContainerLog(ctx context.Context, in *ru
 	return c.backendRuntimeServiceClient.ReopenContainerLog(ctx, in)
 }
 func (c *RuntimeManagerCriServer) ExecSync(ctx context.Context, in *runtimeapi.ExecSyncRequest) (*runtimeapi.ExecSyncResponse, error) {
-	return c.backendRuntimeServiceClient.ExecSync(ctx, in)
+	rsp, err := c.interceptRuntimeRequest(ExecSync, ctx, in,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return c.backendRuntimeServiceClient.ExecSync(ctx, req.(*runtimeapi.ExecSyncRequest))
+		})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.(*runtimeapi.ExecSyncResponse), err
 }
 func (c *RuntimeManagerCriServer) Exec(ctx context.Context, in *runtimeapi.ExecRequest) (*runtimeapi.ExecResponse, error) {
 	return c.backendRuntimeServiceClient.Exec(ctx, in)
@@ -154,5 +175,12 @@ func (c *RuntimeManagerCriServer) PortForward(ctx context.Context, in *runtimeap
 }
 
 func (c *RuntimeManagerCriServer) UpdateRuntimeConfig(ctx context.Context, in *runtimeapi.UpdateRuntimeConfigRequest) (*runtimeapi.UpdateRuntimeConfigResponse, error) {
-	return c.backendRuntimeServiceClient.UpdateRuntimeConfig(ctx, in)
+	rsp, err := c.interceptRuntimeRequest(UpdateRuntimeConfig, ctx, in,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return c.backendRuntimeServiceClient.UpdateRuntimeConfig(ctx, req.(*runtimeapi.UpdateRuntimeConfigRequest))
+		})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.(*runtimeapi.UpdateRuntimeConfigResponse), err
 }
@@ -33,4 +33,9 @@ const (
 	StopContainer
 	RemoveContainer
 	UpdateContainerResources
+	ExecSync
+	ContainerStats
+	ListContainerStats
+	UpdateRuntimeConfig
+	PullImage
 )
@@ -80,6 +80,7 @@ func (d *RuntimeManagerDockerServer) HandleCreateContainer(ctx context.Context,
 				PodLabels:            podInfo.Labels,
 				PodCgroupParent:      podInfo.CgroupParent,
 				ContainerEnvs:        splitDockerEnv(ContainerConfig.Env),
+				ContainerDevices:     dockerDevicesToKoord(hostConfig.Devices),
 			},
 		}
 		runtimeHookPath = config.CreateContainer
@@ -141,6 +142,10 @@ func (d *RuntimeManagerDockerServer) HandleCreateContainer(ctx context.Context,
 			cfgBody.Env = generateEnvList(resp.ContainerEnvs)
 			containerInfo.ContainerEnvs = resp.ContainerEnvs
 		}
+		if resp.ContainerDevices != nil {
+			cfgBody.HostConfig.Devices = koordDevicesToDocker(resp.ContainerDevices)
+			containerInfo.ContainerDevices = resp.ContainerDevices
+		}
 	}
 
 	// send req to docker
@@ -90,6 +90,8 @@ func (d *RuntimeManagerDockerServer) ServeHTTP(wr http.ResponseWriter, req *http
 	d.Direct(wr, req)
 }
 
+// failOver rebuilds the pod and container infos missing in store from docker, and removes the stale ones whose pod
+// or container has gone. The infos loaded from checkpoint are kept.
 func (d *RuntimeManagerDockerServer) failOver(dockerClient proxyDockerClient) error {
 
 	type dockerWrapper struct {
@@ -103,7 +105,12 @@ func (d *RuntimeManagerDockerServer) failOver(dockerClient proxyDockerClient) er
 		klog.Errorf("Failed to get container list in failover, err: %v", err)
 		return err
 	}
+	activeContainers := make(map[string]struct{}, len(cs))
 	for _, c := range cs {
+		activeContainers[c.ID] = struct{}{}
+		if store.GetPodSandboxInfo(c.ID) != nil || store.GetContainerInfo(c.ID) != nil {
+			continue
+		}
 		containerJson, err := dockerClient.ContainerInspect(context.TODO(), c.ID)
 		if err != nil {
 			klog.Errorf("Failed to get container detail of id %s", c.ID)
@@ -162,6 +169,20 @@ func (d *RuntimeManagerDockerServer) failOver(dockerClient proxyDockerClient) er
 		}
 		store.WriteContainerInfo(c.ID, cInfo)
 	}
+
+	// docker sandboxes and containers share the same id space
+	for _, podID := range store.ListPodSandboxIDs() {
+		if _, ok := activeContainers[podID]; !ok {
+			klog.Infof("remove stale pod %v from store", podID)
+			store.DeletePodSandboxInfo(podID)
+		}
+	}
+	for _, containerID := range store.ListContainerIDs() {
+		if _, ok := activeContainers[containerID]; !ok {
+			klog.Infof("remove stale container %v from store", containerID)
+			store.DeleteContainerInfo(containerID)
+		}
+	}
 	info, err := dockerClient.Info(context.TODO())
 	if err != nil {
 		klog.Errorf("Failed to get docker server info, err: %v", err)
@@ -96,6 +96,9 @@ func encodeData(data interface{}) (*bytes.Buffer, error) {
 	return params, nil
 }
 
+// HostConfigToResource transfers the docker host config to resources.
+// Docker sets the memory reservation as `memory.low` on cgroups-v2, so it is mapped to MemoryLowInBytes, while the
+// memory.min, memory.high, hugepage limits and unified resources are not supported by docker.
 func HostConfigToResource(config *container.HostConfig) *v1alpha1.LinuxContainerResources {
 	if config == nil {
 		return nil
@@ -109,6 +112,7 @@ func HostConfigToResource(config *container.HostConfig) *v1alpha1.LinuxContainer
 		CpusetCpus:             config.CpusetCpus,
 		CpusetMems:             config.CpusetMems,
 		MemorySwapLimitInBytes: config.MemorySwap,
+		MemoryLowInBytes:       config.MemoryReservation,
 	}
 }
 
@@ -171,6 +175,7 @@ func UpdateHostConfigByResource(config *container.HostConfig, resources *v1alpha
 	config.CpusetCpus = resources.CpusetCpus
 	config.CpusetMems = resources.CpusetMems
 	config.MemorySwap = resources.MemorySwapLimitInBytes
+	config.MemoryReservation = resources.MemoryLowInBytes
 	return config
 }
 
@@ -185,6 +190,7 @@ func UpdateUpdateConfigByResource(containerConfig *container.UpdateConfig, resou
 	containerConfig.CpusetCpus = resources.CpusetCpus
 	containerConfig.CpusetMems = resources.CpusetMems
 	containerConfig.MemorySwap = resources.MemorySwapLimitInBytes
+	containerConfig.MemoryReservation = resources.MemoryLowInBytes
 	return containerConfig
 }
 
@@ -213,6 +219,9 @@ func MergeResourceByUpdateConfig(resources *v1alpha1.LinuxContainerResources, co
 	if containerConfig.MemorySwap > 0 {
 		resources.MemorySwapLimitInBytes = containerConfig.MemorySwap
 	}
+	if containerConfig.MemoryReservation > 0 {
+		resources.MemoryLowInBytes = containerConfig.MemoryReservation
+	}
 	return resources
 }
 
@@ -244,6 +253,30 @@ func splitDockerEnv(dockerEnvs []string) map[string]string {
 	return res
 }
 
+func dockerDevicesToKoord(devices []container.DeviceMapping) []*v1alpha1.LinuxDevice {
+	var res []*v1alpha1.LinuxDevice
+	for _, device := range devices {
+		res = append(res, &v1alpha1.LinuxDevice{
+			ContainerPath: device.PathInContainer,
+			HostPath:      device.PathOnHost,
+			Permissions:   device.CgroupPermissions,
+		})
+	}
+	return res
+}
+
+func koordDevicesToDocker(devices []*v1alpha1.LinuxDevice) []container.DeviceMapping {
+	var res []container.DeviceMapping
+	for _, device := range devices {
+		res = append(res, container.DeviceMapping{
+			PathOnHost:        device.GetHostPath(),
+			PathInContainer:   device.GetContainerPath(),
+			CgroupPermissions: device.GetPermissions(),
+		})
+	}
+	return res
+}
+
 func generateEnvList(envs map[string]string) (result []string) {
 	for key, value := range envs {
 		result = append(result, fmt.Sprintf("%s=%s", key, value))
@@ -215,6 +215,18 @@ func Test_UpdateHostConfigByResource(t *testing.T) {
 				OomScoreAdj: 10,
 			},
 		},
+		{&v1alpha1.LinuxContainerResources{
+			CpusetMems:       "0-1",
+			MemoryLowInBytes: 1048576,
+		},
+			&container.HostConfig{},
+			&container.HostConfig{
+				Resources: container.Resources{
+					CpusetMems:        "0-1",
+					MemoryReservation: 1048576,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		config := UpdateHostConfigByResource(tt.config, tt.resources)
@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	podCheckpointDir       = "pods"
+	containerCheckpointDir = "containers"
+	checkpointFileSuffix   = ".json"
+)
+
+// checkpointer persists the pod and container infos to disk, so they would survive the restart of runtime-proxy.
+// Each info is written to a single file named by its id, and the write is atomic by renaming a temporary file.
+type checkpointer struct {
+	dir string
+}
+
+// InitCheckpoint enables the checkpoint of pod and container infos under dir, and loads the infos checkpointed before
+// into the store. An empty dir keeps the infos in memory only.
+func InitCheckpoint(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	c := &checkpointer{dir: dir}
+	for _, subDir := range []string{podCheckpointDir, containerCheckpointDir} {
+		if err := os.MkdirAll(filepath.Join(dir, subDir), 0700); err != nil {
+			return fmt.Errorf("failed to create checkpoint dir %v, err: %w", dir, err)
+		}
+	}
+
+	pods := map[string]*PodSandboxInfo{}
+	if err := c.loadAll(podCheckpointDir, func(id string, data []byte) error {
+		pod := &PodSandboxInfo{}
+		if err := json.Unmarshal(data, pod); err != nil {
+			return err
+		}
+		pods[id] = pod
+		return nil
+	}); err != nil {
+		return err
+	}
+	containers := map[string]*ContainerInfo{}
+	if err := c.loadAll(containerCheckpointDir, func(id string, data []byte) error {
+		container := &ContainerInfo{}
+		if err := json.Unmarshal(data, container); err != nil {
+			return err
+		}
+		containers[id] = container
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	for id, pod := range pods {
+		m.podInfos[id] = pod
+	}
+	for id, container := range containers {
+		m.containerInfos[id] = container
+	}
+	m.checkpointer = c
+	klog.Infof("load %v pods and %v containers from checkpoint dir %v", len(pods), len(containers), dir)
+	return nil
+}
+
+// loadAll loads all checkpoint files in the sub dir, the corrupted ones are removed.
+func (c *checkpointer) loadAll(subDir string, load func(id string, data []byte) error) error {
+	dir := filepath.Join(c.dir, subDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint dir %v, err: %w", dir, err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, checkpointFileSuffix) {
+			// including the temporary files left by the interrupted writes
+			continue
+		}
+		file := filepath.Join(dir, name)
+		data, err := os.ReadFile(file)
+		if err == nil {
+			err = load(strings.TrimSuffix(name, checkpointFileSuffix), data)
+		}
+		if err != nil {
+			klog.Warningf("failed to load checkpoint %v, remove it, err: %v", file, err)
+			_ = os.Remove(file)
+		}
+	}
+	return nil
+}
+
+func (c *checkpointer) write(subDir, id string, obj interface{}) error {
+	file, err := c.filePath(subDir, id)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	tmpFile, err := os.CreateTemp(filepath.Dir(file), "."+id+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err = tmpFile.Write(data); err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), file)
+}
+
+func (c *checkpointer) remove(subDir, id string) error {
+	file, err := c.filePath(subDir, id)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(file); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *checkpointer) filePath(subDir, id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return "", fmt.Errorf("invalid checkpoint id %q", id)
+	}
+	return filepath.Join(c.dir, subDir, id+checkpointFileSuffix), nil
+}
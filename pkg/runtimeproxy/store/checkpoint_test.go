@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+)
+
+func TestCheckpoint(t *testing.T) {
+	m.reset()
+	defer m.reset()
+	dir := t.TempDir()
+	assert.NoError(t, InitCheckpoint(dir))
+
+	pod := generateSimplePodSandbox()
+	container := &ContainerInfo{
+		ContainerResourceHookRequest: &v1alpha1.ContainerResourceHookRequest{
+			PodMeta: pod.PodMeta,
+			ContainerMeta: &v1alpha1.ContainerMetadata{
+				Name: "container",
+				Id:   "container1",
+			},
+			ContainerResources: &v1alpha1.LinuxContainerResources{
+				CpuShares:  1024,
+				CpusetCpus: "0-3",
+			},
+			ContainerEnvs: map[string]string{"key": "value"},
+		},
+	}
+	assert.NoError(t, WritePodSandboxInfo("pod1", pod))
+	assert.NoError(t, WritePodSandboxInfo("pod2", pod))
+	assert.NoError(t, WriteContainerInfo("container1", container))
+	assert.Error(t, WriteContainerInfo("../container2", container))
+	DeletePodSandboxInfo("pod2")
+	// corrupted checkpoint would be removed during loading
+	corruptedFile := filepath.Join(dir, containerCheckpointDir, "corrupted.json")
+	assert.NoError(t, os.WriteFile(corruptedFile, []byte("{"), 0600))
+
+	// restart and reload from checkpoint
+	m.reset()
+	assert.Nil(t, GetPodSandboxInfo("pod1"))
+	assert.NoError(t, InitCheckpoint(dir))
+	assert.Equal(t, []string{"pod1"}, ListPodSandboxIDs())
+	assert.Equal(t, pod.GetPodMeta().GetName(), GetPodSandboxInfo("pod1").GetPodMeta().GetName())
+	assert.Equal(t, []string{"container1"}, ListContainerIDs())
+	gotContainer := GetContainerInfo("container1")
+	assert.Equal(t, container.GetContainerMeta().GetId(), gotContainer.GetContainerMeta().GetId())
+	assert.Equal(t, container.GetContainerResources().GetCpusetCpus(), gotContainer.GetContainerResources().GetCpusetCpus())
+	assert.Equal(t, container.GetContainerEnvs(), gotContainer.GetContainerEnvs())
+	_, err := os.Stat(corruptedFile)
+	assert.True(t, os.IsNotExist(err))
+
+	DeleteContainerInfo("container1")
+	_, err = os.Stat(filepath.Join(dir, containerCheckpointDir, "container1.json"))
+	assert.True(t, os.IsNotExist(err))
+}
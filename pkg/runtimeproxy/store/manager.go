@@ -19,6 +19,8 @@ package store
 import (
 	"sync"
 
+	"k8s.io/klog/v2"
+
 	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
 )
 
@@ -54,6 +56,8 @@ type metaManager struct {
 	sync.RWMutex
 	podInfos       map[string]*PodSandboxInfo
 	containerInfos map[string]*ContainerInfo
+	// checkpointer persists the infos to disk, which is nil when checkpoint is disabled
+	checkpointer *checkpointer
 }
 
 // reset. currently only used by test case
@@ -62,6 +66,7 @@ func (mm *metaManager) reset() {
 	defer mm.Unlock()
 	mm.podInfos = make(map[string]*PodSandboxInfo, defaultPoolSize)
 	mm.containerInfos = make(map[string]*ContainerInfo, defaultPoolSize)
+	mm.checkpointer = nil
 }
 
 var m = &metaManager{
@@ -74,15 +79,27 @@ func WritePodSandboxInfo(podUID string, pod *PodSandboxInfo) error {
 	m.Lock()
 	defer m.Unlock()
 	m.podInfos[podUID] = pod
-	return nil
+	if m.checkpointer == nil {
+		return nil
+	}
+	if pod == nil {
+		return m.checkpointer.remove(podCheckpointDir, podUID)
+	}
+	return m.checkpointer.write(podCheckpointDir, podUID, pod)
 }
 
-// WriteContainerInfo returns
+// WriteContainerInfo checkpoints the container level info
 func WriteContainerInfo(containerUID string, container *ContainerInfo) error {
 	m.Lock()
 	defer m.Unlock()
 	m.containerInfos[containerUID] = container
-	return nil
+	if m.checkpointer == nil {
+		return nil
+	}
+	if container == nil {
+		return m.checkpointer.remove(containerCheckpointDir, containerUID)
+	}
+	return m.checkpointer.write(containerCheckpointDir, containerUID, container)
 }
 
 // GetPodSandboxInfo returns sandbox info
@@ -103,6 +120,11 @@ func DeletePodSandboxInfo(podUID string) {
 	m.Lock()
 	defer m.Unlock()
 	delete(m.podInfos, podUID)
+	if m.checkpointer != nil {
+		if err := m.checkpointer.remove(podCheckpointDir, podUID); err != nil {
+			klog.Warningf("failed to remove checkpoint of pod %v, err: %v", podUID, err)
+		}
+	}
 }
 
 // DeleteContainerInfo delete container checkpoint indexed by containerUID
@@ -110,4 +132,31 @@ func DeleteContainerInfo(containerUID string) {
 	m.Lock()
 	defer m.Unlock()
 	delete(m.containerInfos, containerUID)
+	if m.checkpointer != nil {
+		if err := m.checkpointer.remove(containerCheckpointDir, containerUID); err != nil {
+			klog.Warningf("failed to remove checkpoint of container %v, err: %v", containerUID, err)
+		}
+	}
+}
+
+// ListPodSandboxIDs returns the ids of all pods in the store
+func ListPodSandboxIDs() []string {
+	m.RLock()
+	defer m.RUnlock()
+	ids := make([]string, 0, len(m.podInfos))
+	for id := range m.podInfos {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ListContainerIDs returns the ids of all containers in the store
+func ListContainerIDs() []string {
+	m.RLock()
+	defer m.RUnlock()
+	ids := make([]string, 0, len(m.containerInfos))
+	for id := range m.containerInfos {
+		ids = append(ids, id)
+	}
+	return ids
 }
@@ -72,8 +72,11 @@ var (
 
 	defaultQuotaGroupNamespace = "koordinator-system"
 
-	defaultMonitorAllQuotas       = pointer.Bool(false)
-	defaultEnableCheckParentQuota = pointer.Bool(false)
+	defaultMonitorAllQuotas        = pointer.Bool(false)
+	defaultEnableCheckParentQuota  = pointer.Bool(false)
+	defaultFairSharePolicy         = FairSharePolicySharedWeight
+	defaultAdmissionQueuePolicy    = AdmissionQueuePolicyNone
+	defaultEnableAdmissionBackfill = pointer.Bool(false)
 
 	defaultTimeout           = 600 * time.Second
 	defaultControllerWorkers = 1
@@ -146,6 +149,15 @@ func SetDefaults_ElasticQuotaArgs(obj *ElasticQuotaArgs) {
 	if obj.EnableCheckParentQuota == nil {
 		obj.EnableCheckParentQuota = defaultEnableCheckParentQuota
 	}
+	if obj.FairSharePolicy == "" {
+		obj.FairSharePolicy = defaultFairSharePolicy
+	}
+	if obj.AdmissionQueuePolicy == "" {
+		obj.AdmissionQueuePolicy = defaultAdmissionQueuePolicy
+	}
+	if obj.EnableAdmissionBackfill == nil {
+		obj.EnableAdmissionBackfill = defaultEnableAdmissionBackfill
+	}
 }
 
 func SetDefaults_CoschedulingArgs(obj *CoschedulingArgs) {
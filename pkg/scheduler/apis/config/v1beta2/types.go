@@ -60,12 +60,12 @@ type LoadAwareSchedulingArgs struct {
 type LoadAwareSchedulingAggregatedArgs struct {
 	// UsageThresholds indicates the resource utilization threshold of the machine based on percentile statistics
 	UsageThresholds map[corev1.ResourceName]int64 `json:"usageThresholds,omitempty"`
-	// UsageAggregationType indicates the percentile type of the machine's utilization when filtering
+	// UsageAggregationType indicates the percentile or EWMA type of the machine's utilization when filtering
 	UsageAggregationType slov1alpha1.AggregationType `json:"usageAggregationType,omitempty"`
 	// UsageAggregatedDuration indicates the statistical period of the percentile of the machine's utilization when filtering
 	UsageAggregatedDuration *metav1.Duration `json:"usageAggregatedDuration,omitempty"`
 
-	// ScoreAggregationType indicates the percentile type of the machine's utilization when scoring
+	// ScoreAggregationType indicates the percentile or EWMA type of the machine's utilization when scoring
 	ScoreAggregationType slov1alpha1.AggregationType `json:"scoreAggregationType,omitempty"`
 	// ScoreAggregatedDuration indicates the statistical period of the percentile of Prod Pod's utilization when scoring
 	ScoreAggregatedDuration *metav1.Duration `json:"scoreAggregatedDuration,omitempty"`
@@ -176,8 +176,44 @@ type ElasticQuotaArgs struct {
 
 	// EnableCheckParentQuota check parentQuotaGroups' used and runtime Quota in PreFilter
 	EnableCheckParentQuota *bool `json:"enableCheckParentQuota,omitempty"`
+
+	// FairSharePolicy indicates how the idle resources are shared between the child quotaGroups.
+	// Default is SharedWeight.
+	FairSharePolicy ElasticQuotaFairSharePolicy `json:"fairSharePolicy,omitempty"`
+
+	// AdmissionQueuePolicy indicates the order in which the pending pods of a quotaGroup are admitted.
+	// Default is None, which admits the pods in any order.
+	AdmissionQueuePolicy ElasticQuotaAdmissionQueuePolicy `json:"admissionQueuePolicy,omitempty"`
+
+	// EnableAdmissionBackfill admits the pods behind the head of the admission queue as long as they don't delay the head.
+	EnableAdmissionBackfill *bool `json:"enableAdmissionBackfill,omitempty"`
 }
 
+// ElasticQuotaFairSharePolicy is a "string" type.
+type ElasticQuotaFairSharePolicy string
+
+const (
+	// FairSharePolicySharedWeight splits the idle resources by the shared weight of the quotaGroups,
+	// one resource dimension at a time.
+	FairSharePolicySharedWeight ElasticQuotaFairSharePolicy = "SharedWeight"
+	// FairSharePolicyDominantResourceFairness splits the idle resources across all resource dimensions together
+	// with the weighted Dominant Resource Fairness.
+	FairSharePolicyDominantResourceFairness ElasticQuotaFairSharePolicy = "DominantResourceFairness"
+)
+
+// ElasticQuotaAdmissionQueuePolicy is a "string" type.
+type ElasticQuotaAdmissionQueuePolicy string
+
+const (
+	// AdmissionQueuePolicyNone doesn't queue the pending pods, every pod is admitted once it fits the quota.
+	AdmissionQueuePolicyNone ElasticQuotaAdmissionQueuePolicy = "None"
+	// AdmissionQueuePolicyFIFO admits the pending pods of a quotaGroup in the order of their creation.
+	AdmissionQueuePolicyFIFO ElasticQuotaAdmissionQueuePolicy = "FIFO"
+	// AdmissionQueuePolicyPriority admits the pending pods of a quotaGroup in the order of their priority,
+	// and the pods with the same priority in the order of their creation.
+	AdmissionQueuePolicyPriority ElasticQuotaAdmissionQueuePolicy = "Priority"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // CoschedulingArgs defines the parameters for Gang Scheduling plugin.
@@ -174,6 +174,9 @@ func autoConvert_v1beta2_ElasticQuotaArgs_To_config_ElasticQuotaArgs(in *Elastic
 	out.QuotaGroupNamespace = in.QuotaGroupNamespace
 	out.MonitorAllQuotas = (*bool)(unsafe.Pointer(in.MonitorAllQuotas))
 	out.EnableCheckParentQuota = (*bool)(unsafe.Pointer(in.EnableCheckParentQuota))
+	out.FairSharePolicy = config.ElasticQuotaFairSharePolicy(in.FairSharePolicy)
+	out.AdmissionQueuePolicy = config.ElasticQuotaAdmissionQueuePolicy(in.AdmissionQueuePolicy)
+	out.EnableAdmissionBackfill = (*bool)(unsafe.Pointer(in.EnableAdmissionBackfill))
 	return nil
 }
 
@@ -190,6 +193,9 @@ func autoConvert_config_ElasticQuotaArgs_To_v1beta2_ElasticQuotaArgs(in *config.
 	out.QuotaGroupNamespace = in.QuotaGroupNamespace
 	out.MonitorAllQuotas = (*bool)(unsafe.Pointer(in.MonitorAllQuotas))
 	out.EnableCheckParentQuota = (*bool)(unsafe.Pointer(in.EnableCheckParentQuota))
+	out.FairSharePolicy = ElasticQuotaFairSharePolicy(in.FairSharePolicy)
+	out.AdmissionQueuePolicy = ElasticQuotaAdmissionQueuePolicy(in.AdmissionQueuePolicy)
+	out.EnableAdmissionBackfill = (*bool)(unsafe.Pointer(in.EnableAdmissionBackfill))
 	return nil
 }
 
@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 )
 
@@ -51,12 +52,33 @@ func ValidateLoadAwareSchedulingArgs(args *config.LoadAwareSchedulingArgs) error
 		}
 	}
 
+	if args.Aggregated != nil {
+		if err := validateAggregationType(args.Aggregated.UsageAggregationType); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("aggregated", "usageAggregationType"), args.Aggregated.UsageAggregationType, err.Error()))
+		}
+		if err := validateAggregationType(args.Aggregated.ScoreAggregationType); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("aggregated", "scoreAggregationType"), args.Aggregated.ScoreAggregationType, err.Error()))
+		}
+		if err := validateResourceThresholds(args.Aggregated.UsageThresholds); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("aggregated", "usageThresholds"), args.Aggregated.UsageThresholds, err.Error()))
+		}
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return allErrs.ToAggregate()
 }
 
+func validateAggregationType(aggregationType slov1alpha1.AggregationType) error {
+	switch aggregationType {
+	case "", slov1alpha1.AVG, slov1alpha1.P50, slov1alpha1.P90, slov1alpha1.P95, slov1alpha1.P99, slov1alpha1.EWMA:
+		return nil
+	default:
+		return fmt.Errorf("unsupported aggregation type %v", aggregationType)
+	}
+}
+
 func validateResourceWeights(resources map[corev1.ResourceName]int64) error {
 	for resourceName, weight := range resources {
 		if weight <= 0 {
@@ -116,6 +138,18 @@ func ValidateElasticQuotaArgs(elasticArgs *config.ElasticQuotaArgs) error {
 		return fmt.Errorf("elasticQuotaArgs error, RevokePodCycle should be a positive value")
 	}
 
+	switch elasticArgs.FairSharePolicy {
+	case "", config.FairSharePolicySharedWeight, config.FairSharePolicyDominantResourceFairness:
+	default:
+		return fmt.Errorf("elasticQuotaArgs error, unsupported FairSharePolicy %q", elasticArgs.FairSharePolicy)
+	}
+
+	switch elasticArgs.AdmissionQueuePolicy {
+	case "", config.AdmissionQueuePolicyNone, config.AdmissionQueuePolicyFIFO, config.AdmissionQueuePolicyPriority:
+	default:
+		return fmt.Errorf("elasticQuotaArgs error, unsupported AdmissionQueuePolicy %q", elasticArgs.AdmissionQueuePolicy)
+	}
+
 	return nil
 }
 
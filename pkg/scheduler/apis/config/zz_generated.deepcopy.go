@@ -126,6 +126,11 @@ func (in *ElasticQuotaArgs) DeepCopyInto(out *ElasticQuotaArgs) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.EnableAdmissionBackfill != nil {
+		in, out := &in.EnableAdmissionBackfill, &out.EnableAdmissionBackfill
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frameworkext
+
+import (
+	"sync"
+
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+const reservationStateKey = "koordinator.sh/reservation"
+
+// ReservationState is shared in CycleState by the Reservation plugin and the plugins which allocate the reserved
+// resources with concrete IDs, e.g. NodeNUMAResource reserves CPUs and DeviceShare reserves GPUs.
+// The Reservation plugin records the reservations matched by the Pod in PreFilter, and nominates the reservation
+// that the Pod allocates in Reserve. So the Reservation plugin must run before the other plugins in Reserve,
+// and then the Pod can inherit exactly the CPUs and devices of the nominated reservation.
+type ReservationState struct {
+	lock sync.RWMutex
+	// matched records the available reservations on each node that the Pod matches.
+	matched map[string][]*schedulingv1alpha1.Reservation
+	// nominated records the reservation on each node that the Pod allocates in Reserve.
+	nominated map[string]*schedulingv1alpha1.Reservation
+}
+
+func (s *ReservationState) Clone() framework.StateData {
+	return s
+}
+
+// InitReservationState writes an empty ReservationState into the CycleState if it does not exist.
+func InitReservationState(cycleState *framework.CycleState) *ReservationState {
+	if s := GetReservationState(cycleState); s != nil {
+		return s
+	}
+	s := &ReservationState{
+		matched:   map[string][]*schedulingv1alpha1.Reservation{},
+		nominated: map[string]*schedulingv1alpha1.Reservation{},
+	}
+	cycleState.Write(reservationStateKey, s)
+	return s
+}
+
+// GetReservationState returns the ReservationState in the CycleState, or nil if the Pod does not match any reservation.
+func GetReservationState(cycleState *framework.CycleState) *ReservationState {
+	value, err := cycleState.Read(reservationStateKey)
+	if err != nil {
+		return nil
+	}
+	s, _ := value.(*ReservationState)
+	return s
+}
+
+// GetMatchedReservations returns the reservations on the node that the Pod matches.
+func (s *ReservationState) GetMatchedReservations(nodeName string) []*schedulingv1alpha1.Reservation {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.matched[nodeName]
+}
+
+// SetMatchedReservations records the reservations on the node that the Pod matches.
+func (s *ReservationState) SetMatchedReservations(nodeName string, reservations []*schedulingv1alpha1.Reservation) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(reservations) == 0 {
+		delete(s.matched, nodeName)
+		return
+	}
+	s.matched[nodeName] = reservations
+}
+
+// GetNominatedReservation returns the reservation on the node that the Pod allocates.
+func (s *ReservationState) GetNominatedReservation(nodeName string) *schedulingv1alpha1.Reservation {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.nominated[nodeName]
+}
+
+// SetNominatedReservation records the reservation on the node that the Pod allocates.
+func (s *ReservationState) SetNominatedReservation(nodeName string, reservation *schedulingv1alpha1.Reservation) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.nominated[nodeName] = reservation
+}
+
+// DeleteNominatedReservation removes the reservation on the node that the Pod allocates, e.g. in Unreserve.
+func (s *ReservationState) DeleteNominatedReservation(nodeName string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.nominated, nodeName)
+}
+
+// GetNominatedReservation returns the reservation on the node that the Pod allocates, or nil if there is none.
+func GetNominatedReservation(cycleState *framework.CycleState, nodeName string) *schedulingv1alpha1.Reservation {
+	if s := GetReservationState(cycleState); s != nil {
+		return s.GetNominatedReservation(nodeName)
+	}
+	return nil
+}
+
+// GetMatchedReservations returns the reservations on the node that the Pod matches, or nil if there is none.
+func GetMatchedReservations(cycleState *framework.CycleState, nodeName string) []*schedulingv1alpha1.Reservation {
+	if s := GetReservationState(cycleState); s != nil {
+		return s.GetMatchedReservations(nodeName)
+	}
+	return nil
+}
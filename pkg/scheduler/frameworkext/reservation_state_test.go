@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frameworkext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+func TestReservationState(t *testing.T) {
+	cycleState := framework.NewCycleState()
+	assert.Nil(t, GetReservationState(cycleState))
+	assert.Nil(t, GetMatchedReservations(cycleState, "test-node-1"))
+	assert.Nil(t, GetNominatedReservation(cycleState, "test-node-1"))
+
+	s := InitReservationState(cycleState)
+	assert.NotNil(t, s)
+	assert.Same(t, s, GetReservationState(cycleState))
+	assert.Same(t, s, InitReservationState(cycleState))
+
+	r := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-reservation",
+			UID:  "123456",
+		},
+	}
+	s.SetMatchedReservations("test-node-1", []*schedulingv1alpha1.Reservation{r})
+	assert.Equal(t, []*schedulingv1alpha1.Reservation{r}, GetMatchedReservations(cycleState, "test-node-1"))
+	assert.Nil(t, GetMatchedReservations(cycleState, "test-node-2"))
+	s.SetMatchedReservations("test-node-1", nil)
+	assert.Nil(t, GetMatchedReservations(cycleState, "test-node-1"))
+
+	s.SetNominatedReservation("test-node-1", r)
+	assert.Same(t, r, GetNominatedReservation(cycleState, "test-node-1"))
+	s.DeleteNominatedReservation("test-node-1")
+	assert.Nil(t, GetNominatedReservation(cycleState, "test-node-1"))
+}
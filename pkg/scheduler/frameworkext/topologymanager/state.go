@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologymanager
+
+import (
+	"sort"
+	"sync"
+
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+const stateKey = "koordinator.sh/numa-topology"
+
+// NUMATopologyState is shared in CycleState by the plugins which allocate NUMA-aware resources,
+// e.g. NodeNUMAResource allocates CPUs and memory, DeviceShare allocates GPUs and RDMA devices.
+// The plugins run in the configured order, and each plugin narrows the feasible NUMA Nodes in Filter,
+// so that the later plugins can align their resources to the same NUMA Nodes.
+type NUMATopologyState struct {
+	lock sync.RWMutex
+	// feasibleNUMANodes records the NUMA Nodes of each node that satisfy the Pod.
+	// It only exists for the nodes that require the resources to be aligned on a single NUMA Node.
+	feasibleNUMANodes map[string][]int
+	// affinities records the NUMA Nodes allocated to the Pod in Reserve.
+	affinities map[string]NUMAAffinity
+}
+
+// NUMAAffinity describes the NUMA Nodes that the resources of the Pod should be allocated on.
+type NUMAAffinity struct {
+	NUMANodes []int
+	// Required indicates that the resources must be allocated on the NUMA Nodes,
+	// otherwise the NUMA Nodes are only preferred.
+	Required bool
+}
+
+// Clone deep copies the NUMATopologyState, e.g. the preemption evaluates the nodes with the cloned CycleState,
+// and the NUMA Nodes narrowed there should not leak into the original one.
+func (s *NUMATopologyState) Clone() framework.StateData {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	clone := &NUMATopologyState{
+		feasibleNUMANodes: make(map[string][]int, len(s.feasibleNUMANodes)),
+		affinities:        make(map[string]NUMAAffinity, len(s.affinities)),
+	}
+	for nodeName, numaNodes := range s.feasibleNUMANodes {
+		clone.feasibleNUMANodes[nodeName] = append([]int{}, numaNodes...)
+	}
+	for nodeName, affinity := range s.affinities {
+		affinity.NUMANodes = append([]int{}, affinity.NUMANodes...)
+		clone.affinities[nodeName] = affinity
+	}
+	return clone
+}
+
+// InitState writes an empty NUMATopologyState into the CycleState if it does not exist.
+func InitState(cycleState *framework.CycleState) *NUMATopologyState {
+	if s := GetState(cycleState); s != nil {
+		return s
+	}
+	s := &NUMATopologyState{
+		feasibleNUMANodes: map[string][]int{},
+		affinities:        map[string]NUMAAffinity{},
+	}
+	cycleState.Write(stateKey, s)
+	return s
+}
+
+// GetState returns the NUMATopologyState in the CycleState, or nil if the Pod does not need NUMA alignment.
+func GetState(cycleState *framework.CycleState) *NUMATopologyState {
+	value, err := cycleState.Read(stateKey)
+	if err != nil {
+		return nil
+	}
+	s, _ := value.(*NUMATopologyState)
+	return s
+}
+
+// GetFeasibleNUMANodes returns the feasible NUMA Nodes of the node, false if there is no constraint.
+func (s *NUMATopologyState) GetFeasibleNUMANodes(nodeName string) ([]int, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	numaNodes, ok := s.feasibleNUMANodes[nodeName]
+	return numaNodes, ok
+}
+
+// SetFeasibleNUMANodes sets the feasible NUMA Nodes of the node, which must be a subset of the previous ones if existed.
+func (s *NUMATopologyState) SetFeasibleNUMANodes(nodeName string, numaNodes []int) {
+	numaNodes = append([]int{}, numaNodes...)
+	sort.Ints(numaNodes)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.feasibleNUMANodes[nodeName] = numaNodes
+}
+
+// GetAffinity returns the NUMA Nodes allocated to the Pod on the node.
+func (s *NUMATopologyState) GetAffinity(nodeName string) (NUMAAffinity, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	affinity, ok := s.affinities[nodeName]
+	return affinity, ok
+}
+
+// SetAffinity records the NUMA Nodes allocated to the Pod on the node.
+func (s *NUMATopologyState) SetAffinity(nodeName string, affinity NUMAAffinity) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.affinities[nodeName] = affinity
+}
+
+// DeleteAffinity removes the NUMA Nodes allocated to the Pod on the node, e.g. in Unreserve.
+func (s *NUMATopologyState) DeleteAffinity(nodeName string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.affinities, nodeName)
+}
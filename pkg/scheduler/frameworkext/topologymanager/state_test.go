@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologymanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+func TestNUMATopologyState(t *testing.T) {
+	cycleState := framework.NewCycleState()
+	assert.Nil(t, GetState(cycleState))
+
+	s := InitState(cycleState)
+	assert.NotNil(t, s)
+	assert.Same(t, s, GetState(cycleState))
+	assert.Same(t, s, InitState(cycleState))
+
+	_, ok := s.GetFeasibleNUMANodes("test-node-1")
+	assert.False(t, ok)
+	s.SetFeasibleNUMANodes("test-node-1", []int{1, 0})
+	numaNodes, ok := s.GetFeasibleNUMANodes("test-node-1")
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 1}, numaNodes)
+
+	_, ok = s.GetAffinity("test-node-1")
+	assert.False(t, ok)
+	affinity := NUMAAffinity{NUMANodes: []int{1}, Required: true}
+	s.SetAffinity("test-node-1", affinity)
+	got, ok := s.GetAffinity("test-node-1")
+	assert.True(t, ok)
+	assert.Equal(t, affinity, got)
+
+	s.DeleteAffinity("test-node-1")
+	_, ok = s.GetAffinity("test-node-1")
+	assert.False(t, ok)
+}
+
+func TestNUMATopologyStateClone(t *testing.T) {
+	cycleState := framework.NewCycleState()
+	s := InitState(cycleState)
+	s.SetFeasibleNUMANodes("test-node-1", []int{0, 1})
+	s.SetAffinity("test-node-1", NUMAAffinity{NUMANodes: []int{1}, Required: true})
+
+	clonedCycleState := cycleState.Clone()
+	cloned := GetState(clonedCycleState)
+	assert.NotSame(t, s, cloned)
+	cloned.SetFeasibleNUMANodes("test-node-1", []int{1})
+	cloned.SetFeasibleNUMANodes("test-node-2", []int{0})
+	cloned.DeleteAffinity("test-node-1")
+
+	numaNodes, ok := s.GetFeasibleNUMANodes("test-node-1")
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 1}, numaNodes)
+	_, ok = s.GetFeasibleNUMANodes("test-node-2")
+	assert.False(t, ok)
+	affinity, ok := s.GetAffinity("test-node-1")
+	assert.True(t, ok)
+	assert.Equal(t, NUMAAffinity{NUMANodes: []int{1}, Required: true}, affinity)
+}
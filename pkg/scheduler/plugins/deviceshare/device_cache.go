@@ -17,6 +17,7 @@ limitations under the License.
 package deviceshare
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"sync"
@@ -24,11 +25,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	"k8s.io/klog/v2"
 
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
 // deviceResources is used to present resources per device.
@@ -73,6 +76,14 @@ type nodeDevice struct {
 	deviceFree  map[schedulingv1alpha1.DeviceType]deviceResources
 	deviceUsed  map[schedulingv1alpha1.DeviceType]deviceResources
 	allocateSet map[schedulingv1alpha1.DeviceType]map[types.NamespacedName]map[int]corev1.ResourceList
+	// deviceTopologies records the topology of each device by the minor,
+	// and the devices without topology information are not recorded.
+	deviceTopologies map[schedulingv1alpha1.DeviceType]map[int]*schedulingv1alpha1.DeviceTopology
+	// deviceVFs records the SR-IOV virtual functions of each device by the minor,
+	// and the devices without virtual functions are not recorded.
+	deviceVFs map[schedulingv1alpha1.DeviceType]map[int][]schedulingv1alpha1.VirtualFunction
+	// vfUsed records the minors of the virtual functions allocated from each device by the minor.
+	vfUsed map[schedulingv1alpha1.DeviceType]map[int]sets.Int
 }
 
 func newNodeDevice() *nodeDevice {
@@ -84,6 +95,104 @@ func newNodeDevice() *nodeDevice {
 	}
 }
 
+// filterByNUMANodes returns a view of the nodeDevice that only contains the devices on the given NUMA Nodes
+// and the devices without NUMA affinity. The view is only used to allocate devices and must not be updated.
+func (n *nodeDevice) filterByNUMANodes(numaNodes []int) *nodeDevice {
+	inNUMANodes := func(deviceType schedulingv1alpha1.DeviceType, minor int) bool {
+		topology := n.deviceTopologies[deviceType][minor]
+		if topology == nil || topology.NodeID < 0 {
+			return true
+		}
+		for _, v := range numaNodes {
+			if v == int(topology.NodeID) {
+				return true
+			}
+		}
+		return false
+	}
+	filter := func(in map[schedulingv1alpha1.DeviceType]deviceResources) map[schedulingv1alpha1.DeviceType]deviceResources {
+		out := make(map[schedulingv1alpha1.DeviceType]deviceResources, len(in))
+		for deviceType, resources := range in {
+			filtered := make(deviceResources)
+			for minor, resourceList := range resources {
+				if inNUMANodes(deviceType, minor) {
+					filtered[minor] = resourceList
+				}
+			}
+			out[deviceType] = filtered
+		}
+		return out
+	}
+	return &nodeDevice{
+		deviceTotal:      filter(n.deviceTotal),
+		deviceFree:       filter(n.deviceFree),
+		deviceUsed:       filter(n.deviceUsed),
+		allocateSet:      n.allocateSet,
+		deviceTopologies: n.deviceTopologies,
+		deviceVFs:        n.deviceVFs,
+		vfUsed:           n.vfUsed,
+	}
+}
+
+// filterByReservation returns a view of the nodeDevice that only contains the devices reserved by the reservation,
+// and the resources of the devices allocated to the owners of the reservation are excluded. The virtual functions
+// reserved by the reservation are kept to allocate. The view is only used to allocate devices and must not be updated.
+func (n *nodeDevice) filterByReservation(reserved apiext.DeviceAllocations, owners []corev1.ObjectReference) *nodeDevice {
+	view := &nodeDevice{
+		deviceTotal:      make(map[schedulingv1alpha1.DeviceType]deviceResources),
+		deviceFree:       make(map[schedulingv1alpha1.DeviceType]deviceResources),
+		deviceUsed:       make(map[schedulingv1alpha1.DeviceType]deviceResources),
+		allocateSet:      n.allocateSet,
+		deviceTopologies: n.deviceTopologies,
+		deviceVFs:        make(map[schedulingv1alpha1.DeviceType]map[int][]schedulingv1alpha1.VirtualFunction),
+		vfUsed:           make(map[schedulingv1alpha1.DeviceType]map[int]sets.Int),
+	}
+	for deviceType, allocations := range reserved {
+		total := make(deviceResources)
+		free := make(deviceResources)
+		vfs := make(map[int][]schedulingv1alpha1.VirtualFunction)
+		vfUsed := make(map[int]sets.Int)
+		for _, allocation := range allocations {
+			minor := int(allocation.Minor)
+			// the device has been removed or become unhealthy
+			if n.deviceTotal[deviceType][minor] == nil {
+				continue
+			}
+			total[minor] = n.deviceTotal[deviceType][minor]
+			free[minor] = quotav1.Add(free[minor], allocation.Resources)
+			extension, err := apiext.GetDeviceAllocationExtension(allocation)
+			if err == nil && extension != nil && len(extension.VirtualFunctions) > 0 {
+				vfs[minor] = append(vfs[minor], extension.VirtualFunctions...)
+			}
+		}
+		// the whole devices reserved without virtual functions share the virtual functions with the node
+		for minor := range total {
+			if _, ok := vfs[minor]; !ok && len(n.deviceVFs[deviceType][minor]) > 0 {
+				vfs[minor] = n.deviceVFs[deviceType][minor]
+				vfUsed[minor] = n.vfUsed[deviceType][minor]
+			}
+		}
+		used := make(deviceResources)
+		for _, owner := range owners {
+			ownerAllocations := n.allocateSet[deviceType][types.NamespacedName{Namespace: owner.Namespace, Name: owner.Name}]
+			for minor, resources := range ownerAllocations {
+				if _, ok := free[minor]; ok {
+					used[minor] = quotav1.Add(used[minor], resources)
+				}
+			}
+		}
+		for minor, resources := range used {
+			free[minor] = quotav1.SubtractWithNonNegativeResult(free[minor], resources)
+		}
+		view.deviceTotal[deviceType] = total
+		view.deviceFree[deviceType] = free
+		view.deviceUsed[deviceType] = used
+		view.deviceVFs[deviceType] = vfs
+		view.vfUsed[deviceType] = vfUsed
+	}
+	return view
+}
+
 func (n *nodeDevice) getNodeDeviceSummary() *NodeDeviceSummary {
 	n.lock.RLock()
 	defer n.lock.RUnlock()
@@ -146,6 +255,7 @@ func (n *nodeDevice) updateCacheUsed(deviceAllocations apiext.DeviceAllocations,
 				continue
 			}
 			n.updateDeviceUsed(deviceType, allocations, add)
+			n.updateVFUsed(deviceType, allocations, add)
 			n.resetDeviceFree(deviceType)
 			n.updateAllocateSet(deviceType, allocations, pod, add)
 		}
@@ -199,6 +309,60 @@ func (n *nodeDevice) updateDeviceUsed(deviceType schedulingv1alpha1.DeviceType,
 	}
 }
 
+func (n *nodeDevice) updateVFUsed(deviceType schedulingv1alpha1.DeviceType, allocations []*apiext.DeviceAllocation, add bool) {
+	for _, allocation := range allocations {
+		extension, err := apiext.GetDeviceAllocationExtension(allocation)
+		if err != nil {
+			klog.Errorf("failed to parse the extension of %v allocation, minor: %v, err: %v", deviceType, allocation.Minor, err)
+			continue
+		}
+		if extension == nil || len(extension.VirtualFunctions) == 0 {
+			continue
+		}
+		if n.vfUsed == nil {
+			n.vfUsed = make(map[schedulingv1alpha1.DeviceType]map[int]sets.Int)
+		}
+		if n.vfUsed[deviceType] == nil {
+			n.vfUsed[deviceType] = make(map[int]sets.Int)
+		}
+		used := n.vfUsed[deviceType][int(allocation.Minor)]
+		if used == nil {
+			used = sets.NewInt()
+			n.vfUsed[deviceType][int(allocation.Minor)] = used
+		}
+		for _, vf := range extension.VirtualFunctions {
+			if add {
+				used.Insert(int(vf.Minor))
+			} else {
+				used.Delete(int(vf.Minor))
+			}
+		}
+		if used.Len() == 0 {
+			delete(n.vfUsed[deviceType], int(allocation.Minor))
+		}
+	}
+}
+
+// getFreeVF returns the free virtual function with the smallest minor of the device.
+// It returns nil and true if the device has no virtual function.
+func (n *nodeDevice) getFreeVF(deviceType schedulingv1alpha1.DeviceType, minor int) (*schedulingv1alpha1.VirtualFunction, bool) {
+	vfs := n.deviceVFs[deviceType][minor]
+	if len(vfs) == 0 {
+		return nil, true
+	}
+	used := n.vfUsed[deviceType][minor]
+	var free *schedulingv1alpha1.VirtualFunction
+	for i := range vfs {
+		if used.Has(int(vfs[i].Minor)) {
+			continue
+		}
+		if free == nil || vfs[i].Minor < free.Minor {
+			free = &vfs[i]
+		}
+	}
+	return free, free != nil
+}
+
 func (n *nodeDevice) isValid(deviceType schedulingv1alpha1.DeviceType, pod *corev1.Pod, add bool) bool {
 	allocateSet := n.allocateSet[deviceType]
 	if allocateSet == nil {
@@ -315,14 +479,32 @@ func (n *nodeDevice) tryAllocateCommonDevice(podRequest corev1.ResourceList, dev
 
 	orderedDeviceResources := sortDeviceResourcesByMinor(n.deviceFree[deviceType])
 	for _, deviceResource := range orderedDeviceResources {
-		if satisfied, _ := quotav1.LessThanOrEqual(podRequest, deviceResource.resources); satisfied {
-			deviceAllocations = append(deviceAllocations, &apiext.DeviceAllocation{
-				Minor:     int32(deviceResource.minor),
-				Resources: podRequest,
-			})
-			allocateResult[deviceType] = deviceAllocations
-			return nil
+		if satisfied, _ := quotav1.LessThanOrEqual(podRequest, deviceResource.resources); !satisfied {
+			continue
+		}
+		allocation := &apiext.DeviceAllocation{
+			Minor:     int32(deviceResource.minor),
+			Resources: podRequest,
+		}
+		// the pods sharing a device with SR-IOV virtual functions are allocated with a dedicated virtual function
+		if isSharedCommonDevicePod(podRequest, deviceType) {
+			vf, ok := n.getFreeVF(deviceType, deviceResource.minor)
+			if !ok {
+				continue
+			}
+			if vf != nil {
+				extension, err := json.Marshal(&apiext.DeviceAllocationExtension{
+					VirtualFunctions: []schedulingv1alpha1.VirtualFunction{*vf},
+				})
+				if err != nil {
+					return err
+				}
+				allocation.Extension = extension
+			}
 		}
+		deviceAllocations = append(deviceAllocations, allocation)
+		allocateResult[deviceType] = deviceAllocations
+		return nil
 	}
 	klog.V(5).Infof("node resource does not satisfy pod's %v request", deviceType)
 	return fmt.Errorf("node does not have enough %v", deviceType)
@@ -346,23 +528,26 @@ func (n *nodeDevice) tryAllocateGPU(podRequest corev1.ResourceList, allocateResu
 			apiext.GPUMemory:      *resource.NewQuantity(gpuMem.Value()/gpuWanted, resource.BinarySI),
 			apiext.GPUMemoryRatio: *resource.NewQuantity(gpuMemRatio.Value()/gpuWanted, resource.DecimalSI),
 		}
-		satisfiedDeviceCount := 0
+		var satisfiedMinors []int
 		orderedDeviceResources := sortDeviceResourcesByMinor(n.deviceFree[schedulingv1alpha1.GPU])
 		for _, deviceResource := range orderedDeviceResources {
 			if satisfied, _ := quotav1.LessThanOrEqual(podRequestPerCard, deviceResource.resources); satisfied {
-				satisfiedDeviceCount++
-				deviceAllocations = append(deviceAllocations, &apiext.DeviceAllocation{
-					Minor:     int32(deviceResource.minor),
-					Resources: podRequestPerCard,
-				})
-			}
-			if satisfiedDeviceCount == int(gpuWanted) {
-				allocateResult[schedulingv1alpha1.GPU] = deviceAllocations
-				return nil
+				satisfiedMinors = append(satisfiedMinors, deviceResource.minor)
 			}
 		}
-		klog.V(5).Infof("node GPU resource does not satisfy pod's multiple GPU request, expect %v, got %v", gpuWanted, satisfiedDeviceCount)
-		return fmt.Errorf("node does not have enough GPU")
+		if len(satisfiedMinors) < int(gpuWanted) {
+			klog.V(5).Infof("node GPU resource does not satisfy pod's multiple GPU request, expect %v, got %v", gpuWanted, len(satisfiedMinors))
+			return fmt.Errorf("node does not have enough GPU")
+		}
+		// prefer the GPUs best connected by NVLink or PCIe switch
+		for _, minor := range n.selectBestConnectedGPUs(satisfiedMinors, int(gpuWanted)) {
+			deviceAllocations = append(deviceAllocations, &apiext.DeviceAllocation{
+				Minor:     int32(minor),
+				Resources: podRequestPerCard,
+			})
+		}
+		allocateResult[schedulingv1alpha1.GPU] = deviceAllocations
+		return nil
 	}
 
 	orderedDeviceResources := sortDeviceResourcesByMinor(n.deviceFree[schedulingv1alpha1.GPU])
@@ -431,11 +616,32 @@ func (n *nodeDeviceCache) updateNodeDevice(nodeName string, device *schedulingv1
 	defer info.lock.Unlock()
 
 	nodeDeviceResource := map[schedulingv1alpha1.DeviceType]deviceResources{}
-	for _, deviceInfo := range device.Spec.Devices {
+	var deviceTopologies map[schedulingv1alpha1.DeviceType]map[int]*schedulingv1alpha1.DeviceTopology
+	var deviceVFs map[schedulingv1alpha1.DeviceType]map[int][]schedulingv1alpha1.VirtualFunction
+	for i := range device.Spec.Devices {
+		deviceInfo := &device.Spec.Devices[i]
 		if nodeDeviceResource[deviceInfo.Type] == nil {
 			nodeDeviceResource[deviceInfo.Type] = make(deviceResources)
 		}
-		if !deviceInfo.Health {
+		if deviceInfo.Topology != nil {
+			if deviceTopologies == nil {
+				deviceTopologies = map[schedulingv1alpha1.DeviceType]map[int]*schedulingv1alpha1.DeviceTopology{}
+			}
+			if deviceTopologies[deviceInfo.Type] == nil {
+				deviceTopologies[deviceInfo.Type] = map[int]*schedulingv1alpha1.DeviceTopology{}
+			}
+			deviceTopologies[deviceInfo.Type][int(*deviceInfo.Minor)] = deviceInfo.Topology.DeepCopy()
+		}
+		if len(deviceInfo.VFs) > 0 {
+			if deviceVFs == nil {
+				deviceVFs = map[schedulingv1alpha1.DeviceType]map[int][]schedulingv1alpha1.VirtualFunction{}
+			}
+			if deviceVFs[deviceInfo.Type] == nil {
+				deviceVFs[deviceInfo.Type] = map[int][]schedulingv1alpha1.VirtualFunction{}
+			}
+			deviceVFs[deviceInfo.Type][int(*deviceInfo.Minor)] = append([]schedulingv1alpha1.VirtualFunction{}, deviceInfo.VFs...)
+		}
+		if !util.IsDeviceHealthy(device, deviceInfo) {
 			nodeDeviceResource[deviceInfo.Type][int(*deviceInfo.Minor)] = make(corev1.ResourceList)
 			klog.Errorf("Find device unhealthy, nodeName:%v, deviceType:%v, minor:%v",
 				nodeName, deviceInfo.Type, deviceInfo.Minor)
@@ -446,6 +652,8 @@ func (n *nodeDeviceCache) updateNodeDevice(nodeName string, device *schedulingv1
 		}
 	}
 
+	info.deviceTopologies = deviceTopologies
+	info.deviceVFs = deviceVFs
 	info.resetDeviceTotal(nodeDeviceResource)
 }
 
@@ -17,12 +17,16 @@ limitations under the License.
 package deviceshare
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 )
 
@@ -42,3 +46,171 @@ func Test_newNodeDevice(t *testing.T) {
 	}
 	assert.Equal(t, expectNodeDevice, newNodeDevice())
 }
+
+func newTestGPUDeviceInfo(minor int32, numaNode int32) schedulingv1alpha1.DeviceInfo {
+	return schedulingv1alpha1.DeviceInfo{
+		Type:   schedulingv1alpha1.GPU,
+		Minor:  pointer.Int32(minor),
+		Health: true,
+		Resources: v1.ResourceList{
+			apiext.GPUCore:        resource.MustParse("100"),
+			apiext.GPUMemoryRatio: resource.MustParse("100"),
+			apiext.GPUMemory:      resource.MustParse("16Gi"),
+		},
+		Topology: &schedulingv1alpha1.DeviceTopology{
+			SocketID: numaNode,
+			NodeID:   numaNode,
+		},
+	}
+}
+
+func Test_nodeDevice_filterByNUMANodes(t *testing.T) {
+	cache := newNodeDeviceCache()
+	cache.updateNodeDevice("test-node", &schedulingv1alpha1.Device{
+		Spec: schedulingv1alpha1.DeviceSpec{
+			Devices: []schedulingv1alpha1.DeviceInfo{
+				newTestGPUDeviceInfo(0, 0),
+				newTestGPUDeviceInfo(1, 1),
+				newTestGPUDeviceInfo(2, -1),
+			},
+		},
+	})
+	nodeDeviceInfo := cache.getNodeDevice("test-node")
+	assert.Len(t, nodeDeviceInfo.deviceTopologies[schedulingv1alpha1.GPU], 3)
+	assert.Equal(t, int32(1), nodeDeviceInfo.deviceTopologies[schedulingv1alpha1.GPU][1].NodeID)
+
+	filtered := nodeDeviceInfo.filterByNUMANodes([]int{1})
+	for _, resources := range []map[schedulingv1alpha1.DeviceType]deviceResources{filtered.deviceTotal, filtered.deviceFree} {
+		var minors []int
+		for minor := range resources[schedulingv1alpha1.GPU] {
+			minors = append(minors, minor)
+		}
+		assert.ElementsMatch(t, []int{1, 2}, minors)
+	}
+	// the original nodeDevice must not be changed
+	assert.Len(t, nodeDeviceInfo.deviceTotal[schedulingv1alpha1.GPU], 3)
+	assert.Len(t, nodeDeviceInfo.deviceFree[schedulingv1alpha1.GPU], 3)
+}
+
+func Test_nodeDevice_tryAllocateRDMAWithVFs(t *testing.T) {
+	cache := newNodeDeviceCache()
+	cache.updateNodeDevice("test-node", &schedulingv1alpha1.Device{
+		Spec: schedulingv1alpha1.DeviceSpec{
+			Devices: []schedulingv1alpha1.DeviceInfo{
+				{
+					Type:   schedulingv1alpha1.RDMA,
+					Minor:  pointer.Int32(0),
+					Health: true,
+					Resources: v1.ResourceList{
+						apiext.KoordRDMA: resource.MustParse("100"),
+					},
+					VFs: []schedulingv1alpha1.VirtualFunction{
+						{Minor: 0, BusID: "0000:1f:00.2"},
+						{Minor: 1, BusID: "0000:1f:00.3"},
+					},
+				},
+				{
+					Type:   schedulingv1alpha1.RDMA,
+					Minor:  pointer.Int32(1),
+					Health: true,
+					Resources: v1.ResourceList{
+						apiext.KoordRDMA: resource.MustParse("100"),
+					},
+				},
+			},
+		},
+	})
+	nodeDeviceInfo := cache.getNodeDevice("test-node")
+	assert.Len(t, nodeDeviceInfo.deviceVFs[schedulingv1alpha1.RDMA][0], 2)
+
+	podRequest := v1.ResourceList{apiext.KoordRDMA: resource.MustParse("1")}
+	allocateVF := func(podName string) *apiext.DeviceAllocation {
+		allocations, err := nodeDeviceInfo.tryAllocateDevice(podRequest.DeepCopy())
+		assert.NoError(t, err)
+		assert.Len(t, allocations[schedulingv1alpha1.RDMA], 1)
+		pod := &v1.Pod{}
+		pod.Namespace, pod.Name = "default", podName
+		nodeDeviceInfo.updateCacheUsed(allocations, pod, true)
+		return allocations[schedulingv1alpha1.RDMA][0]
+	}
+
+	// the shared pods are allocated with the dedicated virtual functions of the device
+	for i, expectedBusID := range []string{"0000:1f:00.2", "0000:1f:00.3"} {
+		allocation := allocateVF(fmt.Sprintf("test-pod-%d", i))
+		assert.Equal(t, int32(0), allocation.Minor)
+		extension, err := apiext.GetDeviceAllocationExtension(allocation)
+		assert.NoError(t, err)
+		assert.Equal(t, []schedulingv1alpha1.VirtualFunction{{Minor: int32(i), BusID: expectedBusID}}, extension.VirtualFunctions)
+	}
+	// all the virtual functions are used, so the device without virtual functions is shared
+	allocation := allocateVF("test-pod-2")
+	assert.Equal(t, int32(1), allocation.Minor)
+	assert.Empty(t, allocation.Extension)
+
+	// the whole device request could not be satisfied by the shared devices
+	_, err := nodeDeviceInfo.tryAllocateDevice(v1.ResourceList{apiext.KoordRDMA: resource.MustParse("100")})
+	assert.Error(t, err)
+
+	// the virtual function is released with the pod
+	pod := &v1.Pod{}
+	pod.Namespace, pod.Name = "default", "test-pod-0"
+	nodeDeviceInfo.updateCacheUsed(apiext.DeviceAllocations{
+		schedulingv1alpha1.RDMA: []*apiext.DeviceAllocation{{
+			Minor:     0,
+			Resources: podRequest,
+			Extension: []byte(`{"vfs":[{"minor":0,"busID":"0000:1f:00.2"}]}`),
+		}},
+	}, pod, false)
+	assert.Equal(t, []int{1}, nodeDeviceInfo.vfUsed[schedulingv1alpha1.RDMA][0].List())
+}
+
+func Test_nodeDevice_filterByReservation(t *testing.T) {
+	cache := newNodeDeviceCache()
+	cache.updateNodeDevice("test-node", &schedulingv1alpha1.Device{
+		Spec: schedulingv1alpha1.DeviceSpec{
+			Devices: []schedulingv1alpha1.DeviceInfo{
+				newTestGPUDeviceInfo(0, 0),
+				newTestGPUDeviceInfo(1, 0),
+				newTestGPUDeviceInfo(2, 1),
+				newTestGPUDeviceInfo(3, 1),
+			},
+		},
+	})
+	nodeDeviceInfo := cache.getNodeDevice("test-node")
+	fullGPU := v1.ResourceList{
+		apiext.GPUCore:        resource.MustParse("100"),
+		apiext.GPUMemoryRatio: resource.MustParse("100"),
+		apiext.GPUMemory:      resource.MustParse("16Gi"),
+	}
+	reserved := apiext.DeviceAllocations{
+		schedulingv1alpha1.GPU: []*apiext.DeviceAllocation{
+			{Minor: 2, Resources: fullGPU},
+			{Minor: 3, Resources: fullGPU},
+		},
+	}
+	reservePod := &v1.Pod{}
+	reservePod.Namespace, reservePod.Name = "default", "test-reservation"
+	nodeDeviceInfo.updateCacheUsed(reserved, reservePod, true)
+
+	// the reserved devices are not free for the other pods
+	allocations, err := nodeDeviceInfo.tryAllocateDevice(v1.ResourceList{apiext.GPUCore: resource.MustParse("100"), apiext.GPUMemoryRatio: resource.MustParse("100")})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), allocations[schedulingv1alpha1.GPU][0].Minor)
+
+	// the owner allocates the reserved devices which are not allocated to the other owners
+	owner := &v1.Pod{}
+	owner.Namespace, owner.Name = "default", "test-owner-0"
+	nodeDeviceInfo.updateCacheUsed(apiext.DeviceAllocations{
+		schedulingv1alpha1.GPU: []*apiext.DeviceAllocation{{Minor: 2, Resources: fullGPU}},
+	}, owner, true)
+	filtered := nodeDeviceInfo.filterByReservation(reserved, []v1.ObjectReference{{Namespace: owner.Namespace, Name: owner.Name}})
+	allocations, err = filtered.tryAllocateDevice(v1.ResourceList{apiext.GPUCore: resource.MustParse("100"), apiext.GPUMemoryRatio: resource.MustParse("100")})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), allocations[schedulingv1alpha1.GPU][0].Minor)
+	_, err = filtered.tryAllocateDevice(v1.ResourceList{apiext.GPUCore: resource.MustParse("200"), apiext.GPUMemoryRatio: resource.MustParse("200")})
+	assert.Error(t, err)
+
+	// the original nodeDevice must not be changed
+	assert.Len(t, nodeDeviceInfo.deviceTotal[schedulingv1alpha1.GPU], 4)
+	assert.Len(t, nodeDeviceInfo.deviceUsed[schedulingv1alpha1.GPU], 2)
+}
@@ -346,6 +346,65 @@ func Test_nodeDeviceCache_onDeviceUpdate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "device is unhealthy in status conditions",
+			oldDevice: generateFakeDevice(),
+			newDevice: &schedulingv1alpha1.Device{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node-1",
+				},
+				Spec: schedulingv1alpha1.DeviceSpec{
+					Devices: []schedulingv1alpha1.DeviceInfo{
+						{
+							UUID:   string(uuid.NewUUID()),
+							Minor:  pointer.Int32Ptr(1),
+							Health: true,
+							Type:   schedulingv1alpha1.GPU,
+							Resources: corev1.ResourceList{
+								apiext.GPUCore:        resource.MustParse("100"),
+								apiext.GPUMemoryRatio: resource.MustParse("100"),
+								apiext.GPUMemory:      resource.MustParse("32Gi"),
+							},
+						},
+					},
+				},
+				Status: schedulingv1alpha1.DeviceStatus{
+					Conditions: []schedulingv1alpha1.DeviceConditions{
+						{
+							Minor:     1,
+							Type:      schedulingv1alpha1.GPU,
+							XIDErrors: []int64{79},
+							Conditions: []schedulingv1alpha1.DeviceCondition{
+								{
+									Type:   schedulingv1alpha1.DeviceHealthy,
+									Status: metav1.ConditionFalse,
+									Reason: "CriticalXIDError",
+								},
+							},
+						},
+					},
+				},
+			},
+			deviceCache: &nodeDeviceCache{
+				nodeDeviceInfos: generateFakeNodeDeviceInfos(),
+			},
+			wantCache: map[string]*nodeDevice{
+				"test-node-1": {
+					deviceTotal: map[schedulingv1alpha1.DeviceType]deviceResources{
+						schedulingv1alpha1.GPU: {
+							1: corev1.ResourceList{},
+						},
+					},
+					deviceFree: map[schedulingv1alpha1.DeviceType]deviceResources{
+						schedulingv1alpha1.GPU: {
+							1: corev1.ResourceList{},
+						},
+					},
+					deviceUsed:  map[schedulingv1alpha1.DeviceType]deviceResources{},
+					allocateSet: map[schedulingv1alpha1.DeviceType]map[types.NamespacedName]map[int]corev1.ResourceList{},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
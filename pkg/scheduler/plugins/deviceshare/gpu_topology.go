@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deviceshare
+
+import (
+	"sort"
+
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+// The connection scores between two GPUs, the higher the better.
+const (
+	// gpuLinkCrossNUMA means the GPUs communicate across NUMA Nodes or Sockets.
+	gpuLinkCrossNUMA int64 = 0
+	// gpuLinkSameNUMA means the GPUs are on the same NUMA Node but under different PCIe switches.
+	gpuLinkSameNUMA int64 = 1
+	// gpuLinkSamePCIESwitch means the GPUs are under the same PCIe switch.
+	gpuLinkSamePCIESwitch int64 = 2
+	// gpuLinkNVLink means the GPUs are directly connected by NVLink.
+	gpuLinkNVLink int64 = 3
+)
+
+// gpuLinkScore returns the connection score between two GPUs.
+func gpuLinkScore(a, b *schedulingv1alpha1.DeviceTopology, minorA, minorB int) int64 {
+	if a == nil || b == nil {
+		return gpuLinkCrossNUMA
+	}
+	for _, peer := range a.NVLinks {
+		if int(peer) == minorB {
+			return gpuLinkNVLink
+		}
+	}
+	for _, peer := range b.NVLinks {
+		if int(peer) == minorA {
+			return gpuLinkNVLink
+		}
+	}
+	if a.PCIEID != "" && a.PCIEID == b.PCIEID {
+		return gpuLinkSamePCIESwitch
+	}
+	if a.NodeID >= 0 && a.NodeID == b.NodeID && a.SocketID == b.SocketID {
+		return gpuLinkSameNUMA
+	}
+	return gpuLinkCrossNUMA
+}
+
+func (n *nodeDevice) hasGPUTopology() bool {
+	return len(n.deviceTopologies[schedulingv1alpha1.GPU]) > 0
+}
+
+// gpuTopologyScore returns the sum of the connection scores between each pair of the GPUs.
+func (n *nodeDevice) gpuTopologyScore(minors []int) int64 {
+	topologies := n.deviceTopologies[schedulingv1alpha1.GPU]
+	var score int64
+	for i := 0; i < len(minors); i++ {
+		for j := i + 1; j < len(minors); j++ {
+			score += gpuLinkScore(topologies[minors[i]], topologies[minors[j]], minors[i], minors[j])
+		}
+	}
+	return score
+}
+
+// selectBestConnectedGPUs selects the wanted number of GPUs from the candidates which are sorted by the minor.
+// Starting from each candidate, it greedily adds the GPU best connected to the selected ones,
+// and returns the set with the highest topology score. The GPUs with smaller minors are preferred on ties.
+func (n *nodeDevice) selectBestConnectedGPUs(candidates []int, wanted int) []int {
+	if wanted <= 0 || len(candidates) < wanted {
+		return nil
+	}
+	if len(candidates) == wanted || !n.hasGPUTopology() {
+		return candidates[:wanted]
+	}
+
+	topologies := n.deviceTopologies[schedulingv1alpha1.GPU]
+	var bestSelected []int
+	bestScore := int64(-1)
+	for _, seed := range candidates {
+		selected := []int{seed}
+		chosen := map[int]bool{seed: true}
+		var score int64
+		for len(selected) < wanted {
+			next, nextScore := -1, int64(-1)
+			for _, candidate := range candidates {
+				if chosen[candidate] {
+					continue
+				}
+				var s int64
+				for _, v := range selected {
+					s += gpuLinkScore(topologies[v], topologies[candidate], v, candidate)
+				}
+				if s > nextScore {
+					next, nextScore = candidate, s
+				}
+			}
+			selected = append(selected, next)
+			chosen[next] = true
+			score += nextScore
+		}
+		if score > bestScore {
+			bestSelected, bestScore = selected, score
+		}
+	}
+	sort.Ints(bestSelected)
+	return bestSelected
+}
+
+// scoreGPUTopology normalizes the topology score of the allocated GPUs into [0, MaxNodeScore].
+func (n *nodeDevice) scoreGPUTopology(minors []int) int64 {
+	if len(minors) <= 1 {
+		return framework.MaxNodeScore
+	}
+	maxScore := int64(len(minors)*(len(minors)-1)/2) * gpuLinkNVLink
+	return n.gpuTopologyScore(minors) * framework.MaxNodeScore / maxScore
+}
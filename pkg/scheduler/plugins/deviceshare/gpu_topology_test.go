@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deviceshare
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+// newTestGPUTopologyDevice returns 8 GPUs, GPU 0-3 on NUMA Node 0 and GPU 4-7 on NUMA Node 1.
+// Every two GPUs are under the same PCIe switch, and GPU 1-2, GPU 5-6 are connected by NVLink.
+func newTestGPUTopologyDevice() *schedulingv1alpha1.Device {
+	pcieIDs := []string{"0000:00:01.0", "0000:00:01.0", "0000:00:02.0", "0000:00:02.0", "0000:80:01.0", "0000:80:01.0", "0000:80:02.0", "0000:80:02.0"}
+	nvlinks := map[int32][]int32{1: {2}, 2: {1}, 5: {6}, 6: {5}}
+	device := &schedulingv1alpha1.Device{}
+	for i := int32(0); i < 8; i++ {
+		deviceInfo := newTestGPUDeviceInfo(i, i/4)
+		deviceInfo.Topology.PCIEID = pcieIDs[i]
+		deviceInfo.Topology.NVLinks = nvlinks[i]
+		device.Spec.Devices = append(device.Spec.Devices, deviceInfo)
+	}
+	return device
+}
+
+func Test_gpuLinkScore(t *testing.T) {
+	cache := newNodeDeviceCache()
+	cache.updateNodeDevice("test-node", newTestGPUTopologyDevice())
+	nodeDeviceInfo := cache.getNodeDevice("test-node")
+	topologies := nodeDeviceInfo.deviceTopologies[schedulingv1alpha1.GPU]
+
+	assert.Equal(t, gpuLinkNVLink, gpuLinkScore(topologies[1], topologies[2], 1, 2))
+	assert.Equal(t, gpuLinkSamePCIESwitch, gpuLinkScore(topologies[0], topologies[1], 0, 1))
+	assert.Equal(t, gpuLinkSameNUMA, gpuLinkScore(topologies[0], topologies[3], 0, 3))
+	assert.Equal(t, gpuLinkCrossNUMA, gpuLinkScore(topologies[0], topologies[4], 0, 4))
+	assert.Equal(t, gpuLinkCrossNUMA, gpuLinkScore(nil, topologies[4], 0, 4))
+}
+
+func Test_nodeDevice_selectBestConnectedGPUs(t *testing.T) {
+	cache := newNodeDeviceCache()
+	cache.updateNodeDevice("test-node", newTestGPUTopologyDevice())
+	nodeDeviceInfo := cache.getNodeDevice("test-node")
+
+	tests := []struct {
+		name       string
+		candidates []int
+		wanted     int
+		want       []int
+	}{
+		{
+			name:       "prefer NVLink",
+			candidates: []int{0, 1, 2, 3, 4, 5, 6, 7},
+			wanted:     2,
+			want:       []int{1, 2},
+		},
+		{
+			name:       "prefer the same PCIe switch",
+			candidates: []int{0, 1, 3, 4},
+			wanted:     2,
+			want:       []int{0, 1},
+		},
+		{
+			name:       "prefer the same NUMA Node",
+			candidates: []int{0, 2, 4, 5, 6, 7},
+			wanted:     4,
+			want:       []int{4, 5, 6, 7},
+		},
+		{
+			name:       "not enough candidates",
+			candidates: []int{0},
+			wanted:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nodeDeviceInfo.selectBestConnectedGPUs(tt.candidates, tt.wanted))
+		})
+	}
+
+	// without topology, the GPUs are selected by the minor
+	assert.Equal(t, []int{0, 3}, newNodeDevice().selectBestConnectedGPUs([]int{0, 3, 5}, 2))
+}
+
+func Test_Plugin_ScoreWithGPUTopology(t *testing.T) {
+	cache := newNodeDeviceCache()
+	cache.updateNodeDevice("test-node-topology", newTestGPUTopologyDevice())
+	device := newTestGPUTopologyDevice()
+	for i := range device.Spec.Devices {
+		device.Spec.Devices[i].Topology = nil
+	}
+	cache.updateNodeDevice("test-node-no-topology", device)
+
+	p := &Plugin{nodeDeviceCache: cache, allocator: &defaultAllocator{}}
+	tests := []struct {
+		name     string
+		nodeName string
+		request  corev1.ResourceList
+		want     int64
+	}{
+		{
+			name:     "NVLink connected GPUs",
+			nodeName: "test-node-topology",
+			request: corev1.ResourceList{
+				apiext.GPUCore:        resource.MustParse("200"),
+				apiext.GPUMemoryRatio: resource.MustParse("200"),
+			},
+			want: framework.MaxNodeScore,
+		},
+		{
+			name:     "GPUs in the same NUMA Node",
+			nodeName: "test-node-topology",
+			request: corev1.ResourceList{
+				apiext.GPUCore:        resource.MustParse("400"),
+				apiext.GPUMemoryRatio: resource.MustParse("400"),
+			},
+			// 1 NVLink, 2 PCIe switches and 3 same NUMA Node links
+			want: (3 + 2*2 + 3*1) * framework.MaxNodeScore / (6 * gpuLinkNVLink),
+		},
+		{
+			name:     "node without topology",
+			nodeName: "test-node-no-topology",
+			request: corev1.ResourceList{
+				apiext.GPUCore:        resource.MustParse("200"),
+				apiext.GPUMemoryRatio: resource.MustParse("200"),
+			},
+			want: 0,
+		},
+		{
+			name:     "single GPU",
+			nodeName: "test-node-topology",
+			request: corev1.ResourceList{
+				apiext.GPUCore:        resource.MustParse("100"),
+				apiext.GPUMemoryRatio: resource.MustParse("100"),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cycleState := framework.NewCycleState()
+			cycleState.Write(stateKey, &preFilterState{convertedDeviceResource: tt.request})
+			score, status := p.Score(context.TODO(), cycleState, &corev1.Pod{}, tt.nodeName)
+			assert.True(t, status.IsSuccess())
+			assert.Equal(t, tt.want, score)
+		})
+	}
+}
@@ -21,9 +21,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/api/v1/resource"
@@ -33,6 +31,7 @@ import (
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/topologymanager"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
@@ -48,6 +47,9 @@ const (
 
 	// ErrInsufficientDevices when node can't satisfy Pod's requested resource.
 	ErrInsufficientDevices = "Insufficient Devices"
+
+	// ErrUnalignedDevices when node can't allocate devices on the same NUMA Node as other resources.
+	ErrUnalignedDevices = "node(s) cannot align devices on the same NUMA Node"
 )
 
 type Plugin struct {
@@ -59,6 +61,7 @@ type Plugin struct {
 var (
 	_ framework.PreFilterPlugin = &Plugin{}
 	_ framework.FilterPlugin    = &Plugin{}
+	_ framework.ScorePlugin     = &Plugin{}
 	_ framework.ReservePlugin   = &Plugin{}
 	_ framework.PreBindPlugin   = &Plugin{}
 )
@@ -157,14 +160,99 @@ func (p *Plugin) Filter(ctx context.Context, cycleState *framework.CycleState, p
 	nodeDeviceInfo.lock.RLock()
 	defer nodeDeviceInfo.lock.RUnlock()
 
-	allocateResult, err := p.allocator.Allocate(nodeInfo.Node().Name, pod, podRequest, nodeDeviceInfo)
-	if len(allocateResult) != 0 && err == nil {
-		return nil
+	// the Pod can allocate the free devices, or the devices reserved by any reservation on the node that the Pod matches
+	nodeDevices := []*nodeDevice{nodeDeviceInfo}
+	for _, reservation := range frameworkext.GetMatchedReservations(cycleState, nodeInfo.Node().Name) {
+		if reservedNodeDevice := filterReservedNodeDevice(nodeDeviceInfo, reservation, pod); reservedNodeDevice != nil {
+			nodeDevices = append(nodeDevices, reservedNodeDevice)
+		}
+	}
+
+	// narrow the feasible NUMA Nodes if the node requires the resources aligned on a single NUMA Node
+	if topologyState := topologymanager.GetState(cycleState); topologyState != nil {
+		if feasibleNUMANodes, ok := topologyState.GetFeasibleNUMANodes(nodeInfo.Node().Name); ok {
+			var alignedNUMANodes []int
+			for _, numaNode := range feasibleNUMANodes {
+				for _, nd := range nodeDevices {
+					allocateResult, err := p.allocator.Allocate(nodeInfo.Node().Name, pod, podRequest, nd.filterByNUMANodes([]int{numaNode}))
+					if len(allocateResult) != 0 && err == nil {
+						alignedNUMANodes = append(alignedNUMANodes, numaNode)
+						break
+					}
+				}
+			}
+			if len(alignedNUMANodes) == 0 {
+				return framework.NewStatus(framework.Unschedulable, ErrUnalignedDevices)
+			}
+			topologyState.SetFeasibleNUMANodes(nodeInfo.Node().Name, alignedNUMANodes)
+			return nil
+		}
+	}
+
+	for _, nd := range nodeDevices {
+		allocateResult, err := p.allocator.Allocate(nodeInfo.Node().Name, pod, podRequest, nd)
+		if len(allocateResult) != 0 && err == nil {
+			return nil
+		}
 	}
 
 	return framework.NewStatus(framework.Unschedulable, ErrInsufficientDevices)
 }
 
+// filterReservedNodeDevice returns a view of the devices reserved by the reservation which the Pod can allocate,
+// or nil if the reservation does not reserve any device.
+func filterReservedNodeDevice(nodeDeviceInfo *nodeDevice, reservation *schedulingv1alpha1.Reservation, pod *corev1.Pod) *nodeDevice {
+	reserved, err := apiext.GetDeviceAllocations(reservation.Annotations)
+	if err != nil || len(reserved) == 0 {
+		return nil
+	}
+	var owners []corev1.ObjectReference
+	for _, owner := range reservation.Status.CurrentOwners {
+		if owner.UID != pod.UID {
+			owners = append(owners, owner)
+		}
+	}
+	return nodeDeviceInfo.filterByReservation(reserved, owners)
+}
+
+// Score prefers the nodes on which the multiple GPUs requested by the Pod can be allocated with better topology,
+// e.g. connected by NVLink or under the same PCIe switch.
+func (p *Plugin) Score(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, nodeName string) (int64, *framework.Status) {
+	state, status := getPreFilterState(cycleState)
+	if !status.IsSuccess() {
+		return 0, status
+	}
+	if state.skip || !hasDeviceResource(state.convertedDeviceResource, schedulingv1alpha1.GPU) ||
+		!isMultipleGPUPod(state.convertedDeviceResource) {
+		return 0, nil
+	}
+
+	nodeDeviceInfo := p.nodeDeviceCache.getNodeDevice(nodeName)
+	if nodeDeviceInfo == nil {
+		return 0, nil
+	}
+
+	nodeDeviceInfo.lock.RLock()
+	defer nodeDeviceInfo.lock.RUnlock()
+
+	if !nodeDeviceInfo.hasGPUTopology() {
+		return 0, nil
+	}
+	allocateResult, err := p.allocator.Allocate(nodeName, pod, state.convertedDeviceResource, nodeDeviceInfo)
+	if err != nil || len(allocateResult[schedulingv1alpha1.GPU]) == 0 {
+		return 0, nil
+	}
+	minors := make([]int, 0, len(allocateResult[schedulingv1alpha1.GPU]))
+	for _, allocation := range allocateResult[schedulingv1alpha1.GPU] {
+		minors = append(minors, int(allocation.Minor))
+	}
+	return nodeDeviceInfo.scoreGPUTopology(minors), nil
+}
+
+func (p *Plugin) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
+
 func (p *Plugin) Reserve(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, nodeName string) *framework.Status {
 	state, status := getPreFilterState(cycleState)
 	if !status.IsSuccess() {
@@ -184,7 +272,15 @@ func (p *Plugin) Reserve(ctx context.Context, cycleState *framework.CycleState,
 	nodeDeviceInfo.lock.Lock()
 	defer nodeDeviceInfo.lock.Unlock()
 
-	allocateResult, err := p.allocator.Allocate(nodeName, pod, podRequest, nodeDeviceInfo)
+	// the Pod inherits the devices of the reservation nominated by the Reservation plugin if the reservation holds devices
+	allocateFrom := nodeDeviceInfo
+	if reservation := frameworkext.GetNominatedReservation(cycleState, nodeName); reservation != nil {
+		if reservedNodeDevice := filterReservedNodeDevice(nodeDeviceInfo, reservation, pod); reservedNodeDevice != nil {
+			allocateFrom = reservedNodeDevice
+		}
+	}
+
+	allocateResult, err := p.allocateWithNUMAAffinity(cycleState, nodeName, pod, podRequest, allocateFrom)
 	if err != nil || len(allocateResult) == 0 {
 		return framework.NewStatus(framework.Unschedulable, ErrInsufficientDevices)
 	}
@@ -194,6 +290,20 @@ func (p *Plugin) Reserve(ctx context.Context, cycleState *framework.CycleState,
 	return nil
 }
 
+// allocateWithNUMAAffinity allocates the devices on the NUMA Nodes allocated to the Pod by other plugins, e.g. NodeNUMAResource.
+// If the NUMA affinity is not required, it falls back to allocate the devices on any NUMA Node.
+func (p *Plugin) allocateWithNUMAAffinity(cycleState *framework.CycleState, nodeName string, pod *corev1.Pod, podRequest corev1.ResourceList, nodeDeviceInfo *nodeDevice) (apiext.DeviceAllocations, error) {
+	if topologyState := topologymanager.GetState(cycleState); topologyState != nil {
+		if affinity, ok := topologyState.GetAffinity(nodeName); ok && len(affinity.NUMANodes) > 0 {
+			allocateResult, err := p.allocator.Allocate(nodeName, pod, podRequest, nodeDeviceInfo.filterByNUMANodes(affinity.NUMANodes))
+			if (err == nil && len(allocateResult) != 0) || affinity.Required {
+				return allocateResult, err
+			}
+		}
+	}
+	return p.allocator.Allocate(nodeName, pod, podRequest, nodeDeviceInfo)
+}
+
 func (p *Plugin) Unreserve(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, nodeName string) {
 	state, status := getPreFilterState(cycleState)
 	if !status.IsSuccess() {
@@ -239,14 +349,10 @@ func (p *Plugin) PreBind(ctx context.Context, cycleState *framework.CycleState,
 	// 	patchContainerGPUResource(newPod, podRequest)
 	// }
 
-	patchBytes, err := util.GeneratePodPatch(pod, newPod)
-	if err != nil {
-		return framework.NewStatus(framework.Error, err.Error())
-	}
-	err = util.RetryOnConflictOrTooManyRequests(func() error {
-		_, podErr := p.handle.ClientSet().CoreV1().Pods(pod.Namespace).
-			Patch(ctx, pod.Name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
-		return podErr
+	// patch pod or reservation (if the pod is a reserve pod) with new annotations
+	err := util.RetryOnConflictOrTooManyRequests(func() error {
+		_, err1 := util.NewPatch().WithHandle(p.handle).AddAnnotations(newPod.Annotations).PatchPodOrReservation(pod)
+		return err1
 	})
 	if err != nil {
 		return framework.NewStatus(framework.Error, err.Error())
@@ -277,6 +383,7 @@ func New(obj runtime.Object, handle framework.Handle) (framework.Plugin, error)
 	deviceCache := newNodeDeviceCache()
 	registerDeviceEventHandler(deviceCache, extendedHandle.KoordinatorSharedInformerFactory())
 	registerPodEventHandler(deviceCache, handle.SharedInformerFactory())
+	registerReservationEventHandler(deviceCache, extendedHandle.KoordinatorSharedInformerFactory())
 
 	allocatorOpts := AllocatorOptions{
 		SharedInformerFactory:      extendedHandle.SharedInformerFactory(),
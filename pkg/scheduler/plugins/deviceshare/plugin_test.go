@@ -46,6 +46,8 @@ import (
 	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/topologymanager"
+	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
 type fakeExtendedHandle struct {
@@ -1513,6 +1515,108 @@ func Test_Plugin_Reserve(t *testing.T) {
 	}
 }
 
+func Test_Plugin_FilterAndReserveWithNUMAAffinity(t *testing.T) {
+	testNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+		},
+	}
+	testNodeInfo := &framework.NodeInfo{}
+	testNodeInfo.SetNode(testNode)
+	tests := []struct {
+		name                  string
+		feasibleNUMANodes     []int
+		affinity              *topologymanager.NUMAAffinity
+		wantFilter            *framework.Status
+		wantFeasibleNUMANodes []int
+		wantReserve           *framework.Status
+		wantMinor             int32
+	}{
+		{
+			name:                  "narrow the feasible NUMA Nodes",
+			feasibleNUMANodes:     []int{0, 1},
+			wantFeasibleNUMANodes: []int{1},
+			affinity:              &topologymanager.NUMAAffinity{NUMANodes: []int{1}, Required: true},
+			wantMinor:             1,
+		},
+		{
+			name:              "cannot align devices on the feasible NUMA Nodes",
+			feasibleNUMANodes: []int{0},
+			wantFilter:        framework.NewStatus(framework.Unschedulable, ErrUnalignedDevices),
+		},
+		{
+			name:        "required NUMA affinity cannot be satisfied",
+			affinity:    &topologymanager.NUMAAffinity{NUMANodes: []int{0}, Required: true},
+			wantReserve: framework.NewStatus(framework.Unschedulable, ErrInsufficientDevices),
+		},
+		{
+			name:      "fallback if NUMA affinity is preferred",
+			affinity:  &topologymanager.NUMAAffinity{NUMANodes: []int{0}},
+			wantMinor: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newNodeDeviceCache()
+			cache.updateNodeDevice("test-node", &schedulingv1alpha1.Device{
+				Spec: schedulingv1alpha1.DeviceSpec{
+					Devices: []schedulingv1alpha1.DeviceInfo{
+						newTestGPUDeviceInfo(0, 0),
+						newTestGPUDeviceInfo(1, 1),
+					},
+				},
+			})
+			// GPU 0 is fully used
+			cache.getNodeDevice("test-node").updateCacheUsed(apiext.DeviceAllocations{
+				schedulingv1alpha1.GPU: {
+					{
+						Minor: 0,
+						Resources: corev1.ResourceList{
+							apiext.GPUCore:        resource.MustParse("100"),
+							apiext.GPUMemoryRatio: resource.MustParse("100"),
+							apiext.GPUMemory:      resource.MustParse("16Gi"),
+						},
+					},
+				},
+			}, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "used-pod"}}, true)
+
+			p := &Plugin{nodeDeviceCache: cache, allocator: &defaultAllocator{}}
+			state := &preFilterState{
+				skip: false,
+				convertedDeviceResource: corev1.ResourceList{
+					apiext.GPUCore:        resource.MustParse("100"),
+					apiext.GPUMemoryRatio: resource.MustParse("100"),
+				},
+			}
+			cycleState := framework.NewCycleState()
+			cycleState.Write(stateKey, state)
+			topologyState := topologymanager.InitState(cycleState)
+			if tt.feasibleNUMANodes != nil {
+				topologyState.SetFeasibleNUMANodes("test-node", tt.feasibleNUMANodes)
+			}
+
+			status := p.Filter(context.TODO(), cycleState, &corev1.Pod{}, testNodeInfo)
+			assert.Equal(t, tt.wantFilter, status)
+			if !status.IsSuccess() {
+				return
+			}
+			feasibleNUMANodes, _ := topologyState.GetFeasibleNUMANodes("test-node")
+			assert.Equal(t, tt.wantFeasibleNUMANodes, feasibleNUMANodes)
+
+			if tt.affinity != nil {
+				topologyState.SetAffinity("test-node", *tt.affinity)
+			}
+			status = p.Reserve(context.TODO(), cycleState, &corev1.Pod{}, "test-node")
+			assert.Equal(t, tt.wantReserve, status)
+			if !status.IsSuccess() {
+				return
+			}
+			assert.Len(t, state.allocationResult[schedulingv1alpha1.GPU], 1)
+			assert.Equal(t, tt.wantMinor, state.allocationResult[schedulingv1alpha1.GPU][0].Minor)
+		})
+	}
+}
+
 func sortDeviceAllocations(deviceAllocations apiext.DeviceAllocations) {
 	for k, v := range deviceAllocations {
 		sort.Slice(v, func(i, j int) bool {
@@ -1878,15 +1982,43 @@ func Test_Plugin_PreBind(t *testing.T) {
 			},
 		},
 	}
+	testReservation := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:  "234567890",
+			Name: "test-reservation",
+		},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{
+				Spec: testPod.Spec,
+			},
+		},
+	}
+	koordClientSet := koordfake.NewSimpleClientset(testReservation)
+	extendHandle, _ := frameworkext.NewExtendedHandle(
+		frameworkext.WithKoordinatorClientSet(koordClientSet),
+	)
+	testAllocationResult := apiext.DeviceAllocations{
+		schedulingv1alpha1.GPU: {
+			{
+				Minor: 0,
+				Resources: corev1.ResourceList{
+					apiext.GPUCore:        resource.MustParse("100"),
+					apiext.GPUMemoryRatio: resource.MustParse("100"),
+					apiext.GPUMemory:      resource.MustParse("16Gi"),
+				},
+			},
+		},
+	}
 	type args struct {
 		state *preFilterState
 		pod   *corev1.Pod
 	}
 	tests := []struct {
-		name       string
-		args       args
-		handle     frameworkext.ExtendedHandle
-		wantStatus *framework.Status
+		name            string
+		args            args
+		handle          frameworkext.ExtendedHandle
+		wantStatus      *framework.Status
+		wantReservation *schedulingv1alpha1.Reservation
 	}{
 		{
 			name: "empty state",
@@ -1937,7 +2069,19 @@ func Test_Plugin_PreBind(t *testing.T) {
 					},
 				},
 			},
-			handle: &fakeExtendedHandle{cs: kubefake.NewSimpleClientset(testPod)},
+			handle: &fakeExtendedHandle{ExtendedHandle: extendHandle, cs: kubefake.NewSimpleClientset(testPod)},
+		},
+		{
+			name: "pre-bind reserve pod successfully",
+			args: args{
+				pod: util.NewReservePod(testReservation),
+				state: &preFilterState{
+					skip:             false,
+					allocationResult: testAllocationResult,
+				},
+			},
+			handle:          &fakeExtendedHandle{ExtendedHandle: extendHandle, cs: kubefake.NewSimpleClientset()},
+			wantReservation: testReservation,
 		},
 	}
 	for _, tt := range tests {
@@ -1949,6 +2093,13 @@ func Test_Plugin_PreBind(t *testing.T) {
 			}
 			status := p.PreBind(context.TODO(), cycleState, tt.args.pod, "test-node")
 			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantReservation != nil {
+				r, err := koordClientSet.SchedulingV1alpha1().Reservations().Get(context.TODO(), tt.wantReservation.Name, metav1.GetOptions{})
+				assert.NoError(t, err)
+				allocations, err := apiext.GetDeviceAllocations(r.Annotations)
+				assert.NoError(t, err)
+				assert.Equal(t, testAllocationResult, allocations)
+			}
 		})
 	}
 }
@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deviceshare
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+// registerReservationEventHandler keeps the devices allocated to the available reservations in the cache,
+// so that the devices are held for the owners of the reservations. The devices are recorded with the reserve pod,
+// which is also allocated in Reserve.
+func registerReservationEventHandler(deviceCache *nodeDeviceCache, koordSharedInformerFactory koordinatorinformers.SharedInformerFactory) {
+	reservationInformer := koordSharedInformerFactory.Scheduling().V1alpha1().Reservations().Informer()
+	eventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    deviceCache.onReservationAdd,
+		UpdateFunc: deviceCache.onReservationUpdate,
+		DeleteFunc: deviceCache.onReservationDelete,
+	}
+	frameworkexthelper.ForceSyncFromInformer(context.TODO().Done(), koordSharedInformerFactory, reservationInformer, eventHandler)
+}
+
+func (n *nodeDeviceCache) onReservationAdd(obj interface{}) {
+	r, ok := obj.(*schedulingv1alpha1.Reservation)
+	if !ok {
+		klog.Errorf("reservation cache add failed to parse, obj %T", obj)
+		return
+	}
+	n.updateReservation(r)
+}
+
+func (n *nodeDeviceCache) onReservationUpdate(oldObj, newObj interface{}) {
+	r, ok := newObj.(*schedulingv1alpha1.Reservation)
+	if !ok {
+		klog.Errorf("reservation cache update failed to parse, obj %T", newObj)
+		return
+	}
+	n.updateReservation(r)
+}
+
+func (n *nodeDeviceCache) onReservationDelete(obj interface{}) {
+	var r *schedulingv1alpha1.Reservation
+	switch t := obj.(type) {
+	case *schedulingv1alpha1.Reservation:
+		r = t
+	case cache.DeletedFinalStateUnknown:
+		var ok bool
+		r, ok = t.Obj.(*schedulingv1alpha1.Reservation)
+		if !ok {
+			klog.V(5).Infof("reservation cache remove failed to parse, obj %T", obj)
+			return
+		}
+	default:
+		return
+	}
+	n.updateReservationCacheUsed(r, false)
+}
+
+func (n *nodeDeviceCache) updateReservation(r *schedulingv1alpha1.Reservation) {
+	// the reserve pod of a pending reservation may have been allocated in Reserve, so only release the devices of
+	// the reservations which will not be allocated any more
+	if util.IsReservationFailed(r) || util.IsReservationSucceeded(r) {
+		n.updateReservationCacheUsed(r, false)
+		return
+	}
+	if util.IsReservationAvailable(r) {
+		n.updateReservationCacheUsed(r, true)
+	}
+}
+
+func (n *nodeDeviceCache) updateReservationCacheUsed(r *schedulingv1alpha1.Reservation, add bool) {
+	nodeName := util.GetReservationNodeName(r)
+	if nodeName == "" {
+		return
+	}
+	devicesAllocation, err := apiext.GetDeviceAllocations(r.Annotations)
+	if err != nil {
+		klog.Errorf("failed to get device allocation from reservation %v, err: %v", klog.KObj(r), err)
+		return
+	}
+	if len(devicesAllocation) == 0 {
+		return
+	}
+
+	info := n.getNodeDevice(nodeName)
+	if info == nil {
+		if !add {
+			return
+		}
+		info = n.createNodeDevice(nodeName)
+		klog.V(5).Infof("node device cache not found, nodeName: %v, reservation: %v, createNodeDevice", nodeName, klog.KObj(r))
+	}
+
+	info.lock.Lock()
+	defer info.lock.Unlock()
+
+	info.updateCacheUsed(devicesAllocation, util.NewReservePod(r), add)
+	klog.V(5).InfoS("reservation cache updated", "reservation", klog.KObj(r), "add", add)
+}
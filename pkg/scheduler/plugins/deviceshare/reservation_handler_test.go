@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deviceshare
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/client-go/tools/cache"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+func Test_nodeDeviceCache_onReservationEvents(t *testing.T) {
+	allocations := apiext.DeviceAllocations{
+		schedulingv1alpha1.GPU: []*apiext.DeviceAllocation{
+			{
+				Minor: 0,
+				Resources: corev1.ResourceList{
+					apiext.GPUCore:        resource.MustParse("100"),
+					apiext.GPUMemoryRatio: resource.MustParse("100"),
+					apiext.GPUMemory:      resource.MustParse("16Gi"),
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(allocations)
+	assert.NoError(t, err)
+	reservation := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-reservation",
+			UID:  "123456",
+			Annotations: map[string]string{
+				apiext.AnnotationDeviceAllocated: string(data),
+			},
+		},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{},
+		},
+		Status: schedulingv1alpha1.ReservationStatus{
+			Phase:    schedulingv1alpha1.ReservationAvailable,
+			NodeName: "test-node",
+		},
+	}
+	reservePodKey := types.NamespacedName{Namespace: "default", Name: string(reservation.UID)}
+
+	deviceCache := newNodeDeviceCache()
+	deviceCache.onReservationAdd(&corev1.Node{})
+	assert.Nil(t, deviceCache.getNodeDevice("test-node"))
+
+	deviceCache.onReservationAdd(reservation)
+	info := deviceCache.getNodeDevice("test-node")
+	assert.NotNil(t, info)
+	assert.Len(t, info.allocateSet[schedulingv1alpha1.GPU][reservePodKey], 1)
+	assert.True(t, quotav1.Equals(allocations[schedulingv1alpha1.GPU][0].Resources, info.deviceUsed[schedulingv1alpha1.GPU][0]))
+
+	succeeded := reservation.DeepCopy()
+	succeeded.Status.Phase = schedulingv1alpha1.ReservationSucceeded
+	deviceCache.onReservationUpdate(reservation, succeeded)
+	assert.Empty(t, info.allocateSet[schedulingv1alpha1.GPU][reservePodKey])
+	assert.Empty(t, info.deviceUsed[schedulingv1alpha1.GPU][0])
+
+	deviceCache.onReservationUpdate(succeeded, reservation)
+	assert.Len(t, info.allocateSet[schedulingv1alpha1.GPU][reservePodKey], 1)
+	deviceCache.onReservationDelete(cache.DeletedFinalStateUnknown{Obj: reservation})
+	assert.Empty(t, info.allocateSet[schedulingv1alpha1.GPU][reservePodKey])
+	assert.Empty(t, info.deviceUsed[schedulingv1alpha1.GPU][0])
+}
@@ -197,6 +197,20 @@ func isMultipleCommonDevicePod(podRequest corev1.ResourceList, deviceType schedu
 	}
 }
 
+// isSharedCommonDevicePod returns whether the pod requests a part of a device, e.g. 50 of koordinator.sh/rdma.
+func isSharedCommonDevicePod(podRequest corev1.ResourceList, deviceType schedulingv1alpha1.DeviceType) bool {
+	var commonDevice resource.Quantity
+	switch deviceType {
+	case schedulingv1alpha1.RDMA:
+		commonDevice = podRequest[apiext.KoordRDMA]
+	case schedulingv1alpha1.FPGA:
+		commonDevice = podRequest[apiext.KoordFPGA]
+	default:
+		return false
+	}
+	return commonDevice.Value() > 0 && commonDevice.Value() < 100
+}
+
 func isMultipleGPUPod(podRequest corev1.ResourceList) bool {
 	if podRequest == nil || len(podRequest) == 0 {
 		klog.Warningf("pod request should not be empty")
@@ -0,0 +1,443 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/coscheduling/util"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/elasticquota/core"
+)
+
+// AdmissionJob is an entry of the admission queue of a quotaGroup. The pending pods of a gang are queued together
+// as one job, so that the gang is admitted as a whole instead of being interleaved with the jobs behind it.
+type AdmissionJob struct {
+	// Position is the 1-based position of the job in the admission queue, the head of the queue is at 1.
+	Position          int         `json:"position"`
+	Name              string      `json:"name"`
+	IsGang            bool        `json:"isGang,omitempty"`
+	Pods              []string    `json:"pods"`
+	Priority          int32       `json:"priority"`
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+	// Request is the resource reserved for the job, which is the request of the queued pods, or the request of the
+	// members still missing to reach the min member of the gang if it is larger.
+	Request corev1.ResourceList `json:"request"`
+}
+
+type admissionJobKey struct {
+	name   string
+	isGang bool
+}
+
+func getAdmissionJobKey(pod *corev1.Pod) admissionJobKey {
+	if gangName := util.GetGangNameByPod(pod); gangName != "" {
+		return admissionJobKey{name: util.GetId(pod.Namespace, gangName), isGang: true}
+	}
+	return admissionJobKey{name: pod.Namespace + "/" + pod.Name}
+}
+
+func getAdmissionPodKey(pod *corev1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
+
+// admissionJob is the job queued in the admissionQueues, which holds the queued pods of the job.
+type admissionJob struct {
+	key               admissionJobKey
+	priority          int32
+	creationTimestamp metav1.Time
+	// minMember is the min member of the gang declared on its pods, only the gangs declaring it by the annotation
+	// or label of the pods reserve the request of the missing members.
+	minMember   int
+	pods        map[string]*corev1.Pod
+	podRequests map[string]corev1.ResourceList
+}
+
+func newAdmissionJob(pod *corev1.Pod) *admissionJob {
+	return &admissionJob{
+		key:               getAdmissionJobKey(pod),
+		priority:          corev1helpers.PodPriority(pod),
+		creationTimestamp: pod.CreationTimestamp,
+		pods:              map[string]*corev1.Pod{},
+		podRequests:       map[string]corev1.ResourceList{},
+	}
+}
+
+// refresh recalculates the order of the job with its pods, the gang is queued by its most important and earliest member.
+func (j *admissionJob) refresh() {
+	first := true
+	j.minMember = 0
+	for _, pod := range j.pods {
+		priority := corev1helpers.PodPriority(pod)
+		if first || priority > j.priority {
+			j.priority = priority
+		}
+		if first || pod.CreationTimestamp.Before(&j.creationTimestamp) {
+			j.creationTimestamp = pod.CreationTimestamp
+		}
+		first = false
+		if minMember, err := util.GetGangMinNumFromPod(pod); err == nil && minMember > j.minMember {
+			j.minMember = minMember
+		}
+	}
+}
+
+type admissionPodJob struct {
+	quotaName string
+	key       admissionJobKey
+}
+
+// admissionQueues maintains the admission queues of the quotaGroups incrementally. A pod joins the admission queue of
+// its quotaGroup once it is refused by the quota check, and leaves the queue once it passes the quota check, is
+// assigned or deleted, so the pods unschedulable for other reasons, e.g. the node affinity, never block the queue.
+type admissionQueues struct {
+	lock sync.RWMutex
+	less func(a, b *admissionJob) bool
+	// queues stores the jobs of each quotaGroup sorted by less
+	queues map[string][]*admissionJob
+	jobs   map[string]map[admissionJobKey]*admissionJob
+	// podJobs stores the quotaGroup and the job each queued pod is queued in
+	podJobs map[string]admissionPodJob
+	// assignedGangMembers stores the assigned pods of each gang, which don't need to be reserved any more
+	assignedGangMembers map[string]sets.String
+}
+
+func newAdmissionQueues(less func(a, b *admissionJob) bool) *admissionQueues {
+	return &admissionQueues{
+		less:                less,
+		queues:              map[string][]*admissionJob{},
+		jobs:                map[string]map[admissionJobKey]*admissionJob{},
+		podJobs:             map[string]admissionPodJob{},
+		assignedGangMembers: map[string]sets.String{},
+	}
+}
+
+// searchNoLock returns the index where the job would be in the sorted queue.
+func (q *admissionQueues) searchNoLock(queue []*admissionJob, job *admissionJob) int {
+	return sort.Search(len(queue), func(i int) bool {
+		return !q.less(queue[i], job)
+	})
+}
+
+func (q *admissionQueues) removeJobNoLock(quotaName string, job *admissionJob) {
+	queue := q.queues[quotaName]
+	for i := q.searchNoLock(queue, job); i < len(queue); i++ {
+		if queue[i] == job {
+			queue = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+	if len(queue) == 0 {
+		delete(q.queues, quotaName)
+		return
+	}
+	q.queues[quotaName] = queue
+}
+
+func (q *admissionQueues) insertJobNoLock(quotaName string, job *admissionJob) {
+	queue := q.queues[quotaName]
+	i := q.searchNoLock(queue, job)
+	queue = append(queue, nil)
+	copy(queue[i+1:], queue[i:])
+	queue[i] = job
+	q.queues[quotaName] = queue
+}
+
+// enqueue adds the pod refused by the quota check into the admission queue of the quotaGroup.
+func (q *admissionQueues) enqueue(quotaName string, pod *corev1.Pod, podRequest corev1.ResourceList) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	podKey := getAdmissionPodKey(pod)
+	key := getAdmissionJobKey(pod)
+	if podJob, ok := q.podJobs[podKey]; ok && (podJob.quotaName != quotaName || podJob.key != key) {
+		q.dequeueNoLock(pod)
+	}
+	if q.jobs[quotaName] == nil {
+		q.jobs[quotaName] = map[admissionJobKey]*admissionJob{}
+	}
+	job := q.jobs[quotaName][key]
+	if job == nil {
+		job = newAdmissionJob(pod)
+		q.jobs[quotaName][key] = job
+	} else {
+		q.removeJobNoLock(quotaName, job)
+	}
+	job.pods[podKey] = pod
+	job.podRequests[podKey] = podRequest.DeepCopy()
+	job.refresh()
+	q.insertJobNoLock(quotaName, job)
+	q.podJobs[podKey] = admissionPodJob{quotaName: quotaName, key: key}
+}
+
+// dequeue removes the pod from the admission queue it is queued in.
+func (q *admissionQueues) dequeue(pod *corev1.Pod) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.dequeueNoLock(pod)
+}
+
+func (q *admissionQueues) dequeueNoLock(pod *corev1.Pod) {
+	podKey := getAdmissionPodKey(pod)
+	podJob, ok := q.podJobs[podKey]
+	if !ok {
+		return
+	}
+	delete(q.podJobs, podKey)
+	quotaName, key := podJob.quotaName, podJob.key
+	job := q.jobs[quotaName][key]
+	if job == nil {
+		return
+	}
+	q.removeJobNoLock(quotaName, job)
+	delete(job.pods, podKey)
+	delete(job.podRequests, podKey)
+	if len(job.pods) == 0 {
+		delete(q.jobs[quotaName], key)
+		if len(q.jobs[quotaName]) == 0 {
+			delete(q.jobs, quotaName)
+		}
+		return
+	}
+	job.refresh()
+	q.insertJobNoLock(quotaName, job)
+}
+
+// updateAssigned records whether the member of the gang is assigned, and removes the assigned pod from the queue.
+func (q *admissionQueues) updateAssigned(pod *corev1.Pod, assigned bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if assigned {
+		q.dequeueNoLock(pod)
+	}
+	key := getAdmissionJobKey(pod)
+	if !key.isGang {
+		return
+	}
+	podKey := getAdmissionPodKey(pod)
+	if assigned {
+		if q.assignedGangMembers[key.name] == nil {
+			q.assignedGangMembers[key.name] = sets.NewString()
+		}
+		q.assignedGangMembers[key.name].Insert(podKey)
+		return
+	}
+	if members := q.assignedGangMembers[key.name]; members != nil {
+		members.Delete(podKey)
+		if members.Len() == 0 {
+			delete(q.assignedGangMembers, key.name)
+		}
+	}
+}
+
+// getReservedRequestNoLock returns the resource reserved for the job. A gang reserves the request of its missing
+// members to reach the min member, which is estimated with its largest queued member.
+func (q *admissionQueues) getReservedRequestNoLock(job *admissionJob) corev1.ResourceList {
+	request := corev1.ResourceList{}
+	var memberRequest corev1.ResourceList
+	for _, podRequest := range job.podRequests {
+		request = quotav1.Add(request, podRequest)
+		memberRequest = quotav1.Max(memberRequest, podRequest)
+	}
+	if !job.key.isGang || job.minMember <= 0 {
+		return request
+	}
+	missing := job.minMember - q.assignedGangMembers[job.key.name].Len()
+	return quotav1.Max(request, multiplyResourceList(memberRequest, missing))
+}
+
+func (q *admissionQueues) toAdmissionJobNoLock(job *admissionJob, position int) *AdmissionJob {
+	admissionJob := &AdmissionJob{
+		Position:          position,
+		Name:              job.key.name,
+		IsGang:            job.key.isGang,
+		Priority:          job.priority,
+		CreationTimestamp: job.creationTimestamp,
+		Request:           q.getReservedRequestNoLock(job),
+	}
+	for _, pod := range job.pods {
+		admissionJob.Pods = append(admissionJob.Pods, pod.Name)
+	}
+	sort.Strings(admissionJob.Pods)
+	return admissionJob
+}
+
+// getQueue returns the jobs in the admission queue of the quotaGroup.
+func (q *admissionQueues) getQueue(quotaName string) []*AdmissionJob {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+
+	queue := q.queues[quotaName]
+	jobs := make([]*AdmissionJob, 0, len(queue))
+	for i, job := range queue {
+		jobs = append(jobs, q.toAdmissionJobNoLock(job, i+1))
+	}
+	return jobs
+}
+
+// getJobsAhead returns the jobs queued ahead of the job of the pod in the admission queue of the quotaGroup. The pod
+// not queued yet is placed by the order of its own job.
+func (q *admissionQueues) getJobsAhead(quotaName string, pod *corev1.Pod) []*AdmissionJob {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+
+	queue := q.queues[quotaName]
+	if len(queue) == 0 {
+		return nil
+	}
+	job := q.jobs[quotaName][getAdmissionJobKey(pod)]
+	if job == nil {
+		job = newAdmissionJob(pod)
+	}
+	n := q.searchNoLock(queue, job)
+	jobs := make([]*AdmissionJob, 0, n)
+	for i := 0; i < n; i++ {
+		jobs = append(jobs, q.toAdmissionJobNoLock(queue[i], i+1))
+	}
+	return jobs
+}
+
+func multiplyResourceList(resourceList corev1.ResourceList, n int) corev1.ResourceList {
+	result := corev1.ResourceList{}
+	if n <= 0 {
+		return result
+	}
+	for name, quantity := range resourceList {
+		result[name] = *resource.NewMilliQuantity(quantity.MilliValue()*int64(n), quantity.Format)
+	}
+	return result
+}
+
+func (g *Plugin) isAdmissionQueueEnabled() bool {
+	policy := g.pluginArgs.AdmissionQueuePolicy
+	return policy != "" && policy != config.AdmissionQueuePolicyNone
+}
+
+func (g *Plugin) lessAdmissionJob(a, b *admissionJob) bool {
+	if g.pluginArgs.AdmissionQueuePolicy == config.AdmissionQueuePolicyPriority && a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	if !a.creationTimestamp.Equal(&b.creationTimestamp) {
+		return a.creationTimestamp.Before(&b.creationTimestamp)
+	}
+	if a.key.name != b.key.name {
+		return a.key.name < b.key.name
+	}
+	return a.key.isGang && !b.key.isGang
+}
+
+// enqueueAdmission adds the pod refused by the quota check into the admission queue of its quotaGroup.
+func (g *Plugin) enqueueAdmission(quotaName string, pod *corev1.Pod, podRequest corev1.ResourceList) {
+	if !g.isAdmissionQueueEnabled() {
+		return
+	}
+	g.admissionQueues.enqueue(quotaName, pod, podRequest)
+	klog.V(5).InfoS("Pod is queued in the admission queue", "pod", klog.KObj(pod), "quotaName", quotaName)
+}
+
+// dequeueAdmission removes the pod from the admission queue it is queued in.
+func (g *Plugin) dequeueAdmission(pod *corev1.Pod) {
+	if !g.isAdmissionQueueEnabled() {
+		return
+	}
+	g.admissionQueues.dequeue(pod)
+}
+
+// updateAdmissionAssigned records whether the pod is assigned, the assigned pod leaves the admission queue.
+func (g *Plugin) updateAdmissionAssigned(pod *corev1.Pod, assigned bool) {
+	if !g.isAdmissionQueueEnabled() {
+		return
+	}
+	g.admissionQueues.updateAssigned(pod, assigned)
+}
+
+// checkAdmissionQueue admits the pod if no job of its quotaGroup refused by the quota is queued ahead of it. Without
+// backfill, the pod behind the head is admitted only if the quota is enough for all the jobs ahead of it, so it never
+// overtakes them. With backfill, the pod is admitted as long as the quota left in the dimensions it requests is still
+// enough for the head, so it cannot delay the head. The jobs requesting more than the max of the quotaGroup, e.g. a gang
+// whose min member can never fit, are never admitted, so they don't block the jobs behind them.
+func (g *Plugin) checkAdmissionQueue(quotaInfo *core.QuotaInfo, pod *corev1.Pod, podRequest corev1.ResourceList) *framework.Status {
+	if !g.isAdmissionQueueEnabled() {
+		return framework.NewStatus(framework.Success, "")
+	}
+	jobsAhead := filterAdmissibleJobs(g.admissionQueues.getJobsAhead(quotaInfo.Name, pod), quotaInfo.GetMax())
+	if len(jobsAhead) == 0 {
+		return framework.NewStatus(framework.Success, "")
+	}
+
+	var reserved corev1.ResourceList
+	var resourceNames []corev1.ResourceName
+	if g.pluginArgs.EnableAdmissionBackfill != nil && *g.pluginArgs.EnableAdmissionBackfill {
+		reserved = jobsAhead[0].Request
+		resourceNames = quotav1.ResourceNames(quotav1.RemoveZeros(podRequest))
+	} else {
+		for _, job := range jobsAhead {
+			reserved = quotav1.Add(reserved, job.Request)
+		}
+		resourceNames = quotav1.ResourceNames(quotav1.Add(reserved, podRequest))
+	}
+	quotaUsed := quotaInfo.GetUsed()
+	quotaRuntime := quotaInfo.GetRuntime()
+	newUsed := quotav1.Mask(quotav1.Add(quotav1.Add(quotaUsed, reserved), podRequest), resourceNames)
+	if isLessEqual, exceedDimensions := quotav1.LessThanOrEqual(newUsed, quotav1.Mask(quotaRuntime, resourceNames)); !isLessEqual {
+		return framework.NewStatus(framework.Unschedulable, fmt.Sprintf("Scheduling refused due to the admission queue, "+
+			"quotaName: %v, position: %v, head: %v, runtime: %v, used: %v, reserved for the jobs ahead: %v, exceedDimensions: %v",
+			quotaInfo.Name, len(jobsAhead)+1, jobsAhead[0].Name, printResourceList(quotaRuntime), printResourceList(quotaUsed),
+			printResourceList(reserved), exceedDimensions))
+	}
+	return framework.NewStatus(framework.Success, "")
+}
+
+// filterAdmissibleJobs returns the jobs whose request fits in the max of the quotaGroup.
+func filterAdmissibleJobs(jobs []*AdmissionJob, max corev1.ResourceList) []*AdmissionJob {
+	resourceNames := quotav1.ResourceNames(max)
+	admissibleJobs := make([]*AdmissionJob, 0, len(jobs))
+	for _, job := range jobs {
+		if isLessEqual, _ := quotav1.LessThanOrEqual(quotav1.Mask(job.Request, resourceNames), max); !isLessEqual {
+			klog.V(5).InfoS("Skip the job requesting more than the max of the quota in the admission queue",
+				"job", job.Name, "request", printResourceList(job.Request), "max", printResourceList(max))
+			continue
+		}
+		admissibleJobs = append(admissibleJobs, job)
+	}
+	return admissibleJobs
+}
+
+// GetAdmissionQueue returns the admission queue of the quotaGroup.
+func (g *Plugin) GetAdmissionQueue(quotaName string) ([]*AdmissionJob, bool) {
+	quotaInfo := g.groupQuotaManager.GetQuotaInfoByName(quotaName)
+	if quotaInfo == nil {
+		return nil, false
+	}
+	if !g.isAdmissionQueueEnabled() {
+		return []*AdmissionJob{}, true
+	}
+	return g.admissionQueues.getQueue(quotaName), true
+}
@@ -0,0 +1,368 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+)
+
+func createPendingPod(name, quotaName, gangName string, priority int32, createdAt int, cpu, mem int64) *corev1.Pod {
+	pod := defaultCreatePodWithQuotaNameAndVersion(name, quotaName, "1", priority, cpu, mem)
+	pod.Namespace = "ns"
+	pod.Spec.NodeName = ""
+	pod.Status.Phase = corev1.PodPending
+	pod.CreationTimestamp = metav1.NewTime(time.Unix(int64(createdAt), 0))
+	if gangName != "" {
+		pod.Annotations = map[string]string{extension.AnnotationGangName: gangName}
+	}
+	return pod
+}
+
+func TestPlugin_GetAdmissionQueue(t *testing.T) {
+	pods := []*corev1.Pod{
+		createPendingPod("pod-1", "test1", "", 0, 1, 1, 10),
+		createPendingPod("pod-2", "test1", "", 10, 2, 2, 20),
+		createPendingPod("gang-1", "test1", "gang", 0, 3, 3, 30),
+		createPendingPod("gang-2", "test1", "gang", 5, 0, 4, 40),
+	}
+	assignedPod := createPendingPod("pod-0", "test1", "", 100, 0, 5, 50)
+	assignedPod.Spec.NodeName = "test-node"
+
+	tests := []struct {
+		name      string
+		policy    config.ElasticQuotaAdmissionQueuePolicy
+		wantQueue []*AdmissionJob
+	}{
+		{
+			name:      "admission queue is disabled",
+			policy:    config.AdmissionQueuePolicyNone,
+			wantQueue: []*AdmissionJob{},
+		},
+		{
+			name:   "FIFO",
+			policy: config.AdmissionQueuePolicyFIFO,
+			wantQueue: []*AdmissionJob{
+				{Position: 1, Name: "ns/gang", IsGang: true, Pods: []string{"gang-1", "gang-2"}, Priority: 5,
+					CreationTimestamp: metav1.NewTime(time.Unix(0, 0)), Request: createResourceList(7, 70)},
+				{Position: 2, Name: "ns/pod-1", Pods: []string{"pod-1"}, Priority: 0,
+					CreationTimestamp: metav1.NewTime(time.Unix(1, 0)), Request: createResourceList(1, 10)},
+				{Position: 3, Name: "ns/pod-2", Pods: []string{"pod-2"}, Priority: 10,
+					CreationTimestamp: metav1.NewTime(time.Unix(2, 0)), Request: createResourceList(2, 20)},
+			},
+		},
+		{
+			name:   "Priority",
+			policy: config.AdmissionQueuePolicyPriority,
+			wantQueue: []*AdmissionJob{
+				{Position: 1, Name: "ns/pod-2", Pods: []string{"pod-2"}, Priority: 10,
+					CreationTimestamp: metav1.NewTime(time.Unix(2, 0)), Request: createResourceList(2, 20)},
+				{Position: 2, Name: "ns/gang", IsGang: true, Pods: []string{"gang-1", "gang-2"}, Priority: 5,
+					CreationTimestamp: metav1.NewTime(time.Unix(0, 0)), Request: createResourceList(7, 70)},
+				{Position: 3, Name: "ns/pod-1", Pods: []string{"pod-1"}, Priority: 0,
+					CreationTimestamp: metav1.NewTime(time.Unix(1, 0)), Request: createResourceList(1, 10)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suit := newPluginTestSuit(t, nil)
+			p, err := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+			assert.NoError(t, err)
+			gp := p.(*Plugin)
+			gp.pluginArgs.AdmissionQueuePolicy = tt.policy
+			gp.OnQuotaAdd(CreateQuota2("test1", extension.RootQuotaName, 100, 1000, 10, 100, 1, 1, false))
+			gp.OnPodAdd(assignedPod)
+			for _, pod := range pods {
+				gp.OnPodAdd(pod)
+			}
+			// only the pods refused by the quota are queued
+			qi := gp.groupQuotaManager.GetQuotaInfoByName("test1")
+			qi.Lock()
+			qi.CalculateInfo.Runtime = createResourceList(0, 0)
+			qi.UnLock()
+			for _, pod := range pods {
+				assert.False(t, gp.PreFilter(context.TODO(), framework.NewCycleState(), pod).IsSuccess())
+			}
+
+			queue, exist := gp.GetAdmissionQueue("test1")
+			assert.True(t, exist)
+			assert.Equal(t, len(tt.wantQueue), len(queue))
+			for i := range tt.wantQueue {
+				want, got := tt.wantQueue[i], queue[i]
+				assert.Equal(t, want.Position, got.Position)
+				assert.Equal(t, want.Name, got.Name)
+				assert.Equal(t, want.IsGang, got.IsGang)
+				assert.Equal(t, want.Pods, got.Pods)
+				assert.Equal(t, want.Priority, got.Priority)
+				assert.True(t, want.CreationTimestamp.Equal(&got.CreationTimestamp))
+				assert.True(t, quotav1.Equals(want.Request, got.Request), "want %v, got %v", want.Request, got.Request)
+			}
+
+			_, exist = gp.GetAdmissionQueue("not-exist")
+			assert.False(t, exist)
+		})
+	}
+}
+
+func TestPlugin_PreFilterAdmissionQueue(t *testing.T) {
+	// The head asks for more memory than the runtime, and the small pods queue behind it.
+	head := createPendingPod("head", "test1", "", 0, 1, 10, 100)
+	cpuOnly := createPendingPod("cpu-only", "test1", "", 0, 2, 5, 0)
+	small := createPendingPod("small", "test1", "", 0, 3, 1, 10)
+
+	tests := []struct {
+		name            string
+		policy          config.ElasticQuotaAdmissionQueuePolicy
+		backfill        bool
+		runtime         corev1.ResourceList
+		pod             *corev1.Pod
+		wantCode        framework.Code
+		wantMsgContains string
+	}{
+		{
+			name:     "admission queue is disabled",
+			policy:   config.AdmissionQueuePolicyNone,
+			runtime:  createResourceList(20, 50),
+			pod:      small,
+			wantCode: framework.Success,
+		},
+		{
+			name:            "head is refused by the quota only",
+			policy:          config.AdmissionQueuePolicyFIFO,
+			runtime:         createResourceList(20, 50),
+			pod:             head,
+			wantCode:        framework.Unschedulable,
+			wantMsgContains: "insufficient quotas",
+		},
+		{
+			name:            "pod can't overtake the head",
+			policy:          config.AdmissionQueuePolicyFIFO,
+			runtime:         createResourceList(20, 50),
+			pod:             cpuOnly,
+			wantCode:        framework.Unschedulable,
+			wantMsgContains: "position: 2, head: ns/head",
+		},
+		{
+			name:     "pod is admitted when the quota is enough for all the jobs ahead",
+			policy:   config.AdmissionQueuePolicyFIFO,
+			runtime:  createResourceList(20, 200),
+			pod:      small,
+			wantCode: framework.Success,
+		},
+		{
+			name:     "backfill the pod which doesn't delay the head",
+			policy:   config.AdmissionQueuePolicyFIFO,
+			backfill: true,
+			runtime:  createResourceList(20, 50),
+			pod:      cpuOnly,
+			wantCode: framework.Success,
+		},
+		{
+			name:            "don't backfill the pod which needs the resource the head waits for",
+			policy:          config.AdmissionQueuePolicyFIFO,
+			backfill:        true,
+			runtime:         createResourceList(20, 50),
+			pod:             small,
+			wantCode:        framework.Unschedulable,
+			wantMsgContains: "position: 3, head: ns/head",
+		},
+		{
+			name:            "don't backfill the pod which leaves too little for the head",
+			policy:          config.AdmissionQueuePolicyFIFO,
+			backfill:        true,
+			runtime:         createResourceList(12, 50),
+			pod:             cpuOnly,
+			wantCode:        framework.Unschedulable,
+			wantMsgContains: "exceedDimensions: [cpu]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suit := newPluginTestSuit(t, nil)
+			p, err := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+			assert.NoError(t, err)
+			gp := p.(*Plugin)
+			gp.pluginArgs.AdmissionQueuePolicy = tt.policy
+			gp.pluginArgs.EnableAdmissionBackfill = pointer.Bool(tt.backfill)
+			gp.OnQuotaAdd(CreateQuota2("test1", extension.RootQuotaName, 100, 1000, 10, 100, 1, 1, false))
+			for _, pod := range []*corev1.Pod{head, cpuOnly, small} {
+				gp.OnPodAdd(pod)
+			}
+			// all the pods are refused by the quota and queued at first
+			qi := gp.groupQuotaManager.GetQuotaInfoByName("test1")
+			qi.Lock()
+			qi.CalculateInfo.Runtime = createResourceList(0, 0)
+			qi.UnLock()
+			for _, pod := range []*corev1.Pod{head, cpuOnly, small} {
+				gp.PreFilter(context.TODO(), framework.NewCycleState(), pod)
+			}
+			qi.Lock()
+			qi.CalculateInfo.Runtime = tt.runtime.DeepCopy()
+			qi.UnLock()
+
+			status := gp.PreFilter(context.TODO(), framework.NewCycleState(), tt.pod)
+			assert.Equal(t, tt.wantCode, status.Code(), status.Message())
+			assert.Contains(t, status.Message(), tt.wantMsgContains)
+			// The pods waiting in the admission queue don't preempt others either.
+			queued := strings.Contains(status.Message(), "admission queue")
+			assert.Equal(t, !queued, gp.podEligibleToPreemptOthers(tt.pod, nil, nil, nil))
+		})
+	}
+}
+
+func TestPlugin_AdmissionQueueUpdate(t *testing.T) {
+	suit := newPluginTestSuit(t, nil)
+	p, err := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+	assert.NoError(t, err)
+	gp := p.(*Plugin)
+	gp.pluginArgs.AdmissionQueuePolicy = config.AdmissionQueuePolicyFIFO
+	gp.pluginArgs.EnableAdmissionBackfill = pointer.Bool(false)
+	gp.OnQuotaAdd(CreateQuota2("test1", extension.RootQuotaName, 100, 1000, 10, 100, 1, 1, false))
+	qi := gp.groupQuotaManager.GetQuotaInfoByName("test1")
+	setRuntime := func(runtime corev1.ResourceList) {
+		qi.Lock()
+		qi.CalculateInfo.Runtime = runtime.DeepCopy()
+		qi.UnLock()
+	}
+	getQueuedJobs := func() []string {
+		queue, _ := gp.GetAdmissionQueue("test1")
+		var names []string
+		for _, job := range queue {
+			names = append(names, job.Name)
+		}
+		return names
+	}
+
+	// the head is unschedulable for reasons other than the quota, so it is never queued and never blocks the others
+	affinity := createPendingPod("affinity", "test1", "", 0, 1, 10, 100)
+	later := createPendingPod("later", "test1", "", 0, 2, 10, 100)
+	for _, pod := range []*corev1.Pod{affinity, later} {
+		gp.OnPodAdd(pod)
+	}
+	setRuntime(createResourceList(10, 100))
+	assert.True(t, gp.PreFilter(context.TODO(), framework.NewCycleState(), affinity).IsSuccess())
+	assert.True(t, gp.PreFilter(context.TODO(), framework.NewCycleState(), later).IsSuccess())
+	assert.Nil(t, getQueuedJobs())
+
+	// the pod refused by the quota is queued, and leaves the queue once it passes the quota check
+	setRuntime(createResourceList(5, 50))
+	assert.False(t, gp.PreFilter(context.TODO(), framework.NewCycleState(), affinity).IsSuccess())
+	assert.Equal(t, []string{"ns/affinity"}, getQueuedJobs())
+	assert.False(t, gp.PreFilter(context.TODO(), framework.NewCycleState(), later).IsSuccess())
+	assert.Equal(t, []string{"ns/affinity", "ns/later"}, getQueuedJobs())
+	setRuntime(createResourceList(10, 100))
+	assert.True(t, gp.PreFilter(context.TODO(), framework.NewCycleState(), affinity).IsSuccess())
+	assert.Equal(t, []string{"ns/later"}, getQueuedJobs())
+
+	// the assigned and deleted pods leave the queue
+	setRuntime(createResourceList(5, 50))
+	assert.False(t, gp.PreFilter(context.TODO(), framework.NewCycleState(), affinity).IsSuccess())
+	assert.Equal(t, []string{"ns/affinity", "ns/later"}, getQueuedJobs())
+	assignedPod := affinity.DeepCopy()
+	assignedPod.ResourceVersion = "2"
+	assignedPod.Spec.NodeName = "test-node"
+	gp.OnPodUpdate(affinity, assignedPod)
+	assert.Equal(t, []string{"ns/later"}, getQueuedJobs())
+	gp.OnPodDelete(later)
+	assert.Nil(t, getQueuedJobs())
+}
+
+func TestPlugin_AdmissionQueueGangMinMember(t *testing.T) {
+	suit := newPluginTestSuit(t, nil)
+	p, err := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+	assert.NoError(t, err)
+	gp := p.(*Plugin)
+	gp.pluginArgs.AdmissionQueuePolicy = config.AdmissionQueuePolicyFIFO
+	gp.OnQuotaAdd(CreateQuota2("test1", extension.RootQuotaName, 100, 1000, 10, 100, 1, 1, false))
+	qi := gp.groupQuotaManager.GetQuotaInfoByName("test1")
+	qi.Lock()
+	qi.CalculateInfo.Runtime = createResourceList(1, 10)
+	qi.UnLock()
+
+	var members []*corev1.Pod
+	for _, name := range []string{"gang-1", "gang-2", "gang-3"} {
+		pod := createPendingPod(name, "test1", "gang", 0, 1, 2, 20)
+		pod.Annotations[extension.AnnotationGangMinNum] = "4"
+		members = append(members, pod)
+		gp.OnPodAdd(pod)
+	}
+	// only two members are created yet, the gang reserves the request of all its 4 min members
+	for _, pod := range members[:2] {
+		assert.False(t, gp.PreFilter(context.TODO(), framework.NewCycleState(), pod).IsSuccess())
+	}
+	queue, _ := gp.GetAdmissionQueue("test1")
+	assert.Equal(t, 1, len(queue))
+	assert.Equal(t, []string{"gang-1", "gang-2"}, queue[0].Pods)
+	assert.True(t, quotav1.Equals(createResourceList(8, 80), queue[0].Request), queue[0].Request)
+
+	// the assigned members don't need to be reserved any more
+	assert.Equal(t, framework.NewStatus(framework.Success, ""), gp.Reserve(context.TODO(), framework.NewCycleState(), members[2], "test-node"))
+	queue, _ = gp.GetAdmissionQueue("test1")
+	assert.True(t, quotav1.Equals(createResourceList(6, 60), queue[0].Request), queue[0].Request)
+	gp.Unreserve(context.TODO(), framework.NewCycleState(), members[2], "test-node")
+	queue, _ = gp.GetAdmissionQueue("test1")
+	assert.True(t, quotav1.Equals(createResourceList(8, 80), queue[0].Request), queue[0].Request)
+}
+
+func TestPlugin_AdmissionQueueSkipJobsOverMax(t *testing.T) {
+	suit := newPluginTestSuit(t, nil)
+	p, err := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+	assert.NoError(t, err)
+	gp := p.(*Plugin)
+	gp.pluginArgs.AdmissionQueuePolicy = config.AdmissionQueuePolicyFIFO
+	gp.pluginArgs.EnableAdmissionBackfill = pointer.Bool(false)
+	gp.OnQuotaAdd(CreateQuota2("test1", extension.RootQuotaName, 100, 1000, 10, 100, 1, 1, false))
+	qi := gp.groupQuotaManager.GetQuotaInfoByName("test1")
+	setRuntime := func(runtime corev1.ResourceList) {
+		qi.Lock()
+		qi.CalculateInfo.Runtime = runtime.DeepCopy()
+		qi.UnLock()
+	}
+
+	// the head requests more than the max, and the gang can never reach its min member within the max
+	head := createPendingPod("head", "test1", "", 0, 1, 200, 100)
+	gangMember := createPendingPod("gang-1", "test1", "gang", 0, 2, 30, 10)
+	gangMember.Annotations[extension.AnnotationGangMinNum] = "4"
+	small := createPendingPod("small", "test1", "", 0, 3, 1, 10)
+	for _, pod := range []*corev1.Pod{head, gangMember, small} {
+		gp.OnPodAdd(pod)
+	}
+	setRuntime(createResourceList(0, 0))
+	for _, pod := range []*corev1.Pod{head, gangMember, small} {
+		assert.False(t, gp.PreFilter(context.TODO(), framework.NewCycleState(), pod).IsSuccess())
+	}
+	queue, _ := gp.GetAdmissionQueue("test1")
+	assert.Equal(t, 3, len(queue))
+
+	// the jobs which can never be admitted don't block the jobs behind them
+	setRuntime(createResourceList(20, 50))
+	status := gp.PreFilter(context.TODO(), framework.NewCycleState(), small)
+	assert.True(t, status.IsSuccess(), status.Message())
+}
@@ -19,6 +19,8 @@ package elasticquota
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"sort"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
@@ -99,11 +101,12 @@ func (ctrl *Controller) syncHandler() []error {
 
 	for _, eq := range eqList {
 		func() {
-			used, request, runtime, err := ctrl.groupQuotaManager.GetQuotaInformationForSyncHandler(eq.Name)
+			status, err := ctrl.groupQuotaManager.GetQuotaStatusForSyncHandler(eq.Name)
 			if err != nil {
 				errors = append(errors, err)
 				return
 			}
+			used, request, runtime := status.Used, status.Request, status.Runtime
 
 			var oriRuntime, oriRequest v1.ResourceList
 			if eq.Annotations[extension.AnnotationRequest] != "" {
@@ -118,10 +121,22 @@ func (ctrl *Controller) syncHandler() []error {
 					return
 				}
 			}
-			// Ignore this loop if the runtime/request/used doesn't change
+			// overwrite the status if it is broken
+			oriStatus, err := extension.GetQuotaStatus(eq)
+			if err != nil {
+				klog.V(4).ErrorS(err, "Failed to parse the status of elastic quota", "elasticQuota", eq.Name)
+			}
+			updateQuotaStatusConditions(status, oriStatus, metav1.Now())
+			statusBytes, err := json.Marshal(status)
+			if err != nil {
+				errors = append(errors, err)
+				return
+			}
+			// Ignore this loop if the runtime/request/used/status doesn't change
 			if quotav1.Equals(quotav1.RemoveZeros(eq.Status.Used), quotav1.RemoveZeros(used)) &&
 				quotav1.Equals(quotav1.RemoveZeros(oriRuntime), quotav1.RemoveZeros(runtime)) &&
-				quotav1.Equals(quotav1.RemoveZeros(oriRequest), quotav1.RemoveZeros(request)) {
+				quotav1.Equals(quotav1.RemoveZeros(oriRequest), quotav1.RemoveZeros(request)) &&
+				eq.Annotations[extension.AnnotationQuotaStatus] == string(statusBytes) {
 				return
 			}
 			newEQ := eq.DeepCopy()
@@ -140,11 +155,13 @@ func (ctrl *Controller) syncHandler() []error {
 			}
 			newEQ.Annotations[extension.AnnotationRuntime] = string(runtimeBytes)
 			newEQ.Annotations[extension.AnnotationRequest] = string(requestBytes)
+			newEQ.Annotations[extension.AnnotationQuotaStatus] = string(statusBytes)
 			newEQ.Status.Used = used
 
-			klog.V(5).Infof("quota:%v, oldUsed:%v, newUsed:%v, oldRuntime:%v, newRuntime:%v, oldRequest:%v, newRequest:%v",
+			klog.V(5).Infof("quota:%v, oldUsed:%v, newUsed:%v, oldRuntime:%v, newRuntime:%v, oldRequest:%v, newRequest:%v, oldStatus:%v, newStatus:%v",
 				eq.Name, eq.Status.Used, used, eq.Annotations[extension.AnnotationRuntime], string(runtimeBytes),
-				eq.Annotations[extension.AnnotationRequest], string(requestBytes))
+				eq.Annotations[extension.AnnotationRequest], string(requestBytes),
+				eq.Annotations[extension.AnnotationQuotaStatus], string(statusBytes))
 
 			patch, err := util.CreateMergePatch(eq, newEQ)
 			if err != nil {
@@ -165,3 +182,64 @@ func (ctrl *Controller) syncHandler() []error {
 	}
 	return errors
 }
+
+// updateQuotaStatusConditions sets the OverUsed and Starved conditions of the status. The LastTransitionTime of
+// a condition is kept from the old status if the condition status doesn't change.
+func updateQuotaStatusConditions(status, oldStatus *extension.ElasticQuotaStatus, now metav1.Time) {
+	overUsed := newQuotaCondition(extension.ElasticQuotaOverUsed, getExceedDimensions(status.Used, status.Runtime),
+		"UsedExceedsRuntime", "used exceeds runtime", "UsedWithinRuntime")
+	starved := newQuotaCondition(extension.ElasticQuotaStarved, getStarvedDimensions(status.Request, status.Min, status.Runtime),
+		"RuntimeBelowMin", "runtime is less than the request within min", "RuntimeSatisfied")
+
+	status.Conditions = nil
+	for _, condition := range []*extension.ElasticQuotaCondition{overUsed, starved} {
+		condition.LastTransitionTime = now
+		if oldCondition := oldStatus.GetQuotaCondition(condition.Type); oldCondition != nil && oldCondition.Status == condition.Status {
+			condition.LastTransitionTime = oldCondition.LastTransitionTime
+		}
+		status.Conditions = append(status.Conditions, *condition)
+	}
+
+	status.OverUsedSince = nil
+	if overUsed.Status == v1.ConditionTrue {
+		overUsedSince := overUsed.LastTransitionTime
+		status.OverUsedSince = &overUsedSince
+	}
+}
+
+func newQuotaCondition(conditionType extension.ElasticQuotaConditionType, dimensions []v1.ResourceName,
+	trueReason, trueMessage, falseReason string) *extension.ElasticQuotaCondition {
+	if len(dimensions) == 0 {
+		return &extension.ElasticQuotaCondition{
+			Type:   conditionType,
+			Status: v1.ConditionFalse,
+			Reason: falseReason,
+		}
+	}
+	return &extension.ElasticQuotaCondition{
+		Type:    conditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  trueReason,
+		Message: fmt.Sprintf("%s in resource dimensions: %v", trueMessage, dimensions),
+	}
+}
+
+// getExceedDimensions returns the sorted resource dimensions in which used exceeds limit.
+func getExceedDimensions(used, limit v1.ResourceList) []v1.ResourceName {
+	_, dimensions := quotav1.LessThanOrEqual(used, limit)
+	sort.Slice(dimensions, func(i, j int) bool { return dimensions[i] < dimensions[j] })
+	return dimensions
+}
+
+// getStarvedDimensions returns the sorted resource dimensions in which runtime is less than the request within min.
+func getStarvedDimensions(request, min, runtime v1.ResourceList) []v1.ResourceName {
+	guaranteedRequest := v1.ResourceList{}
+	for name, minQuantity := range min {
+		requestQuantity := request[name]
+		if requestQuantity.Cmp(minQuantity) > 0 {
+			requestQuantity = minQuantity
+		}
+		guaranteedRequest[name] = requestQuantity
+	}
+	return getExceedDimensions(guaranteedRequest, runtime)
+}
@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -216,6 +217,147 @@ func TestController_Run(t *testing.T) {
 	}
 }
 
+func TestController_SyncQuotaStatus(t *testing.T) {
+	ctx := context.TODO()
+	suit := newPluginTestSuitWithPod(t, nil, nil)
+	p := suit.plugin.(*Plugin)
+	p.groupQuotaManager.UpdateClusterTotalResource(MakeResourceList().CPU(100).Mem(100).Obj())
+	ctrl := NewElasticQuotaController(p.client, p.quotaLister, p.groupQuotaManager)
+	eq := MakeEQ("t1-ns1", "t1-ns1").
+		Min(MakeResourceList().CPU(3).Mem(5).Obj()).
+		Max(MakeResourceList().CPU(5).Mem(15).Obj()).Obj()
+	_, err := suit.client.SchedulingV1alpha1().ElasticQuotas(eq.Namespace).Create(ctx, eq, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	pod := MakePod("t1-ns1", "pod1").Phase(v1.PodPending).Label(extension.LabelQuotaName, "t1-ns1").
+		Container(MakeResourceList().CPU(1).Mem(2).Obj()).UID("pod1").Obj()
+
+	for i := 0; i < 10 && p.groupQuotaManager.GetQuotaInfoByName(eq.Name) == nil; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	p.OnPodAdd(pod)
+
+	assert.Empty(t, ctrl.syncHandler())
+	get, err := suit.client.SchedulingV1alpha1().ElasticQuotas(eq.Namespace).Get(ctx, eq.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	status, err := extension.GetQuotaStatus(get)
+	assert.NoError(t, err)
+	assert.NotNil(t, status)
+	assert.True(t, quotav1.Equals(MakeResourceList().CPU(1).Mem(2).Obj(), status.Request))
+	assert.True(t, quotav1.Equals(MakeResourceList().CPU(1).Mem(2).Obj(), status.Runtime))
+	assert.True(t, quotav1.Equals(MakeResourceList().CPU(3).Mem(5).Obj(), status.Min))
+	assert.True(t, quotav1.Equals(MakeResourceList().CPU(5).Mem(15).Obj(), status.Max))
+	assert.Equal(t, v1.ConditionFalse, status.GetQuotaCondition(extension.ElasticQuotaOverUsed).Status)
+	assert.Equal(t, v1.ConditionFalse, status.GetQuotaCondition(extension.ElasticQuotaStarved).Status)
+	assert.Nil(t, status.OverUsedSince)
+}
+
+func TestUpdateQuotaStatusConditions(t *testing.T) {
+	lastTime := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	tests := []struct {
+		name              string
+		status            *extension.ElasticQuotaStatus
+		oldStatus         *extension.ElasticQuotaStatus
+		wantOverUsed      extension.ElasticQuotaCondition
+		wantStarved       extension.ElasticQuotaCondition
+		wantOverUsedSince *metav1.Time
+	}{
+		{
+			name: "neither over used nor starved",
+			status: &extension.ElasticQuotaStatus{
+				Used:    MakeResourceList().CPU(5).Mem(5).Obj(),
+				Request: MakeResourceList().CPU(20).Mem(20).Obj(),
+				Runtime: MakeResourceList().CPU(10).Mem(10).Obj(),
+				Min:     MakeResourceList().CPU(10).Mem(10).Obj(),
+			},
+			wantOverUsed: extension.ElasticQuotaCondition{
+				Type:               extension.ElasticQuotaOverUsed,
+				Status:             v1.ConditionFalse,
+				LastTransitionTime: now,
+				Reason:             "UsedWithinRuntime",
+			},
+			wantStarved: extension.ElasticQuotaCondition{
+				Type:               extension.ElasticQuotaStarved,
+				Status:             v1.ConditionFalse,
+				LastTransitionTime: now,
+				Reason:             "RuntimeSatisfied",
+			},
+		},
+		{
+			name: "over used and starved",
+			status: &extension.ElasticQuotaStatus{
+				Used:    MakeResourceList().CPU(5).Mem(15).Obj(),
+				Request: MakeResourceList().CPU(20).Mem(20).Obj(),
+				Runtime: MakeResourceList().CPU(5).Mem(10).Obj(),
+				Min:     MakeResourceList().CPU(10).Mem(10).Obj(),
+			},
+			oldStatus: &extension.ElasticQuotaStatus{
+				Conditions: []extension.ElasticQuotaCondition{
+					{
+						Type:               extension.ElasticQuotaOverUsed,
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: lastTime,
+					},
+				},
+			},
+			wantOverUsed: extension.ElasticQuotaCondition{
+				Type:               extension.ElasticQuotaOverUsed,
+				Status:             v1.ConditionTrue,
+				LastTransitionTime: now,
+				Reason:             "UsedExceedsRuntime",
+				Message:            "used exceeds runtime in resource dimensions: [memory]",
+			},
+			wantStarved: extension.ElasticQuotaCondition{
+				Type:               extension.ElasticQuotaStarved,
+				Status:             v1.ConditionTrue,
+				LastTransitionTime: now,
+				Reason:             "RuntimeBelowMin",
+				Message:            "runtime is less than the request within min in resource dimensions: [cpu]",
+			},
+			wantOverUsedSince: &now,
+		},
+		{
+			name: "keep the last transition time of over used",
+			status: &extension.ElasticQuotaStatus{
+				Used:    MakeResourceList().CPU(15).Mem(15).Obj(),
+				Request: MakeResourceList().CPU(5).Mem(5).Obj(),
+				Runtime: MakeResourceList().CPU(5).Mem(5).Obj(),
+				Min:     MakeResourceList().CPU(10).Mem(10).Obj(),
+			},
+			oldStatus: &extension.ElasticQuotaStatus{
+				Conditions: []extension.ElasticQuotaCondition{
+					{
+						Type:               extension.ElasticQuotaOverUsed,
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: lastTime,
+					},
+				},
+			},
+			wantOverUsed: extension.ElasticQuotaCondition{
+				Type:               extension.ElasticQuotaOverUsed,
+				Status:             v1.ConditionTrue,
+				LastTransitionTime: lastTime,
+				Reason:             "UsedExceedsRuntime",
+				Message:            "used exceeds runtime in resource dimensions: [cpu memory]",
+			},
+			wantStarved: extension.ElasticQuotaCondition{
+				Type:               extension.ElasticQuotaStarved,
+				Status:             v1.ConditionFalse,
+				LastTransitionTime: now,
+				Reason:             "RuntimeSatisfied",
+			},
+			wantOverUsedSince: &lastTime,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updateQuotaStatusConditions(tt.status, tt.oldStatus, now)
+			assert.Equal(t, []extension.ElasticQuotaCondition{tt.wantOverUsed, tt.wantStarved}, tt.status.Conditions)
+			assert.Equal(t, tt.wantOverUsedSince, tt.status.OverUsedSince)
+		})
+	}
+}
+
 type eqWrapper struct{ *v1alpha1.ElasticQuota }
 
 func MakeEQ(namespace, name string) *eqWrapper {
@@ -270,6 +412,11 @@ func (p *podWrapper) Label(string1, string2 string) *podWrapper {
 	return p
 }
 
+func (p *podWrapper) NodeSelector(nodeSelector map[string]string) *podWrapper {
+	p.Pod.Spec.NodeSelector = nodeSelector
+	return p
+}
+
 func (p *podWrapper) Phase(phase v1.PodPhase) *podWrapper {
 	p.Pod.Status.Phase = phase
 	return p
@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"math"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// drfGroup stores a childGroup's quotaNodes of all resource dimensions in the Dominant Resource Fairness.
+type drfGroup struct {
+	quotaNodes map[v1.ResourceName]*quotaNode
+	// demand is the resource that the group wants to borrow above its runtimeQuota without the shared resources
+	demand map[v1.ResourceName]float64
+	// borrowed is the shared resource that the group has got
+	borrowed map[v1.ResourceName]float64
+	// speed is how fast the group borrows its demand, it is the weight divided by the dominant share of the demand,
+	// so that the dominant shares of the groups divided by their weights grow equally.
+	speed float64
+}
+
+// dominantResourceRedistributionNoLock distributes the totalResource to the childGroups with the weighted Dominant
+// Resource Fairness. Different from the redistribution of each quotaTree, all resource dimensions are considered
+// together: the resources that a childGroup borrows are proportional to its demand, and the shared resources are
+// distributed by progressive filling to equalize the dominant shares of the childGroups divided by their weights.
+// The weight of a childGroup is the max share of its sharedWeight in the totalResource. Once a resource is used up,
+// the childGroups keep borrowing the other resources, so that no shared resource is left idle.
+func (qtw *RuntimeQuotaCalculator) dominantResourceRedistributionNoLock() {
+	//lock outside
+	totalResource := make(map[v1.ResourceName]float64, len(qtw.resourceKeys))
+	toPartitionResource := make(map[v1.ResourceName]float64, len(qtw.resourceKeys))
+	groups := make(map[string]*drfGroup)
+	for resKey := range qtw.resourceKeys {
+		total := float64(getQuantityValue(*qtw.totalResource.Name(resKey, resource.DecimalSI), resKey))
+		totalResource[resKey] = total
+		toPartitionResource[resKey] = total
+		for quotaName, node := range qtw.quotaTree[resKey].quotaNodes {
+			group, ok := groups[quotaName]
+			if !ok {
+				group = &drfGroup{
+					quotaNodes: make(map[v1.ResourceName]*quotaNode),
+					demand:     make(map[v1.ResourceName]float64),
+					borrowed:   make(map[v1.ResourceName]float64),
+				}
+				groups[quotaName] = group
+			}
+			group.quotaNodes[resKey] = node
+			if node.resetRuntimeQuota() {
+				group.demand[resKey] = float64(node.limitedRequest() - node.runtimeQuota)
+			}
+			toPartitionResource[resKey] -= float64(node.runtimeQuota)
+		}
+	}
+
+	activeGroups := make([]*drfGroup, 0, len(groups))
+	for _, group := range groups {
+		weight, dominantShare := float64(0), float64(0)
+		for resKey, node := range group.quotaNodes {
+			if totalResource[resKey] <= 0 {
+				continue
+			}
+			weight = math.Max(weight, float64(node.sharedWeight)/totalResource[resKey])
+			dominantShare = math.Max(dominantShare, group.demand[resKey]/totalResource[resKey])
+		}
+		if weight > 0 && dominantShare > 0 {
+			group.speed = weight / dominantShare
+			activeGroups = append(activeGroups, group)
+		}
+	}
+
+	for len(activeGroups) > 0 {
+		// all active groups borrow at their speeds, the step stops when any group is satisfied with
+		// any resource or any resource is used up.
+		step := math.MaxFloat64
+		consumption := make(map[v1.ResourceName]float64)
+		for _, group := range activeGroups {
+			for resKey, demand := range group.demand {
+				if !group.isBorrowing(resKey, toPartitionResource) {
+					continue
+				}
+				consumption[resKey] += group.speed * demand
+				step = math.Min(step, (demand-group.borrowed[resKey])/(group.speed*demand))
+			}
+		}
+		for resKey, consumed := range consumption {
+			step = math.Min(step, math.Max(toPartitionResource[resKey], 0)/consumed)
+		}
+
+		needAdjustGroups := activeGroups[:0]
+		for _, group := range activeGroups {
+			stillBorrowing := false
+			for resKey, demand := range group.demand {
+				if !group.isBorrowing(resKey, toPartitionResource) {
+					continue
+				}
+				group.borrowed[resKey] += step * group.speed * demand
+				stillBorrowing = true
+			}
+			if stillBorrowing {
+				needAdjustGroups = append(needAdjustGroups, group)
+			}
+		}
+		for resKey, consumed := range consumption {
+			toPartitionResource[resKey] -= step * consumed
+		}
+		activeGroups = needAdjustGroups
+	}
+
+	for _, group := range groups {
+		for resKey, borrowed := range group.borrowed {
+			// tolerate the floating-point error, e.g. 0.75*80000 may be 59999.99...
+			group.quotaNodes[resKey].runtimeQuota += int64(math.Min(borrowed, group.demand[resKey]) + 1e-6)
+		}
+	}
+}
+
+// isBorrowing returns whether the group still borrows the resource, i.e. the demand is not satisfied and
+// the resource has at least one unit left.
+func (g *drfGroup) isBorrowing(resKey v1.ResourceName, toPartitionResource map[v1.ResourceName]float64) bool {
+	demand := g.demand[resKey]
+	return demand > 0 && g.borrowed[resKey] < demand*(1-1e-9) && toPartitionResource[resKey] >= 1
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+)
+
+func TestRuntimeQuotaCalculator_DominantResourceFairness(t *testing.T) {
+	tests := []struct {
+		name         string
+		sharedWeight []corev1.ResourceList
+		request      []corev1.ResourceList
+		wantRuntime  []corev1.ResourceList
+	}{
+		{
+			name:         "equal weights",
+			sharedWeight: []corev1.ResourceList{createResourceList(100, 100), createResourceList(100, 100)},
+			request:      []corev1.ResourceList{createResourceList(80, 20), createResourceList(45, 80)},
+			// the cpu is used up when both groups get 80% of their demand, then the memory is borrowed
+			wantRuntime: []corev1.ResourceList{createResourceList(64, 20), createResourceList(36, 80)},
+		},
+		{
+			name:         "the weight of test1 is twice of test2",
+			sharedWeight: []corev1.ResourceList{createResourceList(200, 0), createResourceList(100, 0)},
+			request:      []corev1.ResourceList{createResourceList(80, 20), createResourceList(40, 80)},
+			// the cpu is used up when test1 gets all and test2 gets 50% of their demand, then the memory is borrowed
+			wantRuntime: []corev1.ResourceList{createResourceList(80, 20), createResourceList(20, 80)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qtw := createRuntimeQuotaCalculator()
+			qtw.setFairSharePolicy(config.FairSharePolicyDominantResourceFairness)
+			qtw.setClusterTotalResource(createResourceList(100, 100))
+
+			max := createResourceList(100, 100)
+			min := createResourceList(0, 0)
+			test1 := createQuotaInfoWithRes("test1", max, min)
+			updateQuotaInfo(qtw, test1, max, min, tt.sharedWeight[0])
+			test1.CalculateInfo.Request = tt.request[0]
+			qtw.updateOneGroupRequest(test1)
+
+			test2 := createQuotaInfoWithRes("test2", max, min)
+			updateQuotaInfo(qtw, test2, max, min, tt.sharedWeight[1])
+			test2.CalculateInfo.Request = tt.request[1]
+			qtw.updateOneGroupRequest(test2)
+
+			qtw.updateOneGroupRuntimeQuota(test1)
+			qtw.updateOneGroupRuntimeQuota(test2)
+			assert.Equal(t, tt.wantRuntime[0], test1.CalculateInfo.Runtime)
+			assert.Equal(t, tt.wantRuntime[1], test2.CalculateInfo.Runtime)
+		})
+	}
+}
+
+func TestRuntimeQuotaCalculator_DominantResourceFairnessWithMin(t *testing.T) {
+	qtw := createRuntimeQuotaCalculator()
+	qtw.setFairSharePolicy(config.FairSharePolicyDominantResourceFairness)
+	qtw.setClusterTotalResource(createResourceList(100, 1000))
+
+	// test1 gets its min and lends the rest, test2 borrows all the idle resources above its min
+	max := createResourceList(100, 1000)
+	min := createResourceList(50, 500)
+	sharedWeight := createResourceList(1, 1)
+	test1 := createQuotaInfoWithRes("test1", max, min)
+	updateQuotaInfo(qtw, test1, max, min, sharedWeight)
+	test1.CalculateInfo.Request = createResourceList(20, 500)
+	qtw.updateOneGroupRequest(test1)
+
+	test2 := createQuotaInfoWithRes("test2", max, min)
+	updateQuotaInfo(qtw, test2, max, min, sharedWeight)
+	test2.CalculateInfo.Request = createResourceList(100, 1000)
+	qtw.updateOneGroupRequest(test2)
+
+	qtw.updateOneGroupRuntimeQuota(test1)
+	qtw.updateOneGroupRuntimeQuota(test2)
+	assert.Equal(t, createResourceList(20, 500), test1.CalculateInfo.Runtime)
+	// the memory is used up at first, so test2 stops borrowing the cpu
+	assert.Equal(t, createResourceList(80, 500), test2.CalculateInfo.Runtime)
+}
@@ -19,6 +19,7 @@ package core
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 
 	v1 "k8s.io/api/core/v1"
@@ -28,6 +29,7 @@ import (
 	"sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
@@ -49,7 +51,13 @@ type GroupQuotaManager struct {
 	scaleMinQuotaEnabled bool
 	// scaleMinQuotaManager is used when overRootResource
 	scaleMinQuotaManager *ScaleMinQuotaManager
-	once                 sync.Once
+	// fairSharePolicy decides how the runtimeQuotaCalculators share the resources between the childGroups
+	fairSharePolicy config.ElasticQuotaFairSharePolicy
+	// nodeResourceMap stores the labels and allocatable of the nodes, which decide the total resource of each quota tree
+	nodeResourceMap map[string]*nodeResource
+	// treeTotalResource stores the total resource of each independent quota tree, key is the name of the tree root
+	treeTotalResource map[string]v1.ResourceList
+	once              sync.Once
 }
 
 func NewGroupQuotaManager(systemGroupMax, defaultGroupMax v1.ResourceList) *GroupQuotaManager {
@@ -61,6 +69,8 @@ func NewGroupQuotaManager(systemGroupMax, defaultGroupMax v1.ResourceList) *Grou
 		runtimeQuotaCalculatorMap:               make(map[string]*RuntimeQuotaCalculator),
 		quotaTopoNodeMap:                        make(map[string]*QuotaTopoNode),
 		scaleMinQuotaManager:                    NewScaleMinQuotaManager(),
+		nodeResourceMap:                         make(map[string]*nodeResource),
+		treeTotalResource:                       make(map[string]v1.ResourceList),
 	}
 	quotaManager.quotaInfoMap[extension.SystemQuotaName] = NewQuotaInfo(false, true, extension.SystemQuotaName, extension.RootQuotaName)
 	quotaManager.quotaInfoMap[extension.SystemQuotaName].setMaxQuotaNoLock(systemGroupMax)
@@ -79,6 +89,24 @@ func (gqm *GroupQuotaManager) setScaleMinQuotaEnabled(flag bool) {
 	klog.V(5).Infof("Set ScaleMinQuotaEnabled, flag:%v", gqm.scaleMinQuotaEnabled)
 }
 
+// SetFairSharePolicy sets the policy that all the runtimeQuotaCalculators use to share the resources.
+func (gqm *GroupQuotaManager) SetFairSharePolicy(policy config.ElasticQuotaFairSharePolicy) {
+	gqm.hierarchyUpdateLock.Lock()
+	defer gqm.hierarchyUpdateLock.Unlock()
+
+	gqm.fairSharePolicy = policy
+	for _, runtimeQuotaCalculator := range gqm.runtimeQuotaCalculatorMap {
+		runtimeQuotaCalculator.setFairSharePolicy(policy)
+	}
+	klog.V(5).Infof("Set FairSharePolicy, policy:%v", gqm.fairSharePolicy)
+}
+
+func (gqm *GroupQuotaManager) newRuntimeQuotaCalculatorNoLock(treeName string) *RuntimeQuotaCalculator {
+	runtimeQuotaCalculator := NewRuntimeQuotaCalculator(treeName)
+	runtimeQuotaCalculator.setFairSharePolicy(gqm.fairSharePolicy)
+	return runtimeQuotaCalculator
+}
+
 func (gqm *GroupQuotaManager) UpdateClusterTotalResource(deltaRes v1.ResourceList) {
 	gqm.hierarchyUpdateLock.Lock()
 	defer gqm.hierarchyUpdateLock.Unlock()
@@ -105,7 +133,7 @@ func (gqm *GroupQuotaManager) updateClusterTotalResourceNoLock(deltaRes v1.Resou
 
 	if !quotav1.IsZero(diffRes) {
 		gqm.totalResourceExceptSystemAndDefaultUsed = totalResNoSysOrDefault.DeepCopy()
-		gqm.runtimeQuotaCalculatorMap[extension.RootQuotaName].setClusterTotalResource(totalResNoSysOrDefault)
+		gqm.runtimeQuotaCalculatorMap[extension.RootQuotaName].setClusterTotalResource(gqm.getDefaultTreeTotalResourceNoLock())
 		klog.V(5).Infof("UpdateClusterResource finish totalResourceExceptSystemAndDefaultUsed:%v", gqm.totalResourceExceptSystemAndDefaultUsed)
 	}
 }
@@ -143,7 +171,7 @@ func (gqm *GroupQuotaManager) recursiveUpdateGroupTreeWithDeltaRequest(deltaReq
 		newSubLimitReq := curQuotaInfo.getLimitRequestNoLock()
 		deltaReq = quotav1.Subtract(newSubLimitReq, oldSubLimitReq)
 
-		directParRuntimeCalculatorPtr := gqm.getRuntimeQuotaCalculatorByNameNoLock(curQuotaInfo.ParentName)
+		directParRuntimeCalculatorPtr := gqm.getRuntimeQuotaCalculatorByNameNoLock(getParentCalculatorName(curQuotaInfo))
 		if directParRuntimeCalculatorPtr == nil {
 			klog.Errorf("treeWrapper not exist! quotaName:%v  parentName:%v", curQuotaInfo.Name, curQuotaInfo.ParentName)
 			return
@@ -189,17 +217,17 @@ func (gqm *GroupQuotaManager) RefreshRuntimeNoLock(quotaName string) v1.Resource
 	}
 
 	if quotaName == extension.SystemQuotaName || quotaName == extension.DefaultQuotaName {
-		return quotaInfo.getMax()
+		return quotaInfo.GetMax()
 	}
 
 	curToAllParInfos := gqm.getCurToAllParentGroupQuotaInfoNoLock(quotaInfo.Name)
 
 	defer gqm.scopedLockForQuotaInfo(curToAllParInfos)()
 
-	totalRes := gqm.totalResourceExceptSystemAndDefaultUsed.DeepCopy()
+	totalRes := gqm.getTreeTotalResourceNoLock(curToAllParInfos[len(curToAllParInfos)-1])
 	for i := len(curToAllParInfos) - 1; i >= 0; i-- {
 		quotaInfo = curToAllParInfos[i]
-		parRuntimeQuotaCalculator := gqm.getRuntimeQuotaCalculatorByNameNoLock(quotaInfo.ParentName)
+		parRuntimeQuotaCalculator := gqm.getRuntimeQuotaCalculatorByNameNoLock(getParentCalculatorName(quotaInfo))
 		if parRuntimeQuotaCalculator == nil {
 			klog.Errorf("treeWrapper not exist! parentQuotaName:%v", quotaInfo.ParentName)
 			return nil
@@ -213,7 +241,7 @@ func (gqm *GroupQuotaManager) RefreshRuntimeNoLock(quotaName string) v1.Resource
 		// 1. execute scaleMin logic with totalRes and update scaledMin if needed
 		if gqm.scaleMinQuotaEnabled {
 			needScale, newMinQuota := gqm.scaleMinQuotaManager.getScaledMinQuota(
-				totalRes, quotaInfo.ParentName, quotaInfo.Name)
+				totalRes, getParentCalculatorName(quotaInfo), quotaInfo.Name)
 			if needScale {
 				gqm.updateOneGroupAutoScaleMinQuotaNoLock(quotaInfo, newMinQuota)
 			}
@@ -241,7 +269,7 @@ func (gqm *GroupQuotaManager) RefreshRuntimeNoLock(quotaName string) v1.Resource
 func (gqm *GroupQuotaManager) updateOneGroupAutoScaleMinQuotaNoLock(quotaInfo *QuotaInfo, newMinRes v1.ResourceList) {
 	if !quotav1.Equals(quotaInfo.CalculateInfo.AutoScaleMin, newMinRes) {
 		quotaInfo.setAutoScaleMinQuotaNoLock(newMinRes)
-		gqm.runtimeQuotaCalculatorMap[quotaInfo.ParentName].updateOneGroupMinQuota(quotaInfo)
+		gqm.runtimeQuotaCalculatorMap[getParentCalculatorName(quotaInfo)].updateOneGroupMinQuota(quotaInfo)
 	}
 }
 
@@ -383,8 +411,12 @@ func (gqm *GroupQuotaManager) resetAllGroupQuotaNoLock() {
 	// clear old runtimeQuotaCalculator
 	gqm.runtimeQuotaCalculatorMap = make(map[string]*RuntimeQuotaCalculator)
 	// reset runtimeQuotaCalculator
-	gqm.runtimeQuotaCalculatorMap[extension.RootQuotaName] = NewRuntimeQuotaCalculator(extension.RootQuotaName)
-	gqm.runtimeQuotaCalculatorMap[extension.RootQuotaName].setClusterTotalResource(gqm.totalResourceExceptSystemAndDefaultUsed)
+	gqm.runtimeQuotaCalculatorMap[extension.RootQuotaName] = gqm.newRuntimeQuotaCalculatorNoLock(extension.RootQuotaName)
+	for _, treeRoot := range gqm.getTreeRootsNoLock() {
+		treeCalculatorName := getTreeCalculatorName(treeRoot.Name)
+		gqm.runtimeQuotaCalculatorMap[treeCalculatorName] = gqm.newRuntimeQuotaCalculatorNoLock(treeCalculatorName)
+	}
+	gqm.updateTreeTotalResourceNoLock()
 	rootNode := gqm.quotaTopoNodeMap[extension.RootQuotaName]
 	gqm.resetAllGroupQuotaRecursiveNoLock(rootNode)
 	gqm.updateResourceKeyNoLock()
@@ -402,7 +434,7 @@ func (gqm *GroupQuotaManager) resetAllGroupQuotaNoLock() {
 func (gqm *GroupQuotaManager) resetAllGroupQuotaRecursiveNoLock(rootNode *QuotaTopoNode) {
 	childGroupQuotaInfos := rootNode.getChildGroupQuotaInfos()
 	for subName, topoNode := range childGroupQuotaInfos {
-		gqm.runtimeQuotaCalculatorMap[subName] = NewRuntimeQuotaCalculator(subName)
+		gqm.runtimeQuotaCalculatorMap[subName] = gqm.newRuntimeQuotaCalculatorNoLock(subName)
 
 		gqm.updateOneGroupMaxQuotaNoLock(topoNode.quotaInfo)
 		gqm.updateMinQuotaNoLock(topoNode.quotaInfo)
@@ -417,14 +449,14 @@ func (gqm *GroupQuotaManager) updateOneGroupMaxQuotaNoLock(quotaInfo *QuotaInfo)
 	quotaInfo.lock.Lock()
 	defer quotaInfo.lock.Unlock()
 
-	runtimeQuotaCalculator := gqm.getRuntimeQuotaCalculatorByNameNoLock(quotaInfo.ParentName)
+	runtimeQuotaCalculator := gqm.getRuntimeQuotaCalculatorByNameNoLock(getParentCalculatorName(quotaInfo))
 	runtimeQuotaCalculator.updateOneGroupMaxQuota(quotaInfo)
 }
 
 // updateMinQuotaNoLock no need to lock gqm.lock
 func (gqm *GroupQuotaManager) updateMinQuotaNoLock(quotaInfo *QuotaInfo) {
 	gqm.updateOneGroupOriginalMinQuotaNoLock(quotaInfo)
-	gqm.scaleMinQuotaManager.update(quotaInfo.ParentName, quotaInfo.Name,
+	gqm.scaleMinQuotaManager.update(getParentCalculatorName(quotaInfo), quotaInfo.Name,
 		quotaInfo.CalculateInfo.Min.DeepCopy(), gqm.scaleMinQuotaEnabled)
 }
 
@@ -434,7 +466,7 @@ func (gqm *GroupQuotaManager) updateOneGroupOriginalMinQuotaNoLock(quotaInfo *Qu
 	defer quotaInfo.lock.Unlock()
 
 	quotaInfo.setAutoScaleMinQuotaNoLock(quotaInfo.CalculateInfo.Min)
-	gqm.runtimeQuotaCalculatorMap[quotaInfo.ParentName].updateOneGroupMinQuota(quotaInfo)
+	gqm.runtimeQuotaCalculatorMap[getParentCalculatorName(quotaInfo)].updateOneGroupMinQuota(quotaInfo)
 }
 
 // updateOneGroupSharedWeightNoLock no need to lock gqm.lock
@@ -442,7 +474,7 @@ func (gqm *GroupQuotaManager) updateOneGroupSharedWeightNoLock(quotaInfo *QuotaI
 	quotaInfo.lock.Lock()
 	defer quotaInfo.lock.Unlock()
 
-	gqm.runtimeQuotaCalculatorMap[quotaInfo.ParentName].updateOneGroupSharedWeight(quotaInfo)
+	gqm.runtimeQuotaCalculatorMap[getParentCalculatorName(quotaInfo)].updateOneGroupSharedWeight(quotaInfo)
 }
 
 func (gqm *GroupQuotaManager) updateResourceKeyNoLock() {
@@ -591,6 +623,7 @@ func (gqm *GroupQuotaManager) GetQuotaSummary(quotaName string) (*QuotaInfoSumma
 	quotaSummary := quotaInfo.GetQuotaSummary()
 	runtime := gqm.RefreshRuntimeNoLock(quotaName)
 	quotaSummary.Runtime = runtime.DeepCopy()
+	quotaSummary.FairSharePolicy = string(gqm.fairSharePolicy)
 	return quotaSummary, true
 }
 
@@ -603,6 +636,7 @@ func (gqm *GroupQuotaManager) GetQuotaSummaries() map[string]*QuotaInfoSummary {
 		quotaSummary := quotaInfo.GetQuotaSummary()
 		runtime := gqm.RefreshRuntimeNoLock(quotaName)
 		quotaSummary.Runtime = runtime.DeepCopy()
+		quotaSummary.FairSharePolicy = string(gqm.fairSharePolicy)
 		result[quotaName] = quotaSummary
 	}
 
@@ -672,17 +706,32 @@ func (gqm *GroupQuotaManager) UnreservePod(quotaName string, p *v1.Pod) {
 	gqm.updatePodIsAssignedNoLock(quotaName, p, false)
 }
 
-func (gqm *GroupQuotaManager) GetQuotaInformationForSyncHandler(quotaName string) (used, request, runtime v1.ResourceList, err error) {
+// GetQuotaStatusForSyncHandler returns the status of the quota except the conditions, which is synced into
+// the ElasticQuota by the controller.
+func (gqm *GroupQuotaManager) GetQuotaStatusForSyncHandler(quotaName string) (*extension.ElasticQuotaStatus, error) {
 	gqm.hierarchyUpdateLock.RLock()
 	defer gqm.hierarchyUpdateLock.RUnlock()
 
 	quotaInfo := gqm.getQuotaInfoByNameNoLock(quotaName)
 	if quotaInfo == nil {
-		return nil, nil, nil, fmt.Errorf("groupQuotaManager doesn't have this quota:%v", quotaName)
+		return nil, fmt.Errorf("groupQuotaManager doesn't have this quota:%v", quotaName)
 	}
 
-	runtime = gqm.RefreshRuntimeNoLock(quotaName)
-	return quotaInfo.GetUsed(), quotaInfo.GetRequest(), runtime, nil
+	runtime := gqm.RefreshRuntimeNoLock(quotaName)
+	status := &extension.ElasticQuotaStatus{
+		Used:    quotaInfo.GetUsed(),
+		Request: quotaInfo.GetRequest(),
+		Runtime: runtime.DeepCopy(),
+		Min:     quotaInfo.GetGuaranteed(),
+		Max:     quotaInfo.GetMax(),
+	}
+	if topoNode := gqm.quotaTopoNodeMap[quotaName]; topoNode != nil {
+		for childName := range topoNode.getChildGroupQuotaInfos() {
+			status.Children = append(status.Children, childName)
+		}
+		sort.Strings(status.Children)
+	}
+	return status, nil
 }
 
 func getPodName(oldPod, newPod *v1.Pod) string {
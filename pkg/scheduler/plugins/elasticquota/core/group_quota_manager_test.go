@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 )
 
 const (
@@ -251,6 +252,60 @@ func TestGroupQuotaManager_NotAllowLentResource(t *testing.T) {
 	assert.Equal(t, int64(40), runtime.Cpu().Value())
 }
 
+func TestGroupQuotaManager_BorrowAndLendLimit(t *testing.T) {
+	gqm := NewGroupQuotaManager4Test()
+
+	deltaRes := createResourceList(100, 0)
+	gqm.UpdateClusterTotalResource(deltaRes)
+
+	// test1 borrows at most 5 above its min, test2 lends at most 10 of its min
+	quota := CreateQuota("test1", extension.RootQuotaName, 100, 0, 40, 0, true, false)
+	quota.Annotations[extension.AnnotationBorrowLimit] = `{"cpu":5}`
+	assert.NoError(t, gqm.UpdateQuota(quota, false))
+	quota = CreateQuota("test2", extension.RootQuotaName, 100, 0, 40, 0, true, false)
+	quota.Annotations[extension.AnnotationLendLimit] = `{"cpu":10}`
+	assert.NoError(t, gqm.UpdateQuota(quota, false))
+	AddQuotaToManager(t, gqm, "test3", extension.RootQuotaName, 100, 0, 10, 0, true, false)
+
+	gqm.updateGroupDeltaRequestNoLock("test1", createResourceList(100, 0))
+	gqm.updateGroupDeltaRequestNoLock("test3", createResourceList(100, 0))
+	runtime := gqm.RefreshRuntime("test1")
+	assert.Equal(t, int64(45), runtime.Cpu().Value())
+	runtime = gqm.RefreshRuntime("test2")
+	assert.Equal(t, int64(30), runtime.Cpu().Value())
+	runtime = gqm.RefreshRuntime("test3")
+	assert.Equal(t, int64(25), runtime.Cpu().Value())
+
+	summary, ok := gqm.GetQuotaSummary("test1")
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), summary.BorrowLimit.Cpu().Value())
+	summary, ok = gqm.GetQuotaSummary("test2")
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), summary.LendLimit.Cpu().Value())
+}
+
+func TestGroupQuotaManager_DominantResourceFairness(t *testing.T) {
+	gqm := NewGroupQuotaManager4Test()
+	gqm.SetFairSharePolicy(config.FairSharePolicyDominantResourceFairness)
+
+	deltaRes := createResourceList(100, 100)
+	gqm.UpdateClusterTotalResource(deltaRes)
+
+	AddQuotaToManager(t, gqm, "test1", extension.RootQuotaName, 100, 100, 0, 0, true, false)
+	AddQuotaToManager(t, gqm, "test2", extension.RootQuotaName, 100, 100, 0, 0, true, false)
+	gqm.updateGroupDeltaRequestNoLock("test1", createResourceList(80, 20))
+	gqm.updateGroupDeltaRequestNoLock("test2", createResourceList(45, 80))
+
+	summaries := gqm.GetQuotaSummaries()
+	assert.Equal(t, createResourceList(64, 20), summaries["test1"].Runtime)
+	assert.Equal(t, createResourceList(36, 80), summaries["test2"].Runtime)
+	assert.Equal(t, string(config.FairSharePolicyDominantResourceFairness), summaries["test1"].FairSharePolicy)
+
+	gqm.SetFairSharePolicy(config.FairSharePolicySharedWeight)
+	assert.Equal(t, createResourceList(55, 20), gqm.RefreshRuntime("test1"))
+	assert.Equal(t, createResourceList(45, 80), gqm.RefreshRuntime("test2"))
+}
+
 func TestGroupQuotaManager_UpdateQuotaRequest(t *testing.T) {
 	gqm := NewGroupQuotaManager4Test()
 
@@ -1087,6 +1142,8 @@ func NewGroupQuotaManager4Test() *GroupQuotaManager {
 		runtimeQuotaCalculatorMap:               make(map[string]*RuntimeQuotaCalculator),
 		scaleMinQuotaManager:                    NewScaleMinQuotaManager(),
 		quotaTopoNodeMap:                        make(map[string]*QuotaTopoNode),
+		nodeResourceMap:                         make(map[string]*nodeResource),
+		treeTotalResource:                       make(map[string]v1.ResourceList),
 	}
 	quotaManager.quotaInfoMap[extension.SystemQuotaName] = NewQuotaInfo(false, true, extension.SystemQuotaName, "")
 	quotaManager.quotaInfoMap[extension.DefaultQuotaName] = NewQuotaInfo(false, true, extension.DefaultQuotaName, "")
@@ -1183,26 +1240,37 @@ func TestGroupQuotaManager_UpdatePodCache_UpdatePodIsAssigned_GetPodIsAssigned_U
 
 func TestNewGroupQuotaManager(t *testing.T) {
 	gqm := NewGroupQuotaManager(createResourceList(100, 100), createResourceList(300, 300))
-	assert.Equal(t, createResourceList(100, 100), gqm.GetQuotaInfoByName("system").getMax())
-	assert.Equal(t, createResourceList(300, 300), gqm.GetQuotaInfoByName("default").getMax())
+	assert.Equal(t, createResourceList(100, 100), gqm.GetQuotaInfoByName("system").GetMax())
+	assert.Equal(t, createResourceList(300, 300), gqm.GetQuotaInfoByName("default").GetMax())
 	assert.True(t, gqm.scaleMinQuotaEnabled)
 	gqm.UpdateClusterTotalResource(createResourceList(500, 500))
 	assert.Equal(t, createResourceList(500, 500), gqm.GetClusterTotalResource())
 }
 
-func TestGroupQuotaManager_GetQuotaInformationForSyncHandler(t *testing.T) {
+func TestGroupQuotaManager_GetQuotaStatusForSyncHandler(t *testing.T) {
 	gqm := NewGroupQuotaManager4Test()
-	qi1 := createQuota("1", extension.RootQuotaName, 400, 400, 10, 10)
-	gqm.UpdateQuota(qi1, false)
 	gqm.UpdateClusterTotalResource(createResourceList(1000, 1000))
+	gqm.UpdateQuota(CreateQuota("p", extension.RootQuotaName, 400, 400, 100, 100, true, true), false)
+	gqm.UpdateQuota(CreateQuota("2", "p", 400, 400, 10, 10, true, false), false)
+	gqm.UpdateQuota(CreateQuota("1", "p", 400, 400, 10, 10, true, false), false)
 	gqm.updateGroupDeltaRequestNoLock("1", createResourceList(100, 100))
-	gqm.RefreshRuntime("1")
 	gqm.updateGroupDeltaUsedNoLock("1", createResourceList(10, 10))
-	used, request, runtime, err := gqm.GetQuotaInformationForSyncHandler("1")
+
+	status, err := gqm.GetQuotaStatusForSyncHandler("1")
 	assert.Nil(t, err)
-	assert.Equal(t, used, createResourceList(10, 10))
-	assert.Equal(t, request, createResourceList(100, 100))
-	assert.Equal(t, runtime, createResourceList(100, 100))
+	assert.Equal(t, createResourceList(10, 10), status.Used)
+	assert.Equal(t, createResourceList(100, 100), status.Request)
+	assert.Equal(t, createResourceList(100, 100), status.Runtime)
+	assert.Equal(t, createResourceList(10, 10), status.Min)
+	assert.Equal(t, createResourceList(400, 400), status.Max)
+	assert.Nil(t, status.Children)
+
+	status, err = gqm.GetQuotaStatusForSyncHandler("p")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, status.Children)
+
+	_, err = gqm.GetQuotaStatusForSyncHandler("3")
+	assert.NotNil(t, err)
 }
 
 func TestGetPodName(t *testing.T) {
@@ -21,6 +21,7 @@ import (
 	"sync"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	"k8s.io/klog/v2"
 	resourcev1 "k8s.io/kubernetes/pkg/api/v1/resource"
@@ -46,6 +47,12 @@ type QuotaCalculateInfo struct {
 	SharedWeight v1.ResourceList
 	// Runtime is the current actual resource that can be used by the quota group
 	Runtime v1.ResourceList
+	// BorrowLimit is the upper limit of resources that the quota group can borrow above its min,
+	// the resource dimensions not specified are unlimited
+	BorrowLimit v1.ResourceList
+	// LendLimit is the upper limit of resources in its min that the quota group can lend to others,
+	// the resource dimensions not specified are unlimited
+	LendLimit v1.ResourceList
 }
 
 type QuotaInfo struct {
@@ -59,9 +66,13 @@ type QuotaInfo struct {
 	RuntimeVersion int64
 	// Allow lent resource to other quota group
 	AllowLentResource bool
-	CalculateInfo     QuotaCalculateInfo
-	PodCache          map[string]*PodInfo
-	lock              sync.Mutex
+	// The pods can not be preempted by other quota groups which reclaim their lent resources
+	NonPreemptible bool
+	// The node selector of the quota tree rooted on the quota, only works if the parent is root
+	TreeNodeSelector map[string]string
+	CalculateInfo    QuotaCalculateInfo
+	PodCache         map[string]*PodInfo
+	lock             sync.Mutex
 }
 
 func NewQuotaInfo(isParent, allowLentResource bool, name, parentName string) *QuotaInfo {
@@ -96,6 +107,8 @@ func (qi *QuotaInfo) DeepCopy() *QuotaInfo {
 		ParentName:        qi.ParentName,
 		IsParent:          qi.IsParent,
 		AllowLentResource: qi.AllowLentResource,
+		NonPreemptible:    qi.NonPreemptible,
+		TreeNodeSelector:  copyNodeSelector(qi.TreeNodeSelector),
 		RuntimeVersion:    qi.RuntimeVersion,
 		PodCache:          make(map[string]*PodInfo),
 		CalculateInfo: QuotaCalculateInfo{
@@ -106,6 +119,8 @@ func (qi *QuotaInfo) DeepCopy() *QuotaInfo {
 			Request:      qi.CalculateInfo.Request.DeepCopy(),
 			SharedWeight: qi.CalculateInfo.SharedWeight.DeepCopy(),
 			Runtime:      qi.CalculateInfo.Runtime.DeepCopy(),
+			BorrowLimit:  qi.CalculateInfo.BorrowLimit.DeepCopy(),
+			LendLimit:    qi.CalculateInfo.LendLimit.DeepCopy(),
 		},
 	}
 	for name, pod := range qi.PodCache {
@@ -124,6 +139,8 @@ func (qi *QuotaInfo) GetQuotaSummary() *QuotaInfoSummary {
 	quotaInfoSummary.IsParent = qi.IsParent
 	quotaInfoSummary.RuntimeVersion = qi.RuntimeVersion
 	quotaInfoSummary.AllowLentResource = qi.AllowLentResource
+	quotaInfoSummary.NonPreemptible = qi.NonPreemptible
+	quotaInfoSummary.TreeNodeSelector = copyNodeSelector(qi.TreeNodeSelector)
 	quotaInfoSummary.Max = qi.CalculateInfo.Max.DeepCopy()
 	quotaInfoSummary.Min = qi.CalculateInfo.Min.DeepCopy()
 	quotaInfoSummary.AutoScaleMin = qi.CalculateInfo.AutoScaleMin.DeepCopy()
@@ -131,6 +148,8 @@ func (qi *QuotaInfo) GetQuotaSummary() *QuotaInfoSummary {
 	quotaInfoSummary.Request = qi.CalculateInfo.Request.DeepCopy()
 	quotaInfoSummary.SharedWeight = qi.CalculateInfo.SharedWeight.DeepCopy()
 	quotaInfoSummary.Runtime = qi.CalculateInfo.Runtime.DeepCopy()
+	quotaInfoSummary.BorrowLimit = qi.CalculateInfo.BorrowLimit.DeepCopy()
+	quotaInfoSummary.LendLimit = qi.CalculateInfo.LendLimit.DeepCopy()
 
 	for podName, podInfo := range qi.PodCache {
 		quotaInfoSummary.PodCache[podName] = &SimplePodInfo{
@@ -142,7 +161,7 @@ func (qi *QuotaInfo) GetQuotaSummary() *QuotaInfoSummary {
 	return quotaInfoSummary
 }
 
-// updateQuotaInfoFromRemote the CRD(max/oriMin/sharedWeight/allowLentResource/isParent/ParentName) of the quota maybe changed,
+// updateQuotaInfoFromRemote the CRD(max/oriMin/sharedWeight/borrowLimit/lendLimit/allowLentResource/nonPreemptible/treeNodeSelector/isParent/ParentName) of the quota maybe changed,
 // so need update localQuotaInfo's information from inputQuotaInfo.
 func (qi *QuotaInfo) updateQuotaInfoFromRemote(quotaInfo *QuotaInfo) {
 	qi.lock.Lock()
@@ -155,7 +174,11 @@ func (qi *QuotaInfo) updateQuotaInfoFromRemote(quotaInfo *QuotaInfo) {
 		sharedWeight = quotaInfo.CalculateInfo.Max.DeepCopy()
 	}
 	qi.CalculateInfo.SharedWeight = sharedWeight
+	qi.CalculateInfo.BorrowLimit = quotaInfo.CalculateInfo.BorrowLimit.DeepCopy()
+	qi.CalculateInfo.LendLimit = quotaInfo.CalculateInfo.LendLimit.DeepCopy()
 	qi.AllowLentResource = quotaInfo.AllowLentResource
+	qi.NonPreemptible = quotaInfo.NonPreemptible
+	qi.TreeNodeSelector = copyNodeSelector(quotaInfo.TreeNodeSelector)
 	qi.IsParent = quotaInfo.IsParent
 	qi.ParentName = quotaInfo.ParentName
 }
@@ -220,13 +243,21 @@ func (qi *QuotaInfo) GetUsed() v1.ResourceList {
 	return qi.CalculateInfo.Used.DeepCopy()
 }
 
+// GetGuaranteed returns the resources guaranteed to the quota group, i.e. the min scaled if the sum of min
+// is larger than the total resource, in the resource dimensions of max.
+func (qi *QuotaInfo) GetGuaranteed() v1.ResourceList {
+	qi.lock.Lock()
+	defer qi.lock.Unlock()
+	return quotav1.Mask(qi.CalculateInfo.AutoScaleMin, quotav1.ResourceNames(qi.CalculateInfo.Max))
+}
+
 func (qi *QuotaInfo) GetRuntime() v1.ResourceList {
 	qi.lock.Lock()
 	defer qi.lock.Unlock()
 	return qi.CalculateInfo.Runtime.DeepCopy()
 }
 
-func (qi *QuotaInfo) getMax() v1.ResourceList {
+func (qi *QuotaInfo) GetMax() v1.ResourceList {
 	qi.lock.Lock()
 	defer qi.lock.Unlock()
 	return qi.CalculateInfo.Max.DeepCopy()
@@ -239,10 +270,14 @@ func NewQuotaInfoFromQuota(quota *v1alpha1.ElasticQuota) *QuotaInfo {
 	allowLentResource := extension.IsAllowLentResource(quota)
 
 	quotaInfo := NewQuotaInfo(isParent, allowLentResource, quota.Name, parentName)
+	quotaInfo.NonPreemptible = extension.IsNonPreemptible(quota)
+	quotaInfo.TreeNodeSelector = extension.GetTreeNodeSelector(quota)
 	quotaInfo.setMinQuotaNoLock(quota.Spec.Min)
 	quotaInfo.setMaxQuotaNoLock(quota.Spec.Max)
 	newSharedWeight := extension.GetSharedWeight(quota)
 	quotaInfo.setSharedWeightNoLock(newSharedWeight)
+	quotaInfo.CalculateInfo.BorrowLimit = extension.GetBorrowLimit(quota)
+	quotaInfo.CalculateInfo.LendLimit = extension.GetLendLimit(quota)
 
 	return quotaInfo
 }
@@ -265,7 +300,11 @@ func (qi *QuotaInfo) isQuotaMetaChange(quotaInfo *QuotaInfo) bool {
 	if !quotav1.Equals(qi.CalculateInfo.Max, quotaInfo.CalculateInfo.Max) ||
 		!quotav1.Equals(qi.CalculateInfo.Min, quotaInfo.CalculateInfo.Min) ||
 		!quotav1.Equals(qi.CalculateInfo.SharedWeight, quotaInfo.CalculateInfo.SharedWeight) ||
+		!quotav1.Equals(qi.CalculateInfo.BorrowLimit, quotaInfo.CalculateInfo.BorrowLimit) ||
+		!quotav1.Equals(qi.CalculateInfo.LendLimit, quotaInfo.CalculateInfo.LendLimit) ||
 		qi.AllowLentResource != quotaInfo.AllowLentResource ||
+		qi.NonPreemptible != quotaInfo.NonPreemptible ||
+		!labels.Equals(qi.TreeNodeSelector, quotaInfo.TreeNodeSelector) ||
 		qi.IsParent != quotaInfo.IsParent ||
 		qi.ParentName != quotaInfo.ParentName {
 		return true
@@ -273,6 +312,17 @@ func (qi *QuotaInfo) isQuotaMetaChange(quotaInfo *QuotaInfo) bool {
 	return false
 }
 
+func copyNodeSelector(nodeSelector map[string]string) map[string]string {
+	if nodeSelector == nil {
+		return nil
+	}
+	result := make(map[string]string, len(nodeSelector))
+	for k, v := range nodeSelector {
+		result[k] = v
+	}
+	return result
+}
+
 func (qi *QuotaInfo) isPodExist(pod *v1.Pod) bool {
 	qi.lock.Lock()
 	defer qi.lock.Unlock()
@@ -332,6 +382,20 @@ func (qi *QuotaInfo) GetPodCache() map[string]*v1.Pod {
 	return pods
 }
 
+// GetPendingPods returns the pods of the quota which are not assigned to any node yet.
+func (qi *QuotaInfo) GetPendingPods() []*v1.Pod {
+	qi.lock.Lock()
+	defer qi.lock.Unlock()
+
+	var pods []*v1.Pod
+	for _, podInfo := range qi.PodCache {
+		if !podInfo.isAssigned {
+			pods = append(pods, podInfo.pod)
+		}
+	}
+	return pods
+}
+
 func (qi *QuotaInfo) CheckPodIsAssigned(pod *v1.Pod) bool {
 	qi.lock.Lock()
 	defer qi.lock.Unlock()
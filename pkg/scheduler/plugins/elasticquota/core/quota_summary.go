@@ -31,6 +31,9 @@ type QuotaInfoSummary struct {
 	IsParent          bool   `json:"isParent"`
 	RuntimeVersion    int64  `json:"runtimeVersion"`
 	AllowLentResource bool   `json:"allowLentResource"`
+	NonPreemptible    bool   `json:"nonPreemptible,omitempty"`
+
+	TreeNodeSelector map[string]string `json:"treeNodeSelector,omitempty"`
 
 	Max          v1.ResourceList `json:"max"`
 	Min          v1.ResourceList `json:"min"`
@@ -39,6 +42,10 @@ type QuotaInfoSummary struct {
 	Request      v1.ResourceList `json:"request"`
 	SharedWeight v1.ResourceList `json:"sharedWeight"`
 	Runtime      v1.ResourceList `json:"runtime"`
+	BorrowLimit  v1.ResourceList `json:"borrowLimit,omitempty"`
+	LendLimit    v1.ResourceList `json:"lendLimit,omitempty"`
+
+	FairSharePolicy string `json:"fairSharePolicy,omitempty"`
 
 	PodCache map[string]*SimplePodInfo `json:"podCache"`
 }
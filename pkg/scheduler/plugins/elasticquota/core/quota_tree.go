@@ -0,0 +1,270 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+// A quota whose parent is root and has a TreeNodeSelector is the root of an independent quota tree. The runtime of
+// the tree root is calculated with the total resource of the nodes selected by the tree, instead of sharing the
+// cluster total resource with the other quotas under root. The quotas not in any independent tree belong to the
+// default tree, which shares the total resource of the nodes not selected by any tree.
+
+type nodeResource struct {
+	labels      map[string]string
+	allocatable v1.ResourceList
+}
+
+// isTreeRoot returns whether the quota is the root of an independent quota tree.
+func isTreeRoot(quotaInfo *QuotaInfo) bool {
+	return quotaInfo.ParentName == extension.RootQuotaName && len(quotaInfo.TreeNodeSelector) != 0
+}
+
+// getTreeCalculatorName returns the name of the runtimeQuotaCalculator which calculates the runtime of the tree root,
+// the name doesn't conflict with any quota name.
+func getTreeCalculatorName(treeRootName string) string {
+	return extension.RootQuotaName + "/" + treeRootName
+}
+
+// getParentCalculatorName returns the name of the runtimeQuotaCalculator which calculates the runtime of the quota.
+func getParentCalculatorName(quotaInfo *QuotaInfo) string {
+	if isTreeRoot(quotaInfo) {
+		return getTreeCalculatorName(quotaInfo.Name)
+	}
+	return quotaInfo.ParentName
+}
+
+// UpdateNodeResource records the labels and allocatable of the node, and applies the change of the node to the cluster
+// total resource and the total resource of the quota trees the node belongs to before and after the change.
+func (gqm *GroupQuotaManager) UpdateNodeResource(nodeName string, nodeLabels map[string]string, allocatable v1.ResourceList) {
+	gqm.hierarchyUpdateLock.Lock()
+	defer gqm.hierarchyUpdateLock.Unlock()
+
+	defer gqm.scopedLockForQuotaInfo([]*QuotaInfo{gqm.getQuotaInfoByNameNoLock(extension.SystemQuotaName),
+		gqm.getQuotaInfoByNameNoLock(extension.DefaultQuotaName)})()
+
+	treeRoots := gqm.getTreeRootsNoLock()
+	deltaRes := allocatable
+	if oldNode, ok := gqm.nodeResourceMap[nodeName]; ok {
+		gqm.updateNodeTreeTotalResourceNoLock(treeRoots, oldNode, false)
+		deltaRes = quotav1.Subtract(allocatable, oldNode.allocatable)
+	}
+	node := &nodeResource{
+		labels:      nodeLabels,
+		allocatable: allocatable.DeepCopy(),
+	}
+	gqm.nodeResourceMap[nodeName] = node
+	gqm.updateNodeTreeTotalResourceNoLock(treeRoots, node, true)
+	gqm.updateClusterTotalResourceNoLock(deltaRes)
+	gqm.updateDefaultTreeTotalResourceNoLock()
+}
+
+// DeleteNodeResource removes the node, and subtracts its allocatable from the cluster total resource and the total
+// resource of the quota tree it belongs to.
+func (gqm *GroupQuotaManager) DeleteNodeResource(nodeName string) {
+	gqm.hierarchyUpdateLock.Lock()
+	defer gqm.hierarchyUpdateLock.Unlock()
+
+	oldNode, ok := gqm.nodeResourceMap[nodeName]
+	if !ok {
+		return
+	}
+	defer gqm.scopedLockForQuotaInfo([]*QuotaInfo{gqm.getQuotaInfoByNameNoLock(extension.SystemQuotaName),
+		gqm.getQuotaInfoByNameNoLock(extension.DefaultQuotaName)})()
+
+	delete(gqm.nodeResourceMap, nodeName)
+	gqm.updateNodeTreeTotalResourceNoLock(gqm.getTreeRootsNoLock(), oldNode, false)
+	gqm.updateClusterTotalResourceNoLock(quotav1.Subtract(v1.ResourceList{}, oldNode.allocatable))
+	gqm.updateDefaultTreeTotalResourceNoLock()
+}
+
+// GetQuotaTreeName returns the name of the independent quota tree the quota belongs to, i.e. the name of the tree root,
+// or RootQuotaName if the quota belongs to the default tree.
+func (gqm *GroupQuotaManager) GetQuotaTreeName(quotaName string) string {
+	gqm.hierarchyUpdateLock.RLock()
+	defer gqm.hierarchyUpdateLock.RUnlock()
+
+	if treeRoot := gqm.getQuotaTreeRootNoLock(quotaName); treeRoot != nil {
+		return treeRoot.Name
+	}
+	return extension.RootQuotaName
+}
+
+// GetQuotaTreeNodeSelector returns the node selector of the independent quota tree the quota belongs to,
+// or nil if the quota belongs to the default tree.
+func (gqm *GroupQuotaManager) GetQuotaTreeNodeSelector(quotaName string) map[string]string {
+	gqm.hierarchyUpdateLock.RLock()
+	defer gqm.hierarchyUpdateLock.RUnlock()
+
+	if treeRoot := gqm.getQuotaTreeRootNoLock(quotaName); treeRoot != nil {
+		return copyNodeSelector(treeRoot.TreeNodeSelector)
+	}
+	return nil
+}
+
+func (gqm *GroupQuotaManager) getQuotaTreeRootNoLock(quotaName string) *QuotaInfo {
+	curToAllParInfos := gqm.getCurToAllParentGroupQuotaInfoNoLock(quotaName)
+	if len(curToAllParInfos) == 0 {
+		return nil
+	}
+	if topQuotaInfo := curToAllParInfos[len(curToAllParInfos)-1]; isTreeRoot(topQuotaInfo) {
+		return topQuotaInfo
+	}
+	return nil
+}
+
+// GetNodeTreeName returns the name of the independent quota tree which selects the node with the labels,
+// or RootQuotaName if the node belongs to the default tree.
+func (gqm *GroupQuotaManager) GetNodeTreeName(nodeLabels map[string]string) string {
+	gqm.hierarchyUpdateLock.RLock()
+	defer gqm.hierarchyUpdateLock.RUnlock()
+
+	return gqm.getNodeTreeNameNoLock(gqm.getTreeRootsNoLock(), nodeLabels)
+}
+
+// QuotaTreeSelector is the node selector of an independent quota tree.
+type QuotaTreeSelector struct {
+	TreeName string
+	Selector labels.Selector
+}
+
+// GetQuotaTreeSelectors returns the node selectors of the independent quota trees, in the order used to decide which
+// tree a node selected by several trees belongs to.
+func (gqm *GroupQuotaManager) GetQuotaTreeSelectors() []QuotaTreeSelector {
+	gqm.hierarchyUpdateLock.RLock()
+	defer gqm.hierarchyUpdateLock.RUnlock()
+
+	treeRoots := gqm.getTreeRootsNoLock()
+	treeSelectors := make([]QuotaTreeSelector, 0, len(treeRoots))
+	for _, treeRoot := range treeRoots {
+		treeSelectors = append(treeSelectors, QuotaTreeSelector{
+			TreeName: treeRoot.Name,
+			Selector: labels.SelectorFromSet(treeRoot.TreeNodeSelector),
+		})
+	}
+	return treeSelectors
+}
+
+// MatchNodeTreeName returns the name of the first independent quota tree in treeSelectors which selects the node with
+// the labels, or RootQuotaName if the node belongs to the default tree.
+func MatchNodeTreeName(treeSelectors []QuotaTreeSelector, nodeLabels map[string]string) string {
+	for _, treeSelector := range treeSelectors {
+		if treeSelector.Selector.Matches(labels.Set(nodeLabels)) {
+			return treeSelector.TreeName
+		}
+	}
+	return extension.RootQuotaName
+}
+
+// getTreeRootsNoLock returns the tree roots sorted by name.
+func (gqm *GroupQuotaManager) getTreeRootsNoLock() []*QuotaInfo {
+	var treeRoots []*QuotaInfo
+	for _, quotaInfo := range gqm.quotaInfoMap {
+		if isTreeRoot(quotaInfo) {
+			treeRoots = append(treeRoots, quotaInfo)
+		}
+	}
+	sort.Slice(treeRoots, func(i, j int) bool { return treeRoots[i].Name < treeRoots[j].Name })
+	return treeRoots
+}
+
+// getNodeTreeNameNoLock returns the first tree which selects the node, so a node selected by several trees
+// only belongs to one of them.
+func (gqm *GroupQuotaManager) getNodeTreeNameNoLock(treeRoots []*QuotaInfo, nodeLabels map[string]string) string {
+	for _, treeRoot := range treeRoots {
+		if labels.SelectorFromSet(treeRoot.TreeNodeSelector).Matches(labels.Set(nodeLabels)) {
+			return treeRoot.Name
+		}
+	}
+	return extension.RootQuotaName
+}
+
+// updateTreeTotalResourceNoLock recalculates the total resource of each independent quota tree with the nodes it
+// selects, and updates the total resource of the tree calculators and the root calculator.
+func (gqm *GroupQuotaManager) updateTreeTotalResourceNoLock() {
+	treeRoots := gqm.getTreeRootsNoLock()
+	treeTotalResource := make(map[string]v1.ResourceList, len(treeRoots))
+	for _, treeRoot := range treeRoots {
+		treeTotalResource[treeRoot.Name] = v1.ResourceList{}
+	}
+	for _, node := range gqm.nodeResourceMap {
+		if treeName := gqm.getNodeTreeNameNoLock(treeRoots, node.labels); treeName != extension.RootQuotaName {
+			treeTotalResource[treeName] = quotav1.Add(treeTotalResource[treeName], node.allocatable)
+		}
+	}
+	gqm.treeTotalResource = treeTotalResource
+
+	for treeName, totalResource := range treeTotalResource {
+		if runtimeQuotaCalculator := gqm.getRuntimeQuotaCalculatorByNameNoLock(getTreeCalculatorName(treeName)); runtimeQuotaCalculator != nil {
+			runtimeQuotaCalculator.setClusterTotalResource(totalResource)
+		}
+	}
+	gqm.runtimeQuotaCalculatorMap[extension.RootQuotaName].setClusterTotalResource(gqm.getDefaultTreeTotalResourceNoLock())
+	klog.V(5).Infof("UpdateTreeTotalResource finish treeTotalResource:%v", treeTotalResource)
+}
+
+// updateNodeTreeTotalResourceNoLock adds or subtracts the allocatable of the node to the total resource of the
+// independent quota tree which selects the node, nothing changes if the node belongs to the default tree.
+func (gqm *GroupQuotaManager) updateNodeTreeTotalResourceNoLock(treeRoots []*QuotaInfo, node *nodeResource, add bool) {
+	treeName := gqm.getNodeTreeNameNoLock(treeRoots, node.labels)
+	if treeName == extension.RootQuotaName {
+		return
+	}
+	if add {
+		gqm.treeTotalResource[treeName] = quotav1.Add(gqm.treeTotalResource[treeName], node.allocatable)
+	} else {
+		gqm.treeTotalResource[treeName] = quotav1.SubtractWithNonNegativeResult(gqm.treeTotalResource[treeName], node.allocatable)
+	}
+	if runtimeQuotaCalculator := gqm.getRuntimeQuotaCalculatorByNameNoLock(getTreeCalculatorName(treeName)); runtimeQuotaCalculator != nil {
+		runtimeQuotaCalculator.setClusterTotalResource(gqm.treeTotalResource[treeName])
+	}
+}
+
+// updateDefaultTreeTotalResourceNoLock updates the total resource of the root calculator if it changes.
+func (gqm *GroupQuotaManager) updateDefaultTreeTotalResourceNoLock() {
+	totalResource := gqm.getDefaultTreeTotalResourceNoLock()
+	rootCalculator := gqm.runtimeQuotaCalculatorMap[extension.RootQuotaName]
+	if !quotav1.Equals(totalResource, rootCalculator.getClusterTotalResource()) {
+		rootCalculator.setClusterTotalResource(totalResource)
+	}
+}
+
+// getDefaultTreeTotalResourceNoLock returns the total resource except systemQuotaGroup and DefaultQuotaGroup's used
+// of the nodes not selected by any independent quota tree.
+func (gqm *GroupQuotaManager) getDefaultTreeTotalResourceNoLock() v1.ResourceList {
+	totalResource := gqm.totalResourceExceptSystemAndDefaultUsed.DeepCopy()
+	for _, treeTotalResource := range gqm.treeTotalResource {
+		totalResource = quotav1.SubtractWithNonNegativeResult(totalResource, treeTotalResource)
+	}
+	return totalResource
+}
+
+// getTreeTotalResourceNoLock returns the total resource of the tree which the top quota under root belongs to.
+func (gqm *GroupQuotaManager) getTreeTotalResourceNoLock(topQuotaInfo *QuotaInfo) v1.ResourceList {
+	if isTreeRoot(topQuotaInfo) {
+		return gqm.treeTotalResource[topQuotaInfo.Name].DeepCopy()
+	}
+	return gqm.getDefaultTreeTotalResourceNoLock()
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+func TestGroupQuotaManager_MultiQuotaTree(t *testing.T) {
+	gqm := NewGroupQuotaManager4Test()
+	gqm.UpdateNodeResource("gpu-node", map[string]string{"pool": "gpu"}, createResourceList(40, 40))
+	gqm.UpdateNodeResource("cpu-node", map[string]string{"pool": "cpu"}, createResourceList(60, 60))
+
+	gpuQuota := CreateQuota("gpu", extension.RootQuotaName, 100, 100, 0, 0, true, true)
+	gpuQuota.Annotations[extension.AnnotationTreeNodeSelector] = `{"pool":"gpu"}`
+	gqm.UpdateQuota(gpuQuota, false)
+	gqm.UpdateQuota(CreateQuota("gpu-a", "gpu", 100, 100, 0, 0, true, false), false)
+	gqm.UpdateQuota(CreateQuota("cpu-a", extension.RootQuotaName, 100, 100, 0, 0, true, false), false)
+	gqm.updateGroupDeltaRequestNoLock("gpu-a", createResourceList(100, 100))
+	gqm.updateGroupDeltaRequestNoLock("cpu-a", createResourceList(100, 100))
+
+	// each tree only shares the resources of its own nodes
+	assert.Equal(t, createResourceList(40, 40), gqm.RefreshRuntime("gpu-a"))
+	assert.Equal(t, createResourceList(60, 60), gqm.RefreshRuntime("cpu-a"))
+
+	assert.Equal(t, "gpu", gqm.GetQuotaTreeName("gpu"))
+	assert.Equal(t, "gpu", gqm.GetQuotaTreeName("gpu-a"))
+	assert.Equal(t, extension.RootQuotaName, gqm.GetQuotaTreeName("cpu-a"))
+	assert.Equal(t, map[string]string{"pool": "gpu"}, gqm.GetQuotaTreeNodeSelector("gpu-a"))
+	assert.Nil(t, gqm.GetQuotaTreeNodeSelector("cpu-a"))
+	assert.Equal(t, "gpu", gqm.GetNodeTreeName(map[string]string{"pool": "gpu"}))
+	assert.Equal(t, extension.RootQuotaName, gqm.GetNodeTreeName(map[string]string{"pool": "cpu"}))
+
+	// the tree total resource changes with its nodes
+	gqm.UpdateNodeResource("gpu-node", map[string]string{"pool": "gpu"}, createResourceList(20, 20))
+	assert.Equal(t, createResourceList(20, 20), gqm.RefreshRuntime("gpu-a"))
+	assert.Equal(t, createResourceList(60, 60), gqm.RefreshRuntime("cpu-a"))
+
+	// the node moves between the trees with its labels
+	gqm.UpdateNodeResource("cpu-node", map[string]string{"pool": "gpu"}, createResourceList(60, 60))
+	assert.Equal(t, createResourceList(80, 80), gqm.treeTotalResource["gpu"])
+	assert.Equal(t, createResourceList(80, 80), gqm.RefreshRuntime("gpu-a"))
+	gqm.UpdateNodeResource("cpu-node", map[string]string{"pool": "cpu"}, createResourceList(60, 60))
+	assert.Equal(t, createResourceList(20, 20), gqm.treeTotalResource["gpu"])
+	assert.Equal(t, createResourceList(60, 60), gqm.RefreshRuntime("cpu-a"))
+	assert.Equal(t, createResourceList(80, 80), gqm.GetClusterTotalResource())
+
+	// the quotas share all the resources after the tree node selector is removed
+	delete(gpuQuota.Annotations, extension.AnnotationTreeNodeSelector)
+	gqm.UpdateQuota(gpuQuota, false)
+	assert.Equal(t, extension.RootQuotaName, gqm.GetQuotaTreeName("gpu-a"))
+	assert.Equal(t, createResourceList(40, 40), gqm.RefreshRuntime("gpu-a"))
+	assert.Equal(t, createResourceList(40, 40), gqm.RefreshRuntime("cpu-a"))
+
+	gqm.DeleteNodeResource("gpu-node")
+	assert.Equal(t, 1, len(gqm.nodeResourceMap))
+	assert.Equal(t, createResourceList(60, 60), gqm.GetClusterTotalResource())
+	assert.Equal(t, createResourceList(60, 60), gqm.GetClusterTotalResource())
+}
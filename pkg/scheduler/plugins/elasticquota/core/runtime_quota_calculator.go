@@ -22,6 +22,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 )
 
 // quotaNode stores the corresponding quotaInfo's information in a specific resource dimension.
@@ -32,8 +34,13 @@ type quotaNode struct {
 	min               int64
 	runtimeQuota      int64
 	allowLentResource bool
+	// borrowLimit and lendLimit are noLimit if not specified
+	borrowLimit int64
+	lendLimit   int64
 }
 
+const noLimit int64 = -1
+
 func NewQuotaNode(quotaName string, sharedWeight, request, min int64, allowLentResource bool) *quotaNode {
 	return &quotaNode{
 		quotaName:         quotaName,
@@ -42,7 +49,40 @@ func NewQuotaNode(quotaName string, sharedWeight, request, min int64, allowLentR
 		min:               min,
 		runtimeQuota:      0,
 		allowLentResource: allowLentResource,
+		borrowLimit:       noLimit,
+		lendLimit:         noLimit,
+	}
+}
+
+// limitedRequest returns the request limited by the borrowLimit, the runtimeQuota never exceeds it.
+func (node *quotaNode) limitedRequest() int64 {
+	if node.borrowLimit >= 0 && node.request > node.min+node.borrowLimit {
+		return node.min + node.borrowLimit
 	}
+	return node.request
+}
+
+// resetRuntimeQuota sets the runtimeQuota without the shared resources, and returns whether the node needs to
+// compete for the shared resources.
+func (node *quotaNode) resetRuntimeQuota() bool {
+	request := node.limitedRequest()
+	if request > node.min {
+		// if a node's request > autoScaleMin, the node needs adjustQuota
+		// the node's runtime is autoScaleMin
+		node.runtimeQuota = node.min
+		return true
+	}
+	if !node.allowLentResource {
+		// if node is not allowLentResource, even if the request is smaller
+		// than autoScaleMin, runtimeQuota is autoScaleMin.
+		node.runtimeQuota = node.min
+	} else if node.lendLimit >= 0 && node.min-request > node.lendLimit {
+		// the node lends no more than lendLimit
+		node.runtimeQuota = node.min - node.lendLimit
+	} else {
+		node.runtimeQuota = request
+	}
+	return false
 }
 
 // quotaTree abstract the struct to calculate each resource dimension's runtime Quota independently
@@ -101,20 +141,9 @@ func (qt *quotaTree) redistribution(totalResource int64) {
 	totalSharedWeight := int64(0)
 	needAdjustQuotaNodes := make([]*quotaNode, 0)
 	for _, node := range qt.quotaNodes {
-		if node.request > node.min {
-			// if a node's request > autoScaleMin, the node needs adjustQuota
-			// the node's runtime is autoScaleMin
+		if node.resetRuntimeQuota() {
 			needAdjustQuotaNodes = append(needAdjustQuotaNodes, node)
 			totalSharedWeight += node.sharedWeight
-			node.runtimeQuota = node.min
-		} else {
-			if node.allowLentResource {
-				node.runtimeQuota = node.request
-			} else {
-				// if node is not allowLentResource, even if the request is smaller
-				// than autoScaleMin, runtimeQuota is request.
-				node.runtimeQuota = node.min
-			}
 		}
 		toPartitionResource -= node.runtimeQuota
 	}
@@ -135,13 +164,13 @@ func (qt *quotaTree) iterationForRedistribution(totalRes, totalSharedWeight int6
 	for _, node := range nodes {
 		runtimeQuotaDelta := int64(float64(node.sharedWeight)*float64(totalRes)/float64(totalSharedWeight) + 0.5)
 		node.runtimeQuota += runtimeQuotaDelta
-		if node.runtimeQuota < node.request {
+		if request := node.limitedRequest(); node.runtimeQuota < request {
 			// if node's runtime is still less than request, the node still need to iterate.
 			needAdjustQuotaNodes = append(needAdjustQuotaNodes, node)
 			needAdjustTotalSharedWeight += node.sharedWeight
 		} else {
-			toPartitionResource += node.runtimeQuota - node.request
-			node.runtimeQuota = node.request
+			toPartitionResource += node.runtimeQuota - request
+			node.runtimeQuota = request
 		}
 	}
 
@@ -163,6 +192,8 @@ type RuntimeQuotaCalculator struct {
 	totalResource        v1.ResourceList              // the parentQuotaInfo's runtimeQuota or the clusterResource
 	lock                 sync.Mutex
 	treeName             string // the same as the parentQuotaInfo's Name
+	// fairSharePolicy decides how the shared resources are split between the childGroups
+	fairSharePolicy config.ElasticQuotaFairSharePolicy
 }
 
 func NewRuntimeQuotaCalculator(treeName string) *RuntimeQuotaCalculator {
@@ -227,10 +258,7 @@ func (qtw *RuntimeQuotaCalculator) updateOneGroupMaxQuota(quotaInfo *QuotaInfo)
 		if exist, _ := qtw.quotaTree[resKey].find(quotaInfo.Name); exist {
 			qtw.quotaTree[resKey].updateRequest(quotaInfo.Name, getQuantityValue(reqLimitPerKey, resKey))
 		} else {
-			sharedWeightPerKey := *quotaInfo.CalculateInfo.SharedWeight.Name(resKey, resource.DecimalSI)
-			autoScaleMinQuotaPerKey := *quotaInfo.CalculateInfo.AutoScaleMin.Name(resKey, resource.DecimalSI)
-			qtw.quotaTree[resKey].insert(quotaInfo.Name, getQuantityValue(sharedWeightPerKey, resKey), getQuantityValue(reqLimitPerKey, resKey),
-				getQuantityValue(autoScaleMinQuotaPerKey, resKey), quotaInfo.AllowLentResource)
+			qtw.insertQuotaNodeNoLock(quotaInfo, resKey, newRequestLimit)
 		}
 
 		// update reqLimitPerKey
@@ -257,10 +285,7 @@ func (qtw *RuntimeQuotaCalculator) updateOneGroupMinQuota(quotaInfo *QuotaInfo)
 		if exist, _ := qtw.quotaTree[resKey].find(quotaInfo.Name); exist {
 			qtw.quotaTree[resKey].updateMin(quotaInfo.Name, getQuantityValue(newMinQuotaPerKey, resKey))
 		} else {
-			sharedWeightPerKey := *quotaInfo.CalculateInfo.SharedWeight.Name(resKey, resource.DecimalSI)
-			reqLimitPerKey := *reqLimit.Name(resKey, resource.DecimalSI)
-			qtw.quotaTree[resKey].insert(quotaInfo.Name, getQuantityValue(sharedWeightPerKey, resKey), getQuantityValue(reqLimitPerKey, resKey),
-				getQuantityValue(newMinQuotaPerKey, resKey), quotaInfo.AllowLentResource)
+			qtw.insertQuotaNodeNoLock(quotaInfo, resKey, reqLimit)
 		}
 	}
 
@@ -284,10 +309,7 @@ func (qtw *RuntimeQuotaCalculator) updateOneGroupSharedWeight(quotaInfo *QuotaIn
 		if exist, _ := qtw.quotaTree[resKey].find(quotaInfo.Name); exist {
 			qtw.quotaTree[resKey].updateSharedWeight(quotaInfo.Name, getQuantityValue(newSharedWeightPerKey, resKey))
 		} else {
-			reqLimitPerKey := *reqLimit.Name(resKey, resource.DecimalSI)
-			minQuotaPerKey := *quotaInfo.CalculateInfo.AutoScaleMin.Name(resKey, resource.DecimalSI)
-			qtw.quotaTree[resKey].insert(quotaInfo.Name, getQuantityValue(newSharedWeightPerKey, resKey), getQuantityValue(reqLimitPerKey, resKey),
-				getQuantityValue(minQuotaPerKey, resKey), quotaInfo.AllowLentResource)
+			qtw.insertQuotaNodeNoLock(quotaInfo, resKey, reqLimit)
 		}
 	}
 
@@ -298,6 +320,23 @@ func (qtw *RuntimeQuotaCalculator) updateOneGroupSharedWeight(quotaInfo *QuotaIn
 	}
 }
 
+// insertQuotaNodeNoLock inserts the quotaNode of the quotaInfo into the quotaTree of the resource dimension.
+func (qtw *RuntimeQuotaCalculator) insertQuotaNodeNoLock(quotaInfo *QuotaInfo, resKey v1.ResourceName, reqLimit v1.ResourceList) {
+	sharedWeightPerKey := *quotaInfo.CalculateInfo.SharedWeight.Name(resKey, resource.DecimalSI)
+	reqLimitPerKey := *reqLimit.Name(resKey, resource.DecimalSI)
+	minQuotaPerKey := *quotaInfo.CalculateInfo.AutoScaleMin.Name(resKey, resource.DecimalSI)
+	qtw.quotaTree[resKey].insert(quotaInfo.Name, getQuantityValue(sharedWeightPerKey, resKey), getQuantityValue(reqLimitPerKey, resKey),
+		getQuantityValue(minQuotaPerKey, resKey), quotaInfo.AllowLentResource)
+
+	_, node := qtw.quotaTree[resKey].find(quotaInfo.Name)
+	if borrowLimit, ok := quotaInfo.CalculateInfo.BorrowLimit[resKey]; ok {
+		node.borrowLimit = getQuantityValue(borrowLimit, resKey)
+	}
+	if lendLimit, ok := quotaInfo.CalculateInfo.LendLimit[resKey]; ok {
+		node.lendLimit = getQuantityValue(lendLimit, resKey)
+	}
+}
+
 // needUpdateOneGroupRequest if oldReqLimit is the same as newReqLimit, no need to adjustQuota.
 // the request of one group may change frequently, but the cost of adjustQuota is high, so here
 // need to judge whether you need to update QuotaNode's request or not.
@@ -331,10 +370,7 @@ func (qtw *RuntimeQuotaCalculator) updateOneGroupRequest(quotaInfo *QuotaInfo) {
 		if exist, _ := qtw.quotaTree[resKey].find(quotaInfo.Name); exist {
 			qtw.quotaTree[resKey].updateRequest(quotaInfo.Name, getQuantityValue(reqLimitPerKey, resKey))
 		} else {
-			sharedWeightPerKey := *quotaInfo.CalculateInfo.SharedWeight.Name(resKey, resource.DecimalSI)
-			minQuotaPerKey := *quotaInfo.CalculateInfo.AutoScaleMin.Name(resKey, resource.DecimalSI)
-			qtw.quotaTree[resKey].insert(quotaInfo.Name, getQuantityValue(sharedWeightPerKey, resKey), getQuantityValue(reqLimitPerKey, resKey),
-				getQuantityValue(minQuotaPerKey, resKey), quotaInfo.AllowLentResource)
+			qtw.insertQuotaNodeNoLock(quotaInfo, resKey, newReqLimit)
 		}
 
 		// update reqLimitPerKey
@@ -395,14 +431,37 @@ func (qtw *RuntimeQuotaCalculator) getGroupRequestLimitNoLock(quotaName string)
 	return res
 }
 
+func (qtw *RuntimeQuotaCalculator) getClusterTotalResource() v1.ResourceList {
+	qtw.lock.Lock()
+	defer qtw.lock.Unlock()
+
+	return qtw.totalResource.DeepCopy()
+}
+
 func (qtw *RuntimeQuotaCalculator) getVersion() int64 {
 	qtw.lock.Lock()
 	defer qtw.lock.Unlock()
 	return qtw.globalRuntimeVersion
 }
 
+// setFairSharePolicy changes how the shared resources are split between the childGroups, then increase globalRuntimeVersion
+func (qtw *RuntimeQuotaCalculator) setFairSharePolicy(policy config.ElasticQuotaFairSharePolicy) {
+	qtw.lock.Lock()
+	defer qtw.lock.Unlock()
+
+	if qtw.fairSharePolicy == policy {
+		return
+	}
+	qtw.fairSharePolicy = policy
+	qtw.globalRuntimeVersion++
+}
+
 func (qtw *RuntimeQuotaCalculator) calculateRuntimeNoLock() {
 	//lock outside
+	if qtw.fairSharePolicy == config.FairSharePolicyDominantResourceFairness {
+		qtw.dominantResourceRedistributionNoLock()
+		return
+	}
 	for resKey := range qtw.resourceKeys {
 		totalResourcePerKey := *qtw.totalResource.Name(resKey, resource.DecimalSI)
 		qtw.quotaTree[resKey].redistribution(getQuantityValue(totalResourcePerKey, resKey))
@@ -18,6 +18,7 @@ package elasticquota
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	"k8s.io/klog/v2"
 
@@ -44,7 +45,7 @@ func (g *Plugin) OnNodeAdd(obj interface{}) {
 		return
 	}
 	g.nodeResourceMap[node.Name] = struct{}{}
-	g.groupQuotaManager.UpdateClusterTotalResource(allocatable)
+	g.groupQuotaManager.UpdateNodeResource(node.Name, node.Labels, allocatable)
 	klog.V(5).Infof("OnNodeAddFunc success %v", node.Name)
 }
 
@@ -72,12 +73,12 @@ func (g *Plugin) OnNodeUpdate(oldObj, newObj interface{}) {
 	oldNodeAllocatable := core.RunDecorateNode(oldNode).Status.Allocatable
 	newNodeAllocatable := core.RunDecorateNode(newNode).Status.Allocatable
 
-	if quotav1.Equals(oldNodeAllocatable, newNodeAllocatable) {
+	// the labels decide which quota tree the node belongs to
+	if quotav1.Equals(oldNodeAllocatable, newNodeAllocatable) && labels.Equals(oldNode.Labels, newNode.Labels) {
 		return
 	}
 
-	deltaNodeAllocatable := quotav1.Subtract(newNodeAllocatable, oldNodeAllocatable)
-	g.groupQuotaManager.UpdateClusterTotalResource(deltaNodeAllocatable)
+	g.groupQuotaManager.UpdateNodeResource(newNode.Name, newNode.Labels, newNodeAllocatable)
 	klog.V(5).Infof("OnNodeUpdateFunc success:%v [%v]", newNode.Name, newNodeAllocatable)
 }
 
@@ -95,9 +96,7 @@ func (g *Plugin) OnNodeDelete(obj interface{}) {
 		return
 	}
 
-	allocatable := core.RunDecorateNode(node).Status.Allocatable
-	delta := quotav1.Subtract(corev1.ResourceList{}, allocatable)
-	g.groupQuotaManager.UpdateClusterTotalResource(delta)
+	g.groupQuotaManager.DeleteNodeResource(node.Name)
 	delete(g.nodeResourceMap, node.Name)
-	klog.V(5).Infof("OnNodeDeleteFunc success:%v [%v]", node.Name, delta)
+	klog.V(5).Infof("OnNodeDeleteFunc success:%v", node.Name)
 }
@@ -47,6 +47,9 @@ const (
 	Name                              = "ElasticQuota"
 	MigrateDefaultQuotaGroupsPodCycle = 1 * time.Second
 	postFilterKey                     = "PostFilter" + Name
+	quotaTreeKey                      = "QuotaTree" + Name
+
+	ErrNodeNotInQuotaTree = "node(s) didn't match the node selector of the quota tree"
 )
 
 type PostFilterState struct {
@@ -59,6 +62,17 @@ func (p *PostFilterState) Clone() framework.StateData {
 	}
 }
 
+// quotaTreeState records the quota tree of the pod and the node selectors of all the independent quota trees, which
+// are resolved once in PreFilter and used by Filter for every node.
+type quotaTreeState struct {
+	treeName      string
+	treeSelectors []core.QuotaTreeSelector
+}
+
+func (s *quotaTreeState) Clone() framework.StateData {
+	return s
+}
+
 type Plugin struct {
 	handle      framework.Handle
 	client      versioned.Interface
@@ -71,10 +85,12 @@ type Plugin struct {
 	nodeResourceMapLock sync.Mutex
 	nodeResourceMap     map[string]struct{}
 	groupQuotaManager   *core.GroupQuotaManager
+	admissionQueues     *admissionQueues
 }
 
 var (
 	_ framework.PreFilterPlugin  = &Plugin{}
+	_ framework.FilterPlugin     = &Plugin{}
 	_ framework.PostFilterPlugin = &Plugin{}
 	_ framework.ReservePlugin    = &Plugin{}
 )
@@ -109,6 +125,8 @@ func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error)
 		groupQuotaManager: core.NewGroupQuotaManager(pluginArgs.SystemQuotaGroupMax, pluginArgs.DefaultQuotaGroupMax),
 		nodeResourceMap:   make(map[string]struct{}),
 	}
+	elasticQuota.admissionQueues = newAdmissionQueues(elasticQuota.lessAdmissionJob)
+	elasticQuota.groupQuotaManager.SetFairSharePolicy(pluginArgs.FairSharePolicy)
 	if err := core.RunDecorateInit(handle); err != nil {
 		return nil, err
 	}
@@ -165,6 +183,12 @@ func (g *Plugin) PreFilter(ctx context.Context, state *framework.CycleState, pod
 		return framework.NewStatus(framework.Error, fmt.Sprintf("Could not find the specified ElasticQuota"))
 	}
 	g.snapshotPostFilterState(quotaName, state)
+	treeState := &quotaTreeState{}
+	if !isExemptFromQuotaTree(quotaName, pod) {
+		treeState.treeName = g.groupQuotaManager.GetQuotaTreeName(quotaName)
+		treeState.treeSelectors = g.groupQuotaManager.GetQuotaTreeSelectors()
+	}
+	state.Write(quotaTreeKey, treeState)
 	quotaUsed := quotaInfo.GetUsed()
 	quotaRuntime := quotaInfo.GetRuntime()
 
@@ -173,14 +197,25 @@ func (g *Plugin) PreFilter(ctx context.Context, state *framework.CycleState, pod
 	newUsed := quotav1.Add(podRequest, quotaUsed)
 
 	if isLessEqual, exceedDimensions := quotav1.LessThanOrEqual(newUsed, quotaRuntime); !isLessEqual {
+		g.enqueueAdmission(quotaName, pod, podRequest)
 		return framework.NewStatus(framework.Unschedulable, fmt.Sprintf("Scheduling refused due to insufficient quotas, "+
 			"quotaName: %v, runtime: %v, used: %v, pod's request: %v, exceedDimensions: %v",
 			quotaName, printResourceList(quotaRuntime), printResourceList(quotaUsed), printResourceList(podRequest), exceedDimensions))
 	}
 
 	if *g.pluginArgs.EnableCheckParentQuota {
-		return g.checkQuotaRecursive(quotaName, []string{quotaName}, podRequest)
+		if status := g.checkQuotaRecursive(quotaName, []string{quotaName}, podRequest); !status.IsSuccess() {
+			g.enqueueAdmission(quotaName, pod, podRequest)
+			return status
+		}
+	}
+
+	if status := g.checkAdmissionQueue(quotaInfo, pod, podRequest); !status.IsSuccess() {
+		return status
 	}
+	// The pod passes the quota check, so it doesn't wait in the admission queue any more even if it is unschedulable
+	// for other reasons.
+	g.dequeueAdmission(pod)
 
 	return framework.NewStatus(framework.Success, "")
 }
@@ -199,6 +234,10 @@ func (g *Plugin) AddPod(ctx context.Context, state *framework.CycleState, podToS
 		return framework.NewStatus(framework.Error, err.Error())
 	}
 	quotaInfo := postFilterState.quotaInfo
+	// The pods in other quotas don't consume the quota of podToSchedule.
+	if g.getPodAssociateQuotaName(podInfoToAdd.Pod) != quotaInfo.Name {
+		return framework.NewStatus(framework.Success, "")
+	}
 	if err = quotaInfo.UpdatePodIsAssigned(podInfoToAdd.Pod, true); err != nil {
 		return framework.NewStatus(framework.Error, err.Error())
 	}
@@ -218,6 +257,10 @@ func (g *Plugin) RemovePod(ctx context.Context, state *framework.CycleState, pod
 		return framework.NewStatus(framework.Error, err.Error())
 	}
 	quotaInfo := postFilterState.quotaInfo
+	// The pods in other quotas don't consume the quota of podToSchedule.
+	if g.getPodAssociateQuotaName(podInfoToRemove.Pod) != quotaInfo.Name {
+		return framework.NewStatus(framework.Success, "")
+	}
 	if err = quotaInfo.UpdatePodIsAssigned(podInfoToRemove.Pod, false); err != nil {
 		return framework.NewStatus(framework.Error, err.Error())
 	}
@@ -227,7 +270,30 @@ func (g *Plugin) RemovePod(ctx context.Context, state *framework.CycleState, pod
 	return framework.NewStatus(framework.Success, "")
 }
 
-// PostFilter modify the defaultPreemption, only allow pods in the same quota can preempt others.
+// Filter only allows the pods to run on the nodes of the quota tree their quota belongs to, i.e. the pods whose quota is
+// in an independent quota tree run on the nodes selected by the tree, and the other pods run on the nodes not selected
+// by any tree, so that the quota trees don't share resources with each other. The system pods and DaemonSet pods can
+// run on any node.
+func (g *Plugin) Filter(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	treeState, err := getQuotaTreeState(state)
+	if err != nil {
+		return framework.NewStatus(framework.Error, err.Error())
+	}
+	if len(treeState.treeSelectors) == 0 {
+		return nil
+	}
+	node := nodeInfo.Node()
+	if node == nil {
+		return framework.NewStatus(framework.Error, "node not found")
+	}
+	if core.MatchNodeTreeName(treeState.treeSelectors, node.Labels) != treeState.treeName {
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrNodeNotInQuotaTree)
+	}
+	return nil
+}
+
+// PostFilter modify the defaultPreemption, only allow pods in the same quota can preempt others,
+// unless the quota is below its min and reclaims the resources lent to other quotas.
 func (g *Plugin) PostFilter(ctx context.Context, state *framework.CycleState, pod *corev1.Pod,
 	filteredNodeStatusMap framework.NodeToStatusMap) (*framework.PostFilterResult, *framework.Status) {
 	nnn, status := g.preempt(ctx, state, pod, filteredNodeStatusMap)
@@ -245,10 +311,12 @@ func (g *Plugin) PostFilter(ctx context.Context, state *framework.CycleState, po
 func (g *Plugin) Reserve(ctx context.Context, state *framework.CycleState, p *corev1.Pod, nodeName string) *framework.Status {
 	quotaName := g.getPodAssociateQuotaName(p)
 	g.groupQuotaManager.ReservePod(quotaName, p)
+	g.updateAdmissionAssigned(p, true)
 	return framework.NewStatus(framework.Success, "")
 }
 
 func (g *Plugin) Unreserve(ctx context.Context, state *framework.CycleState, p *corev1.Pod, nodeName string) {
 	quotaName := g.getPodAssociateQuotaName(p)
 	g.groupQuotaManager.UnreservePod(quotaName, p)
+	g.updateAdmissionAssigned(p, false)
 }
@@ -34,6 +34,7 @@ import (
 	schedulinglisterv1alpha1 "sigs.k8s.io/scheduler-plugins/pkg/generated/listers/scheduling/v1alpha1"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/elasticquota/core"
 )
 
 // getPodAssociateQuotaName If pod's don't have the "quota-name" label, we will use the namespace to associate pod with quota
@@ -41,7 +42,7 @@ import (
 func (g *Plugin) getPodAssociateQuotaName(pod *v1.Pod) string {
 	quotaName := extension.GetQuotaName(pod)
 	if quotaName == "" {
-		quotaName = GetQuotaName(g.quotaLister, pod)
+		quotaName = GetQuotaName(g.quotaLister, g.groupQuotaManager, pod)
 	}
 	// can't get the quotaInfo by quotaName, let the pod belongs to DefaultQuotaGroup
 	if g.groupQuotaManager.GetQuotaInfoByName(quotaName) == nil {
@@ -51,7 +52,10 @@ func (g *Plugin) getPodAssociateQuotaName(pod *v1.Pod) string {
 	return quotaName
 }
 
-var GetQuotaName = func(quotaLister schedulinglisterv1alpha1.ElasticQuotaLister, pod *v1.Pod) string {
+// GetQuotaName returns the quota in the namespace of the pod. If there are multiple quotas in the namespace, e.g.
+// one in each quota tree, the pod is charged against the quota whose tree node selector matches the pod's node
+// selector, otherwise the quota in the default tree.
+var GetQuotaName = func(quotaLister schedulinglisterv1alpha1.ElasticQuotaLister, groupQuotaManager *core.GroupQuotaManager, pod *v1.Pod) string {
 	list, err := quotaLister.ElasticQuotas(pod.Namespace).List(labels.Everything())
 	if err != nil {
 		runtime.HandleError(err)
@@ -60,7 +64,27 @@ var GetQuotaName = func(quotaLister schedulinglisterv1alpha1.ElasticQuotaLister,
 	if len(list) == 0 {
 		return extension.DefaultQuotaName
 	}
-	// todo when elastic quota supports multiple instances in a namespace, modify this
+	if len(list) == 1 {
+		return list[0].Name
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	defaultTreeQuotaName := ""
+	for _, quota := range list {
+		treeNodeSelector := groupQuotaManager.GetQuotaTreeNodeSelector(quota.Name)
+		if len(treeNodeSelector) == 0 {
+			if defaultTreeQuotaName == "" {
+				defaultTreeQuotaName = quota.Name
+			}
+			continue
+		}
+		if labels.SelectorFromSet(treeNodeSelector).Matches(labels.Set(pod.Spec.NodeSelector)) {
+			return quota.Name
+		}
+	}
+	if defaultTreeQuotaName != "" {
+		return defaultTreeQuotaName
+	}
 	return list[0].Name
 }
 
@@ -169,6 +193,31 @@ func getPostFilterState(cycleState *framework.CycleState) (*PostFilterState, err
 	return s, nil
 }
 
+func getQuotaTreeState(cycleState *framework.CycleState) (*quotaTreeState, error) {
+	c, err := cycleState.Read(quotaTreeKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %q from cycleState: %v", quotaTreeKey, err)
+	}
+
+	s, ok := c.(*quotaTreeState)
+	if !ok {
+		return nil, fmt.Errorf("%+v convert to ElasticQuota.quotaTreeState error", c)
+	}
+	return s, nil
+}
+
+// isExemptFromQuotaTree returns whether the pod can run on the nodes of any quota tree, i.e. the pods in the system
+// quota and the DaemonSet pods, which run on every node regardless of the quota trees.
+func isExemptFromQuotaTree(quotaName string, pod *v1.Pod) bool {
+	if quotaName == extension.SystemQuotaName {
+		return true
+	}
+	if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+		return true
+	}
+	return false
+}
+
 func (g *Plugin) checkQuotaRecursive(curQuotaName string, quotaNameTopo []string, podRequest v1.ResourceList) *framework.Status {
 	quotaInfo := g.groupQuotaManager.GetQuotaInfoByName(curQuotaName)
 	quotaUsed := quotaInfo.GetUsed()
@@ -36,6 +36,15 @@ func (g *Plugin) RegisterEndpoints(group *gin.RouterGroup) {
 		}
 		c.JSON(http.StatusOK, quotaSummary)
 	})
+	group.GET("/quota/:name/queue", func(c *gin.Context) {
+		quotaName := c.Param("name")
+		queue, exist := g.GetAdmissionQueue(quotaName)
+		if !exist {
+			services.ResponseErrorMessage(c, http.StatusNotFound, "cannot find quota %s", quotaName)
+			return
+		}
+		c.JSON(http.StatusOK, queue)
+	})
 	group.GET("/quotas", func(c *gin.Context) {
 		quotaSummaries := g.GetQuotaSummaries()
 		c.JSON(http.StatusOK, quotaSummaries)
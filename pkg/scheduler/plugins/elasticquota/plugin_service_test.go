@@ -17,6 +17,7 @@ limitations under the License.
 package elasticquota
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -27,8 +28,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/elasticquota/core"
 )
 
@@ -149,3 +152,48 @@ func TestEndpointsQueryQuotaInfo(t *testing.T) {
 		assert.True(t, quotav1.Equals(quotaSummary.PodCache[podToCreate.Namespace+"/"+podToCreate.Name].Resource, createResourceList(33, 33)))
 	}
 }
+
+func TestEndpointsQueryAdmissionQueue(t *testing.T) {
+	suit := newPluginTestSuit(t, nil)
+	p, err := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+	assert.NotNil(t, p)
+	assert.Nil(t, err)
+
+	eq := p.(*Plugin)
+	eq.pluginArgs.AdmissionQueuePolicy = config.AdmissionQueuePolicyFIFO
+	eq.OnQuotaAdd(CreateQuota2("test1", "", 100, 100, 10, 10, 20, 20, false))
+	pod2 := createPendingPod("pod2", "test1", "", 0, 2, 1, 1)
+	pod1 := createPendingPod("pod1", "test1", "", 0, 1, 1, 1)
+	eq.OnPodAdd(pod2)
+	eq.OnPodAdd(pod1)
+	qi := eq.groupQuotaManager.GetQuotaInfoByName("test1")
+	qi.Lock()
+	qi.CalculateInfo.Runtime = createResourceList(0, 0)
+	qi.UnLock()
+	for _, pod := range []*corev1.Pod{pod2, pod1} {
+		assert.False(t, eq.PreFilter(context.TODO(), framework.NewCycleState(), pod).IsSuccess())
+	}
+
+	engine := gin.Default()
+	eq.RegisterEndpoints(engine.Group("/"))
+	{
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/quota/test1/queue", nil)
+		engine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		var queue []*AdmissionJob
+		err = json.NewDecoder(w.Result().Body).Decode(&queue)
+		assert.NoError(t, err)
+		assert.Len(t, queue, 2)
+		assert.Equal(t, 1, queue[0].Position)
+		assert.Equal(t, []string{"pod1"}, queue[0].Pods)
+		assert.Equal(t, 2, queue[1].Position)
+		assert.Equal(t, []string{"pod2"}, queue[1].Pods)
+	}
+	{
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/quota/not-exist/queue", nil)
+		engine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	}
+}
@@ -862,6 +862,89 @@ func TestPlugin_PreFilter(t *testing.T) {
 	}
 }
 
+func TestPlugin_FilterQuotaTree(t *testing.T) {
+	suit := newPluginTestSuit(t, nil)
+	p, _ := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+	gp := p.(*Plugin)
+	ctx := context.TODO()
+
+	gpuQuota := CreateQuota2("gpu", extension.RootQuotaName, 100, 100, 0, 0, 100, 100, false)
+	gpuQuota.Namespace = "t1-ns1"
+	gpuQuota.Annotations[extension.AnnotationTreeNodeSelector] = `{"pool":"gpu"}`
+	cpuQuota := CreateQuota2("cpu", extension.RootQuotaName, 100, 100, 0, 0, 100, 100, false)
+	cpuQuota.Namespace = "t1-ns1"
+	for _, quota := range []*v1alpha1.ElasticQuota{gpuQuota, cpuQuota} {
+		_, err := suit.client.SchedulingV1alpha1().ElasticQuotas(quota.Namespace).Create(ctx, quota, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	for i := 0; i < 10 && (gp.groupQuotaManager.GetQuotaInfoByName("gpu") == nil || gp.groupQuotaManager.GetQuotaInfoByName("cpu") == nil); i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	gpuNode := schedulertesting.MakeNode().Name("gpu-node").Label("pool", "gpu").Obj()
+	cpuNode := schedulertesting.MakeNode().Name("cpu-node").Label("pool", "cpu").Obj()
+	daemonSetPod := MakePod("t1-ns1", "pod5").Obj()
+	daemonSetPod.OwnerReferences = []metav1.OwnerReference{
+		{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "ds", Controller: pointer.Bool(true)},
+	}
+	tests := []struct {
+		name      string
+		pod       *corev1.Pod
+		wantQuota string
+		wantGPU   *framework.Status
+		wantCPU   *framework.Status
+	}{
+		{
+			name:      "pod selects the gpu pool",
+			pod:       MakePod("t1-ns1", "pod1").NodeSelector(map[string]string{"pool": "gpu"}).Obj(),
+			wantQuota: "gpu",
+			wantGPU:   nil,
+			wantCPU:   framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrNodeNotInQuotaTree),
+		},
+		{
+			name:      "pod without node selector",
+			pod:       MakePod("t1-ns1", "pod2").Obj(),
+			wantQuota: "cpu",
+			wantGPU:   framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrNodeNotInQuotaTree),
+			wantCPU:   nil,
+		},
+		{
+			name:      "pod with quota label",
+			pod:       MakePod("t1-ns1", "pod3").Label(extension.LabelQuotaName, "gpu").Obj(),
+			wantQuota: "gpu",
+			wantGPU:   nil,
+			wantCPU:   framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrNodeNotInQuotaTree),
+		},
+		{
+			name:      "system pod runs on any node",
+			pod:       MakePod("t1-ns1", "pod4").Label(extension.LabelQuotaName, extension.SystemQuotaName).Obj(),
+			wantQuota: extension.SystemQuotaName,
+			wantGPU:   nil,
+			wantCPU:   nil,
+		},
+		{
+			name:      "DaemonSet pod runs on any node",
+			pod:       daemonSetPod,
+			wantQuota: "cpu",
+			wantGPU:   nil,
+			wantCPU:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantQuota, gp.getPodAssociateQuotaName(tt.pod))
+			state := framework.NewCycleState()
+			assert.True(t, gp.PreFilter(ctx, state, tt.pod).IsSuccess())
+			gpuNodeInfo := framework.NewNodeInfo()
+			gpuNodeInfo.SetNode(gpuNode)
+			assert.Equal(t, tt.wantGPU, gp.Filter(ctx, state, tt.pod, gpuNodeInfo))
+			cpuNodeInfo := framework.NewNodeInfo()
+			cpuNodeInfo.SetNode(cpuNode)
+			assert.Equal(t, tt.wantCPU, gp.Filter(ctx, state, tt.pod, cpuNodeInfo))
+		})
+	}
+}
+
 func TestPlugin_PreFilter_CheckParent(t *testing.T) {
 	test := []struct {
 		name           string
@@ -1218,6 +1301,172 @@ func TestPlugin_DryRunPreemption(t *testing.T) {
 			},
 			clusterResource: createResourceList(0, 100),
 		},
+		{
+			name: "reclaim lent resources from other quotaGroup",
+			pod:  makePod("t1-p", "ns1", 50, 0, 0, midPriority, "", "t1-p"),
+			pods: []*corev1.Pod{
+				makePod("t1-p1", "ns2", 50, 0, 0, lowPriority, "t1-p1", "node-a"),
+				makePod("t1-p2", "ns2", 50, 0, 0, midPriority-1, "t1-p2", "node-a"),
+				makePod("t1-p3", "ns2", 50, 0, 0, highPriority, "t1-p3", "node-a"),
+			},
+			nodes: []*corev1.Node{
+				schedulertesting.MakeNode().Name("node-a").Capacity(res).Obj(),
+			},
+			nodesStatuses: framework.NodeToStatusMap{
+				"node-a": framework.NewStatus(framework.Unschedulable),
+			},
+			want: []defaultpreemption.Candidate{
+				&candidate{
+					victims: &extenderv1.Victims{
+						Pods: []*corev1.Pod{
+							makePod("t1-p1", "ns2", 50, 0, 0, lowPriority, "t1-p1", "node-a"),
+						},
+						NumPDBViolations: 0,
+					},
+					name: "node-a",
+				},
+			},
+			quotaInfos: []*core.QuotaInfo{
+				{
+					Name:          "ns1",
+					ParentName:    "root",
+					PodCache:      make(map[string]*core.PodInfo),
+					CalculateInfo: core.QuotaCalculateInfo{Min: createResourceList(0, 100)},
+				},
+				{
+					Name:          "ns2",
+					ParentName:    "root",
+					PodCache:      make(map[string]*core.PodInfo),
+					CalculateInfo: core.QuotaCalculateInfo{Min: createResourceList(0, 0)},
+				},
+			},
+			clusterResource: createResourceList(0, 400),
+		},
+		{
+			name: "not reclaim lent resources from the pods with equal priority",
+			pod:  makePod("t1-p", "ns1", 50, 0, 0, midPriority, "", "t1-p"),
+			pods: []*corev1.Pod{
+				makePod("t1-p1", "ns2", 50, 0, 0, midPriority, "t1-p1", "node-a"),
+				makePod("t1-p2", "ns2", 50, 0, 0, midPriority, "t1-p2", "node-a"),
+				makePod("t1-p3", "ns2", 50, 0, 0, highPriority, "t1-p3", "node-a"),
+			},
+			nodes: []*corev1.Node{
+				schedulertesting.MakeNode().Name("node-a").Capacity(res).Obj(),
+			},
+			nodesStatuses: framework.NodeToStatusMap{
+				"node-a": framework.NewStatus(framework.Unschedulable),
+			},
+			want: nil,
+			quotaInfos: []*core.QuotaInfo{
+				{
+					Name:          "ns1",
+					ParentName:    "root",
+					PodCache:      make(map[string]*core.PodInfo),
+					CalculateInfo: core.QuotaCalculateInfo{Min: createResourceList(0, 100)},
+				},
+				{
+					Name:          "ns2",
+					ParentName:    "root",
+					PodCache:      make(map[string]*core.PodInfo),
+					CalculateInfo: core.QuotaCalculateInfo{Min: createResourceList(0, 0)},
+				},
+			},
+			clusterResource: createResourceList(0, 400),
+		},
+		{
+			name: "not reclaim lent resources from non-preemptible quotaGroup",
+			pod:  makePod("t1-p", "ns1", 50, 0, 0, midPriority, "", "t1-p"),
+			pods: []*corev1.Pod{
+				makePod("t1-p1", "ns2", 50, 0, 0, lowPriority, "t1-p1", "node-a"),
+				makePod("t1-p2", "ns2", 50, 0, 0, midPriority-1, "t1-p2", "node-a"),
+				makePod("t1-p3", "ns2", 50, 0, 0, highPriority, "t1-p3", "node-a"),
+			},
+			nodes: []*corev1.Node{
+				schedulertesting.MakeNode().Name("node-a").Capacity(res).Obj(),
+			},
+			nodesStatuses: framework.NodeToStatusMap{
+				"node-a": framework.NewStatus(framework.Unschedulable),
+			},
+			want: nil,
+			quotaInfos: []*core.QuotaInfo{
+				{
+					Name:          "ns1",
+					ParentName:    "root",
+					PodCache:      make(map[string]*core.PodInfo),
+					CalculateInfo: core.QuotaCalculateInfo{Min: createResourceList(0, 100)},
+				},
+				{
+					Name:           "ns2",
+					ParentName:     "root",
+					PodCache:       make(map[string]*core.PodInfo),
+					CalculateInfo:  core.QuotaCalculateInfo{Min: createResourceList(0, 0)},
+					NonPreemptible: true,
+				},
+			},
+			clusterResource: createResourceList(0, 400),
+		},
+		{
+			name: "not reclaim lent resources when quotaGroup exceeds its min",
+			pod:  makePod("t1-p", "ns1", 50, 0, 0, midPriority, "", "t1-p"),
+			pods: []*corev1.Pod{
+				makePod("t1-p1", "ns2", 50, 0, 0, lowPriority, "t1-p1", "node-a"),
+				makePod("t1-p2", "ns2", 50, 0, 0, midPriority-1, "t1-p2", "node-a"),
+				makePod("t1-p3", "ns2", 50, 0, 0, highPriority, "t1-p3", "node-a"),
+			},
+			nodes: []*corev1.Node{
+				schedulertesting.MakeNode().Name("node-a").Capacity(res).Obj(),
+			},
+			nodesStatuses: framework.NodeToStatusMap{
+				"node-a": framework.NewStatus(framework.Unschedulable),
+			},
+			want: nil,
+			quotaInfos: []*core.QuotaInfo{
+				{
+					Name:          "ns1",
+					ParentName:    "root",
+					PodCache:      make(map[string]*core.PodInfo),
+					CalculateInfo: core.QuotaCalculateInfo{Min: createResourceList(0, 0)},
+				},
+				{
+					Name:          "ns2",
+					ParentName:    "root",
+					PodCache:      make(map[string]*core.PodInfo),
+					CalculateInfo: core.QuotaCalculateInfo{Min: createResourceList(0, 0)},
+				},
+			},
+			clusterResource: createResourceList(0, 400),
+		},
+		{
+			name: "not reclaim lent resources below the min of other quotaGroup",
+			pod:  makePod("t1-p", "ns1", 100, 0, 0, midPriority, "", "t1-p"),
+			pods: []*corev1.Pod{
+				makePod("t1-p1", "ns2", 50, 0, 0, lowPriority, "t1-p1", "node-a"),
+				makePod("t1-p2", "ns2", 50, 0, 0, midPriority-1, "t1-p2", "node-a"),
+				makePod("t1-p3", "ns2", 50, 0, 0, highPriority, "t1-p3", "node-a"),
+			},
+			nodes: []*corev1.Node{
+				schedulertesting.MakeNode().Name("node-a").Capacity(res).Obj(),
+			},
+			nodesStatuses: framework.NodeToStatusMap{
+				"node-a": framework.NewStatus(framework.Unschedulable),
+			},
+			want: nil,
+			quotaInfos: []*core.QuotaInfo{
+				{
+					Name:          "ns1",
+					ParentName:    "root",
+					PodCache:      make(map[string]*core.PodInfo),
+					CalculateInfo: core.QuotaCalculateInfo{Min: createResourceList(0, 100)},
+				},
+				{
+					Name:          "ns2",
+					ParentName:    "root",
+					PodCache:      make(map[string]*core.PodInfo),
+					CalculateInfo: core.QuotaCalculateInfo{Min: createResourceList(0, 100)},
+				},
+			},
+			clusterResource: createResourceList(0, 400),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1230,7 +1479,10 @@ func TestPlugin_DryRunPreemption(t *testing.T) {
 
 			gqm.UpdateClusterTotalResource(tt.clusterResource)
 			for _, quota := range tt.quotaInfos {
-				q := CreateQuota2(quota.Name, quota.ParentName, 1000, 1000, 0, 0, 1000, 1000, false)
+				q := CreateQuota2(quota.Name, quota.ParentName, 1000, 1000, 0, quota.CalculateInfo.Min.Memory().Value(), 1000, 1000, false)
+				if quota.NonPreemptible {
+					q.Labels[extension.LabelNonPreemptible] = "true"
+				}
 				pl.OnQuotaAdd(q)
 			}
 			for _, pod := range tt.pods {
@@ -1270,8 +1522,10 @@ func TestPlugin_DryRunPreemption(t *testing.T) {
 			})
 
 			for _, victim := range tt.want {
-				victim.Victims().Pods[0].Labels = make(map[string]string)
-				victim.Victims().Pods[0].Labels[extension.LabelQuotaName] = victim.Victims().Pods[0].Namespace
+				for _, victimPod := range victim.Victims().Pods {
+					victimPod.Labels = make(map[string]string)
+					victimPod.Labels[extension.LabelQuotaName] = victimPod.Namespace
+				}
 			}
 			if diff := gocmp.Diff(tt.want, got, gocmp.AllowUnexported(candidate{})); diff != "" {
 				t.Errorf("Unexpected candidates (-want, +got): %s", diff)
@@ -34,6 +34,9 @@ func (g *Plugin) OnPodAdd(obj interface{}) {
 	pod = core.RunDecoratePod(pod)
 	quotaName := g.getPodAssociateQuotaName(pod)
 	g.groupQuotaManager.OnPodAdd(quotaName, pod)
+	if pod.Spec.NodeName != "" {
+		g.updateAdmissionAssigned(pod, true)
+	}
 	klog.V(5).Infof("OnPodAddFunc %v.%v add success, quotaName:%v", pod.Namespace, pod.Name, quotaName)
 }
 
@@ -51,6 +54,12 @@ func (g *Plugin) OnPodUpdate(oldObj, newObj interface{}) {
 	oldQuotaName := g.getPodAssociateQuotaName(oldPod)
 	newQuotaName := g.getPodAssociateQuotaName(newPod)
 	g.groupQuotaManager.OnPodUpdate(newQuotaName, oldQuotaName, newPod, oldPod)
+	if oldQuotaName != newQuotaName {
+		g.dequeueAdmission(oldPod)
+	}
+	if newPod.Spec.NodeName != "" {
+		g.updateAdmissionAssigned(newPod, true)
+	}
 	klog.V(5).Infof("OnPodUpdateFunc %v.%v update success, quotaName:%v", newPod.Namespace, newPod.Name, newQuotaName)
 }
 
@@ -63,5 +72,7 @@ func (g *Plugin) OnPodDelete(obj interface{}) {
 	pod = core.RunDecoratePod(pod)
 	quotaName := g.getPodAssociateQuotaName(pod)
 	g.groupQuotaManager.OnPodDelete(quotaName, pod)
+	g.dequeueAdmission(pod)
+	g.updateAdmissionAssigned(pod, false)
 	klog.V(5).Infof("OnPodDeleteFunc %v.%v delete success", pod.Namespace, pod.Name)
 }
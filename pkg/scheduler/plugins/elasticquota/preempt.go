@@ -26,6 +26,7 @@ import (
 	policy "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	"k8s.io/apiserver/pkg/util/feature"
 	policylisters "k8s.io/client-go/listers/policy/v1"
@@ -38,6 +39,7 @@ import (
 	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/defaultpreemption"
 	"k8s.io/kubernetes/pkg/scheduler/util"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/elasticquota/core"
 )
 
@@ -133,12 +135,23 @@ func (g *Plugin) findCandidates(
 // considered for preemption.
 // We look at the node that is nominated for this pod and as long as there are
 // terminating pods on the node, we don't consider this for preempting more pods.
+// The terminating pods include the lower priority pods in the same quota, and the
+// pods in other quotas when the quota of this pod reclaims its lent resources.
 func (g *Plugin) podEligibleToPreemptOthers(pod *corev1.Pod, nodeInfos framework.NodeInfoLister, nominatedNodeStatus *framework.Status, state *framework.CycleState) bool {
 	if pod.Spec.PreemptionPolicy != nil && *pod.Spec.PreemptionPolicy == corev1.PreemptNever {
 		klog.V(5).InfoS("Pod is not eligible for preemption because of its preemptionPolicy", "pod", klog.KObj(pod), "preemptionPolicy", corev1.PreemptNever)
 		return false
 	}
 
+	// The pod behind the head of the admission queue should not preempt the resources that the jobs ahead wait for.
+	if quotaInfo := g.groupQuotaManager.GetQuotaInfoByName(g.getPodAssociateQuotaName(pod)); quotaInfo != nil {
+		podRequest, _ := resource.PodRequestsAndLimits(core.RunDecoratePod(pod))
+		if status := g.checkAdmissionQueue(quotaInfo, pod, podRequest); !status.IsSuccess() {
+			klog.V(5).InfoS("Pod is not eligible for preemption because it is waiting in the admission queue", "pod", klog.KObj(pod), "reason", status.Message())
+			return false
+		}
+	}
+
 	nomNodeName := pod.Status.NominatedNodeName
 	if len(nomNodeName) > 0 {
 		// If the pod's nominated node is considered as UnschedulableAndUnresolvable by the filters,
@@ -154,6 +167,10 @@ func (g *Plugin) podEligibleToPreemptOthers(pod *corev1.Pod, nodeInfos framework
 
 		podPriority := corev1helpers.PodPriority(pod)
 		quotaName := g.getPodAssociateQuotaName(pod)
+		reclaimable := false
+		if postFilterState, err := getPostFilterState(state); err == nil {
+			reclaimable = canReclaimLentResource(postFilterState.quotaInfo, pod)
+		}
 		for _, p := range nodeInfo.Pods {
 			if p.Pod.DeletionTimestamp != nil {
 				piQuotaName := g.getPodAssociateQuotaName(p.Pod)
@@ -161,6 +178,10 @@ func (g *Plugin) podEligibleToPreemptOthers(pod *corev1.Pod, nodeInfos framework
 					// There is a terminating pod on the nominated node with the same quotaName.
 					return false
 				}
+				if reclaimable && g.canReclaim(pod, p.Pod) {
+					// There is a terminating pod on the nominated node which lent resources are reclaimed.
+					return false
+				}
 			}
 		}
 	}
@@ -210,6 +231,10 @@ func (g *Plugin) dryRunPreemption(ctx context.Context, state *framework.CycleSta
 // sorted by priority. It first tries to reprieve as many PDB violating pods as
 // possible and then does them same for non-PDB-violating pods while checking
 // that the "pod" can still fit on the node.
+// If the quota of "pod" is still within its min after "pod" is scheduled, the pods
+// in other quotas which borrow resources above their min are potential victims too,
+// except the ones whose PodDisruptionBudget will be violated. The quota of these
+// victims will not be preempted below its min.
 // NOTE: This function assumes that it is never called if "pod" cannot be scheduled
 // due to pod affinity, node affinity, or node anti-affinity reasons. None of
 // these predicates can be satisfied by removing more pods from the node.
@@ -239,15 +264,30 @@ func (g *Plugin) selectVictimsOnNode(
 		}
 		return nil
 	}
-	// As the first step, remove all the lower priority pods from the node and
-	// check if the given pod can be scheduled.
+	postFilterState, _ := getPostFilterState(state)
+	quotaInfo := postFilterState.quotaInfo
+	reclaimable := canReclaimLentResource(quotaInfo, pod)
+
+	// As the first step, remove all the lower priority pods in the same quota and
+	// the reclaimable pods in other quotas from the node and check if the given pod
+	// can be scheduled.
+	var reclaimablePods []*framework.PodInfo
 	for _, pi := range nodeInfo.Pods {
-		// TODO only allow same quotaGroup preemption.
 		if g.canPreempt(pod, pi.Pod) {
 			potentialVictims = append(potentialVictims, pi)
 			if err := removePod(pi); err != nil {
 				return nil, 0, framework.AsStatus(err)
 			}
+		} else if reclaimable && g.canReclaim(pod, pi.Pod) {
+			reclaimablePods = append(reclaimablePods, pi)
+		}
+	}
+	reclaimedPods := map[types.UID]bool{}
+	for _, pi := range g.selectReclaimedPods(reclaimablePods, pdbs) {
+		reclaimedPods[pi.Pod.UID] = true
+		potentialVictims = append(potentialVictims, pi)
+		if err := removePod(pi); err != nil {
+			return nil, 0, framework.AsStatus(err)
 		}
 	}
 
@@ -274,8 +314,6 @@ func (g *Plugin) selectVictimsOnNode(
 	// from the highest priority victims.
 	violatingVictims, nonViolatingVictims := filterPodsWithPDBViolation(potentialVictims, pdbs)
 
-	postFilterState, _ := getPostFilterState(state)
-	quotaInfo := postFilterState.quotaInfo
 	pod = core.RunDecoratePod(pod)
 	podReq, _ := resource.PodRequestsAndLimits(pod)
 
@@ -294,6 +332,10 @@ func (g *Plugin) selectVictimsOnNode(
 			klog.V(5).InfoS("Pod is a potential preemption victim on node", "pod", klog.KObj(rpi), "node", klog.KObj(nodeInfo.Node()))
 		}
 
+		// The reclaimed pods don't consume the quota of "pod".
+		if reclaimedPods[pi.Pod.UID] {
+			return fits, nil
+		}
 		newUsed := quotav1.Add(quotaInfo.GetUsed(), podReq)
 		if isLessEqual, _ := quotav1.LessThanOrEqual(newUsed, postFilterState.quotaInfo.CalculateInfo.Runtime); !isLessEqual {
 			if err := removePod(pi); err != nil {
@@ -419,3 +461,69 @@ func (g *Plugin) canPreempt(pod, victim *corev1.Pod) bool {
 
 	return podPri > vicPri && podQuotaName == vicQuotaName
 }
+
+// canReclaimLentResource returns whether the quota is still within its min after the pod is scheduled,
+// and then the pod can reclaim the resources lent to other quotas.
+func canReclaimLentResource(quotaInfo *core.QuotaInfo, pod *corev1.Pod) bool {
+	if quotaInfo == nil {
+		return false
+	}
+	guaranteed := quotaInfo.GetGuaranteed()
+	if quotav1.IsZero(guaranteed) {
+		return false
+	}
+	podReq, _ := resource.PodRequestsAndLimits(core.RunDecoratePod(pod))
+	newUsed := quotav1.Add(quotaInfo.GetUsed(), podReq)
+	isLessEqual, _ := quotav1.LessThanOrEqual(newUsed, guaranteed)
+	return isLessEqual
+}
+
+// canReclaim returns whether the pod can preempt the victim in another quota to reclaim the lent resources.
+// The victim should have a strictly lower priority like the other preemptions, and its quota should be preemptible.
+func (g *Plugin) canReclaim(pod, victim *corev1.Pod) bool {
+	if corev1helpers.PodPriority(pod) <= corev1helpers.PodPriority(victim) {
+		return false
+	}
+	podQuotaName := g.getPodAssociateQuotaName(pod)
+	vicQuotaName := g.getPodAssociateQuotaName(victim)
+	if podQuotaName == vicQuotaName || vicQuotaName == extension.SystemQuotaName {
+		return false
+	}
+	vicQuotaInfo := g.groupQuotaManager.GetQuotaInfoByName(vicQuotaName)
+	return vicQuotaInfo != nil && !vicQuotaInfo.NonPreemptible
+}
+
+// selectReclaimedPods selects the pods to reclaim from the reclaimable pods, starting from the least important
+// ones. The pods whose PodDisruptionBudget will be violated are skipped, and a pod is selected only if its quota
+// is still not below its min after the pod is preempted.
+func (g *Plugin) selectReclaimedPods(podInfos []*framework.PodInfo, pdbs []*policy.PodDisruptionBudget) []*framework.PodInfo {
+	if len(podInfos) == 0 {
+		return nil
+	}
+	sort.Slice(podInfos, func(i, j int) bool { return util.MoreImportantPod(podInfos[j].Pod, podInfos[i].Pod) })
+	_, nonViolatingPodInfos := filterPodsWithPDBViolation(podInfos, pdbs)
+
+	var reclaimedPodInfos []*framework.PodInfo
+	quotaUsed := map[string]corev1.ResourceList{}
+	for _, pi := range nonViolatingPodInfos {
+		quotaName := g.getPodAssociateQuotaName(pi.Pod)
+		quotaInfo := g.groupQuotaManager.GetQuotaInfoByName(quotaName)
+		if quotaInfo == nil {
+			continue
+		}
+		used, ok := quotaUsed[quotaName]
+		if !ok {
+			used = quotaInfo.GetUsed()
+		}
+		podReq, _ := resource.PodRequestsAndLimits(core.RunDecoratePod(pi.Pod))
+		podReq = quotav1.RemoveZeros(podReq)
+		newUsed := quotav1.Subtract(used, podReq)
+		guaranteed := quotav1.Mask(quotaInfo.GetGuaranteed(), quotav1.ResourceNames(podReq))
+		if isLessEqual, _ := quotav1.LessThanOrEqual(guaranteed, newUsed); !isLessEqual {
+			continue
+		}
+		quotaUsed[quotaName] = newUsed
+		reclaimedPodInfos = append(reclaimedPodInfos, pi)
+	}
+	return reclaimedPodInfos
+}
@@ -56,37 +56,12 @@ func stillInTheReportInterval(assignedTime, updateTime time.Time, reportInterval
 }
 
 func getTargetAggregatedUsage(nodeMetric *slov1alpha1.NodeMetric, aggregatedDuration *metav1.Duration, aggregationType slov1alpha1.AggregationType) *slov1alpha1.ResourceMap {
-	if nodeMetric.Status.NodeMetric == nil || len(nodeMetric.Status.NodeMetric.AggregatedNodeUsages) == 0 {
+	if nodeMetric.Status.NodeMetric == nil {
 		return nil
 	}
-
 	// If no specific period is set, the maximum period recorded by NodeMetrics will be used by default.
 	// This is a default policy.
-	if aggregatedDuration == nil || aggregatedDuration.Duration == 0 {
-		var maxDuration time.Duration
-		var maxIndex int
-		for i, v := range nodeMetric.Status.NodeMetric.AggregatedNodeUsages {
-			if v.Duration.Duration > maxDuration {
-				maxDuration = v.Duration.Duration
-				maxIndex = i
-			}
-		}
-		aggregatedNodeUsage := &nodeMetric.Status.NodeMetric.AggregatedNodeUsages[maxIndex]
-		usage := aggregatedNodeUsage.Usage[aggregationType]
-		if len(usage.ResourceList) > 0 {
-			return &usage
-		}
-	} else if aggregatedDuration != nil {
-		for _, v := range nodeMetric.Status.NodeMetric.AggregatedNodeUsages {
-			if v.Duration.Duration == aggregatedDuration.Duration {
-				usage := v.Usage[aggregationType]
-				if len(usage.ResourceList) > 0 {
-					return &usage
-				}
-			}
-		}
-	}
-	return nil
+	return util.GetTargetAggregatedUsage(nodeMetric.Status.NodeMetric.AggregatedNodeUsages, aggregatedDuration, aggregationType)
 }
 
 func filterWithAggregation(args *schedulingconfig.LoadAwareSchedulingAggregatedArgs) bool {
@@ -150,7 +125,10 @@ func getResourceValue(resourceName corev1.ResourceName, quantity resource.Quanti
 	return quantity.Value()
 }
 
-func buildPodMetricMap(podLister corev1listers.PodLister, nodeMetric *slov1alpha1.NodeMetric, filterProdPod bool) map[string]corev1.ResourceList {
+// buildPodMetricMap returns the usages of the pods reported in the NodeMetric. If the aggregationType is set,
+// the aggregated usage of the pod is preferred and the instant usage is used when it is not reported.
+func buildPodMetricMap(podLister corev1listers.PodLister, nodeMetric *slov1alpha1.NodeMetric, filterProdPod bool,
+	aggregatedDuration *metav1.Duration, aggregationType slov1alpha1.AggregationType) map[string]corev1.ResourceList {
 	if len(nodeMetric.Status.PodsMetric) == 0 {
 		return nil
 	}
@@ -165,6 +143,11 @@ func buildPodMetricMap(podLister corev1listers.PodLister, nodeMetric *slov1alpha
 		}
 		name := getPodNamespacedName(podMetric.Namespace, podMetric.Name)
 		podMetrics[name] = podMetric.PodUsage.ResourceList
+		if aggregationType != "" {
+			if usage := util.GetTargetAggregatedUsage(podMetric.AggregatedPodUsages, aggregatedDuration, aggregationType); usage != nil {
+				podMetrics[name] = usage.ResourceList
+			}
+		}
 	}
 	return podMetrics
 }
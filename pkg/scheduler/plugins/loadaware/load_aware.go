@@ -209,7 +209,7 @@ func (p *Plugin) filterProdUsage(node *corev1.Node, nodeMetric *slov1alpha1.Node
 	}
 
 	// TODO(joseph): maybe we should estimate the Pod that just be scheduled that have not reported
-	podMetrics := buildPodMetricMap(p.podLister, nodeMetric, true)
+	podMetrics := buildPodMetricMap(p.podLister, nodeMetric, true, nil, "")
 	prodPodUsages, _ := sumPodUsages(podMetrics, nil)
 	for resourceName, threshold := range prodUsageThresholds {
 		if threshold == 0 {
@@ -264,7 +264,12 @@ func (p *Plugin) Score(ctx context.Context, state *framework.CycleState, pod *co
 	}
 
 	prodPod := extension.GetPriorityClass(pod) == extension.PriorityProd && p.args.ScoreAccordingProdUsage
-	podMetrics := buildPodMetricMap(p.podLister, nodeMetric, prodPod)
+	var podMetrics map[string]corev1.ResourceList
+	if scoreWithAggregation(p.args.Aggregated) {
+		podMetrics = buildPodMetricMap(p.podLister, nodeMetric, prodPod, &p.args.Aggregated.ScoreAggregatedDuration, p.args.Aggregated.ScoreAggregationType)
+	} else {
+		podMetrics = buildPodMetricMap(p.podLister, nodeMetric, prodPod, nil, "")
+	}
 
 	estimatedUsed, err := p.estimator.Estimate(pod)
 	if err != nil {
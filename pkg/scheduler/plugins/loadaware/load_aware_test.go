@@ -381,6 +381,60 @@ func TestFilterUsage(t *testing.T) {
 			},
 			wantStatus: framework.NewStatus(framework.Unschedulable, fmt.Sprintf(ErrReasonAggregatedUsageExceedThreshold, corev1.ResourceCPU)),
 		},
+		{
+			name:     "filter exceed ewma cpu usage",
+			nodeName: "test-node-1",
+			aggregated: &v1beta2.LoadAwareSchedulingAggregatedArgs{
+				UsageThresholds: map[corev1.ResourceName]int64{
+					corev1.ResourceCPU: 60,
+				},
+				UsageAggregationType:    slov1alpha1.EWMA,
+				UsageAggregatedDuration: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			nodeMetric: &slov1alpha1.NodeMetric{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node-1",
+				},
+				Spec: slov1alpha1.NodeMetricSpec{
+					CollectPolicy: &slov1alpha1.NodeMetricCollectPolicy{
+						ReportIntervalSeconds: pointer.Int64(60),
+					},
+				},
+				Status: slov1alpha1.NodeMetricStatus{
+					UpdateTime: &metav1.Time{
+						Time: time.Now(),
+					},
+					NodeMetric: &slov1alpha1.NodeMetricInfo{
+						NodeUsage: slov1alpha1.ResourceMap{
+							ResourceList: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("30"),
+								corev1.ResourceMemory: resource.MustParse("100Gi"),
+							},
+						},
+						AggregatedNodeUsages: []slov1alpha1.AggregatedUsage{
+							{
+								Duration: metav1.Duration{Duration: 5 * time.Minute},
+								Usage: map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap{
+									slov1alpha1.P95: {
+										ResourceList: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("40"),
+											corev1.ResourceMemory: resource.MustParse("256Gi"),
+										},
+									},
+									slov1alpha1.EWMA: {
+										ResourceList: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("70"),
+											corev1.ResourceMemory: resource.MustParse("256Gi"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantStatus: framework.NewStatus(framework.Unschedulable, fmt.Sprintf(ErrReasonAggregatedUsageExceedThreshold, corev1.ResourceCPU)),
+		},
 		{
 			name:     "filter exceed memory usage",
 			nodeName: "test-node-1",
@@ -1642,6 +1696,111 @@ func TestScore(t *testing.T) {
 			wantScore:  38,
 			wantStatus: nil,
 		},
+		{
+			name:                    "score prod Pod with p95 pod usage",
+			scoreAccordingProdUsage: true,
+			aggregatedArgs: &v1beta2.LoadAwareSchedulingAggregatedArgs{
+				ScoreAggregationType:    slov1alpha1.P95,
+				ScoreAggregatedDuration: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "prod-pod-1",
+				},
+				Spec: corev1.PodSpec{
+					Priority: pointer.Int32(extension.PriorityProdValueMax),
+					Containers: []corev1.Container{
+						{
+							Name: "test-container",
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("16000"),
+									corev1.ResourceMemory: resource.MustParse("32Gi"),
+								},
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("16000"),
+									corev1.ResourceMemory: resource.MustParse("32Gi"),
+								},
+							},
+						},
+					},
+				},
+			},
+			assignedPod: []*podAssignInfo{
+				{
+					timestamp: time.Now(),
+					pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "assign-prod-pod-1",
+						},
+						Spec: corev1.PodSpec{
+							NodeName: "test-node-1",
+							Priority: pointer.Int32(extension.PriorityProdValueMax),
+							Containers: []corev1.Container{
+								{
+									Name: "test-container",
+									Resources: corev1.ResourceRequirements{
+										Limits: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("16"),
+											corev1.ResourceMemory: resource.MustParse("32Gi"),
+										},
+										Requests: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("16"),
+											corev1.ResourceMemory: resource.MustParse("32Gi"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			nodeName: "test-node-1",
+			nodeMetric: &slov1alpha1.NodeMetric{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node-1",
+				},
+				Spec: slov1alpha1.NodeMetricSpec{
+					CollectPolicy: &slov1alpha1.NodeMetricCollectPolicy{
+						ReportIntervalSeconds: pointer.Int64(60),
+					},
+				},
+				Status: slov1alpha1.NodeMetricStatus{
+					UpdateTime: &metav1.Time{
+						Time: time.Now(),
+					},
+					PodsMetric: []*slov1alpha1.PodMetricInfo{
+						{
+							Namespace: "default",
+							Name:      "assign-prod-pod-1",
+							PodUsage: slov1alpha1.ResourceMap{
+								ResourceList: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("30"),
+									corev1.ResourceMemory: resource.MustParse("100Gi"),
+								},
+							},
+							AggregatedPodUsages: []slov1alpha1.AggregatedUsage{
+								{
+									Duration: metav1.Duration{Duration: 5 * time.Minute},
+									Usage: map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap{
+										slov1alpha1.P95: {
+											ResourceList: corev1.ResourceList{
+												corev1.ResourceCPU:    resource.MustParse("60"),
+												corev1.ResourceMemory: resource.MustParse("200Gi"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantScore:  28,
+			wantStatus: nil,
+		},
 		{
 			name: "score request less than limit",
 			pod: &corev1.Pod{
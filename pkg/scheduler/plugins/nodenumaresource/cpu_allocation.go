@@ -19,8 +19,11 @@ package nodenumaresource
 import (
 	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingconfig "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
 )
@@ -30,13 +33,19 @@ type cpuAllocation struct {
 	nodeName      string
 	allocatedPods map[types.UID]cpuset.CPUSet
 	allocatedCPUs CPUDetails
+	// allocatedNUMAResources records the resources allocated on each NUMA Node by pods.
+	allocatedNUMAResources map[types.UID][]extension.NUMANodeResource
+	// numaReservationOwners records the reservation which the NUMA Node resources of the owner pod are allocated from.
+	numaReservationOwners map[types.UID]types.UID
 }
 
 func newCPUAllocation(nodeName string) *cpuAllocation {
 	return &cpuAllocation{
-		nodeName:      nodeName,
-		allocatedPods: map[types.UID]cpuset.CPUSet{},
-		allocatedCPUs: NewCPUDetails(),
+		nodeName:               nodeName,
+		allocatedPods:          map[types.UID]cpuset.CPUSet{},
+		allocatedCPUs:          NewCPUDetails(),
+		allocatedNUMAResources: map[types.UID][]extension.NUMANodeResource{},
+		numaReservationOwners:  map[types.UID]types.UID{},
 	}
 }
 
@@ -91,3 +100,105 @@ func (n *cpuAllocation) getAvailableCPUs(cpuTopology *CPUTopology, maxRefCount i
 	availableCPUs = cpuTopology.CPUDetails.CPUs().Difference(allocated).Difference(reservedCPUs)
 	return
 }
+
+// getAvailableReservedCPUs returns the CPUs allocated to the reservation which are not allocated to the other pods,
+// e.g. the other owners of the reservation. The CPUs allocated to the reservation itself are not counted in allocateInfo.
+func (n *cpuAllocation) getAvailableReservedCPUs(cpuTopology *CPUTopology, maxRefCount int, reservationUID types.UID, reservedCPUs cpuset.CPUSet) (availableCPUs cpuset.CPUSet, allocateInfo CPUDetails) {
+	reservationCPUs := n.allocatedPods[reservationUID]
+	allocateInfo = n.allocatedCPUs.Clone()
+	for _, cpuID := range reservationCPUs.ToSliceNoSort() {
+		cpuInfo, ok := allocateInfo[cpuID]
+		if !ok {
+			continue
+		}
+		cpuInfo.RefCount--
+		if cpuInfo.RefCount == 0 {
+			delete(allocateInfo, cpuID)
+		} else {
+			allocateInfo[cpuID] = cpuInfo
+		}
+	}
+	allocated := allocateInfo.CPUs().Filter(func(cpuID int) bool {
+		return allocateInfo[cpuID].RefCount >= maxRefCount
+	})
+	availableCPUs = reservationCPUs.Intersection(cpuTopology.CPUDetails.CPUs()).Difference(allocated).Difference(reservedCPUs)
+	return
+}
+
+// updateAllocatedNUMAResources records the NUMA Node resources allocated to the pod, which are allocated from the
+// reservation if the reservationUID is specified.
+func (n *cpuAllocation) updateAllocatedNUMAResources(podUID, reservationUID types.UID, numaNodeResources []extension.NUMANodeResource) {
+	if len(numaNodeResources) == 0 {
+		n.releaseNUMAResources(podUID)
+		return
+	}
+	n.allocatedNUMAResources[podUID] = numaNodeResources
+	if reservationUID != "" {
+		n.numaReservationOwners[podUID] = reservationUID
+	} else {
+		delete(n.numaReservationOwners, podUID)
+	}
+}
+
+func (n *cpuAllocation) releaseNUMAResources(podUID types.UID) {
+	delete(n.allocatedNUMAResources, podUID)
+	delete(n.numaReservationOwners, podUID)
+}
+
+// getAvailableNUMAResources returns the available resources of each NUMA Node. The resources held by a reservation
+// are only counted once with the ones allocated to its owners, and the remaining resources of the reservation are
+// also available if the reservationUID is specified.
+func (n *cpuAllocation) getAvailableNUMAResources(numaNodeResources map[int]corev1.ResourceList, reservationUID types.UID) map[int]corev1.ResourceList {
+	ownerAllocated := map[types.UID]map[int]corev1.ResourceList{}
+	for podUID, reservation := range n.numaReservationOwners {
+		if _, ok := n.allocatedNUMAResources[reservation]; !ok {
+			continue
+		}
+		if ownerAllocated[reservation] == nil {
+			ownerAllocated[reservation] = map[int]corev1.ResourceList{}
+		}
+		addNUMANodeResources(ownerAllocated[reservation], n.allocatedNUMAResources[podUID])
+	}
+
+	allocated := map[int]corev1.ResourceList{}
+	for podUID, podNUMAResources := range n.allocatedNUMAResources {
+		if reservation, ok := n.numaReservationOwners[podUID]; ok && ownerAllocated[reservation] != nil {
+			// counted with the reservation
+			continue
+		}
+		owners := ownerAllocated[podUID]
+		if podUID == reservationUID {
+			// the remaining resources of the reservation are available to the pod allocating from it
+			for node, ownerResources := range owners {
+				allocated[node] = quotav1.Add(allocated[node], ownerResources)
+			}
+			continue
+		}
+		if owners == nil {
+			addNUMANodeResources(allocated, podNUMAResources)
+			continue
+		}
+		held := map[int]corev1.ResourceList{}
+		addNUMANodeResources(held, podNUMAResources)
+		for node, heldResources := range held {
+			allocated[node] = quotav1.Add(allocated[node], quotav1.Max(heldResources, owners[node]))
+		}
+		for node, ownerResources := range owners {
+			if _, ok := held[node]; !ok {
+				allocated[node] = quotav1.Add(allocated[node], ownerResources)
+			}
+		}
+	}
+	available := make(map[int]corev1.ResourceList, len(numaNodeResources))
+	for node, allocatable := range numaNodeResources {
+		available[node] = quotav1.SubtractWithNonNegativeResult(allocatable, quotav1.Mask(allocated[node], quotav1.ResourceNames(allocatable)))
+	}
+	return available
+}
+
+func addNUMANodeResources(total map[int]corev1.ResourceList, numaNodeResources []extension.NUMANodeResource) {
+	for _, numaNodeResource := range numaNodeResources {
+		node := int(numaNodeResource.Node)
+		total[node] = quotav1.Add(total[node], numaNodeResource.Resources)
+	}
+}
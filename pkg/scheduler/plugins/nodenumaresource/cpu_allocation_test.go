@@ -20,9 +20,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingconfig "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
 )
@@ -136,3 +139,65 @@ func Test_cpuAllocation_getAvailableCPUs(t *testing.T) {
 	expectAvailableCPUs = cpuset.MustParse("0-1,6-15")
 	assert.Equal(t, expectAvailableCPUs, availableCPUs)
 }
+
+func Test_cpuAllocation_getAvailableReservedCPUs(t *testing.T) {
+	cpuTopology := buildCPUTopologyForTest(2, 1, 4, 2)
+
+	allocationState := newCPUAllocation("test-node-1")
+	reservationUID := uuid.NewUUID()
+	allocationState.addCPUs(cpuTopology, reservationUID, cpuset.MustParse("0-7"), schedulingconfig.CPUExclusivePolicyNone)
+
+	availableCPUs, allocated := allocationState.getAvailableReservedCPUs(cpuTopology, 1, reservationUID, cpuset.NewCPUSet())
+	assert.Equal(t, cpuset.MustParse("0-7"), availableCPUs)
+	assert.Empty(t, allocated)
+
+	// the CPUs allocated to the other owners of the reservation are excluded
+	ownerUID := uuid.NewUUID()
+	allocationState.addCPUs(cpuTopology, ownerUID, cpuset.MustParse("0-3"), schedulingconfig.CPUExclusivePolicyNone)
+	availableCPUs, _ = allocationState.getAvailableReservedCPUs(cpuTopology, 1, reservationUID, cpuset.MustParse("7"))
+	assert.Equal(t, cpuset.MustParse("4-6"), availableCPUs)
+
+	// the unknown reservation has no CPUs
+	availableCPUs, _ = allocationState.getAvailableReservedCPUs(cpuTopology, 1, uuid.NewUUID(), cpuset.NewCPUSet())
+	assert.True(t, availableCPUs.IsEmpty())
+}
+
+func Test_cpuAllocation_getAvailableNUMAResources(t *testing.T) {
+	numaNodeResources := map[int]corev1.ResourceList{
+		0: {corev1.ResourceMemory: resource.MustParse("16Gi")},
+		1: {corev1.ResourceMemory: resource.MustParse("16Gi")},
+	}
+	memory := func(node int32, quantity string) []extension.NUMANodeResource {
+		return []extension.NUMANodeResource{
+			{Node: node, Resources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(quantity)}},
+		}
+	}
+	assertAvailable := func(t *testing.T, want0, want1 string, got map[int]corev1.ResourceList) {
+		assert.True(t, resource.MustParse(want0).Equal(got[0][corev1.ResourceMemory]), "NUMA Node 0: %v", got[0])
+		assert.True(t, resource.MustParse(want1).Equal(got[1][corev1.ResourceMemory]), "NUMA Node 1: %v", got[1])
+	}
+
+	allocationState := newCPUAllocation("test-node-1")
+	reservationUID := uuid.NewUUID()
+	allocationState.updateAllocatedNUMAResources(reservationUID, "", memory(0, "8Gi"))
+	allocationState.updateAllocatedNUMAResources(uuid.NewUUID(), "", memory(1, "4Gi"))
+	assertAvailable(t, "8Gi", "12Gi", allocationState.getAvailableNUMAResources(numaNodeResources, ""))
+	assertAvailable(t, "16Gi", "12Gi", allocationState.getAvailableNUMAResources(numaNodeResources, reservationUID))
+
+	// the resources allocated to the owner are deducted from the ones held by the reservation rather than counted twice
+	ownerUID := uuid.NewUUID()
+	allocationState.updateAllocatedNUMAResources(ownerUID, reservationUID, memory(0, "6Gi"))
+	assertAvailable(t, "8Gi", "12Gi", allocationState.getAvailableNUMAResources(numaNodeResources, ""))
+	assertAvailable(t, "10Gi", "12Gi", allocationState.getAvailableNUMAResources(numaNodeResources, reservationUID))
+
+	// the owners allocating more than the reservation holds
+	allocationState.updateAllocatedNUMAResources(uuid.NewUUID(), reservationUID, memory(0, "4Gi"))
+	allocationState.updateAllocatedNUMAResources(uuid.NewUUID(), reservationUID, memory(1, "2Gi"))
+	assertAvailable(t, "6Gi", "10Gi", allocationState.getAvailableNUMAResources(numaNodeResources, ""))
+	assertAvailable(t, "6Gi", "10Gi", allocationState.getAvailableNUMAResources(numaNodeResources, reservationUID))
+
+	// the owners are counted by themselves after the reservation is released
+	allocationState.releaseNUMAResources(reservationUID)
+	allocationState.releaseNUMAResources(ownerUID)
+	assertAvailable(t, "12Gi", "10Gi", allocationState.getAvailableNUMAResources(numaNodeResources, ""))
+}
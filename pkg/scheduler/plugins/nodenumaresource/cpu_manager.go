@@ -33,16 +33,38 @@ import (
 )
 
 type CPUManager interface {
+	// Allocate allocates the CPUs for the Pod, and the CPUs are only allocated on the NUMA Nodes if specified.
 	Allocate(
 		node *corev1.Node,
 		numCPUsNeeded int,
 		cpuBindPolicy schedulingconfig.CPUBindPolicy,
-		cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy) (cpuset.CPUSet, error)
+		cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy,
+		numaNodes ...int) (cpuset.CPUSet, error)
+
+	// AllocateFromReservation allocates the CPUs for the Pod only from the CPUs allocated to the reservation,
+	// and the CPUs allocated to the other owners of the reservation are excluded.
+	AllocateFromReservation(
+		node *corev1.Node,
+		reservationUID types.UID,
+		numCPUsNeeded int,
+		cpuBindPolicy schedulingconfig.CPUBindPolicy,
+		cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy,
+		numaNodes ...int) (cpuset.CPUSet, error)
 
 	UpdateAllocatedCPUSet(nodeName string, podUID types.UID, cpuset cpuset.CPUSet, cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy)
 
+	// UpdateAllocatedNUMAResources records the resources allocated on NUMA Nodes to the Pod or the reservation with the UID.
+	// The reservationUID is the reservation which the Pod allocates the resources from, and is empty otherwise.
+	UpdateAllocatedNUMAResources(nodeName string, podUID, reservationUID types.UID, numaNodeResources []extension.NUMANodeResource)
+
 	Free(nodeName string, podUID types.UID)
 
+	// GetAllocatedCPUSet returns the CPUs allocated to the Pod or the reservation with the UID.
+	GetAllocatedCPUSet(nodeName string, podUID types.UID) (cpuset.CPUSet, bool)
+
+	// GetAllocatedNUMAResources returns the resources allocated on NUMA Nodes to the Pod or the reservation with the UID.
+	GetAllocatedNUMAResources(nodeName string, podUID types.UID) []extension.NUMANodeResource
+
 	Score(
 		node *corev1.Node,
 		numCPUsNeeded int,
@@ -50,6 +72,11 @@ type CPUManager interface {
 		cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy) int64
 
 	GetAvailableCPUs(nodeName string) (availableCPUs cpuset.CPUSet, allocated CPUDetails, err error)
+
+	// GetAvailableNUMAResources returns the available resources of each NUMA Node, where the remaining resources of
+	// the reservation are also available if the reservationUID is specified,
+	// or nil if the node does not report the resources of NUMA Nodes.
+	GetAvailableNUMAResources(nodeName string, reservationUID types.UID) map[int]corev1.ResourceList
 }
 
 type cpuManagerImpl struct {
@@ -112,6 +139,7 @@ func (c *cpuManagerImpl) Allocate(
 	numCPUsNeeded int,
 	cpuBindPolicy schedulingconfig.CPUBindPolicy,
 	cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy,
+	numaNodes ...int,
 ) (cpuset.CPUSet, error) {
 	result := cpuset.CPUSet{}
 	// The Pod requires the CPU to be allocated according to CPUBindPolicy,
@@ -132,6 +160,9 @@ func (c *cpuManagerImpl) Allocate(
 	defer allocation.lock.Unlock()
 
 	availableCPUs, allocated := allocation.getAvailableCPUs(cpuTopologyOptions.CPUTopology, cpuTopologyOptions.MaxRefCount, reservedCPUs)
+	if len(numaNodes) > 0 {
+		availableCPUs = availableCPUs.Intersection(cpuTopologyOptions.CPUTopology.CPUsInNUMANodeIDs(numaNodes...))
+	}
 	numaAllocateStrategy := c.getNUMAAllocateStrategy(node)
 	result, err := takeCPUs(
 		cpuTopologyOptions.CPUTopology,
@@ -146,6 +177,42 @@ func (c *cpuManagerImpl) Allocate(
 	return result, err
 }
 
+func (c *cpuManagerImpl) AllocateFromReservation(
+	node *corev1.Node,
+	reservationUID types.UID,
+	numCPUsNeeded int,
+	cpuBindPolicy schedulingconfig.CPUBindPolicy,
+	cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy,
+	numaNodes ...int,
+) (cpuset.CPUSet, error) {
+	cpuTopologyOptions := c.topologyManager.GetCPUTopologyOptions(node.Name)
+	if cpuTopologyOptions.CPUTopology == nil {
+		return cpuset.CPUSet{}, errors.New(ErrNotFoundCPUTopology)
+	}
+	if !cpuTopologyOptions.CPUTopology.IsValid() {
+		return cpuset.CPUSet{}, errors.New(ErrInvalidCPUTopology)
+	}
+
+	allocation := c.getOrCreateAllocation(node.Name)
+	allocation.lock.Lock()
+	defer allocation.lock.Unlock()
+
+	availableCPUs, allocated := allocation.getAvailableReservedCPUs(cpuTopologyOptions.CPUTopology, cpuTopologyOptions.MaxRefCount, reservationUID, cpuTopologyOptions.ReservedCPUs)
+	if len(numaNodes) > 0 {
+		availableCPUs = availableCPUs.Intersection(cpuTopologyOptions.CPUTopology.CPUsInNUMANodeIDs(numaNodes...))
+	}
+	return takeCPUs(
+		cpuTopologyOptions.CPUTopology,
+		cpuTopologyOptions.MaxRefCount,
+		availableCPUs,
+		allocated,
+		numCPUsNeeded,
+		cpuBindPolicy,
+		cpuExclusivePolicy,
+		c.getNUMAAllocateStrategy(node),
+	)
+}
+
 func (c *cpuManagerImpl) UpdateAllocatedCPUSet(nodeName string, podUID types.UID, cpuset cpuset.CPUSet, cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy) {
 	cpuTopologyOptions := c.topologyManager.GetCPUTopologyOptions(nodeName)
 	if cpuTopologyOptions.CPUTopology == nil || !cpuTopologyOptions.CPUTopology.IsValid() {
@@ -159,11 +226,35 @@ func (c *cpuManagerImpl) UpdateAllocatedCPUSet(nodeName string, podUID types.UID
 	allocation.updateAllocatedCPUSet(cpuTopologyOptions.CPUTopology, podUID, cpuset, cpuExclusivePolicy)
 }
 
+func (c *cpuManagerImpl) UpdateAllocatedNUMAResources(nodeName string, podUID, reservationUID types.UID, numaNodeResources []extension.NUMANodeResource) {
+	allocation := c.getOrCreateAllocation(nodeName)
+	allocation.lock.Lock()
+	defer allocation.lock.Unlock()
+
+	allocation.updateAllocatedNUMAResources(podUID, reservationUID, numaNodeResources)
+}
+
 func (c *cpuManagerImpl) Free(nodeName string, podUID types.UID) {
 	allocation := c.getOrCreateAllocation(nodeName)
 	allocation.lock.Lock()
 	defer allocation.lock.Unlock()
 	allocation.releaseCPUs(podUID)
+	allocation.releaseNUMAResources(podUID)
+}
+
+func (c *cpuManagerImpl) GetAllocatedCPUSet(nodeName string, podUID types.UID) (cpuset.CPUSet, bool) {
+	allocation := c.getOrCreateAllocation(nodeName)
+	allocation.lock.Lock()
+	defer allocation.lock.Unlock()
+	cpus, ok := allocation.allocatedPods[podUID]
+	return cpus.Clone(), ok
+}
+
+func (c *cpuManagerImpl) GetAllocatedNUMAResources(nodeName string, podUID types.UID) []extension.NUMANodeResource {
+	allocation := c.getOrCreateAllocation(nodeName)
+	allocation.lock.Lock()
+	defer allocation.lock.Unlock()
+	return allocation.allocatedNUMAResources[podUID]
 }
 
 func (c *cpuManagerImpl) Score(
@@ -292,3 +383,15 @@ func (c *cpuManagerImpl) GetAvailableCPUs(nodeName string) (availableCPUs cpuset
 	availableCPUs, allocated = allocation.getAvailableCPUs(cpuTopologyOptions.CPUTopology, cpuTopologyOptions.MaxRefCount, cpuTopologyOptions.ReservedCPUs)
 	return availableCPUs, allocated, nil
 }
+
+func (c *cpuManagerImpl) GetAvailableNUMAResources(nodeName string, reservationUID types.UID) map[int]corev1.ResourceList {
+	cpuTopologyOptions := c.topologyManager.GetCPUTopologyOptions(nodeName)
+	if len(cpuTopologyOptions.NUMANodeResources) == 0 {
+		return nil
+	}
+
+	allocation := c.getOrCreateAllocation(nodeName)
+	allocation.lock.Lock()
+	defer allocation.lock.Unlock()
+	return allocation.getAvailableNUMAResources(cpuTopologyOptions.NUMANodeResources, reservationUID)
+}
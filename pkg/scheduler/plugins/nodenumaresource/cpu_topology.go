@@ -17,6 +17,13 @@ limitations under the License.
 package nodenumaresource
 
 import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingconfig "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
 )
@@ -103,6 +110,54 @@ func (topo *CPUTopology) CPUsPerNode() int {
 	return topo.NumCPUs / topo.NumNodes
 }
 
+// NUMANodeIDs returns the IDs of the NUMA Nodes reported by the node in ascending order.
+func (topo *CPUTopology) NUMANodeIDs() []int {
+	numaNodes := sets.NewInt()
+	for _, info := range topo.CPUDetails {
+		numaNodes.Insert(info.NUMANodeID())
+	}
+	return numaNodes.List()
+}
+
+// CPUsInNUMANodeIDs returns the logical CPU IDs associated with the given NUMA Node IDs reported by the node.
+func (topo *CPUTopology) CPUsInNUMANodeIDs(numaNodes ...int) cpuset.CPUSet {
+	b := cpuset.NewCPUSetBuilder()
+	for _, numaNode := range numaNodes {
+		for cpu, info := range topo.CPUDetails {
+			if info.NUMANodeID() == numaNode {
+				b.Add(cpu)
+			}
+		}
+	}
+	return b.Result()
+}
+
+// NUMANodeResources returns the number of the given CPUs on each NUMA Node, where the NUMA Node is the one
+// reported by the node instead of the NodeID encoded with the socket.
+func (topo *CPUTopology) NUMANodeResources(cpus cpuset.CPUSet) []extension.NUMANodeResource {
+	cpusInNodes := map[int32]int64{}
+	for _, cpu := range cpus.ToSliceNoSort() {
+		info, ok := topo.CPUDetails[cpu]
+		if !ok {
+			continue
+		}
+		cpusInNodes[int32(info.NUMANodeID())]++
+	}
+	nodeResources := make([]extension.NUMANodeResource, 0, len(cpusInNodes))
+	for node, numCPUs := range cpusInNodes {
+		nodeResources = append(nodeResources, extension.NUMANodeResource{
+			Node: node,
+			Resources: corev1.ResourceList{
+				corev1.ResourceCPU: *resource.NewQuantity(numCPUs, resource.DecimalSI),
+			},
+		})
+	}
+	sort.Slice(nodeResources, func(i, j int) bool {
+		return nodeResources[i].Node < nodeResources[j].Node
+	})
+	return nodeResources
+}
+
 // CPUDetails is a map from logical CPU ID to CPUInfo.
 type CPUDetails map[int]CPUInfo
 
@@ -121,6 +176,11 @@ type CPUInfo struct {
 	ExclusivePolicy schedulingconfig.CPUExclusivePolicy `json:"exclusivePolicy"`
 }
 
+// NUMANodeID returns the ID of the NUMA Node reported by the node, since the NodeID is combined with the SocketID.
+func (info CPUInfo) NUMANodeID() int {
+	return info.NodeID & 0xffff
+}
+
 // Clone clones the CPUDetails
 func (d CPUDetails) Clone() CPUDetails {
 	c := make(CPUDetails)
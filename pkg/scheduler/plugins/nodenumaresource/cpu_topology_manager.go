@@ -19,6 +19,8 @@ package nodenumaresource
 import (
 	"sync"
 
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/koordinator-sh/koordinator/apis/extension"
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
 )
@@ -35,6 +37,10 @@ type CPUTopologyOptions struct {
 	ReservedCPUs cpuset.CPUSet                      `json:"reservedCPUs,omitempty"`
 	MaxRefCount  int                                `json:"maxRefCount,omitempty"`
 	Policy       *extension.KubeletCPUManagerPolicy `json:"policy,omitempty"`
+	// NUMANodeResources is the allocatable resources of each NUMA Node reported in NodeResourceTopology zones.
+	NUMANodeResources map[int]corev1.ResourceList `json:"numaNodeResources,omitempty"`
+	// NUMATopologyPolicy is the topology manager policy of kubelet reported in NodeResourceTopology.
+	NUMATopologyPolicy extension.NUMATopologyPolicy `json:"numaTopologyPolicy,omitempty"`
 }
 
 type cpuTopologyManager struct {
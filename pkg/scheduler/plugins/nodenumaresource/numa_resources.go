@@ -0,0 +1,211 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodenumaresource
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	v1helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+)
+
+// numaAlignedDeviceResources are the device resources whose Pods are aligned to NUMA Nodes with the devices.
+var numaAlignedDeviceResources = []corev1.ResourceName{
+	extension.NvidiaGPU, extension.KoordGPU, extension.GPUCore, extension.GPUMemory, extension.GPUMemoryRatio,
+	extension.KoordRDMA, extension.KoordFPGA,
+}
+
+// requireNUMAAlignment returns true if the memory and hugepages of the Pod should be allocated on NUMA Nodes,
+// i.e. the Guaranteed, LSE and LSR Pods and the Pods requesting devices. The memory of other Pods is not bound
+// to any NUMA Node by kubelet or koordlet, so they are not accounted on NUMA Nodes.
+func requireNUMAAlignment(pod *corev1.Pod, requests corev1.ResourceList) bool {
+	if qosClass := GetPodQoSClass(pod); qosClass == extension.QoSLSE || qosClass == extension.QoSLSR {
+		return true
+	}
+	if util.GetKubeQosClass(pod) == corev1.PodQOSGuaranteed {
+		return true
+	}
+	for _, name := range numaAlignedDeviceResources {
+		if quantity, ok := requests[name]; ok && !quantity.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// isNUMAResource returns true if the resource should be allocated on NUMA Nodes besides CPUs.
+func isNUMAResource(name corev1.ResourceName) bool {
+	return name == corev1.ResourceMemory || v1helper.IsHugePageResourceName(name)
+}
+
+// getNUMAResourceRequests returns the requested memory and hugepages of the Pod.
+func getNUMAResourceRequests(requests corev1.ResourceList) corev1.ResourceList {
+	var numaRequests corev1.ResourceList
+	for name, quantity := range requests {
+		if !isNUMAResource(name) || quantity.IsZero() {
+			continue
+		}
+		if numaRequests == nil {
+			numaRequests = corev1.ResourceList{}
+		}
+		numaRequests[name] = quantity.DeepCopy()
+	}
+	return numaRequests
+}
+
+// fitsNUMAResources checks if the available resources can satisfy the requests.
+// The resource that is not reported is treated as zero.
+func fitsNUMAResources(requests, available corev1.ResourceList) bool {
+	for name, request := range requests {
+		free := available[name]
+		if request.Cmp(free) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func sumNUMAResources(available map[int]corev1.ResourceList, numaNodes []int) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, numaNode := range numaNodes {
+		total = quotav1.Add(total, available[numaNode])
+	}
+	return total
+}
+
+func allNUMANodes(numaNodeResources map[int]corev1.ResourceList) []int {
+	numaNodes := make([]int, 0, len(numaNodeResources))
+	for numaNode := range numaNodeResources {
+		numaNodes = append(numaNodes, numaNode)
+	}
+	sort.Ints(numaNodes)
+	return numaNodes
+}
+
+// allocateNUMAResources distributes the requests on the NUMA Nodes in the given order.
+// It fills the former NUMA Nodes first, and returns false if the NUMA Nodes cannot satisfy the requests.
+func allocateNUMAResources(requests corev1.ResourceList, available map[int]corev1.ResourceList, numaNodes []int) (map[int]corev1.ResourceList, bool) {
+	allocated := map[int]corev1.ResourceList{}
+	for name, request := range requests {
+		remaining := request.Value()
+		for _, numaNode := range numaNodes {
+			if remaining <= 0 {
+				break
+			}
+			free := available[numaNode][name]
+			if free.Value() <= 0 {
+				continue
+			}
+			value := free.Value()
+			if value > remaining {
+				value = remaining
+			}
+			if allocated[numaNode] == nil {
+				allocated[numaNode] = corev1.ResourceList{}
+			}
+			allocated[numaNode][name] = *resource.NewQuantity(value, request.Format)
+			remaining -= value
+		}
+		if remaining > 0 {
+			return nil, false
+		}
+	}
+	return allocated, true
+}
+
+// orderNUMANodesByAllocatedCPUs returns the NUMA Nodes of the allocated CPUs first, then the others,
+// so that the memory of the Pod is local to its CPUs as much as possible.
+func orderNUMANodesByAllocatedCPUs(cpuTopology *CPUTopology, cpus cpuset.CPUSet, numaNodes []int) []int {
+	cpuNUMANodes := map[int]bool{}
+	for _, cpu := range cpus.ToSliceNoSort() {
+		if info, ok := cpuTopology.CPUDetails[cpu]; ok {
+			cpuNUMANodes[info.NUMANodeID()] = true
+		}
+	}
+	ordered := make([]int, 0, len(numaNodes))
+	for _, numaNode := range numaNodes {
+		if cpuNUMANodes[numaNode] {
+			ordered = append(ordered, numaNode)
+		}
+	}
+	for _, numaNode := range numaNodes {
+		if !cpuNUMANodes[numaNode] {
+			ordered = append(ordered, numaNode)
+		}
+	}
+	return ordered
+}
+
+// sortNUMANodesByStrategy sorts the NUMA Nodes by the available memory. The NUMA Node with less available memory
+// is preferred under MostAllocated strategy, and the one with more available memory is preferred under LeastAllocated.
+func sortNUMANodesByStrategy(numaNodes []int, available map[int]corev1.ResourceList, leastAllocated bool) {
+	sort.SliceStable(numaNodes, func(i, j int) bool {
+		iMemory := available[numaNodes[i]][corev1.ResourceMemory]
+		jMemory := available[numaNodes[j]][corev1.ResourceMemory]
+		if leastAllocated {
+			return iMemory.Cmp(jMemory) > 0
+		}
+		return iMemory.Cmp(jMemory) < 0
+	})
+}
+
+// buildNUMANodeResources merges the allocated CPUs and the allocated NUMA resources of each NUMA Node.
+func buildNUMANodeResources(cpuTopology *CPUTopology, cpus cpuset.CPUSet, allocated map[int]corev1.ResourceList) []extension.NUMANodeResource {
+	numaNodeResources := cpuTopology.NUMANodeResources(cpus)
+	for i := range numaNodeResources {
+		node := int(numaNodeResources[i].Node)
+		numaNodeResources[i].Resources = quotav1.Add(numaNodeResources[i].Resources, allocated[node])
+	}
+	for node, resources := range allocated {
+		found := false
+		for _, v := range numaNodeResources {
+			if int(v.Node) == node {
+				found = true
+				break
+			}
+		}
+		if !found {
+			numaNodeResources = append(numaNodeResources, extension.NUMANodeResource{
+				Node:      int32(node),
+				Resources: resources.DeepCopy(),
+			})
+		}
+	}
+	sort.Slice(numaNodeResources, func(i, j int) bool {
+		return numaNodeResources[i].Node < numaNodeResources[j].Node
+	})
+	return numaNodeResources
+}
+
+// scoreNUMAResources scores the requests against the available resources, and returns the average of the resources.
+func scoreNUMAResources(requests, available corev1.ResourceList, scoreFn func(requested, capacity int64) int64) int64 {
+	if len(requests) == 0 {
+		return 0
+	}
+	var score int64
+	for name, request := range requests {
+		free := available[name]
+		score += scoreFn(request.Value(), free.Value())
+	}
+	return score / int64(len(requests))
+}
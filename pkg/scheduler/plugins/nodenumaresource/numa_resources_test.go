@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodenumaresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+)
+
+func TestGetNUMAResourceRequests(t *testing.T) {
+	requests := corev1.ResourceList{
+		corev1.ResourceCPU:                   resource.MustParse("4"),
+		corev1.ResourceMemory:                resource.MustParse("8Gi"),
+		corev1.ResourceName("hugepages-2Mi"): resource.MustParse("1Gi"),
+		corev1.ResourceName("hugepages-1Gi"): resource.MustParse("0"),
+	}
+	expected := corev1.ResourceList{
+		corev1.ResourceMemory:                resource.MustParse("8Gi"),
+		corev1.ResourceName("hugepages-2Mi"): resource.MustParse("1Gi"),
+	}
+	assert.True(t, quotav1.Equals(expected, getNUMAResourceRequests(requests)))
+	assert.Nil(t, getNUMAResourceRequests(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}))
+}
+
+func TestAllocateNUMAResources(t *testing.T) {
+	available := map[int]corev1.ResourceList{
+		0: {corev1.ResourceMemory: resource.MustParse("8Gi")},
+		1: {corev1.ResourceMemory: resource.MustParse("16Gi")},
+	}
+	tests := []struct {
+		name      string
+		requests  corev1.ResourceList
+		numaNodes []int
+		want      map[int]corev1.ResourceList
+		wantOK    bool
+	}{
+		{
+			name:      "fill the former NUMA Node first",
+			requests:  corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("12Gi")},
+			numaNodes: []int{0, 1},
+			want: map[int]corev1.ResourceList{
+				0: {corev1.ResourceMemory: resource.MustParse("8Gi")},
+				1: {corev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+			wantOK: true,
+		},
+		{
+			name:      "allocate on the single NUMA Node",
+			requests:  corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("12Gi")},
+			numaNodes: []int{1},
+			want: map[int]corev1.ResourceList{
+				1: {corev1.ResourceMemory: resource.MustParse("12Gi")},
+			},
+			wantOK: true,
+		},
+		{
+			name:      "insufficient on the given NUMA Nodes",
+			requests:  corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("12Gi")},
+			numaNodes: []int{0},
+			wantOK:    false,
+		},
+		{
+			name:      "the resource is not reported",
+			requests:  corev1.ResourceList{corev1.ResourceName("hugepages-2Mi"): resource.MustParse("1Gi")},
+			numaNodes: []int{0, 1},
+			wantOK:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := allocateNUMAResources(tt.requests, available, tt.numaNodes)
+			assert.Equal(t, tt.wantOK, ok)
+			if !tt.wantOK {
+				return
+			}
+			assert.Equal(t, len(tt.want), len(got))
+			for numaNode, want := range tt.want {
+				assert.True(t, quotav1.Equals(want, got[numaNode]), got[numaNode])
+			}
+		})
+	}
+}
+
+func TestOrderNUMANodesByAllocatedCPUs(t *testing.T) {
+	cpuTopology := buildCPUTopologyForTest(2, 1, 4, 2)
+	assert.Equal(t, []int{0, 1}, orderNUMANodesByAllocatedCPUs(cpuTopology, cpuset.NewCPUSet(0, 1), []int{0, 1}))
+	assert.Equal(t, []int{1, 0}, orderNUMANodesByAllocatedCPUs(cpuTopology, cpuset.NewCPUSet(8, 9), []int{0, 1}))
+	assert.Equal(t, []int{0, 1}, orderNUMANodesByAllocatedCPUs(cpuTopology, cpuset.NewCPUSet(7, 8), []int{0, 1}))
+}
+
+func TestSortNUMANodesByStrategy(t *testing.T) {
+	available := map[int]corev1.ResourceList{
+		0: {corev1.ResourceMemory: resource.MustParse("8Gi")},
+		1: {corev1.ResourceMemory: resource.MustParse("16Gi")},
+		2: {corev1.ResourceMemory: resource.MustParse("4Gi")},
+	}
+	numaNodes := []int{0, 1, 2}
+	sortNUMANodesByStrategy(numaNodes, available, true)
+	assert.Equal(t, []int{1, 0, 2}, numaNodes)
+	sortNUMANodesByStrategy(numaNodes, available, false)
+	assert.Equal(t, []int{2, 0, 1}, numaNodes)
+}
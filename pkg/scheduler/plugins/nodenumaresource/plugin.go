@@ -24,12 +24,16 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	resourceapi "k8s.io/kubernetes/pkg/api/v1/resource"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	schedulingconfig "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/topologymanager"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
 )
@@ -47,10 +51,12 @@ const (
 )
 
 const (
-	ErrNotFoundCPUTopology     = "node(s) CPU Topology not found"
-	ErrInvalidCPUTopology      = "node(s) invalid CPU Topology"
-	ErrSMTAlignmentError       = "node(s) requested cpus not multiple cpus per core"
-	ErrRequiredFullPCPUsPolicy = "node(s) required FullPCPUs policy"
+	ErrNotFoundCPUTopology        = "node(s) CPU Topology not found"
+	ErrInvalidCPUTopology         = "node(s) invalid CPU Topology"
+	ErrSMTAlignmentError          = "node(s) requested cpus not multiple cpus per core"
+	ErrRequiredFullPCPUsPolicy    = "node(s) required FullPCPUs policy"
+	ErrInsufficientNUMAResources  = "Insufficient NUMA Node resources"
+	ErrInsufficientSingleNUMANode = "node(s) cannot align resources on a single NUMA Node"
 )
 
 var (
@@ -136,6 +142,9 @@ func NewWithOptions(args runtime.Object, handle framework.Handle, opts ...Option
 		}
 	}
 	registerPodEventHandler(handle, options.cpuManager)
+	if extendedHandle, ok := handle.(frameworkext.ExtendedHandle); ok {
+		registerReservationEventHandler(extendedHandle.KoordinatorSharedInformerFactory(), options.cpuManager)
+	}
 
 	return &Plugin{
 		handle:          handle,
@@ -168,19 +177,42 @@ func (p *Plugin) GetCPUTopologyManager() CPUTopologyManager {
 }
 
 type preFilterState struct {
-	skip                        bool
+	skip bool
+	// skipCPUs indicates that the Pod doesn't bind CPUs but only consumes the NUMA Node resources.
+	skipCPUs                    bool
 	resourceSpec                *extension.ResourceSpec
 	preferredCPUBindPolicy      schedulingconfig.CPUBindPolicy
 	preferredCPUExclusivePolicy schedulingconfig.CPUExclusivePolicy
 	numCPUsNeeded               int
 	allocatedCPUs               cpuset.CPUSet
+	// numaResourceRequests is the requested memory and hugepages which should be allocated on NUMA Nodes.
+	// The Pods not binding CPUs also consume the NUMA Node resources.
+	numaResourceRequests corev1.ResourceList
+	// allocatedNUMAResources is the resources allocated on each NUMA Node, including CPUs.
+	allocatedNUMAResources []extension.NUMANodeResource
 }
 
 func (s *preFilterState) Clone() framework.StateData {
+	var allocatedNUMAResources []extension.NUMANodeResource
+	if s.allocatedNUMAResources != nil {
+		allocatedNUMAResources = make([]extension.NUMANodeResource, 0, len(s.allocatedNUMAResources))
+		for _, v := range s.allocatedNUMAResources {
+			allocatedNUMAResources = append(allocatedNUMAResources, extension.NUMANodeResource{
+				Node:      v.Node,
+				Resources: v.Resources.DeepCopy(),
+			})
+		}
+	}
 	return &preFilterState{
-		skip:          s.skip,
-		resourceSpec:  s.resourceSpec,
-		allocatedCPUs: s.allocatedCPUs.Clone(),
+		skip:                        s.skip,
+		skipCPUs:                    s.skipCPUs,
+		resourceSpec:                s.resourceSpec,
+		preferredCPUBindPolicy:      s.preferredCPUBindPolicy,
+		preferredCPUExclusivePolicy: s.preferredCPUExclusivePolicy,
+		numCPUsNeeded:               s.numCPUsNeeded,
+		allocatedCPUs:               s.allocatedCPUs.Clone(),
+		numaResourceRequests:        s.numaResourceRequests.DeepCopy(),
+		allocatedNUMAResources:      allocatedNUMAResources,
 	}
 }
 
@@ -193,6 +225,7 @@ func (p *Plugin) PreFilter(ctx context.Context, cycleState *framework.CycleState
 	state := &preFilterState{
 		skip: true,
 	}
+	requests, _ := resourceapi.PodRequestsAndLimits(pod)
 	if AllowUseCPUSet(pod) {
 		preferredCPUBindPolicy := resourceSpec.PreferredCPUBindPolicy
 		if preferredCPUBindPolicy == "" || preferredCPUBindPolicy == schedulingconfig.CPUBindPolicyDefault {
@@ -200,7 +233,6 @@ func (p *Plugin) PreFilter(ctx context.Context, cycleState *framework.CycleState
 		}
 		if preferredCPUBindPolicy == schedulingconfig.CPUBindPolicyFullPCPUs ||
 			preferredCPUBindPolicy == schedulingconfig.CPUBindPolicySpreadByPCPUs {
-			requests, _ := resourceapi.PodRequestsAndLimits(pod)
 			requestedCPU := requests.Cpu().MilliValue()
 			if requestedCPU%1000 != 0 {
 				return framework.NewStatus(framework.Error, "the requested CPUs must be integer")
@@ -212,9 +244,20 @@ func (p *Plugin) PreFilter(ctx context.Context, cycleState *framework.CycleState
 				state.preferredCPUBindPolicy = preferredCPUBindPolicy
 				state.preferredCPUExclusivePolicy = resourceSpec.PreferredCPUExclusivePolicy
 				state.numCPUsNeeded = int(requestedCPU / 1000)
+				state.numaResourceRequests = getNUMAResourceRequests(requests)
+				topologymanager.InitState(cycleState)
 			}
 		}
 	}
+	if state.skip && requireNUMAAlignment(pod, requests) {
+		if numaResourceRequests := getNUMAResourceRequests(requests); len(numaResourceRequests) > 0 {
+			state.skip = false
+			state.skipCPUs = true
+			state.resourceSpec = resourceSpec
+			state.numaResourceRequests = numaResourceRequests
+			topologymanager.InitState(cycleState)
+		}
+	}
 
 	cycleState.Write(stateKey, state)
 	return nil
@@ -246,6 +289,9 @@ func (p *Plugin) Filter(ctx context.Context, cycleState *framework.CycleState, p
 	if node == nil {
 		return framework.NewStatus(framework.Error, "node not found")
 	}
+	if state.skipCPUs {
+		return p.filterNUMAResources(cycleState, node, state)
+	}
 
 	cpuTopologyOptions := p.topologyManager.GetCPUTopologyOptions(node.Name)
 	if cpuTopologyOptions.CPUTopology == nil {
@@ -269,9 +315,143 @@ func (p *Plugin) Filter(ctx context.Context, cycleState *framework.CycleState, p
 		}
 	}
 
+	// the Pod can allocate the free CPUs, or the CPUs reserved by any reservation on the node that the Pod matches
+	reservationUIDs := append([]types.UID{""}, p.getReservationsWithCPUs(node.Name, frameworkext.GetMatchedReservations(cycleState, node.Name))...)
+
+	if getNUMATopologyPolicy(node.Labels, cpuTopologyOptions.NUMATopologyPolicy) == extension.NUMATopologyPolicySingleNUMANode {
+		feasibleNUMANodes, err := p.filterSingleNUMANodes(node, state, cpuTopologyOptions.CPUTopology, reservationUIDs)
+		if err != nil {
+			return framework.AsStatus(err)
+		}
+		if len(feasibleNUMANodes) == 0 {
+			return framework.NewStatus(framework.Unschedulable, ErrInsufficientSingleNUMANode)
+		}
+		if topologyState := topologymanager.GetState(cycleState); topologyState != nil {
+			topologyState.SetFeasibleNUMANodes(node.Name, feasibleNUMANodes)
+		}
+		return nil
+	}
+
+	if len(state.numaResourceRequests) > 0 {
+		for _, reservationUID := range reservationUIDs {
+			availableNUMAResources := p.getAvailableNUMAResources(node.Name, reservationUID)
+			if availableNUMAResources == nil ||
+				fitsNUMAResources(state.numaResourceRequests, sumNUMAResources(availableNUMAResources, allNUMANodes(availableNUMAResources))) {
+				return nil
+			}
+		}
+		return framework.NewStatus(framework.Unschedulable, ErrInsufficientNUMAResources)
+	}
+
 	return nil
 }
 
+// filterNUMAResources filters the node for the Pod which doesn't bind CPUs but consumes the NUMA Node resources.
+// The node which doesn't report the NUMA Node resources is always feasible.
+func (p *Plugin) filterNUMAResources(cycleState *framework.CycleState, node *corev1.Node, state *preFilterState) *framework.Status {
+	reservationUIDs := append([]types.UID{""}, p.getReservationsWithNUMAResources(node.Name, frameworkext.GetMatchedReservations(cycleState, node.Name))...)
+	availableNUMAResources := make([]map[int]corev1.ResourceList, 0, len(reservationUIDs))
+	for _, reservationUID := range reservationUIDs {
+		if available := p.getAvailableNUMAResources(node.Name, reservationUID); available != nil {
+			availableNUMAResources = append(availableNUMAResources, available)
+		}
+	}
+	if len(availableNUMAResources) == 0 {
+		return nil
+	}
+
+	cpuTopologyOptions := p.topologyManager.GetCPUTopologyOptions(node.Name)
+	if getNUMATopologyPolicy(node.Labels, cpuTopologyOptions.NUMATopologyPolicy) == extension.NUMATopologyPolicySingleNUMANode {
+		var feasibleNUMANodes []int
+		for _, numaNode := range allNUMANodes(availableNUMAResources[0]) {
+			for _, available := range availableNUMAResources {
+				if fitsNUMAResources(state.numaResourceRequests, available[numaNode]) {
+					feasibleNUMANodes = append(feasibleNUMANodes, numaNode)
+					break
+				}
+			}
+		}
+		if len(feasibleNUMANodes) == 0 {
+			return framework.NewStatus(framework.Unschedulable, ErrInsufficientSingleNUMANode)
+		}
+		if topologyState := topologymanager.GetState(cycleState); topologyState != nil {
+			topologyState.SetFeasibleNUMANodes(node.Name, feasibleNUMANodes)
+		}
+		return nil
+	}
+
+	for _, available := range availableNUMAResources {
+		if fitsNUMAResources(state.numaResourceRequests, sumNUMAResources(available, allNUMANodes(available))) {
+			return nil
+		}
+	}
+	return framework.NewStatus(framework.Unschedulable, ErrInsufficientNUMAResources)
+}
+
+// filterSingleNUMANodes returns the NUMA Nodes that can satisfy both the CPUs and the NUMA resources of the Pod alone.
+// The CPUs and the NUMA resources are allocated from the free ones or the ones reserved by any of the reservations.
+func (p *Plugin) filterSingleNUMANodes(node *corev1.Node, state *preFilterState, cpuTopology *CPUTopology, reservationUIDs []types.UID) ([]int, error) {
+	preferredCPUBindPolicy, err := p.getPreferredCPUBindPolicy(node, state.preferredCPUBindPolicy)
+	if err != nil {
+		return nil, err
+	}
+	availableNUMAResources := make([]map[int]corev1.ResourceList, len(reservationUIDs))
+	for i, reservationUID := range reservationUIDs {
+		availableNUMAResources[i] = p.getAvailableNUMAResources(node.Name, reservationUID)
+	}
+	var feasibleNUMANodes []int
+	for _, numaNode := range cpuTopology.NUMANodeIDs() {
+		for i, reservationUID := range reservationUIDs {
+			if availableNUMAResources[i] != nil && !fitsNUMAResources(state.numaResourceRequests, availableNUMAResources[i][numaNode]) {
+				continue
+			}
+			if _, err := p.allocateCPUs(node, reservationUID, state.numCPUsNeeded, preferredCPUBindPolicy, state.preferredCPUExclusivePolicy, numaNode); err != nil {
+				continue
+			}
+			feasibleNUMANodes = append(feasibleNUMANodes, numaNode)
+			break
+		}
+	}
+	return feasibleNUMANodes, nil
+}
+
+// allocateCPUs allocates the CPUs from the ones reserved by the reservation if the reservationUID is specified,
+// otherwise from the free CPUs of the node.
+func (p *Plugin) allocateCPUs(node *corev1.Node, reservationUID types.UID, numCPUsNeeded int, cpuBindPolicy schedulingconfig.CPUBindPolicy, cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy, numaNodes ...int) (cpuset.CPUSet, error) {
+	if reservationUID != "" {
+		return p.cpuManager.AllocateFromReservation(node, reservationUID, numCPUsNeeded, cpuBindPolicy, cpuExclusivePolicy, numaNodes...)
+	}
+	return p.cpuManager.Allocate(node, numCPUsNeeded, cpuBindPolicy, cpuExclusivePolicy, numaNodes...)
+}
+
+// getAvailableNUMAResources returns the available resources of each NUMA Node, where the resources of the reservation
+// not allocated to its owners are also available if the reservationUID is specified.
+func (p *Plugin) getAvailableNUMAResources(nodeName string, reservationUID types.UID) map[int]corev1.ResourceList {
+	return p.cpuManager.GetAvailableNUMAResources(nodeName, reservationUID)
+}
+
+// getReservationsWithNUMAResources returns the UIDs of the reservations which have been allocated NUMA Node resources on the node.
+func (p *Plugin) getReservationsWithNUMAResources(nodeName string, reservations []*schedulingv1alpha1.Reservation) []types.UID {
+	var reservationUIDs []types.UID
+	for _, r := range reservations {
+		if len(p.cpuManager.GetAllocatedNUMAResources(nodeName, r.UID)) > 0 {
+			reservationUIDs = append(reservationUIDs, r.UID)
+		}
+	}
+	return reservationUIDs
+}
+
+// getReservationsWithCPUs returns the UIDs of the reservations which have been allocated CPUs on the node.
+func (p *Plugin) getReservationsWithCPUs(nodeName string, reservations []*schedulingv1alpha1.Reservation) []types.UID {
+	var reservationUIDs []types.UID
+	for _, r := range reservations {
+		if cpus, ok := p.cpuManager.GetAllocatedCPUSet(nodeName, r.UID); ok && !cpus.IsEmpty() {
+			reservationUIDs = append(reservationUIDs, r.UID)
+		}
+	}
+	return reservationUIDs
+}
+
 func (p *Plugin) Score(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, nodeName string) (int64, *framework.Status) {
 	state, status := getPreFilterState(cycleState)
 	if !status.IsSuccess() {
@@ -289,6 +469,12 @@ func (p *Plugin) Score(ctx context.Context, cycleState *framework.CycleState, po
 	if node == nil {
 		return 0, framework.NewStatus(framework.Error, "node not found")
 	}
+	if state.skipCPUs {
+		if availableNUMAResources := p.cpuManager.GetAvailableNUMAResources(nodeName, ""); availableNUMAResources != nil {
+			return p.scoreNUMAResources(cycleState, node, state, availableNUMAResources), nil
+		}
+		return 0, nil
+	}
 
 	preferredCPUBindPolicy, err := p.getPreferredCPUBindPolicy(node, state.preferredCPUBindPolicy)
 	if err != nil {
@@ -296,9 +482,45 @@ func (p *Plugin) Score(ctx context.Context, cycleState *framework.CycleState, po
 	}
 
 	score := p.cpuManager.Score(node, state.numCPUsNeeded, preferredCPUBindPolicy, state.preferredCPUExclusivePolicy)
+	if len(state.numaResourceRequests) > 0 {
+		if availableNUMAResources := p.cpuManager.GetAvailableNUMAResources(nodeName, ""); availableNUMAResources != nil {
+			numaScore := p.scoreNUMAResources(cycleState, node, state, availableNUMAResources)
+			score = (score + numaScore) / 2
+		}
+	}
 	return score, nil
 }
 
+func (p *Plugin) scoreNUMAResources(cycleState *framework.CycleState, node *corev1.Node, state *preFilterState, availableNUMAResources map[int]corev1.ResourceList) int64 {
+	scoreFn := mostRequestedScore
+	if p.getNUMAAllocateStrategy(node) == schedulingconfig.NUMALeastAllocated {
+		scoreFn = leastRequestedScore
+	}
+
+	cpuTopologyOptions := p.topologyManager.GetCPUTopologyOptions(node.Name)
+	if getNUMATopologyPolicy(node.Labels, cpuTopologyOptions.NUMATopologyPolicy) != extension.NUMATopologyPolicySingleNUMANode {
+		available := sumNUMAResources(availableNUMAResources, allNUMANodes(availableNUMAResources))
+		return scoreNUMAResources(state.numaResourceRequests, available, scoreFn)
+	}
+
+	numaNodes := allNUMANodes(availableNUMAResources)
+	if topologyState := topologymanager.GetState(cycleState); topologyState != nil {
+		if feasibleNUMANodes, ok := topologyState.GetFeasibleNUMANodes(node.Name); ok {
+			numaNodes = feasibleNUMANodes
+		}
+	}
+	var maxScore int64
+	for _, numaNode := range numaNodes {
+		if !fitsNUMAResources(state.numaResourceRequests, availableNUMAResources[numaNode]) {
+			continue
+		}
+		if score := scoreNUMAResources(state.numaResourceRequests, availableNUMAResources[numaNode], scoreFn); score > maxScore {
+			maxScore = score
+		}
+	}
+	return maxScore
+}
+
 func (p *Plugin) ScoreExtensions() framework.ScoreExtensions {
 	return nil
 }
@@ -320,18 +542,143 @@ func (p *Plugin) Reserve(ctx context.Context, cycleState *framework.CycleState,
 	if node == nil {
 		return framework.NewStatus(framework.Error, "node not found")
 	}
+	if state.skipCPUs {
+		return p.reserveNUMAResources(cycleState, pod, node, state)
+	}
 
 	preferredCPUBindPolicy, err := p.getPreferredCPUBindPolicy(node, state.preferredCPUBindPolicy)
 	if err != nil {
 		return framework.AsStatus(err)
 	}
-	result, err := p.cpuManager.Allocate(node, state.numCPUsNeeded, preferredCPUBindPolicy, state.preferredCPUExclusivePolicy)
-	if err != nil {
-		return framework.AsStatus(err)
+
+	// the Pod inherits the CPUs of the reservation nominated by the Reservation plugin if the reservation holds CPUs
+	var reservationUID types.UID
+	if reservation := frameworkext.GetNominatedReservation(cycleState, nodeName); reservation != nil {
+		if reservationUIDs := p.getReservationsWithCPUs(nodeName, []*schedulingv1alpha1.Reservation{reservation}); len(reservationUIDs) > 0 {
+			reservationUID = reservationUIDs[0]
+		}
+	}
+
+	cpuTopologyOptions := p.topologyManager.GetCPUTopologyOptions(nodeName)
+	availableNUMAResources := p.getAvailableNUMAResources(nodeName, reservationUID)
+	var result cpuset.CPUSet
+	var allocatedNUMAResources map[int]corev1.ResourceList
+	var affinity topologymanager.NUMAAffinity
+	if getNUMATopologyPolicy(node.Labels, cpuTopologyOptions.NUMATopologyPolicy) == extension.NUMATopologyPolicySingleNUMANode {
+		numaNodes := cpuTopologyOptions.CPUTopology.NUMANodeIDs()
+		if topologyState := topologymanager.GetState(cycleState); topologyState != nil {
+			if feasibleNUMANodes, ok := topologyState.GetFeasibleNUMANodes(nodeName); ok {
+				numaNodes = append([]int{}, feasibleNUMANodes...)
+			}
+		}
+		if availableNUMAResources != nil {
+			sortNUMANodesByStrategy(numaNodes, availableNUMAResources, p.getNUMAAllocateStrategy(node) == schedulingconfig.NUMALeastAllocated)
+		}
+		for _, numaNode := range numaNodes {
+			if availableNUMAResources != nil {
+				var ok bool
+				allocatedNUMAResources, ok = allocateNUMAResources(state.numaResourceRequests, availableNUMAResources, []int{numaNode})
+				if !ok {
+					continue
+				}
+			}
+			result, err = p.allocateCPUs(node, reservationUID, state.numCPUsNeeded, preferredCPUBindPolicy, state.preferredCPUExclusivePolicy, numaNode)
+			if err == nil {
+				affinity = topologymanager.NUMAAffinity{NUMANodes: []int{numaNode}, Required: true}
+				break
+			}
+		}
+		if result.IsEmpty() {
+			return framework.NewStatus(framework.Unschedulable, ErrInsufficientSingleNUMANode)
+		}
+	} else {
+		result, err = p.allocateCPUs(node, reservationUID, state.numCPUsNeeded, preferredCPUBindPolicy, state.preferredCPUExclusivePolicy)
+		if err != nil {
+			return framework.AsStatus(err)
+		}
+		if availableNUMAResources != nil && len(state.numaResourceRequests) > 0 {
+			numaNodes := orderNUMANodesByAllocatedCPUs(cpuTopologyOptions.CPUTopology, result, allNUMANodes(availableNUMAResources))
+			var ok bool
+			allocatedNUMAResources, ok = allocateNUMAResources(state.numaResourceRequests, availableNUMAResources, numaNodes)
+			if !ok {
+				return framework.NewStatus(framework.Unschedulable, ErrInsufficientNUMAResources)
+			}
+		}
 	}
+
 	p.cpuManager.UpdateAllocatedCPUSet(nodeName, pod.UID, result, state.preferredCPUExclusivePolicy)
 	state.allocatedCPUs = result
 	state.preferredCPUBindPolicy = preferredCPUBindPolicy
+	state.allocatedNUMAResources = buildNUMANodeResources(cpuTopologyOptions.CPUTopology, result, allocatedNUMAResources)
+	if len(allocatedNUMAResources) > 0 {
+		p.cpuManager.UpdateAllocatedNUMAResources(nodeName, pod.UID, reservationUID, state.allocatedNUMAResources)
+	}
+
+	if topologyState := topologymanager.GetState(cycleState); topologyState != nil {
+		if len(affinity.NUMANodes) == 0 {
+			for _, v := range state.allocatedNUMAResources {
+				affinity.NUMANodes = append(affinity.NUMANodes, int(v.Node))
+			}
+		}
+		topologyState.SetAffinity(nodeName, affinity)
+	}
+	return nil
+}
+
+// reserveNUMAResources allocates the NUMA Node resources for the Pod which doesn't bind CPUs.
+func (p *Plugin) reserveNUMAResources(cycleState *framework.CycleState, pod *corev1.Pod, node *corev1.Node, state *preFilterState) *framework.Status {
+	var reservationUID types.UID
+	if reservation := frameworkext.GetNominatedReservation(cycleState, node.Name); reservation != nil {
+		if reservationUIDs := p.getReservationsWithNUMAResources(node.Name, []*schedulingv1alpha1.Reservation{reservation}); len(reservationUIDs) > 0 {
+			reservationUID = reservationUIDs[0]
+		}
+	}
+	availableNUMAResources := p.getAvailableNUMAResources(node.Name, reservationUID)
+	if availableNUMAResources == nil {
+		return nil
+	}
+
+	cpuTopologyOptions := p.topologyManager.GetCPUTopologyOptions(node.Name)
+	leastAllocated := p.getNUMAAllocateStrategy(node) == schedulingconfig.NUMALeastAllocated
+	var allocatedNUMAResources map[int]corev1.ResourceList
+	var affinity topologymanager.NUMAAffinity
+	if getNUMATopologyPolicy(node.Labels, cpuTopologyOptions.NUMATopologyPolicy) == extension.NUMATopologyPolicySingleNUMANode {
+		numaNodes := allNUMANodes(availableNUMAResources)
+		if topologyState := topologymanager.GetState(cycleState); topologyState != nil {
+			if feasibleNUMANodes, ok := topologyState.GetFeasibleNUMANodes(node.Name); ok {
+				numaNodes = append([]int{}, feasibleNUMANodes...)
+			}
+		}
+		sortNUMANodesByStrategy(numaNodes, availableNUMAResources, leastAllocated)
+		for _, numaNode := range numaNodes {
+			var ok bool
+			if allocatedNUMAResources, ok = allocateNUMAResources(state.numaResourceRequests, availableNUMAResources, []int{numaNode}); ok {
+				affinity = topologymanager.NUMAAffinity{NUMANodes: []int{numaNode}, Required: true}
+				break
+			}
+		}
+		if len(affinity.NUMANodes) == 0 {
+			return framework.NewStatus(framework.Unschedulable, ErrInsufficientSingleNUMANode)
+		}
+	} else {
+		numaNodes := allNUMANodes(availableNUMAResources)
+		sortNUMANodesByStrategy(numaNodes, availableNUMAResources, leastAllocated)
+		var ok bool
+		if allocatedNUMAResources, ok = allocateNUMAResources(state.numaResourceRequests, availableNUMAResources, numaNodes); !ok {
+			return framework.NewStatus(framework.Unschedulable, ErrInsufficientNUMAResources)
+		}
+	}
+
+	state.allocatedNUMAResources = buildNUMANodeResources(cpuTopologyOptions.CPUTopology, cpuset.CPUSet{}, allocatedNUMAResources)
+	p.cpuManager.UpdateAllocatedNUMAResources(node.Name, pod.UID, reservationUID, state.allocatedNUMAResources)
+	if topologyState := topologymanager.GetState(cycleState); topologyState != nil {
+		if len(affinity.NUMANodes) == 0 {
+			for _, v := range state.allocatedNUMAResources {
+				affinity.NUMANodes = append(affinity.NUMANodes, int(v.Node))
+			}
+		}
+		topologyState.SetAffinity(node.Name, affinity)
+	}
 	return nil
 }
 
@@ -340,10 +687,13 @@ func (p *Plugin) Unreserve(ctx context.Context, cycleState *framework.CycleState
 	if !status.IsSuccess() {
 		return
 	}
-	if state.skip || state.allocatedCPUs.IsEmpty() {
+	if state.skip || (state.allocatedCPUs.IsEmpty() && len(state.allocatedNUMAResources) == 0) {
 		return
 	}
 	p.cpuManager.Free(nodeName, pod.UID)
+	if topologyState := topologymanager.GetState(cycleState); topologyState != nil {
+		topologyState.DeleteAffinity(nodeName)
+	}
 }
 
 func (p *Plugin) PreBind(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, nodeName string) *framework.Status {
@@ -354,9 +704,11 @@ func (p *Plugin) PreBind(ctx context.Context, cycleState *framework.CycleState,
 	if state.skip {
 		return nil
 	}
-
 	if state.allocatedCPUs.IsEmpty() {
-		return nil
+		if len(state.allocatedNUMAResources) == 0 {
+			return nil
+		}
+		return p.preBindNUMAResources(pod, nodeName, state)
 	}
 
 	podOriginal := pod
@@ -379,7 +731,15 @@ func (p *Plugin) PreBind(ctx context.Context, cycleState *framework.CycleState,
 		pod.Annotations[extension.AnnotationResourceSpec] = string(resourceSpecData)
 	}
 
-	resourceStatus := &extension.ResourceStatus{CPUSet: state.allocatedCPUs.String()}
+	resourceStatus := &extension.ResourceStatus{
+		CPUSet:            state.allocatedCPUs.String(),
+		NUMANodeResources: state.allocatedNUMAResources,
+	}
+	// Record the NUMA Nodes of the allocated CPUs to let koordlet bind the memory of the Pod to the same NUMA Nodes.
+	cpuTopologyOptions := p.topologyManager.GetCPUTopologyOptions(nodeName)
+	if len(resourceStatus.NUMANodeResources) == 0 && cpuTopologyOptions.CPUTopology != nil && cpuTopologyOptions.CPUTopology.IsValid() {
+		resourceStatus.NUMANodeResources = cpuTopologyOptions.CPUTopology.NUMANodeResources(state.allocatedCPUs)
+	}
 	err := SetResourceStatus(pod, resourceStatus)
 	if err != nil {
 		return framework.NewStatus(framework.Error, err.Error())
@@ -400,6 +760,32 @@ func (p *Plugin) PreBind(ctx context.Context, cycleState *framework.CycleState,
 	return nil
 }
 
+// preBindNUMAResources records the NUMA Node resources allocated to the Pod which doesn't bind CPUs,
+// so that they can be restored by the pod event handler.
+func (p *Plugin) preBindNUMAResources(pod *corev1.Pod, nodeName string, state *preFilterState) *framework.Status {
+	podOriginal := pod
+	pod = pod.DeepCopy()
+	resourceStatus := &extension.ResourceStatus{
+		NUMANodeResources: state.allocatedNUMAResources,
+	}
+	if err := SetResourceStatus(pod, resourceStatus); err != nil {
+		return framework.NewStatus(framework.Error, err.Error())
+	}
+
+	err := util.RetryOnConflictOrTooManyRequests(func() error {
+		_, err1 := util.NewPatch().WithHandle(p.handle).AddAnnotations(pod.Annotations).PatchPodOrReservation(podOriginal)
+		return err1
+	})
+	if err != nil {
+		klog.V(3).ErrorS(err, "Failed to preBind Pod with NUMA Node resources",
+			"pod", klog.KObj(pod), "node", nodeName)
+		return framework.NewStatus(framework.Error, err.Error())
+	}
+
+	klog.V(4).Infof("Successfully preBind Pod %s/%s with NUMA Node resources %v", pod.Namespace, pod.Name, state.allocatedNUMAResources)
+	return nil
+}
+
 func (p *Plugin) getPreferredCPUBindPolicy(node *corev1.Node, preferredCPUBindPolicy schedulingconfig.CPUBindPolicy) (schedulingconfig.CPUBindPolicy, error) {
 	cpuTopologyOptions := p.topologyManager.GetCPUTopologyOptions(node.Name)
 	if cpuTopologyOptions.CPUTopology == nil {
@@ -421,3 +807,19 @@ func (p *Plugin) getPreferredCPUBindPolicy(node *corev1.Node, preferredCPUBindPo
 	}
 	return preferredCPUBindPolicy, nil
 }
+
+func (p *Plugin) getNUMAAllocateStrategy(node *corev1.Node) schedulingconfig.NUMAAllocateStrategy {
+	if val := schedulingconfig.NUMAAllocateStrategy(node.Labels[extension.LabelNodeNUMAAllocateStrategy]); val != "" {
+		return val
+	}
+	return GetDefaultNUMAAllocateStrategy(p.pluginArgs)
+}
+
+// getNUMATopologyPolicy returns the NUMA topology policy specified in node labels,
+// or the topology manager policy of kubelet reported in NodeResourceTopology.
+func getNUMATopologyPolicy(nodeLabels map[string]string, reportedPolicy extension.NUMATopologyPolicy) extension.NUMATopologyPolicy {
+	if policy := extension.NUMATopologyPolicy(nodeLabels[extension.LabelNodeNUMATopologyPolicy]); policy != "" {
+		return policy
+	}
+	return reportedPolicy
+}
@@ -18,6 +18,7 @@ package nodenumaresource
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -28,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	"k8s.io/client-go/informers"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
@@ -38,8 +40,11 @@ import (
 	"k8s.io/utils/pointer"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	schedulingconfig "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/v1beta2"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/topologymanager"
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
 
 	_ "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/scheme"
@@ -353,6 +358,98 @@ func TestPlugin_PreFilter(t *testing.T) {
 			},
 			want: framework.NewStatus(framework.Error, "the requested CPUs must be integer"),
 		},
+		{
+			name: "NUMA Node resources with Guaranteed LS Pod",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						extension.LabelPodQoS: string(extension.QoSLS),
+					},
+				},
+				Spec: corev1.PodSpec{
+					Priority: pointer.Int32Ptr(extension.PriorityProdValueMax),
+					Containers: []corev1.Container{
+						{
+							Name: "container-1",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("2"),
+									corev1.ResourceMemory: resource.MustParse("4Gi"),
+								},
+								Limits: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("2"),
+									corev1.ResourceMemory: resource.MustParse("4Gi"),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantState: &preFilterState{
+				skip:                 false,
+				skipCPUs:             true,
+				resourceSpec:         &extension.ResourceSpec{PreferredCPUBindPolicy: extension.CPUBindPolicyDefault},
+				numaResourceRequests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+		},
+		{
+			name: "NUMA Node resources with LS Pod requesting GPU",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						extension.LabelPodQoS: string(extension.QoSLS),
+					},
+				},
+				Spec: corev1.PodSpec{
+					Priority: pointer.Int32Ptr(extension.PriorityProdValueMax),
+					Containers: []corev1.Container{
+						{
+							Name: "container-1",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("2"),
+									corev1.ResourceMemory: resource.MustParse("4Gi"),
+									extension.KoordGPU:    resource.MustParse("100"),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantState: &preFilterState{
+				skip:                 false,
+				skipCPUs:             true,
+				resourceSpec:         &extension.ResourceSpec{PreferredCPUBindPolicy: extension.CPUBindPolicyDefault},
+				numaResourceRequests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+		},
+		{
+			name: "skip Burstable LS Pod without NUMA alignment",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						extension.LabelPodQoS: string(extension.QoSLS),
+					},
+				},
+				Spec: corev1.PodSpec{
+					Priority: pointer.Int32Ptr(extension.PriorityProdValueMax),
+					Containers: []corev1.Container{
+						{
+							Name: "container-1",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("2"),
+									corev1.ResourceMemory: resource.MustParse("4Gi"),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantState: &preFilterState{
+				skip: true,
+			},
+		},
 		{
 			name: "skip Pod with unsupported bind policy",
 			pod: &corev1.Pod{
@@ -814,6 +911,7 @@ func TestPlugin_Reserve(t *testing.T) {
 		pod           *corev1.Pod
 		cpuTopology   *CPUTopology
 		allocatedCPUs []int
+		reservedCPUs  []int
 		want          *framework.Status
 		wantCPUSet    cpuset.CPUSet
 		wantState     *preFilterState
@@ -823,6 +921,37 @@ func TestPlugin_Reserve(t *testing.T) {
 			pod:  &corev1.Pod{},
 			want: framework.AsStatus(framework.ErrNotFound),
 		},
+		{
+			name: "succeed with the CPUs of the nominated reservation",
+			state: &preFilterState{
+				skip:          false,
+				numCPUsNeeded: 4,
+				resourceSpec: &extension.ResourceSpec{
+					PreferredCPUBindPolicy: extension.CPUBindPolicyFullPCPUs,
+				},
+				preferredCPUBindPolicy: schedulingconfig.CPUBindPolicyFullPCPUs,
+			},
+			cpuTopology:  buildCPUTopologyForTest(2, 1, 4, 2),
+			reservedCPUs: []int{8, 9, 10, 11},
+			pod:          &corev1.Pod{},
+			want:         nil,
+			wantCPUSet:   cpuset.NewCPUSet(8, 9, 10, 11),
+		},
+		{
+			name: "failed with insufficient CPUs of the nominated reservation",
+			state: &preFilterState{
+				skip:          false,
+				numCPUsNeeded: 4,
+				resourceSpec: &extension.ResourceSpec{
+					PreferredCPUBindPolicy: extension.CPUBindPolicyFullPCPUs,
+				},
+				preferredCPUBindPolicy: schedulingconfig.CPUBindPolicyFullPCPUs,
+			},
+			cpuTopology:  buildCPUTopologyForTest(2, 1, 4, 2),
+			reservedCPUs: []int{8, 9},
+			pod:          &corev1.Pod{},
+			want:         framework.AsStatus(errors.New("not enough cpus available to satisfy request")),
+		},
 		{
 			name: "error with missing allocationState",
 			state: &preFilterState{
@@ -976,6 +1105,15 @@ func TestPlugin_Reserve(t *testing.T) {
 					allocationState.addCPUs(tt.cpuTopology, uuid.NewUUID(), cpuset.NewCPUSet(tt.allocatedCPUs...), schedulingconfig.CPUExclusivePolicyNone)
 				}
 			}
+			reservation := &schedulingv1alpha1.Reservation{
+				ObjectMeta: metav1.ObjectMeta{
+					UID:  uuid.NewUUID(),
+					Name: "test-reservation",
+				},
+			}
+			if len(tt.reservedCPUs) > 0 {
+				allocationState.addCPUs(tt.cpuTopology, reservation.UID, cpuset.NewCPUSet(tt.reservedCPUs...), schedulingconfig.CPUExclusivePolicyNone)
+			}
 
 			cpuManager := plg.cpuManager.(*cpuManagerImpl)
 			cpuManager.allocationStates[allocationState.nodeName] = allocationState
@@ -986,6 +1124,9 @@ func TestPlugin_Reserve(t *testing.T) {
 			if tt.state != nil {
 				cycleState.Write(stateKey, tt.state)
 			}
+			if len(tt.reservedCPUs) > 0 {
+				frameworkext.InitReservationState(cycleState).SetNominatedReservation("test-node-1", reservation)
+			}
 
 			nodeInfo, err := suit.Handle.SnapshotSharedLister().NodeInfos().Get("test-node-1")
 			assert.NoError(t, err)
@@ -1086,6 +1227,69 @@ func TestPlugin_PreBind(t *testing.T) {
 	assert.Equal(t, expectResourceStatus, resourceStatus)
 }
 
+func TestPlugin_PreBindWithNUMANodeResources(t *testing.T) {
+	suit := newPluginTestSuit(t, nil)
+	p, err := suit.proxyNew(suit.nodeNUMAResourceArgs, suit.Handle)
+	assert.NotNil(t, p)
+	assert.Nil(t, err)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Namespace: "default",
+			Name:      "test-pod-1",
+		},
+	}
+
+	_, status := suit.Handle.ClientSet().CoreV1().Pods("default").Create(context.TODO(), pod, metav1.CreateOptions{})
+	assert.Nil(t, status)
+
+	suit.start()
+
+	plg := p.(*Plugin)
+	plg.topologyManager.UpdateCPUTopologyOptions("test-node-1", func(options *CPUTopologyOptions) {
+		options.CPUTopology = buildCPUTopologyForTest(2, 1, 4, 2)
+	})
+
+	state := &preFilterState{
+		skip:          false,
+		numCPUsNeeded: 4,
+		resourceSpec: &extension.ResourceSpec{
+			PreferredCPUBindPolicy: extension.CPUBindPolicyFullPCPUs,
+		},
+		allocatedCPUs: cpuset.NewCPUSet(6, 7, 8, 9),
+	}
+	cycleState := framework.NewCycleState()
+	cycleState.Write(stateKey, state)
+
+	s := plg.PreBind(context.TODO(), cycleState, pod, "test-node-1")
+	assert.True(t, s.IsSuccess())
+	podModified, status := suit.Handle.ClientSet().CoreV1().Pods("default").Get(context.TODO(), "test-pod-1", metav1.GetOptions{})
+	assert.Nil(t, status)
+	assert.NotNil(t, podModified)
+	resourceStatus, err := extension.GetResourceStatus(podModified.Annotations)
+	assert.NoError(t, err)
+	assert.NotNil(t, resourceStatus)
+	expectResourceStatus := &extension.ResourceStatus{
+		CPUSet: "6-9",
+		NUMANodeResources: []extension.NUMANodeResource{
+			{
+				Node: 0,
+				Resources: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("2"),
+				},
+			},
+			{
+				Node: 1,
+				Resources: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("2"),
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectResourceStatus, resourceStatus)
+}
+
 func TestPlugin_PreBindWithCPUBindPolicyNone(t *testing.T) {
 	suit := newPluginTestSuit(t, nil)
 	p, err := suit.proxyNew(suit.nodeNUMAResourceArgs, suit.Handle)
@@ -1139,3 +1343,357 @@ func TestPlugin_PreBindWithCPUBindPolicyNone(t *testing.T) {
 	}
 	assert.Equal(t, expectedResourceSpec, resourceSpec)
 }
+
+func TestPlugin_FilterAndReserveWithNUMANodeResources(t *testing.T) {
+	tests := []struct {
+		name                      string
+		nodeLabels                map[string]string
+		reportedPolicy            extension.NUMATopologyPolicy
+		numaResourceRequests      corev1.ResourceList
+		wantFilter                *framework.Status
+		wantFeasibleNUMANodes     []int
+		wantCPUSet                cpuset.CPUSet
+		wantNUMANodeResources     []extension.NUMANodeResource
+		wantAffinity              topologymanager.NUMAAffinity
+		wantAvailableNUMAResource map[int]corev1.ResourceList
+	}{
+		{
+			name: "allocate on the single NUMA Node specified by node label",
+			nodeLabels: map[string]string{
+				extension.LabelNodeNUMATopologyPolicy: string(extension.NUMATopologyPolicySingleNUMANode),
+			},
+			numaResourceRequests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+			wantFeasibleNUMANodes: []int{1},
+			wantCPUSet:            cpuset.NewCPUSet(8, 9, 10, 11),
+			wantNUMANodeResources: []extension.NUMANodeResource{
+				{
+					Node: 1,
+					Resources: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("4"),
+						corev1.ResourceMemory: resource.MustParse("16Gi"),
+					},
+				},
+			},
+			wantAffinity: topologymanager.NUMAAffinity{NUMANodes: []int{1}, Required: true},
+			wantAvailableNUMAResource: map[int]corev1.ResourceList{
+				0: {corev1.ResourceMemory: resource.MustParse("8Gi")},
+				1: {corev1.ResourceMemory: resource.MustParse("16Gi")},
+			},
+		},
+		{
+			name:           "failed to align on the single NUMA Node reported by kubelet",
+			reportedPolicy: extension.NUMATopologyPolicySingleNUMANode,
+			numaResourceRequests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("40Gi"),
+			},
+			wantFilter: framework.NewStatus(framework.Unschedulable, ErrInsufficientSingleNUMANode),
+		},
+		{
+			name: "distribute memory across NUMA Nodes when node label overrides the reported policy",
+			nodeLabels: map[string]string{
+				extension.LabelNodeNUMATopologyPolicy: string(extension.NUMATopologyPolicyNone),
+			},
+			reportedPolicy: extension.NUMATopologyPolicySingleNUMANode,
+			numaResourceRequests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("36Gi"),
+			},
+			wantCPUSet: cpuset.NewCPUSet(0, 1, 2, 3),
+			wantNUMANodeResources: []extension.NUMANodeResource{
+				{
+					Node: 0,
+					Resources: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("4"),
+						corev1.ResourceMemory: resource.MustParse("8Gi"),
+					},
+				},
+				{
+					Node: 1,
+					Resources: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("28Gi"),
+					},
+				},
+			},
+			wantAffinity: topologymanager.NUMAAffinity{NUMANodes: []int{0, 1}},
+			wantAvailableNUMAResource: map[int]corev1.ResourceList{
+				0: {corev1.ResourceMemory: resource.MustParse("0")},
+				1: {corev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+		},
+		{
+			name: "insufficient NUMA Node resources",
+			numaResourceRequests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("48Gi"),
+			},
+			wantFilter: framework.NewStatus(framework.Unschedulable, ErrInsufficientNUMAResources),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := []*corev1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "test-node-1",
+						Labels: map[string]string{},
+					},
+					Status: corev1.NodeStatus{
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("16"),
+							corev1.ResourceMemory: resource.MustParse("48Gi"),
+						},
+					},
+				},
+			}
+			for k, v := range tt.nodeLabels {
+				nodes[0].Labels[k] = v
+			}
+
+			suit := newPluginTestSuit(t, nodes)
+			p, err := suit.proxyNew(suit.nodeNUMAResourceArgs, suit.Handle)
+			assert.NotNil(t, p)
+			assert.Nil(t, err)
+
+			plg := p.(*Plugin)
+			plg.topologyManager.UpdateCPUTopologyOptions("test-node-1", func(options *CPUTopologyOptions) {
+				options.CPUTopology = buildCPUTopologyForTest(2, 1, 4, 2)
+				options.NUMANodeResources = map[int]corev1.ResourceList{
+					0: {corev1.ResourceMemory: resource.MustParse("16Gi")},
+					1: {corev1.ResourceMemory: resource.MustParse("32Gi")},
+				}
+				options.NUMATopologyPolicy = tt.reportedPolicy
+			})
+			cpuManager := plg.cpuManager.(*cpuManagerImpl)
+			cpuManager.allocationStates["test-node-1"] = newCPUAllocation("test-node-1")
+			plg.cpuManager.UpdateAllocatedNUMAResources("test-node-1", uuid.NewUUID(), "", []extension.NUMANodeResource{
+				{
+					Node:      0,
+					Resources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+				},
+			})
+
+			suit.start()
+
+			state := &preFilterState{
+				skip:          false,
+				numCPUsNeeded: 4,
+				resourceSpec: &extension.ResourceSpec{
+					PreferredCPUBindPolicy: extension.CPUBindPolicyFullPCPUs,
+				},
+				preferredCPUBindPolicy: schedulingconfig.CPUBindPolicyFullPCPUs,
+				numaResourceRequests:   tt.numaResourceRequests,
+			}
+			cycleState := framework.NewCycleState()
+			cycleState.Write(stateKey, state)
+			topologyState := topologymanager.InitState(cycleState)
+
+			nodeInfo, err := suit.Handle.SnapshotSharedLister().NodeInfos().Get("test-node-1")
+			assert.NoError(t, err)
+			assert.NotNil(t, nodeInfo)
+
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: uuid.NewUUID()}}
+			got := plg.Filter(context.TODO(), cycleState, pod, nodeInfo)
+			assert.Equal(t, tt.wantFilter, got)
+			if !got.IsSuccess() {
+				return
+			}
+			feasibleNUMANodes, ok := topologyState.GetFeasibleNUMANodes("test-node-1")
+			assert.Equal(t, tt.wantFeasibleNUMANodes != nil, ok)
+			assert.Equal(t, tt.wantFeasibleNUMANodes, feasibleNUMANodes)
+
+			assert.True(t, plg.Reserve(context.TODO(), cycleState, pod, "test-node-1").IsSuccess())
+			assert.True(t, tt.wantCPUSet.Equals(state.allocatedCPUs), state.allocatedCPUs.String())
+			assert.Equal(t, len(tt.wantNUMANodeResources), len(state.allocatedNUMAResources))
+			for i := range tt.wantNUMANodeResources {
+				assert.Equal(t, tt.wantNUMANodeResources[i].Node, state.allocatedNUMAResources[i].Node)
+				assert.True(t, quotav1.Equals(tt.wantNUMANodeResources[i].Resources, state.allocatedNUMAResources[i].Resources),
+					state.allocatedNUMAResources[i].Resources)
+			}
+			affinity, ok := topologyState.GetAffinity("test-node-1")
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantAffinity, affinity)
+
+			available := plg.cpuManager.GetAvailableNUMAResources("test-node-1", "")
+			for numaNode, want := range tt.wantAvailableNUMAResource {
+				assert.True(t, quotav1.Equals(want, available[numaNode]), available[numaNode])
+			}
+
+			plg.Unreserve(context.TODO(), cycleState, pod, "test-node-1")
+			_, ok = topologyState.GetAffinity("test-node-1")
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestPlugin_FilterAndReserveNUMANodeResourcesWithoutCPUs(t *testing.T) {
+	tests := []struct {
+		name                      string
+		nodeLabels                map[string]string
+		numaResourceRequests      corev1.ResourceList
+		wantFilter                *framework.Status
+		wantFeasibleNUMANodes     []int
+		wantNUMANodeResources     []extension.NUMANodeResource
+		wantAffinity              topologymanager.NUMAAffinity
+		wantAvailableNUMAResource map[int]corev1.ResourceList
+	}{
+		{
+			name: "allocate on the single NUMA Node",
+			nodeLabels: map[string]string{
+				extension.LabelNodeNUMATopologyPolicy: string(extension.NUMATopologyPolicySingleNUMANode),
+			},
+			numaResourceRequests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+			wantFeasibleNUMANodes: []int{1},
+			wantNUMANodeResources: []extension.NUMANodeResource{
+				{Node: 1, Resources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")}},
+			},
+			wantAffinity: topologymanager.NUMAAffinity{NUMANodes: []int{1}, Required: true},
+			wantAvailableNUMAResource: map[int]corev1.ResourceList{
+				0: {corev1.ResourceMemory: resource.MustParse("8Gi")},
+				1: {corev1.ResourceMemory: resource.MustParse("16Gi")},
+			},
+		},
+		{
+			name: "distribute memory across NUMA Nodes",
+			numaResourceRequests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("36Gi"),
+			},
+			wantNUMANodeResources: []extension.NUMANodeResource{
+				{Node: 0, Resources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")}},
+				{Node: 1, Resources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("28Gi")}},
+			},
+			wantAffinity: topologymanager.NUMAAffinity{NUMANodes: []int{0, 1}},
+			wantAvailableNUMAResource: map[int]corev1.ResourceList{
+				0: {corev1.ResourceMemory: resource.MustParse("0")},
+				1: {corev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+		},
+		{
+			name: "insufficient NUMA Node resources",
+			numaResourceRequests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("48Gi"),
+			},
+			wantFilter: framework.NewStatus(framework.Unschedulable, ErrInsufficientNUMAResources),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := []*corev1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "test-node-1",
+						Labels: tt.nodeLabels,
+					},
+					Status: corev1.NodeStatus{
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("16"),
+							corev1.ResourceMemory: resource.MustParse("48Gi"),
+						},
+					},
+				},
+			}
+			suit := newPluginTestSuit(t, nodes)
+			p, err := suit.proxyNew(suit.nodeNUMAResourceArgs, suit.Handle)
+			assert.NotNil(t, p)
+			assert.Nil(t, err)
+
+			plg := p.(*Plugin)
+			// the NUMA Node resources are accounted without the CPU topology
+			plg.topologyManager.UpdateCPUTopologyOptions("test-node-1", func(options *CPUTopologyOptions) {
+				options.NUMANodeResources = map[int]corev1.ResourceList{
+					0: {corev1.ResourceMemory: resource.MustParse("16Gi")},
+					1: {corev1.ResourceMemory: resource.MustParse("32Gi")},
+				}
+			})
+			plg.cpuManager.UpdateAllocatedNUMAResources("test-node-1", uuid.NewUUID(), "", []extension.NUMANodeResource{
+				{Node: 0, Resources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")}},
+			})
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					UID:       uuid.NewUUID(),
+					Namespace: "default",
+					Name:      "test-pod-1",
+				},
+			}
+			_, err = suit.Handle.ClientSet().CoreV1().Pods("default").Create(context.TODO(), pod, metav1.CreateOptions{})
+			assert.NoError(t, err)
+			suit.start()
+
+			state := &preFilterState{
+				skip:                 false,
+				skipCPUs:             true,
+				resourceSpec:         &extension.ResourceSpec{},
+				numaResourceRequests: tt.numaResourceRequests,
+			}
+			cycleState := framework.NewCycleState()
+			cycleState.Write(stateKey, state)
+			topologyState := topologymanager.InitState(cycleState)
+
+			nodeInfo, err := suit.Handle.SnapshotSharedLister().NodeInfos().Get("test-node-1")
+			assert.NoError(t, err)
+			got := plg.Filter(context.TODO(), cycleState, pod, nodeInfo)
+			assert.Equal(t, tt.wantFilter, got)
+			if !got.IsSuccess() {
+				return
+			}
+			feasibleNUMANodes, ok := topologyState.GetFeasibleNUMANodes("test-node-1")
+			assert.Equal(t, tt.wantFeasibleNUMANodes != nil, ok)
+			assert.Equal(t, tt.wantFeasibleNUMANodes, feasibleNUMANodes)
+
+			assert.True(t, plg.Reserve(context.TODO(), cycleState, pod, "test-node-1").IsSuccess())
+			assert.True(t, state.allocatedCPUs.IsEmpty())
+			assert.Equal(t, len(tt.wantNUMANodeResources), len(state.allocatedNUMAResources))
+			for i := range tt.wantNUMANodeResources {
+				assert.Equal(t, tt.wantNUMANodeResources[i].Node, state.allocatedNUMAResources[i].Node)
+				assert.True(t, quotav1.Equals(tt.wantNUMANodeResources[i].Resources, state.allocatedNUMAResources[i].Resources),
+					state.allocatedNUMAResources[i].Resources)
+			}
+			affinity, ok := topologyState.GetAffinity("test-node-1")
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantAffinity, affinity)
+			available := plg.cpuManager.GetAvailableNUMAResources("test-node-1", "")
+			for numaNode, want := range tt.wantAvailableNUMAResource {
+				assert.True(t, quotav1.Equals(want, available[numaNode]), available[numaNode])
+			}
+
+			assert.True(t, plg.PreBind(context.TODO(), cycleState, pod, "test-node-1").IsSuccess())
+			podModified, err := suit.Handle.ClientSet().CoreV1().Pods("default").Get(context.TODO(), "test-pod-1", metav1.GetOptions{})
+			assert.NoError(t, err)
+			resourceStatus, err := extension.GetResourceStatus(podModified.Annotations)
+			assert.NoError(t, err)
+			assert.Equal(t, "", resourceStatus.CPUSet)
+			assert.Equal(t, len(tt.wantNUMANodeResources), len(resourceStatus.NUMANodeResources))
+
+			plg.Unreserve(context.TODO(), cycleState, pod, "test-node-1")
+			_, ok = topologyState.GetAffinity("test-node-1")
+			assert.False(t, ok)
+			assert.Empty(t, plg.cpuManager.GetAllocatedNUMAResources("test-node-1", pod.UID))
+		})
+	}
+}
+
+func TestPreFilterStateClone(t *testing.T) {
+	state := &preFilterState{
+		skip:                   false,
+		resourceSpec:           &extension.ResourceSpec{PreferredCPUBindPolicy: extension.CPUBindPolicyFullPCPUs},
+		preferredCPUBindPolicy: schedulingconfig.CPUBindPolicyFullPCPUs,
+		numCPUsNeeded:          4,
+		allocatedCPUs:          cpuset.NewCPUSet(0, 1, 2, 3),
+		numaResourceRequests:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+		allocatedNUMAResources: []extension.NUMANodeResource{
+			{Node: 0, Resources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")}},
+		},
+	}
+	cloned := state.Clone().(*preFilterState)
+	assert.Equal(t, state, cloned)
+
+	// the cloned state used by preemption must not share the maps and slices with the original one
+	cloned.numaResourceRequests[corev1.ResourceMemory] = resource.MustParse("8Gi")
+	cloned.allocatedNUMAResources[0].Resources[corev1.ResourceMemory] = resource.MustParse("8Gi")
+	cloned.allocatedNUMAResources[0].Node = 1
+	assert.Equal(t, resource.MustParse("4Gi"), state.numaResourceRequests[corev1.ResourceMemory])
+	assert.Equal(t, int32(0), state.allocatedNUMAResources[0].Node)
+	assert.Equal(t, resource.MustParse("4Gi"), state.allocatedNUMAResources[0].Resources[corev1.ResourceMemory])
+}
@@ -20,9 +20,11 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
@@ -96,7 +98,14 @@ func (c *podEventHandler) updatePod(oldPod, pod *corev1.Pod) {
 		return
 	}
 	cpus, err := cpuset.Parse(resourceStatus.CPUSet)
-	if err != nil || cpus.IsEmpty() {
+	if err != nil {
+		return
+	}
+	// the Pod doesn't bind CPUs but consumes the NUMA Node resources
+	if cpus.IsEmpty() {
+		if len(resourceStatus.NUMANodeResources) > 0 {
+			c.cpuManager.UpdateAllocatedNUMAResources(pod.Spec.NodeName, pod.UID, getAllocatedReservationUID(pod), resourceStatus.NUMANodeResources)
+		}
 		return
 	}
 
@@ -106,6 +115,7 @@ func (c *podEventHandler) updatePod(oldPod, pod *corev1.Pod) {
 	}
 
 	c.cpuManager.UpdateAllocatedCPUSet(pod.Spec.NodeName, pod.UID, cpus, resourceSpec.PreferredCPUExclusivePolicy)
+	c.cpuManager.UpdateAllocatedNUMAResources(pod.Spec.NodeName, pod.UID, getAllocatedReservationUID(pod), resourceStatus.NUMANodeResources)
 }
 
 func (c *podEventHandler) deletePod(pod *corev1.Pod) {
@@ -118,9 +128,18 @@ func (c *podEventHandler) deletePod(pod *corev1.Pod) {
 		return
 	}
 	cpus, err := cpuset.Parse(resourceStatus.CPUSet)
-	if err != nil || cpus.IsEmpty() {
+	if err != nil || (cpus.IsEmpty() && len(resourceStatus.NUMANodeResources) == 0) {
 		return
 	}
 
 	c.cpuManager.Free(pod.Spec.NodeName, pod.UID)
 }
+
+// getAllocatedReservationUID returns the UID of the reservation which the Pod allocates the resources from.
+func getAllocatedReservationUID(pod *corev1.Pod) types.UID {
+	reservationAllocated, err := extension.GetReservationAllocated(pod)
+	if err != nil || reservationAllocated == nil {
+		return ""
+	}
+	return reservationAllocated.UID
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
 
@@ -153,3 +154,41 @@ func TestPodEventHandler(t *testing.T) {
 	}
 
 }
+
+func TestPodEventHandlerWithNUMANodeResources(t *testing.T) {
+	topologyManager := NewCPUTopologyManager()
+	topologyManager.UpdateCPUTopologyOptions("test-node-1", func(options *CPUTopologyOptions) {
+		options.CPUTopology = buildCPUTopologyForTest(2, 2, 4, 2)
+	})
+	cpuManager := &cpuManagerImpl{
+		topologyManager:  topologyManager,
+		allocationStates: map[string]*cpuAllocation{},
+	}
+	handler := &podEventHandler{
+		cpuManager: cpuManager,
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:  uuid.NewUUID(),
+			Name: "test",
+			Annotations: map[string]string{
+				extension.AnnotationResourceStatus: `{"numaNodeResources": [{"node": 1, "resources": {"memory": "4Gi"}}]}`,
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "test-node-1",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	handler.OnAdd(pod)
+	allocation := cpuManager.getOrCreateAllocation("test-node-1")
+	assert.Empty(t, allocation.allocatedPods)
+	assert.Equal(t, []extension.NUMANodeResource{
+		{Node: 1, Resources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")}},
+	}, allocation.allocatedNUMAResources[pod.UID])
+
+	handler.OnDelete(pod)
+	assert.Empty(t, allocation.allocatedNUMAResources)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodenumaresource
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+)
+
+// reservationEventHandler keeps the CPUs allocated to the available reservations in CPUManager, so that the CPUs
+// are held for the owners of the reservations. The CPUs are recorded with the UID of the reservation, which is
+// also the UID of the reserve pod allocated in Reserve.
+type reservationEventHandler struct {
+	cpuManager CPUManager
+}
+
+func registerReservationEventHandler(koordSharedInformerFactory koordinatorinformers.SharedInformerFactory, cpuManager CPUManager) {
+	reservationInformer := koordSharedInformerFactory.Scheduling().V1alpha1().Reservations().Informer()
+	eventHandler := &reservationEventHandler{
+		cpuManager: cpuManager,
+	}
+	frameworkexthelper.ForceSyncFromInformer(context.TODO().Done(), koordSharedInformerFactory, reservationInformer, eventHandler)
+}
+
+func (c *reservationEventHandler) OnAdd(obj interface{}) {
+	r, ok := obj.(*schedulingv1alpha1.Reservation)
+	if !ok {
+		return
+	}
+	c.updateReservation(r)
+}
+
+func (c *reservationEventHandler) OnUpdate(oldObj, newObj interface{}) {
+	r, ok := newObj.(*schedulingv1alpha1.Reservation)
+	if !ok {
+		return
+	}
+	c.updateReservation(r)
+}
+
+func (c *reservationEventHandler) OnDelete(obj interface{}) {
+	var r *schedulingv1alpha1.Reservation
+	switch t := obj.(type) {
+	case *schedulingv1alpha1.Reservation:
+		r = t
+	case cache.DeletedFinalStateUnknown:
+		var ok bool
+		r, ok = t.Obj.(*schedulingv1alpha1.Reservation)
+		if !ok {
+			return
+		}
+	default:
+		break
+	}
+
+	if r == nil {
+		return
+	}
+	c.deleteReservation(r)
+}
+
+func (c *reservationEventHandler) updateReservation(r *schedulingv1alpha1.Reservation) {
+	// the reserve pod of a pending reservation may have been allocated in Reserve, so only release the CPUs of
+	// the reservations which will not be allocated any more
+	if util.IsReservationFailed(r) || util.IsReservationSucceeded(r) {
+		c.deleteReservation(r)
+		return
+	}
+	if !util.IsReservationAvailable(r) {
+		return
+	}
+
+	// the annotations of the reservation overwrite the ones of the template
+	reservePod := util.NewReservePod(r)
+	resourceStatus, err := GetResourceStatus(reservePod.Annotations)
+	if err != nil {
+		return
+	}
+	cpus, err := cpuset.Parse(resourceStatus.CPUSet)
+	if err != nil || cpus.IsEmpty() {
+		return
+	}
+
+	resourceSpec, err := GetResourceSpec(reservePod.Annotations)
+	if err != nil {
+		return
+	}
+
+	nodeName := util.GetReservationNodeName(r)
+	c.cpuManager.UpdateAllocatedCPUSet(nodeName, r.UID, cpus, resourceSpec.PreferredCPUExclusivePolicy)
+	c.cpuManager.UpdateAllocatedNUMAResources(nodeName, r.UID, "", resourceStatus.NUMANodeResources)
+}
+
+func (c *reservationEventHandler) deleteReservation(r *schedulingv1alpha1.Reservation) {
+	nodeName := util.GetReservationNodeName(r)
+	if nodeName == "" {
+		return
+	}
+	c.cpuManager.Free(nodeName, r.UID)
+}
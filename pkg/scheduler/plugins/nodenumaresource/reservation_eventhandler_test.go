@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodenumaresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+)
+
+func TestReservationEventHandler(t *testing.T) {
+	newReservation := func(phase schedulingv1alpha1.ReservationPhase, nodeName string) *schedulingv1alpha1.Reservation {
+		return &schedulingv1alpha1.Reservation{
+			ObjectMeta: metav1.ObjectMeta{
+				UID:  uuid.NewUUID(),
+				Name: "test-reservation",
+				Annotations: map[string]string{
+					extension.AnnotationResourceStatus: `{"cpuset": "0-3"}`,
+				},
+			},
+			Spec: schedulingv1alpha1.ReservationSpec{
+				Template: &corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							extension.LabelPodQoS: string(extension.QoSLSR),
+						},
+						Annotations: map[string]string{
+							extension.AnnotationResourceSpec: `{"preferredCPUBindPolicy": "FullPCPUs"}`,
+						},
+					},
+				},
+			},
+			Status: schedulingv1alpha1.ReservationStatus{
+				Phase:    phase,
+				NodeName: nodeName,
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		reservation *schedulingv1alpha1.Reservation
+		wantAdd     bool
+	}{
+		{
+			name:        "pending reservation",
+			reservation: newReservation(schedulingv1alpha1.ReservationPending, ""),
+		},
+		{
+			name:        "available reservation",
+			reservation: newReservation(schedulingv1alpha1.ReservationAvailable, "test-node-1"),
+			wantAdd:     true,
+		},
+		{
+			name:        "succeeded reservation",
+			reservation: newReservation(schedulingv1alpha1.ReservationSucceeded, "test-node-1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpuTopology := buildCPUTopologyForTest(2, 2, 4, 2)
+			topologyManager := NewCPUTopologyManager()
+			topologyManager.UpdateCPUTopologyOptions("test-node-1", func(options *CPUTopologyOptions) {
+				options.CPUTopology = cpuTopology
+			})
+			cpuManager := &cpuManagerImpl{
+				topologyManager:  topologyManager,
+				allocationStates: map[string]*cpuAllocation{},
+			}
+			handler := &reservationEventHandler{
+				cpuManager: cpuManager,
+			}
+			handler.OnAdd(tt.reservation)
+			handler.OnUpdate(tt.reservation, tt.reservation)
+
+			cpus, ok := cpuManager.GetAllocatedCPUSet("test-node-1", tt.reservation.UID)
+			assert.Equal(t, tt.wantAdd, ok)
+			if tt.wantAdd {
+				assert.Equal(t, cpuset.MustParse("0-3"), cpus)
+			}
+
+			// the CPUs are released when the reservation becomes inactive
+			succeeded := tt.reservation.DeepCopy()
+			succeeded.Status.Phase = schedulingv1alpha1.ReservationSucceeded
+			handler.OnUpdate(tt.reservation, succeeded)
+			_, ok = cpuManager.GetAllocatedCPUSet("test-node-1", tt.reservation.UID)
+			assert.False(t, ok)
+
+			handler.OnAdd(tt.reservation)
+			handler.OnDelete(tt.reservation)
+			allocation := cpuManager.getOrCreateAllocation("test-node-1")
+			assert.Empty(t, allocation.allocatedPods)
+			assert.Empty(t, allocation.allocatedCPUs)
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	nrtv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
 	nrtclientset "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned"
 	nrtinformers "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/informers/externalversions"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
@@ -127,13 +128,18 @@ func (m *nodeResourceTopologyEventHandler) updateNodeResourceTopology(oldNodeRes
 	reservedCPUs := m.getPodAllocsCPUSet(podCPUAllocs)
 	reservedCPUs = reservedCPUs.Union(kubeletReservedCPUs)
 
+	numaNodeResources := extractNUMANodeResources(newNodeResTopology.Zones)
+	numaTopologyPolicy := extractNUMATopologyPolicy(newNodeResTopology.TopologyPolicies)
+
 	nodeName := newNodeResTopology.Name
 	m.topologyManager.UpdateCPUTopologyOptions(nodeName, func(options *CPUTopologyOptions) {
 		*options = CPUTopologyOptions{
-			CPUTopology:  cpuTopology,
-			ReservedCPUs: reservedCPUs,
-			Policy:       kubeletPolicy,
-			MaxRefCount:  options.MaxRefCount,
+			CPUTopology:        cpuTopology,
+			ReservedCPUs:       reservedCPUs,
+			Policy:             kubeletPolicy,
+			MaxRefCount:        options.MaxRefCount,
+			NUMANodeResources:  numaNodeResources,
+			NUMATopologyPolicy: numaTopologyPolicy,
 		}
 	})
 }
@@ -163,3 +169,37 @@ func convertCPUTopology(reportedCPUTopology *extension.CPUTopology) *CPUTopology
 	}
 	return builder.Result()
 }
+
+// extractNUMANodeResources parses the allocatable resources of the NUMA Node zones, e.g. zone "node-0" with type "Node".
+func extractNUMANodeResources(zones nrtv1alpha1.ZoneList) map[int]corev1.ResourceList {
+	var numaNodeResources map[int]corev1.ResourceList
+	for _, zone := range zones {
+		if zone.Type != extension.NUMANodeZoneType {
+			continue
+		}
+		numaNodeID, err := extension.ParseNUMANodeZoneName(zone.Name)
+		if err != nil {
+			klog.V(5).Infof("Failed to parse NUMA Node zone %s, err: %v", zone.Name, err)
+			continue
+		}
+		resources := corev1.ResourceList{}
+		for _, info := range zone.Resources {
+			resources[corev1.ResourceName(info.Name)] = info.Allocatable.DeepCopy()
+		}
+		if numaNodeResources == nil {
+			numaNodeResources = map[int]corev1.ResourceList{}
+		}
+		numaNodeResources[numaNodeID] = resources
+	}
+	return numaNodeResources
+}
+
+func extractNUMATopologyPolicy(topologyPolicies []string) extension.NUMATopologyPolicy {
+	for _, policy := range topologyPolicies {
+		switch nrtv1alpha1.TopologyManagerPolicy(policy) {
+		case nrtv1alpha1.SingleNUMANodePodLevel, nrtv1alpha1.SingleNUMANodeContainerLevel:
+			return extension.NUMATopologyPolicySingleNUMANode
+		}
+	}
+	return extension.NUMATopologyPolicyNone
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodenumaresource
+
+import (
+	"testing"
+
+	nrtv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+func TestExtractNUMANodeResources(t *testing.T) {
+	assert.Nil(t, extractNUMANodeResources(nrtv1alpha1.ZoneList{{Name: "fake-name", Type: "fake-type"}}))
+
+	zones := nrtv1alpha1.ZoneList{
+		{Name: "fake-name", Type: "fake-type"},
+		{Name: "invalid-name", Type: extension.NUMANodeZoneType},
+		{
+			Name: extension.GenNUMANodeZoneName(1),
+			Type: extension.NUMANodeZoneType,
+			Resources: nrtv1alpha1.ResourceInfoList{
+				{
+					Name:        string(corev1.ResourceMemory),
+					Capacity:    resource.MustParse("32Gi"),
+					Allocatable: resource.MustParse("30Gi"),
+					Available:   resource.MustParse("30Gi"),
+				},
+			},
+		},
+	}
+	expected := map[int]corev1.ResourceList{
+		1: {corev1.ResourceMemory: resource.MustParse("30Gi")},
+	}
+	assert.Equal(t, expected, extractNUMANodeResources(zones))
+}
+
+func TestExtractNUMATopologyPolicy(t *testing.T) {
+	assert.Equal(t, extension.NUMATopologyPolicyNone, extractNUMATopologyPolicy(nil))
+	assert.Equal(t, extension.NUMATopologyPolicyNone, extractNUMATopologyPolicy([]string{string(nrtv1alpha1.BestEffort)}))
+	assert.Equal(t, extension.NUMATopologyPolicySingleNUMANode, extractNUMATopologyPolicy([]string{string(nrtv1alpha1.SingleNUMANodePodLevel)}))
+	assert.Equal(t, extension.NUMATopologyPolicySingleNUMANode, extractNUMATopologyPolicy([]string{string(nrtv1alpha1.SingleNUMANodeContainerLevel)}))
+}
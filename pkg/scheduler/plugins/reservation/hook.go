@@ -89,6 +89,16 @@ func (h *Hook) PreFilterHook(handle frameworkext.ExtendedHandle, cycleState *fra
 		return nil, false
 	}
 
+	// share the matched reservations with the plugins allocating the reserved CPUs and devices
+	reservationState := frameworkext.InitReservationState(cycleState)
+	for nodeName, rOnNode := range state.matchedCache.nodeToR {
+		reservations := make([]*schedulingv1alpha1.Reservation, 0, len(rOnNode))
+		for _, rInfo := range rOnNode {
+			reservations = append(reservations, rInfo.GetReservation())
+		}
+		reservationState.SetMatchedReservations(nodeName, reservations)
+	}
+
 	// skip pod pre-filter of affinities/anti-affinities, topology constrains
 	return preparePreFilterPod(pod), true
 }
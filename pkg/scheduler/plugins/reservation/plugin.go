@@ -407,6 +407,8 @@ func (p *Plugin) Reserve(ctx context.Context, cycleState *framework.CycleState,
 		// update assume state
 		state.assumed = reserved
 		cycleState.Write(preFilterStateKey, state)
+		// nominate the reservation for the plugins allocating the reserved CPUs and devices
+		frameworkext.InitReservationState(cycleState).SetNominatedReservation(nodeName, reserved)
 		klog.V(4).InfoS("Attempting to reserve pod to node with reservations", "pod", klog.KObj(pod),
 			"node", nodeName, "matched count", len(rOnNode), "assumed", klog.KObj(reserved))
 		return nil
@@ -441,6 +443,9 @@ func (p *Plugin) Unreserve(ctx context.Context, cycleState *framework.CycleState
 	// clean assume state
 	state.assumed = nil
 	cycleState.Write(preFilterStateKey, state)
+	if reservationState := frameworkext.GetReservationState(cycleState); reservationState != nil {
+		reservationState.DeleteNominatedReservation(nodeName)
+	}
 
 	// update assume cache
 	unreserved := target.DeepCopy()
@@ -19,7 +19,9 @@ package nodemetric
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
@@ -58,6 +60,24 @@ func Test_getNodeMetricCollectPolicy(t *testing.T) {
 				ReportIntervalSeconds:    pointer.Int64(180),
 			},
 		},
+		{
+			name: "config enabled with pod aggregate policy",
+			config: &extension.ColocationStrategy{
+				Enable:                         pointer.Bool(true),
+				MetricAggregateDurationSeconds: pointer.Int64(60),
+				MetricReportIntervalSeconds:    pointer.Int64(180),
+				MetricPodAggregatePolicy: &slov1alpha1.AggregatePolicy{
+					Durations: []metav1.Duration{{Duration: 5 * time.Minute}},
+				},
+			},
+			want: &slov1alpha1.NodeMetricCollectPolicy{
+				AggregateDurationSeconds: pointer.Int64(60),
+				ReportIntervalSeconds:    pointer.Int64(180),
+				PodAggregatePolicy: &slov1alpha1.AggregatePolicy{
+					Durations: []metav1.Duration{{Duration: 5 * time.Minute}},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -39,6 +39,12 @@ func (r *NodeResourceReconciler) calculateBEResource(node *corev1.Node,
 	// pod(All).Used = pod(LS).Used + pod(BE).Used
 	podAllUsed := util.NewZeroResourceList()
 
+	var podUsageAggregationType slov1alpha1.AggregationType
+	if strategy := config.GetNodeColocationStrategy(r.cfgCache.GetCfgCopy(), node); strategy != nil &&
+		strategy.PodUsageAggregationType != nil {
+		podUsageAggregationType = *strategy.PodUsageAggregationType
+	}
+
 	podMetricMap := make(map[string]*slov1alpha1.PodMetricInfo)
 	for _, podMetric := range nodeMetric.Status.PodsMetric {
 		podMetricMap[util.GetPodMetricKey(podMetric)] = podMetric
@@ -66,7 +72,7 @@ func (r *NodeResourceReconciler) calculateBEResource(node *corev1.Node,
 		}
 
 		if qosClass != extension.QoSBE {
-			podLSUsed = quotav1.Add(podLSUsed, r.getPodMetricUsage(podMetric))
+			podLSUsed = quotav1.Add(podLSUsed, r.getPodMetricAggregatedUsage(podMetric, podUsageAggregationType))
 		}
 		podAllUsed = quotav1.Add(podAllUsed, r.getPodMetricUsage(podMetric))
 	}
@@ -99,6 +105,20 @@ func (r *NodeResourceReconciler) getPodMetricUsage(info *slov1alpha1.PodMetricIn
 	return corev1.ResourceList{corev1.ResourceCPU: *cpuUsageQuant, corev1.ResourceMemory: *memUsageQuant}
 }
 
+// getPodMetricAggregatedUsage gets pod usage of the aggregationType with the maximum duration from the PodMetricInfo,
+// and falls back to the instant pod usage if the aggregated usage is not reported
+func (r *NodeResourceReconciler) getPodMetricAggregatedUsage(info *slov1alpha1.PodMetricInfo,
+	aggregationType slov1alpha1.AggregationType) corev1.ResourceList {
+	if aggregationType == "" {
+		return r.getPodMetricUsage(info)
+	}
+	usage := util.GetTargetAggregatedUsage(info.AggregatedPodUsages, nil, aggregationType)
+	if usage == nil {
+		return r.getPodMetricUsage(info)
+	}
+	return r.getPodMetricUsage(&slov1alpha1.PodMetricInfo{PodUsage: *usage})
+}
+
 // getNodeMetricUsage gets node usage from the NodeMetricInfo
 func (r *NodeResourceReconciler) getNodeMetricUsage(info *slov1alpha1.NodeMetricInfo) corev1.ResourceList {
 	cpuQuant := info.NodeUsage.ResourceList[corev1.ResourceCPU]
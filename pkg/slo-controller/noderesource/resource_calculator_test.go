@@ -791,6 +791,82 @@ func Test_getPodMetricUsage(t *testing.T) {
 	}
 }
 
+func Test_getPodMetricAggregatedUsage(t *testing.T) {
+	podMetric := &slov1alpha1.PodMetricInfo{
+		PodUsage: slov1alpha1.ResourceMap{
+			ResourceList: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+		AggregatedPodUsages: []slov1alpha1.AggregatedUsage{
+			{
+				Duration: metav1.Duration{Duration: 5 * time.Minute},
+				Usage: map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap{
+					slov1alpha1.P99: {
+						ResourceList: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("3"),
+							corev1.ResourceMemory: resource.MustParse("5Gi"),
+						},
+					},
+				},
+			},
+			{
+				Duration: metav1.Duration{Duration: 10 * time.Minute},
+				Usage: map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap{
+					slov1alpha1.P99: {
+						ResourceList: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("6Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name            string
+		info            *slov1alpha1.PodMetricInfo
+		aggregationType slov1alpha1.AggregationType
+		want            corev1.ResourceList
+	}{
+		{
+			name:            "use instant usage without aggregation type",
+			info:            podMetric,
+			aggregationType: "",
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+		{
+			name:            "use aggregated usage with the maximum duration",
+			info:            podMetric,
+			aggregationType: slov1alpha1.P99,
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("6Gi"),
+			},
+		},
+		{
+			name:            "fall back to instant usage if aggregated usage not reported",
+			info:            podMetric,
+			aggregationType: slov1alpha1.P50,
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NodeResourceReconciler{}
+			got := r.getPodMetricAggregatedUsage(tt.info, tt.aggregationType)
+			testingCorrectResourceList(t, &tt.want, &got)
+		})
+	}
+}
+
 func Test_getNodeMetricUsage(t *testing.T) {
 	type args struct {
 		info *slov1alpha1.NodeMetricInfo
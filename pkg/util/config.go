@@ -147,6 +147,30 @@ func DefaultMemoryQOS(qos apiext.QoSClass) *slov1alpha1.MemoryQOS {
 	return memoryQOS
 }
 
+// DefaultIOQOS returns the recommended configuration for io qos strategy.
+// In the recommended configuration, BE pods have a lower io weight than LS pods, while no throttling limit is set
+// since the capabilities of the block devices vary on the nodes.
+func DefaultIOQOS(qos apiext.QoSClass) *slov1alpha1.IOQOS {
+	var ioQOS *slov1alpha1.IOQOS
+	switch qos {
+	case apiext.QoSLSR:
+		ioQOS = &slov1alpha1.IOQOS{
+			IOWeightPercent: pointer.Int64Ptr(100),
+		}
+	case apiext.QoSLS:
+		ioQOS = &slov1alpha1.IOQOS{
+			IOWeightPercent: pointer.Int64Ptr(100),
+		}
+	case apiext.QoSBE:
+		ioQOS = &slov1alpha1.IOQOS{
+			IOWeightPercent: pointer.Int64Ptr(50),
+		}
+	default:
+		klog.V(5).Infof("io qos has no auto config for qos %s", qos)
+	}
+	return ioQOS
+}
+
 func DefaultResourceQOSStrategy() *slov1alpha1.ResourceQOSStrategy {
 	return &slov1alpha1.ResourceQOSStrategy{
 		LSRClass: &slov1alpha1.ResourceQOS{
@@ -162,6 +186,10 @@ func DefaultResourceQOSStrategy() *slov1alpha1.ResourceQOSStrategy {
 				Enable:    pointer.BoolPtr(false),
 				MemoryQOS: *DefaultMemoryQOS(apiext.QoSLSR),
 			},
+			IOQOS: &slov1alpha1.IOQOSCfg{
+				Enable: pointer.BoolPtr(false),
+				IOQOS:  *DefaultIOQOS(apiext.QoSLSR),
+			},
 		},
 		LSClass: &slov1alpha1.ResourceQOS{
 			CPUQOS: &slov1alpha1.CPUQOSCfg{
@@ -176,6 +204,10 @@ func DefaultResourceQOSStrategy() *slov1alpha1.ResourceQOSStrategy {
 				Enable:    pointer.BoolPtr(false),
 				MemoryQOS: *DefaultMemoryQOS(apiext.QoSLS),
 			},
+			IOQOS: &slov1alpha1.IOQOSCfg{
+				Enable: pointer.BoolPtr(false),
+				IOQOS:  *DefaultIOQOS(apiext.QoSLS),
+			},
 		},
 		BEClass: &slov1alpha1.ResourceQOS{
 			CPUQOS: &slov1alpha1.CPUQOSCfg{
@@ -190,6 +222,10 @@ func DefaultResourceQOSStrategy() *slov1alpha1.ResourceQOSStrategy {
 				Enable:    pointer.BoolPtr(false),
 				MemoryQOS: *DefaultMemoryQOS(apiext.QoSBE),
 			},
+			IOQOS: &slov1alpha1.IOQOSCfg{
+				Enable: pointer.BoolPtr(false),
+				IOQOS:  *DefaultIOQOS(apiext.QoSBE),
+			},
 		},
 	}
 }
@@ -208,6 +244,10 @@ func NoneResourceQOS(qos apiext.QoSClass) *slov1alpha1.ResourceQOS {
 			Enable:    pointer.BoolPtr(false),
 			MemoryQOS: *NoneMemoryQOS(),
 		},
+		IOQOS: &slov1alpha1.IOQOSCfg{
+			Enable: pointer.BoolPtr(false),
+			IOQOS:  *NoneIOQOS(),
+		},
 	}
 }
 
@@ -240,6 +280,14 @@ func NoneMemoryQOS() *slov1alpha1.MemoryQOS {
 	}
 }
 
+// NoneIOQOS returns the all-disabled configuration for io qos strategy, where the io weight is the same as the
+// kernel default and no throttling limit is set.
+func NoneIOQOS() *slov1alpha1.IOQOS {
+	return &slov1alpha1.IOQOS{
+		IOWeightPercent: pointer.Int64Ptr(50),
+	}
+}
+
 // NoneResourceQOSStrategy indicates the qos strategy with all qos
 func NoneResourceQOSStrategy() *slov1alpha1.ResourceQOSStrategy {
 	return &slov1alpha1.ResourceQOSStrategy{
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+// GetDeviceCondition returns the condition of the specified type, or nil if not found.
+func GetDeviceCondition(conditions []schedulingv1alpha1.DeviceCondition, conditionType schedulingv1alpha1.DeviceConditionType) *schedulingv1alpha1.DeviceCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// GetDeviceConditions returns the health conditions of the device with the type and minor, or nil if not found.
+func GetDeviceConditions(device *schedulingv1alpha1.Device, deviceType schedulingv1alpha1.DeviceType, minor int32) *schedulingv1alpha1.DeviceConditions {
+	if device == nil {
+		return nil
+	}
+	for i := range device.Status.Conditions {
+		if device.Status.Conditions[i].Type == deviceType && device.Status.Conditions[i].Minor == minor {
+			return &device.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsDeviceConditionsHealthy returns false only if the Healthy condition of the device is False.
+func IsDeviceConditionsHealthy(conditions *schedulingv1alpha1.DeviceConditions) bool {
+	if conditions == nil {
+		return true
+	}
+	condition := GetDeviceCondition(conditions.Conditions, schedulingv1alpha1.DeviceHealthy)
+	return condition == nil || condition.Status != metav1.ConditionFalse
+}
+
+// IsDeviceHealthy checks both the health reported in the spec and the Healthy condition in the status.
+func IsDeviceHealthy(device *schedulingv1alpha1.Device, deviceInfo *schedulingv1alpha1.DeviceInfo) bool {
+	if deviceInfo == nil || !deviceInfo.Health {
+		return false
+	}
+	if deviceInfo.Minor == nil {
+		return true
+	}
+	return IsDeviceConditionsHealthy(GetDeviceConditions(device, deviceInfo.Type, *deviceInfo.Minor))
+}
+
+// GetUnhealthyDevices returns the minors of the unhealthy devices grouped by the device type, including the devices
+// which are lost from the spec but still marked as unhealthy in the status.
+func GetUnhealthyDevices(device *schedulingv1alpha1.Device) map[schedulingv1alpha1.DeviceType]sets.Int {
+	if device == nil {
+		return nil
+	}
+	unhealthyDevices := map[schedulingv1alpha1.DeviceType]sets.Int{}
+	addDevice := func(deviceType schedulingv1alpha1.DeviceType, minor int32) {
+		if unhealthyDevices[deviceType] == nil {
+			unhealthyDevices[deviceType] = sets.NewInt()
+		}
+		unhealthyDevices[deviceType].Insert(int(minor))
+	}
+	for i := range device.Spec.Devices {
+		deviceInfo := &device.Spec.Devices[i]
+		if deviceInfo.Minor != nil && !IsDeviceHealthy(device, deviceInfo) {
+			addDevice(deviceInfo.Type, *deviceInfo.Minor)
+		}
+	}
+	for i := range device.Status.Conditions {
+		conditions := &device.Status.Conditions[i]
+		if !IsDeviceConditionsHealthy(conditions) {
+			addDevice(conditions.Type, conditions.Minor)
+		}
+	}
+	return unhealthyDevices
+}
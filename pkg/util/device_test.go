@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+func TestGetUnhealthyDevices(t *testing.T) {
+	device := &schedulingv1alpha1.Device{
+		Spec: schedulingv1alpha1.DeviceSpec{
+			Devices: []schedulingv1alpha1.DeviceInfo{
+				{Minor: pointer.Int32(0), Type: schedulingv1alpha1.GPU, Health: true},
+				{Minor: pointer.Int32(1), Type: schedulingv1alpha1.GPU, Health: true},
+				{Minor: pointer.Int32(0), Type: schedulingv1alpha1.FPGA, Health: false},
+			},
+		},
+		Status: schedulingv1alpha1.DeviceStatus{
+			Conditions: []schedulingv1alpha1.DeviceConditions{
+				{
+					Minor: 0,
+					Type:  schedulingv1alpha1.GPU,
+					Conditions: []schedulingv1alpha1.DeviceCondition{
+						{Type: schedulingv1alpha1.DeviceHealthy, Status: metav1.ConditionTrue},
+					},
+				},
+				{
+					Minor: 1,
+					Type:  schedulingv1alpha1.GPU,
+					Conditions: []schedulingv1alpha1.DeviceCondition{
+						{Type: schedulingv1alpha1.DeviceHealthy, Status: metav1.ConditionFalse, Reason: "UncorrectableECCError"},
+					},
+				},
+				{
+					Minor: 2,
+					Type:  schedulingv1alpha1.GPU,
+					Conditions: []schedulingv1alpha1.DeviceCondition{
+						{Type: schedulingv1alpha1.DeviceHealthy, Status: metav1.ConditionFalse, Reason: "DeviceNotFound"},
+					},
+				},
+			},
+		},
+	}
+	assert.True(t, IsDeviceHealthy(device, &device.Spec.Devices[0]))
+	assert.False(t, IsDeviceHealthy(device, &device.Spec.Devices[1]))
+	assert.False(t, IsDeviceHealthy(device, &device.Spec.Devices[2]))
+	assert.Equal(t, map[schedulingv1alpha1.DeviceType]sets.Int{
+		schedulingv1alpha1.GPU:  sets.NewInt(1, 2),
+		schedulingv1alpha1.FPGA: sets.NewInt(0),
+	}, GetUnhealthyDevices(device))
+	assert.Nil(t, GetUnhealthyDevices(nil))
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+// GetTargetAggregatedUsage returns the usage of the aggregationType in the aggregatedUsages with the
+// aggregatedDuration. If no specific duration is set, the maximum duration in the aggregatedUsages is used.
+// It returns nil if the target usage is not reported.
+func GetTargetAggregatedUsage(aggregatedUsages []slov1alpha1.AggregatedUsage, aggregatedDuration *metav1.Duration,
+	aggregationType slov1alpha1.AggregationType) *slov1alpha1.ResourceMap {
+	if len(aggregatedUsages) == 0 {
+		return nil
+	}
+
+	if aggregatedDuration == nil || aggregatedDuration.Duration == 0 {
+		var maxDuration time.Duration
+		var maxIndex int
+		for i, v := range aggregatedUsages {
+			if v.Duration.Duration > maxDuration {
+				maxDuration = v.Duration.Duration
+				maxIndex = i
+			}
+		}
+		usage := aggregatedUsages[maxIndex].Usage[aggregationType]
+		if len(usage.ResourceList) > 0 {
+			return &usage
+		}
+		return nil
+	}
+
+	for _, v := range aggregatedUsages {
+		if v.Duration.Duration == aggregatedDuration.Duration {
+			usage := v.Usage[aggregationType]
+			if len(usage.ResourceList) > 0 {
+				return &usage
+			}
+		}
+	}
+	return nil
+}
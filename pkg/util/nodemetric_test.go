@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+func Test_GetTargetAggregatedUsage(t *testing.T) {
+	usage5m := slov1alpha1.ResourceMap{
+		ResourceList: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("1"),
+		},
+	}
+	usage10m := slov1alpha1.ResourceMap{
+		ResourceList: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("2"),
+		},
+	}
+	aggregatedUsages := []slov1alpha1.AggregatedUsage{
+		{
+			Duration: metav1.Duration{Duration: 5 * time.Minute},
+			Usage: map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap{
+				slov1alpha1.P95: usage5m,
+			},
+		},
+		{
+			Duration: metav1.Duration{Duration: 10 * time.Minute},
+			Usage: map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap{
+				slov1alpha1.P95: usage10m,
+			},
+		},
+	}
+	tests := []struct {
+		name               string
+		aggregatedUsages   []slov1alpha1.AggregatedUsage
+		aggregatedDuration *metav1.Duration
+		aggregationType    slov1alpha1.AggregationType
+		want               *slov1alpha1.ResourceMap
+	}{
+		{
+			name:            "empty aggregated usages",
+			aggregationType: slov1alpha1.P95,
+			want:            nil,
+		},
+		{
+			name:             "use the maximum duration by default",
+			aggregatedUsages: aggregatedUsages,
+			aggregationType:  slov1alpha1.P95,
+			want:             &usage10m,
+		},
+		{
+			name:               "use the specified duration",
+			aggregatedUsages:   aggregatedUsages,
+			aggregatedDuration: &metav1.Duration{Duration: 5 * time.Minute},
+			aggregationType:    slov1alpha1.P95,
+			want:               &usage5m,
+		},
+		{
+			name:               "duration not reported",
+			aggregatedUsages:   aggregatedUsages,
+			aggregatedDuration: &metav1.Duration{Duration: time.Minute},
+			aggregationType:    slov1alpha1.P95,
+			want:               nil,
+		},
+		{
+			name:             "aggregation type not reported",
+			aggregatedUsages: aggregatedUsages,
+			aggregationType:  slov1alpha1.P99,
+			want:             nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetTargetAggregatedUsage(tt.aggregatedUsages, tt.aggregatedDuration, tt.aggregationType)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
 )
 
 func GetEmptyPodExtendedResources() *apiext.ExtendedResourceSpec {
@@ -83,3 +84,23 @@ func GetCPUSetFromPod(podAnnotations map[string]string) (string, error) {
 	}
 	return podAlloc.CPUSet, nil
 }
+
+// GetCPUSetMemsFromPod returns the NUMA Nodes allocated to the pod in the Linux CPU list format, which is used as the
+// `cpuset.mems` to bind the memory of the pod.
+func GetCPUSetMemsFromPod(podAnnotations map[string]string) (string, error) {
+	if podAnnotations == nil {
+		return "", nil
+	}
+	podAlloc, err := apiext.GetResourceStatus(podAnnotations)
+	if err != nil {
+		return "", err
+	}
+	if len(podAlloc.NUMANodeResources) <= 0 {
+		return "", nil
+	}
+	nodes := make([]int, 0, len(podAlloc.NUMANodeResources))
+	for _, numaNode := range podAlloc.NUMANodeResources {
+		nodes = append(nodes, int(numaNode.Node))
+	}
+	return cpuset.NewCPUSet(nodes...).String(), nil
+}
@@ -59,3 +59,64 @@ func Test_GetCPUSetFromPod(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetCPUSetMemsFromPod(t *testing.T) {
+	type args struct {
+		podAnnotations map[string]string
+		podAlloc       *apiext.ResourceStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "no numa nodes in annotation",
+			args: args{
+				podAnnotations: map[string]string{},
+				podAlloc: &apiext.ResourceStatus{
+					CPUSet: "2-4",
+				},
+			},
+			want:    "",
+			wantErr: false,
+		},
+		{
+			name: "get cpuset mems from annotation",
+			args: args{
+				podAnnotations: map[string]string{},
+				podAlloc: &apiext.ResourceStatus{
+					CPUSet: "6-9",
+					NUMANodeResources: []apiext.NUMANodeResource{
+						{Node: 1},
+						{Node: 0},
+					},
+				},
+			},
+			want:    "0-1",
+			wantErr: false,
+		},
+		{
+			name: "bad annotation format",
+			args: args{
+				podAnnotations: map[string]string{
+					apiext.AnnotationResourceStatus: "bad-format",
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.args.podAlloc != nil {
+				podAllocJson := DumpJSON(tt.args.podAlloc)
+				tt.args.podAnnotations[apiext.AnnotationResourceStatus] = podAllocJson
+			}
+			got, err := GetCPUSetMemsFromPod(tt.args.podAnnotations)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
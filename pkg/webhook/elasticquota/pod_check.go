@@ -19,8 +19,10 @@ package elasticquota
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
@@ -55,7 +57,7 @@ func (qt *quotaTopology) ValidateUpdatePod(oldPod, newPod *corev1.Pod) error {
 func (qt *quotaTopology) getQuotaNameFromPodNoLock(pod *corev1.Pod) string {
 	quotaLabelName := extension.GetQuotaName(pod)
 	if quotaLabelName == "" {
-		quotaLabelName = GetQuotaName(qt.client, pod)
+		quotaLabelName = GetQuotaName(qt.client, pod, qt.getQuotaTreeNodeSelectorNoLock)
 	}
 
 	if _, exist := qt.quotaInfoMap[quotaLabelName]; !exist {
@@ -64,7 +66,23 @@ func (qt *quotaTopology) getQuotaNameFromPodNoLock(pod *corev1.Pod) string {
 	return quotaLabelName
 }
 
-var GetQuotaName = func(clientImpl client.Client, pod *corev1.Pod) string {
+// getQuotaTreeNodeSelectorNoLock returns the node selector of the independent quota tree the quota belongs to,
+// or nil if the quota belongs to the default tree.
+func (qt *quotaTopology) getQuotaTreeNodeSelectorNoLock(quotaName string) map[string]string {
+	quotaInfo, exist := qt.quotaInfoMap[quotaName]
+	for exist && quotaInfo.ParentName != extension.RootQuotaName {
+		quotaInfo, exist = qt.quotaInfoMap[quotaInfo.ParentName]
+	}
+	if !exist {
+		return nil
+	}
+	return quotaInfo.TreeNodeSelector
+}
+
+// GetQuotaName returns the quota in the namespace of the pod the same way as the scheduler. If there are multiple
+// quotas in the namespace, e.g. one in each quota tree, the pod is charged against the quota whose tree node selector
+// matches the pod's node selector, otherwise the quota in the default tree.
+var GetQuotaName = func(clientImpl client.Client, pod *corev1.Pod, getTreeNodeSelector func(quotaName string) map[string]string) string {
 	quotaList := &v1alpha1.ElasticQuotaList{}
 	opts := &client.ListOptions{
 		Namespace: pod.Namespace,
@@ -77,6 +95,30 @@ var GetQuotaName = func(clientImpl client.Client, pod *corev1.Pod) string {
 	if len(quotaList.Items) == 0 {
 		return extension.DefaultQuotaName
 	}
-	// todo when elastic quota supports multiple instances in a namespace, modify this
-	return quotaList.Items[0].Name
+	if len(quotaList.Items) == 1 {
+		return quotaList.Items[0].Name
+	}
+
+	quotaNames := make([]string, 0, len(quotaList.Items))
+	for i := range quotaList.Items {
+		quotaNames = append(quotaNames, quotaList.Items[i].Name)
+	}
+	sort.Strings(quotaNames)
+	defaultTreeQuotaName := ""
+	for _, quotaName := range quotaNames {
+		treeNodeSelector := getTreeNodeSelector(quotaName)
+		if len(treeNodeSelector) == 0 {
+			if defaultTreeQuotaName == "" {
+				defaultTreeQuotaName = quotaName
+			}
+			continue
+		}
+		if labels.SelectorFromSet(treeNodeSelector).Matches(labels.Set(pod.Spec.NodeSelector)) {
+			return quotaName
+		}
+	}
+	if defaultTreeQuotaName != "" {
+		return defaultTreeQuotaName
+	}
+	return quotaNames[0]
 }
@@ -28,7 +28,9 @@ type QuotaInfo struct {
 	AllowLentResource bool
 	Name              string
 	ParentName        string
-	CalculateInfo     QuotaCalculateInfo
+	// TreeNodeSelector is the node selector of the quota tree rooted on the quota, only set on the tree root.
+	TreeNodeSelector map[string]string
+	CalculateInfo    QuotaCalculateInfo
 }
 
 type QuotaCalculateInfo struct {
@@ -59,6 +61,7 @@ func NewQuotaInfoFromQuota(quota *v1alpha1.ElasticQuota) *QuotaInfo {
 	allowLentResource := extension.IsAllowLentResource(quota)
 
 	quotaInfo := NewQuotaInfo(isParent, allowLentResource, quota.Name, parentName)
+	quotaInfo.TreeNodeSelector = extension.GetTreeNodeSelector(quota)
 	quotaInfo.setMinQuotaNoLock(quota.Spec.Min)
 	quotaInfo.setMaxQuotaNoLock(quota.Spec.Max)
 	return quotaInfo
@@ -53,6 +53,10 @@ func (qt *quotaTopology) validateQuotaSelfItem(quota *v1alpha1.ElasticQuota) err
 		}
 	}
 
+	if _, err := extension.ParseTreeNodeSelector(quota); err != nil {
+		return fmt.Errorf("%v %v", quota.Name, err)
+	}
+
 	// minQuota <= maxQuota
 	for key, val := range quota.Spec.Min {
 		if maxVal, exist := quota.Spec.Max[key]; !exist || maxVal.Cmp(val) == -1 {
@@ -230,6 +234,9 @@ func quotaFieldsCopy(q *v1alpha1.ElasticQuota) v1alpha1.ElasticQuota {
 				extension.LabelQuotaParent:   q.Labels[extension.LabelQuotaParent],
 				extension.LabelQuotaIsParent: q.Labels[extension.LabelQuotaIsParent],
 			},
+			Annotations: map[string]string{
+				extension.AnnotationTreeNodeSelector: q.Annotations[extension.AnnotationTreeNodeSelector],
+			},
 		},
 		Spec: *q.Spec.DeepCopy(),
 	}
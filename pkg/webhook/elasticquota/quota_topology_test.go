@@ -17,6 +17,7 @@ limitations under the License.
 package elasticquota
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -90,6 +91,28 @@ func TestQuotaTopology_basicItemCheck(t *testing.T) {
 			quota: MakeQuota("temp").sharedWeight(MakeResourceList().CPU(-1).Mem(1048576).Obj()).Obj(),
 			err:   fmt.Errorf("%v quota.Annotation[%v]'s value < 0, in dimension :%v", "temp", extension.AnnotationSharedWeight, "[cpu]"),
 		},
+		{
+			name:  "tree node selector",
+			quota: MakeQuota("temp").treeNodeSelector(`{"pool":"gpu"}`).Obj(),
+			err:   nil,
+		},
+		{
+			name:  "empty tree node selector",
+			quota: MakeQuota("temp").treeNodeSelector(`{}`).Obj(),
+			err:   fmt.Errorf("%v empty %v", "temp", extension.AnnotationTreeNodeSelector),
+		},
+		{
+			name:  "invalid tree node selector",
+			quota: MakeQuota("temp").treeNodeSelector(`pool=gpu`).Obj(),
+			err: fmt.Errorf("%v invalid %v %v, err: %v", "temp", extension.AnnotationTreeNodeSelector, "pool=gpu",
+				"invalid character 'p' looking for beginning of value"),
+		},
+		{
+			name:  "tree node selector on quota whose parent is not root",
+			quota: MakeQuota("temp").ParentName("parent").treeNodeSelector(`{"pool":"gpu"}`).Obj(),
+			err: fmt.Errorf("%v %v only takes effect on the quota whose parent is %v, parent: %v", "temp",
+				extension.AnnotationTreeNodeSelector, extension.RootQuotaName, "parent"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -514,6 +537,17 @@ func TestQuotaTopology_getQuotaNameFromPod(t *testing.T) {
 	pod.Labels = make(map[string]string)
 	quotaName := qt.getQuotaNameFromPodNoLock(pod)
 	assert.Equal(t, extension.DefaultQuotaName, quotaName)
+
+	// the pod is charged against the quota whose tree matches its node selector, otherwise the quota in the default tree
+	gpuQuota := MakeQuota("a-gpu").Namespace("test-ns").IsParent(true).treeNodeSelector(`{"pool":"gpu"}`).Obj()
+	cpuQuota := MakeQuota("b-cpu").Namespace("test-ns").IsParent(false).Obj()
+	for _, quota := range []*v1alpha1.ElasticQuota{gpuQuota, cpuQuota} {
+		assert.NoError(t, client.Create(context.TODO(), quota))
+		qt.OnQuotaAdd(quota)
+	}
+	assert.Equal(t, "b-cpu", qt.getQuotaNameFromPodNoLock(pod))
+	pod.Spec.NodeSelector = map[string]string{"pool": "gpu"}
+	assert.Equal(t, "a-gpu", qt.getQuotaNameFromPodNoLock(pod))
 }
 
 func TestQuotaTopology_checkParentQuotaInfoExist(t *testing.T) {
@@ -591,6 +625,11 @@ func (q *quotaWrapper) sharedWeight(sharedWeight v1.ResourceList) *quotaWrapper
 	return q
 }
 
+func (q *quotaWrapper) treeNodeSelector(treeNodeSelector string) *quotaWrapper {
+	q.Annotations[extension.AnnotationTreeNodeSelector] = treeNodeSelector
+	return q
+}
+
 func (q *quotaWrapper) IsParent(isParent bool) *quotaWrapper {
 	if isParent {
 		q.Labels[extension.LabelQuotaIsParent] = "true"